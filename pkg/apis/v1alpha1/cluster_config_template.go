@@ -23,6 +23,7 @@ import (
 )
 
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 // +kubebuilder:resource:path=clusterconfigtemplates,scope=Cluster,shortName=cct
 
 type ClusterConfigTemplate struct {
@@ -32,6 +33,10 @@ type ClusterConfigTemplate struct {
 	// Spec describes the config template.
 	// More info: https://cartographer.sh/docs/latest/reference/template/#clusterconfigtemplate
 	Spec ConfigTemplateSpec `json:"spec"`
+
+	// Status describes the inputs the template reads.
+	// +optional
+	Status TemplateStatus `json:"status,omitempty"`
 }
 
 type ConfigTemplateSpec struct {
@@ -53,6 +58,14 @@ type ClusterConfigTemplateList struct {
 	Items           []ClusterConfigTemplate `json:"items"`
 }
 
+func (c *ClusterConfigTemplate) GetTemplateSpec() *TemplateSpec {
+	return &c.Spec.TemplateSpec
+}
+
+func (c *ClusterConfigTemplate) GetTemplateStatus() *TemplateStatus {
+	return &c.Status
+}
+
 func init() {
 	SchemeBuilder.Register(
 		&ClusterConfigTemplate{},
@@ -36,8 +36,6 @@ func (c *ClusterConfigTemplate) ValidateDelete() error {
 	return nil
 }
 
-func (c *ClusterConfigTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(c).
-		Complete()
+func (c *ClusterConfigTemplate) SetupWebhookWithManager(mgr ctrl.Manager, impactAnalyzer ImpactAnalyzer) error {
+	return registerWarningValidator(mgr, c, &TemplateValidator{ImpactAnalyzer: impactAnalyzer})
 }
@@ -28,6 +28,10 @@ var ValidDeliveryTemplates = []client.Object{
 	&ClusterDeploymentTemplate{},
 	&ClusterTemplate{},
 	&ClusterConfigTemplate{},
+	&SourceTemplate{},
+	&DeploymentTemplate{},
+	&Template{},
+	&ConfigTemplate{},
 }
 
 // +kubebuilder:object:root=true
@@ -124,7 +128,9 @@ type DeliveryResource struct {
 
 type DeliveryTemplateReference struct {
 	// Kind of the template to apply
-	// +kubebuilder:validation:Enum=ClusterSourceTemplate;ClusterDeploymentTemplate;ClusterTemplate;ClusterConfigTemplate
+	// The namespaced kinds SourceTemplate, DeploymentTemplate, Template and
+	// ConfigTemplate can only be referenced by a Delivery.
+	// +kubebuilder:validation:Enum=ClusterSourceTemplate;ClusterDeploymentTemplate;ClusterTemplate;ClusterConfigTemplate;SourceTemplate;DeploymentTemplate;Template;ConfigTemplate
 	Kind string `json:"kind"`
 	// Name of the template to apply
 	// +kubebuilder:validation:MinLength=1
@@ -136,6 +142,13 @@ type DeliveryTemplateReference struct {
 	// Only one of Name and Options can be specified.
 	// +kubebuilder:validation:MinItems=2
 	Options []TemplateOption `json:"options,omitempty"`
+
+	// Revision of the template to apply, pinning the resource to that revision of
+	// the template. When omitted the resource follows the template as it changes.
+	// Can only be specified together with Name.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Revision int64 `json:"revision,omitempty"`
 }
 
 type DeploymentReference struct {
@@ -154,6 +167,14 @@ func (c *ClusterDelivery) GetSelectors() LegacySelector {
 	return c.Spec.LegacySelector
 }
 
+func (c *ClusterDelivery) GetDeliverySpec() *DeliverySpec {
+	return &c.Spec
+}
+
+func (c *ClusterDelivery) GetDeliveryStatus() *DeliveryStatus {
+	return &c.Status
+}
+
 func init() {
 	SchemeBuilder.Register(
 		&ClusterDelivery{},
@@ -27,7 +27,7 @@ import (
 var _ webhook.Validator = &ClusterDelivery{}
 
 func (c *ClusterDelivery) ValidateCreate() error {
-	err := c.validateNewState()
+	err := c.validate()
 	if err != nil {
 		return fmt.Errorf("error validating clusterdelivery [%s]: %w", c.Name, err)
 	}
@@ -35,7 +35,7 @@ func (c *ClusterDelivery) ValidateCreate() error {
 }
 
 func (c *ClusterDelivery) ValidateUpdate(_ runtime.Object) error {
-	err := c.validateNewState()
+	err := c.validate()
 	if err != nil {
 		return fmt.Errorf("error validating clusterdelivery [%s]: %w", c.Name, err)
 	}
@@ -46,6 +46,20 @@ func (c *ClusterDelivery) ValidateDelete() error {
 	return nil
 }
 
+func (c *ClusterDelivery) validate() error {
+	if err := c.validateNewState(); err != nil {
+		return err
+	}
+
+	for _, resource := range c.Spec.Resources {
+		if err := validateClusterScopedTemplateKind(resource.TemplateRef.Kind, "Delivery"); err != nil {
+			return fmt.Errorf("error validating resource [%s]: %w", resource.Name, err)
+		}
+	}
+
+	return nil
+}
+
 func (c *ClusterDelivery) validateNewState() error {
 	if len(c.Spec.Selector) == 0 && len(c.Spec.SelectorMatchExpressions) == 0 && len(c.Spec.SelectorMatchFields) == 0 {
 		return fmt.Errorf("at least one selector, selectorMatchExpression, selectorMatchField must be specified")
@@ -87,9 +101,10 @@ func (c *ClusterDelivery) validateNewState() error {
 		for _, option := range resource.TemplateRef.Options {
 			if option.PassThrough != "" {
 				var found bool
-				if resource.TemplateRef.Kind == "ClusterSourceTemplate" {
+				kind := ClusterTemplateKind(resource.TemplateRef.Kind)
+				if kind == "ClusterSourceTemplate" {
 					found = isPassThroughInputFound(resource.Sources, option.PassThrough)
-				} else if resource.TemplateRef.Kind == "ClusterConfigTemplate" {
+				} else if kind == "ClusterConfigTemplate" {
 					found = isPassThroughInputFound(resource.Configs, option.PassThrough)
 				} else {
 					return fmt.Errorf("error validating resource [%s]: TemplateRef.Kind [%s] is not a known type", resource.Name, resource.TemplateRef.Kind)
@@ -111,11 +126,12 @@ func (c *ClusterDelivery) validateNewState() error {
 
 func (c *ClusterDelivery) validateDeploymentPassedToProperReceivers() error {
 	for _, resource := range c.Spec.Resources {
-		if resource.TemplateRef.Kind == "ClusterDeploymentTemplate" && resource.Deployment == nil {
+		isDeploymentTemplate := ClusterTemplateKind(resource.TemplateRef.Kind) == "ClusterDeploymentTemplate"
+		if isDeploymentTemplate && resource.Deployment == nil {
 			return fmt.Errorf("spec.resources[%s] is a ClusterDeploymentTemplate and must receive a deployment", resource.Name)
 		}
 
-		if resource.Deployment != nil && resource.TemplateRef.Kind != "ClusterDeploymentTemplate" {
+		if resource.Deployment != nil && !isDeploymentTemplate {
 			return fmt.Errorf("spec.resources[%s] receives a deployment but is not a ClusterDeploymentTemplate", resource.Name)
 		}
 	}
@@ -136,7 +152,7 @@ func (c *ClusterDelivery) validateResourceNamesUnique() error {
 
 func (c *ClusterDelivery) validateDeploymentTemplateDidNotReceiveConfig() error {
 	for _, resource := range c.Spec.Resources {
-		if resource.TemplateRef.Kind == "ClusterDeploymentTemplate" && resource.Configs != nil {
+		if ClusterTemplateKind(resource.TemplateRef.Kind) == "ClusterDeploymentTemplate" && resource.Configs != nil {
 			return fmt.Errorf("spec.resources[%s] is a ClusterDeploymentTemplate and must not receive config", resource.Name)
 		}
 	}
@@ -168,6 +184,10 @@ func validateDeliveryTemplateRef(ref DeliveryTemplateReference) error {
 		return fmt.Errorf("exactly one of templateRef.Name or templateRef.Options must be specified, found both")
 	}
 
+	if ref.Revision != 0 && ref.Name == "" {
+		return fmt.Errorf("templateRef.Revision can only be specified together with templateRef.Name")
+	}
+
 	if ref.Name == "" && len(ref.Options) < 2 {
 		if len(ref.Options) == 1 {
 			return fmt.Errorf("templateRef.Options must have more than one option")
@@ -181,8 +201,18 @@ func validateDeliveryTemplateRef(ref DeliveryTemplateReference) error {
 	return nil
 }
 
+func (c *ClusterDelivery) blueprintKind() string {
+	return "clusterdelivery"
+}
+
+func (c *ClusterDelivery) blueprintParams() []BlueprintParam {
+	return c.Spec.Params
+}
+
+func (c *ClusterDelivery) blueprintResourceRefs() []blueprintResourceRef {
+	return deliveryResourceRefs(c.Spec.Resources)
+}
+
 func (c *ClusterDelivery) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(c).
-		Complete()
+	return registerWarningValidator(mgr, c, &BlueprintValidator{Client: mgr.GetAPIReader()})
 }
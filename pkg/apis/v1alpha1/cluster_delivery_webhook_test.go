@@ -987,13 +987,17 @@ var _ = Describe("Delivery Validation", func() {
 
 var _ = Describe("DeliveryTemplateReference", func() {
 	It("has valid references", func() {
-		Expect(v1alpha1.ValidDeliveryTemplates).To(HaveLen(4))
+		Expect(v1alpha1.ValidDeliveryTemplates).To(HaveLen(8))
 
 		Expect(v1alpha1.ValidDeliveryTemplates).To(ContainElements(
 			&v1alpha1.ClusterSourceTemplate{},
 			&v1alpha1.ClusterDeploymentTemplate{},
 			&v1alpha1.ClusterTemplate{},
 			&v1alpha1.ClusterConfigTemplate{},
+			&v1alpha1.SourceTemplate{},
+			&v1alpha1.DeploymentTemplate{},
+			&v1alpha1.Template{},
+			&v1alpha1.ConfigTemplate{},
 		))
 	})
 
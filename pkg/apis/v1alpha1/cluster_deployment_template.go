@@ -23,6 +23,7 @@ import (
 )
 
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 // +kubebuilder:resource:path=clusterdeploymenttemplates,scope=Cluster,shortName=cdt
 
 type ClusterDeploymentTemplate struct {
@@ -32,6 +33,10 @@ type ClusterDeploymentTemplate struct {
 	// Spec describes the deployment template.
 	// More info: https://cartographer.sh/docs/latest/reference/template/#clusterdeploymenttemplate
 	Spec DeploymentSpec `json:"spec"`
+
+	// Status describes the inputs the template reads.
+	// +optional
+	Status TemplateStatus `json:"status,omitempty"`
 }
 
 type DeploymentSpec struct {
@@ -87,6 +92,14 @@ type ClusterDeploymentTemplateList struct {
 	Items           []ClusterDeploymentTemplate `json:"items"`
 }
 
+func (c *ClusterDeploymentTemplate) GetTemplateSpec() *TemplateSpec {
+	return &c.Spec.TemplateSpec
+}
+
+func (c *ClusterDeploymentTemplate) GetTemplateStatus() *TemplateStatus {
+	return &c.Status
+}
+
 func init() {
 	SchemeBuilder.Register(
 		&ClusterDeploymentTemplate{},
@@ -39,28 +39,30 @@ func (c *ClusterDeploymentTemplate) ValidateDelete() error {
 }
 
 func (c *ClusterDeploymentTemplate) validate() error {
-	err := c.Spec.TemplateSpec.validate()
+	return c.Spec.validate()
+}
+
+func (s *DeploymentSpec) validate() error {
+	err := s.TemplateSpec.validate()
 	if err != nil {
 		return err
 	}
 
-	if c.bothConditionsSet() || c.neitherConditionSet() {
+	if s.bothConditionsSet() || s.neitherConditionSet() {
 		return fmt.Errorf("invalid spec: must set exactly one of spec.ObservedMatches and spec.ObservedCompletion")
 	}
 
 	return nil
 }
 
-func (c *ClusterDeploymentTemplate) bothConditionsSet() bool {
-	return c.Spec.ObservedMatches != nil && c.Spec.ObservedCompletion != nil
+func (s *DeploymentSpec) bothConditionsSet() bool {
+	return s.ObservedMatches != nil && s.ObservedCompletion != nil
 }
 
-func (c *ClusterDeploymentTemplate) neitherConditionSet() bool {
-	return c.Spec.ObservedMatches == nil && c.Spec.ObservedCompletion == nil
+func (s *DeploymentSpec) neitherConditionSet() bool {
+	return s.ObservedMatches == nil && s.ObservedCompletion == nil
 }
 
-func (c *ClusterDeploymentTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(c).
-		Complete()
+func (c *ClusterDeploymentTemplate) SetupWebhookWithManager(mgr ctrl.Manager, impactAnalyzer ImpactAnalyzer) error {
+	return registerWarningValidator(mgr, c, &TemplateValidator{ImpactAnalyzer: impactAnalyzer})
 }
@@ -23,6 +23,7 @@ import (
 )
 
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 // +kubebuilder:resource:path=clusterimagetemplates,scope=Cluster,shortName=cit
 
 type ClusterImageTemplate struct {
@@ -32,6 +33,10 @@ type ClusterImageTemplate struct {
 	// Spec describes the image template.
 	// More info: https://cartographer.sh/docs/latest/reference/template/#clusterimagetemplate
 	Spec ImageTemplateSpec `json:"spec"`
+
+	// Status describes the inputs the template reads.
+	// +optional
+	Status TemplateStatus `json:"status,omitempty"`
 }
 type ImageTemplateSpec struct {
 	TemplateSpec `json:",inline"`
@@ -53,6 +58,14 @@ type ClusterImageTemplateList struct {
 	Items           []ClusterImageTemplate `json:"items"`
 }
 
+func (c *ClusterImageTemplate) GetTemplateSpec() *TemplateSpec {
+	return &c.Spec.TemplateSpec
+}
+
+func (c *ClusterImageTemplate) GetTemplateStatus() *TemplateStatus {
+	return &c.Status
+}
+
 func init() {
 	SchemeBuilder.Register(
 		&ClusterImageTemplate{},
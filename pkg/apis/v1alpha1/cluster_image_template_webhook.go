@@ -36,8 +36,6 @@ func (c *ClusterImageTemplate) ValidateDelete() error {
 	return nil
 }
 
-func (c *ClusterImageTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(c).
-		Complete()
+func (c *ClusterImageTemplate) SetupWebhookWithManager(mgr ctrl.Manager, impactAnalyzer ImpactAnalyzer) error {
+	return registerWarningValidator(mgr, c, &TemplateValidator{ImpactAnalyzer: impactAnalyzer})
 }
@@ -57,6 +57,14 @@ type RunTemplateSpec struct {
 	// will never display an output
 	// +optional
 	Outputs map[string]string `json:"outputs,omitempty"`
+
+	// TektonResults, when true and the template stamps a Tekton PipelineRun
+	// or TaskRun, fills the runnable's outputs with the run's results
+	// (status.pipelineResults, status.taskResults or status.results), and
+	// reports the failing TaskRun, step and reason of failed runs.
+	// Outputs with the same name take precedence over results.
+	// +optional
+	TektonResults bool `json:"tektonResults,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -23,6 +23,7 @@ import (
 )
 
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 // +kubebuilder:resource:path=clustersourcetemplates,scope=Cluster,shortName=cst
 
 type ClusterSourceTemplate struct {
@@ -32,6 +33,10 @@ type ClusterSourceTemplate struct {
 	// Spec describes the source template.
 	// More info: https://cartographer.sh/docs/latest/reference/template/#clustersourcetemplate
 	Spec SourceTemplateSpec `json:"spec"`
+
+	// Status describes the inputs the template reads.
+	// +optional
+	Status TemplateStatus `json:"status,omitempty"`
 }
 
 type SourceTemplateSpec struct {
@@ -58,6 +63,14 @@ type ClusterSourceTemplateList struct {
 	Items           []ClusterSourceTemplate `json:"items"`
 }
 
+func (c *ClusterSourceTemplate) GetTemplateSpec() *TemplateSpec {
+	return &c.Spec.TemplateSpec
+}
+
+func (c *ClusterSourceTemplate) GetTemplateStatus() *TemplateStatus {
+	return &c.Status
+}
+
 func init() {
 	SchemeBuilder.Register(
 		&ClusterSourceTemplate{},
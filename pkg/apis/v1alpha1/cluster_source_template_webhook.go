@@ -36,8 +36,6 @@ func (c *ClusterSourceTemplate) ValidateDelete() error {
 	return nil
 }
 
-func (c *ClusterSourceTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(c).
-		Complete()
+func (c *ClusterSourceTemplate) SetupWebhookWithManager(mgr ctrl.Manager, impactAnalyzer ImpactAnalyzer) error {
+	return registerWarningValidator(mgr, c, &TemplateValidator{ImpactAnalyzer: impactAnalyzer})
 }
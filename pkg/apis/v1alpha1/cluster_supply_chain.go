@@ -28,6 +28,11 @@ var ValidSupplyChainTemplates = []client.Object{
 	&ClusterImageTemplate{},
 	&ClusterConfigTemplate{},
 	&ClusterTemplate{},
+	&ClusterSupplyChainFragment{},
+	&SourceTemplate{},
+	&ImageTemplate{},
+	&ConfigTemplate{},
+	&Template{},
 }
 
 // +kubebuilder:object:root=true
@@ -71,11 +76,27 @@ type SupplyChainSpec struct {
 	// workload's namespace.
 	// +optional
 	ServiceAccountRef ServiceAccountRef `json:"serviceAccountRef,omitempty"`
+
+	// Priority breaks ties between supply chains whose selectors match a
+	// workload with the same specificity. Of those, the supply chain with
+	// the highest priority is selected.
+	// Defaults to 0.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// Rollout stages the adoption of changes to the supply chain by the workloads
+	// it selects. Without it, every workload adopts a change at once.
+	// +optional
+	Rollout *Rollout `json:"rollout,omitempty"`
 }
 
 type SupplyChainStatus struct {
 	Conditions         []metav1.Condition `json:"conditions,omitempty"`
 	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
+
+	// Rollout describes how far the latest revision of the supply chain is rolled out.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
 }
 
 type SupplyChainResource struct {
@@ -83,7 +104,9 @@ type SupplyChainResource struct {
 	// the name presented in workload statuses to identify this resource.
 	Name string `json:"name"`
 
-	// TemplateRef identifies the template used to produce this resource
+	// TemplateRef identifies the template used to produce this resource.
+	// A templateRef of kind ClusterSupplyChainFragment expands the
+	// resources of the named fragment in place of this resource.
 	TemplateRef SupplyChainTemplateReference `json:"templateRef"`
 
 	// Params are a list of parameters to provide to the template in TemplateRef
@@ -127,7 +150,9 @@ type SupplyChainResource struct {
 
 type SupplyChainTemplateReference struct {
 	// Kind of the template to apply
-	//+kubebuilder:validation:Enum=ClusterSourceTemplate;ClusterImageTemplate;ClusterTemplate;ClusterConfigTemplate
+	// The namespaced kinds SourceTemplate, ImageTemplate, Template and ConfigTemplate
+	// can only be referenced by a SupplyChain.
+	//+kubebuilder:validation:Enum=ClusterSourceTemplate;ClusterImageTemplate;ClusterTemplate;ClusterConfigTemplate;ClusterSupplyChainFragment;SourceTemplate;ImageTemplate;Template;ConfigTemplate
 	Kind string `json:"kind"`
 
 	// Name of the template to apply
@@ -142,6 +167,13 @@ type SupplyChainTemplateReference struct {
 	// Minimum number of items in list is two.
 	// +kubebuilder:validation:MinItems=2
 	Options []TemplateOption `json:"options,omitempty"`
+
+	// Revision of the template to apply, pinning the resource to that revision of
+	// the template. When omitted the resource follows the template as it changes.
+	// Can only be specified together with Name.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Revision int64 `json:"revision,omitempty"`
 }
 
 type FieldSelectorOperator string
@@ -158,6 +190,14 @@ func (c *ClusterSupplyChain) GetSelectors() LegacySelector {
 	return c.Spec.LegacySelector
 }
 
+func (c *ClusterSupplyChain) GetSupplyChainSpec() *SupplyChainSpec {
+	return &c.Spec
+}
+
+func (c *ClusterSupplyChain) GetSupplyChainStatus() *SupplyChainStatus {
+	return &c.Status
+}
+
 func init() {
 	SchemeBuilder.Register(
 		&ClusterSupplyChain{},
@@ -0,0 +1,79 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +versionName=v1alpha1
+// +groupName=carto.run
+// +kubebuilder:object:generate=true
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const SupplyChainFragmentKind = "ClusterSupplyChainFragment"
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=clustersupplychainfragments,scope=Cluster,shortName=cscf
+
+type ClusterSupplyChainFragment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec describes the supply chain fragment.
+	Spec SupplyChainFragmentSpec `json:"spec"`
+}
+
+type SupplyChainFragmentSpec struct {
+	// Resources that are expanded into every supply chain (or fragment) with a
+	// resource whose templateRef is of kind ClusterSupplyChainFragment and
+	// names this fragment. The name of each expanded resource is prefixed
+	// with the name of the including resource, eg: <including>-<resource>.
+	//
+	// A source, image or config reference to a resource that is not in this
+	// list is an input of the fragment. Inputs are provided by the sources,
+	// images and configs of the including resource, matched by their name.
+	Resources []SupplyChainResource `json:"resources"`
+
+	// Output is the name of the resource whose output the fragment exposes.
+	// Resources referring to the including resource consume this output.
+	// Defaults to the last resource of the fragment.
+	// +optional
+	Output string `json:"output,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+type ClusterSupplyChainFragmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterSupplyChainFragment `json:"items"`
+}
+
+func (c *ClusterSupplyChainFragment) GetOutputResourceName() string {
+	if c.Spec.Output != "" {
+		return c.Spec.Output
+	}
+	if len(c.Spec.Resources) == 0 {
+		return ""
+	}
+	return c.Spec.Resources[len(c.Spec.Resources)-1].Name
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&ClusterSupplyChainFragment{},
+		&ClusterSupplyChainFragmentList{},
+	)
+}
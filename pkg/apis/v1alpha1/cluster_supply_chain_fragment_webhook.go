@@ -0,0 +1,182 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/validate-carto-run-v1alpha1-clustersupplychainfragment,mutating=false,failurePolicy=fail,sideEffects=none,admissionReviewVersions=v1beta1;v1,groups=carto.run,resources=clustersupplychainfragments,verbs=create;update,versions=v1alpha1,name=supply-chain-fragment-validator.cartographer.com
+
+// ClusterSupplyChainFragmentValidator validates fragments on admission. Unlike the other
+// validators it needs a client: detecting a cycle of fragments including one another
+// requires reading the fragments that are already on the cluster.
+// +kubebuilder:object:generate=false
+type ClusterSupplyChainFragmentValidator struct {
+	Client client.Reader
+}
+
+var _ admission.CustomValidator = &ClusterSupplyChainFragmentValidator{}
+
+func (v *ClusterSupplyChainFragmentValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return v.validate(ctx, obj)
+}
+
+func (v *ClusterSupplyChainFragmentValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) error {
+	return v.validate(ctx, newObj)
+}
+
+func (v *ClusterSupplyChainFragmentValidator) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+func (v *ClusterSupplyChainFragmentValidator) validate(ctx context.Context, obj runtime.Object) error {
+	fragment, ok := obj.(*ClusterSupplyChainFragment)
+	if !ok {
+		return fmt.Errorf("expected a ClusterSupplyChainFragment but got a %T", obj)
+	}
+
+	if err := fragment.validateNewState(); err != nil {
+		return fmt.Errorf("error validating clustersupplychainfragment [%s]: %w", fragment.Name, err)
+	}
+
+	if err := v.validateNoCycles(ctx, fragment, []string{fragment.Name}); err != nil {
+		return fmt.Errorf("error validating clustersupplychainfragment [%s]: %w", fragment.Name, err)
+	}
+
+	return nil
+}
+
+// validateNoCycles walks the fragments included by fragment, failing if any of them
+// includes a fragment on the path that led to it.
+func (v *ClusterSupplyChainFragmentValidator) validateNoCycles(ctx context.Context, fragment *ClusterSupplyChainFragment, path []string) error {
+	for _, resource := range fragment.Spec.Resources {
+		if resource.TemplateRef.Kind != SupplyChainFragmentKind {
+			continue
+		}
+
+		includedPath := append(append([]string{}, path...), resource.TemplateRef.Name)
+		for _, name := range path {
+			if name == resource.TemplateRef.Name {
+				return fmt.Errorf("resource [%s] creates a cycle of fragments: %s", resource.Name, strings.Join(includedPath, " -> "))
+			}
+		}
+
+		if v.Client == nil {
+			continue
+		}
+
+		included := &ClusterSupplyChainFragment{}
+		err := v.Client.Get(ctx, client.ObjectKey{Name: resource.TemplateRef.Name}, included)
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get fragment [%s] included by resource [%s]: %w", resource.TemplateRef.Name, resource.Name, err)
+		}
+
+		if err := v.validateNoCycles(ctx, included, includedPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ClusterSupplyChainFragment) validateNewState() error {
+	if len(c.Spec.Resources) == 0 {
+		return fmt.Errorf("at least one resource must be specified")
+	}
+
+	names := make(map[string]bool)
+	for _, resource := range c.Spec.Resources {
+		if _, ok := names[resource.Name]; ok {
+			return fmt.Errorf("duplicate resource name [%s] found", resource.Name)
+		}
+		names[resource.Name] = true
+	}
+
+	if c.Spec.Output != "" && !names[c.Spec.Output] {
+		return fmt.Errorf("output [%s] is not a resource of the fragment", c.Spec.Output)
+	}
+
+	for _, resource := range c.Spec.Resources {
+		for _, param := range resource.Params {
+			if err := param.validate(); err != nil {
+				return fmt.Errorf("resource [%s] is invalid: %w", resource.Name, err)
+			}
+		}
+
+		if err := validateSupplyChainTemplateRef(resource.TemplateRef); err != nil {
+			return fmt.Errorf("error validating resource [%s]: %w", resource.Name, err)
+		}
+
+		if err := validateClusterScopedTemplateKind(resource.TemplateRef.Kind, "SupplyChain"); err != nil {
+			return fmt.Errorf("error validating resource [%s]: %w", resource.Name, err)
+		}
+	}
+
+	for _, resource := range c.Spec.Resources {
+		if err := c.validateResourceRefs(resource.Sources, "ClusterSourceTemplate"); err != nil {
+			return fmt.Errorf("invalid sources for resource [%s]: %w", resource.Name, err)
+		}
+
+		if err := c.validateResourceRefs(resource.Images, "ClusterImageTemplate"); err != nil {
+			return fmt.Errorf("invalid images for resource [%s]: %w", resource.Name, err)
+		}
+
+		if err := c.validateResourceRefs(resource.Configs, "ClusterConfigTemplate"); err != nil {
+			return fmt.Errorf("invalid configs for resource [%s]: %w", resource.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// validateResourceRefs checks references to resources of the fragment. References to
+// any other name are inputs of the fragment and are resolved when it is expanded.
+func (c *ClusterSupplyChainFragment) validateResourceRefs(references []ResourceReference, targetKind string) error {
+	for _, ref := range references {
+		for _, resource := range c.Spec.Resources {
+			if resource.Name != ref.Resource {
+				continue
+			}
+			if ClusterTemplateKind(resource.TemplateRef.Kind) != targetKind && resource.TemplateRef.Kind != SupplyChainFragmentKind {
+				return fmt.Errorf(
+					"resource [%s] providing [%s] must reference a %s",
+					resource.Name,
+					ref.Name,
+					targetKind,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *ClusterSupplyChainFragment) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(c).
+		WithValidator(&ClusterSupplyChainFragmentValidator{Client: mgr.GetAPIReader()}).
+		Complete()
+}
@@ -0,0 +1,191 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+var _ = Describe("ClusterSupplyChainFragment Webhook Validation", func() {
+	var (
+		ctx           context.Context
+		fragment      *v1alpha1.ClusterSupplyChainFragment
+		clientObjects []client.Object
+		validator     *v1alpha1.ClusterSupplyChainFragmentValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		clientObjects = nil
+		fragment = &v1alpha1.ClusterSupplyChainFragment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "build",
+			},
+			Spec: v1alpha1.SupplyChainFragmentSpec{
+				Resources: []v1alpha1.SupplyChainResource{
+					{
+						Name: "test",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{
+							Kind: "ClusterSourceTemplate",
+							Name: "tester",
+						},
+						Sources: []v1alpha1.ResourceReference{
+							{Name: "source", Resource: "source"},
+						},
+					},
+					{
+						Name: "image",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{
+							Kind: "ClusterImageTemplate",
+							Name: "kpack",
+						},
+						Sources: []v1alpha1.ResourceReference{
+							{Name: "source", Resource: "test"},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		validator = &v1alpha1.ClusterSupplyChainFragmentValidator{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(clientObjects...).Build(),
+		}
+	})
+
+	Context("well formed fragment", func() {
+		It("creates without error", func() {
+			Expect(validator.ValidateCreate(ctx, fragment)).To(Succeed())
+		})
+
+		It("updates without error", func() {
+			Expect(validator.ValidateUpdate(ctx, nil, fragment)).To(Succeed())
+		})
+
+		It("deletes without error", func() {
+			Expect(validator.ValidateDelete(ctx, fragment)).To(Succeed())
+		})
+	})
+
+	Context("fragment without resources", func() {
+		BeforeEach(func() {
+			fragment.Spec.Resources = nil
+		})
+
+		It("returns an error", func() {
+			Expect(validator.ValidateCreate(ctx, fragment)).To(MatchError(
+				"error validating clustersupplychainfragment [build]: at least one resource must be specified",
+			))
+		})
+	})
+
+	Context("output is not a resource of the fragment", func() {
+		BeforeEach(func() {
+			fragment.Spec.Output = "deploy"
+		})
+
+		It("returns an error", func() {
+			Expect(validator.ValidateCreate(ctx, fragment)).To(MatchError(
+				"error validating clustersupplychainfragment [build]: output [deploy] is not a resource of the fragment",
+			))
+		})
+	})
+
+	Context("a resource of the fragment is referenced as the wrong kind", func() {
+		BeforeEach(func() {
+			fragment.Spec.Resources[1].Sources = nil
+			fragment.Spec.Resources[1].Images = []v1alpha1.ResourceReference{
+				{Name: "image", Resource: "test"},
+			}
+		})
+
+		It("returns an error", func() {
+			Expect(validator.ValidateCreate(ctx, fragment)).To(MatchError(
+				"error validating clustersupplychainfragment [build]: invalid images for resource [image]: resource [test] providing [image] must reference a ClusterImageTemplate",
+			))
+		})
+	})
+
+	Context("fragment includes itself", func() {
+		BeforeEach(func() {
+			fragment.Spec.Resources[1].TemplateRef = v1alpha1.SupplyChainTemplateReference{
+				Kind: "ClusterSupplyChainFragment",
+				Name: "build",
+			}
+		})
+
+		It("returns an error", func() {
+			Expect(validator.ValidateCreate(ctx, fragment)).To(MatchError(
+				"error validating clustersupplychainfragment [build]: resource [image] creates a cycle of fragments: build -> build",
+			))
+		})
+	})
+
+	Context("fragment includes a fragment that includes it", func() {
+		BeforeEach(func() {
+			fragment.Spec.Resources[1].TemplateRef = v1alpha1.SupplyChainTemplateReference{
+				Kind: "ClusterSupplyChainFragment",
+				Name: "scan",
+			}
+			clientObjects = []client.Object{
+				&v1alpha1.ClusterSupplyChainFragment{
+					ObjectMeta: metav1.ObjectMeta{Name: "scan"},
+					Spec: v1alpha1.SupplyChainFragmentSpec{
+						Resources: []v1alpha1.SupplyChainResource{
+							{
+								Name: "rebuild",
+								TemplateRef: v1alpha1.SupplyChainTemplateReference{
+									Kind: "ClusterSupplyChainFragment",
+									Name: "build",
+								},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("returns an error", func() {
+			Expect(validator.ValidateCreate(ctx, fragment)).To(MatchError(
+				"error validating clustersupplychainfragment [build]: resource [rebuild] creates a cycle of fragments: build -> scan -> build",
+			))
+		})
+	})
+
+	Context("fragment includes a fragment that does not exist yet", func() {
+		BeforeEach(func() {
+			fragment.Spec.Resources[1].TemplateRef = v1alpha1.SupplyChainTemplateReference{
+				Kind: "ClusterSupplyChainFragment",
+				Name: "scan",
+			}
+		})
+
+		It("creates without error", func() {
+			Expect(validator.ValidateCreate(ctx, fragment)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,168 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +versionName=v1alpha1
+// +groupName=carto.run
+// +kubebuilder:object:generate=true
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const SupplyChainRevisionKind = "ClusterSupplyChainRevision"
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=clustersupplychainrevisions,scope=Cluster,shortName=cscr
+// +kubebuilder:printcolumn:name="Supply Chain Kind",type="string",JSONPath=`.spec.supplyChainRef.kind`
+// +kubebuilder:printcolumn:name="Supply Chain Namespace",type="string",JSONPath=`.spec.supplyChainRef.namespace`
+// +kubebuilder:printcolumn:name="Supply Chain Name",type="string",JSONPath=`.spec.supplyChainRef.name`
+// +kubebuilder:printcolumn:name="Revision",type="integer",JSONPath=`.spec.revision`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// ClusterSupplyChainRevision is a snapshot of the spec of a supply chain, taken by
+// Cartographer every time the spec changes.
+type ClusterSupplyChainRevision struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec describes the revision.
+	Spec SupplyChainRevisionSpec `json:"spec"`
+}
+
+type SupplyChainRevisionSpec struct {
+	// SupplyChainRef is the supply chain this is a revision of.
+	SupplyChainRef SupplyChainRevisionReference `json:"supplyChainRef"`
+
+	// Revision numbers the changes to the spec of the supply chain, starting at 1.
+	// +kubebuilder:validation:Minimum=1
+	Revision int64 `json:"revision"`
+
+	// SupplyChain is the spec of the supply chain at this revision, without its rollout.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	SupplyChain runtime.RawExtension `json:"supplyChain"`
+}
+
+type SupplyChainRevisionReference struct {
+	// Kind of the supply chain: ClusterSupplyChain or SupplyChain
+	Kind string `json:"kind"`
+
+	// Namespace of the supply chain. Empty for cluster supply chains.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the supply chain.
+	Name string `json:"name"`
+}
+
+// +kubebuilder:object:root=true
+
+type ClusterSupplyChainRevisionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterSupplyChainRevision `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&ClusterSupplyChainRevision{},
+		&ClusterSupplyChainRevisionList{},
+	)
+}
+
+const (
+	SupplyChainRevisionKindLabel      = "carto.run/supply-chain-kind"
+	SupplyChainRevisionNamespaceLabel = "carto.run/supply-chain-namespace"
+	SupplyChainRevisionNameLabel      = "carto.run/supply-chain-name"
+	SupplyChainRevisionRefLabel       = "carto.run/supply-chain-ref"
+)
+
+// SupplyChainRevisionRef references supplyChain in its revisions.
+func SupplyChainRevisionRef(supplyChain SupplyChainObject) SupplyChainRevisionReference {
+	kind := "ClusterSupplyChain"
+	if supplyChain.GetNamespace() != "" {
+		kind = "SupplyChain"
+	}
+	return SupplyChainRevisionReference{Kind: kind, Namespace: supplyChain.GetNamespace(), Name: supplyChain.GetName()}
+}
+
+// SupplyChainRevisionName is the name of a revision of a supply chain.
+func SupplyChainRevisionName(ref SupplyChainRevisionReference, revision int64) string {
+	return revisionName(ref.Kind, ref.Namespace, ref.Name, revision)
+}
+
+// SupplyChainRevisionSelector selects the revisions of the referenced supply chain.
+func SupplyChainRevisionSelector(ref SupplyChainRevisionReference) map[string]string {
+	return map[string]string{
+		SupplyChainRevisionKindLabel:      ref.Kind,
+		SupplyChainRevisionNamespaceLabel: ref.Namespace,
+		SupplyChainRevisionRefLabel:       revisionRefHash(ref.Kind, ref.Namespace, ref.Name),
+	}
+}
+
+// NewSupplyChainRevision snapshots the spec of supplyChain as the given revision.
+func NewSupplyChainRevision(revision int64, supplyChain SupplyChainObject) (*ClusterSupplyChainRevision, error) {
+	snapshot, err := specSnapshot(supplyChain, "supply chain")
+	if err != nil {
+		return nil, err
+	}
+
+	ref := SupplyChainRevisionRef(supplyChain)
+	labels := SupplyChainRevisionSelector(ref)
+	if len(validation.IsValidLabelValue(ref.Name)) == 0 {
+		labels[SupplyChainRevisionNameLabel] = ref.Name
+	}
+
+	return &ClusterSupplyChainRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   SupplyChainRevisionName(ref, revision),
+			Labels: labels,
+		},
+		Spec: SupplyChainRevisionSpec{
+			SupplyChainRef: ref,
+			Revision:       revision,
+			SupplyChain:    runtime.RawExtension{Raw: snapshot},
+		},
+	}, nil
+}
+
+// Matches reports whether the revision is a snapshot of the current spec of supplyChain.
+func (r *ClusterSupplyChainRevision) Matches(supplyChain SupplyChainObject) (bool, error) {
+	snapshot, err := specSnapshot(supplyChain, "supply chain")
+	if err != nil {
+		return false, err
+	}
+
+	matches, err := snapshotsEqual(snapshot, r.Spec.SupplyChain.Raw)
+	if err != nil {
+		return false, fmt.Errorf("failed to compare revision [%s]: %w", r.Name, err)
+	}
+	return matches, nil
+}
+
+// Restore returns supplyChain with the spec it had at this revision. Its metadata and
+// status are left as they are.
+func (r *ClusterSupplyChainRevision) Restore(supplyChain SupplyChainObject) (SupplyChainObject, error) {
+	restored := supplyChain.DeepCopyObject().(SupplyChainObject)
+	*restored.GetSupplyChainSpec() = SupplyChainSpec{}
+	if err := restoreSpec(supplyChain, r.Spec.SupplyChain.Raw, restored); err != nil {
+		return nil, fmt.Errorf("failed to restore revision [%s]: %w", r.Name, err)
+	}
+	return restored, nil
+}
@@ -112,14 +112,19 @@ var _ = Describe("ClusterSupplyChain", func() {
 	})
 
 	Describe("SupplyChainTemplateReference", func() {
-		It("has four valid references", func() {
-			Expect(v1alpha1.ValidSupplyChainTemplates).To(HaveLen(4))
+		It("has nine valid references", func() {
+			Expect(v1alpha1.ValidSupplyChainTemplates).To(HaveLen(9))
 
 			Expect(v1alpha1.ValidSupplyChainTemplates).To(ContainElements(
 				&v1alpha1.ClusterSourceTemplate{},
 				&v1alpha1.ClusterConfigTemplate{},
 				&v1alpha1.ClusterImageTemplate{},
 				&v1alpha1.ClusterTemplate{},
+				&v1alpha1.ClusterSupplyChainFragment{},
+				&v1alpha1.SourceTemplate{},
+				&v1alpha1.ConfigTemplate{},
+				&v1alpha1.ImageTemplate{},
+				&v1alpha1.Template{},
 			))
 		})
 
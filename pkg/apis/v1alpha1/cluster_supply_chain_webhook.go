@@ -27,7 +27,7 @@ import (
 var _ webhook.Validator = &ClusterSupplyChain{}
 
 func (c *ClusterSupplyChain) ValidateCreate() error {
-	err := c.validateNewState()
+	err := c.validate()
 	if err != nil {
 		return fmt.Errorf("error validating clustersupplychain [%s]: %w", c.Name, err)
 	}
@@ -35,7 +35,7 @@ func (c *ClusterSupplyChain) ValidateCreate() error {
 }
 
 func (c *ClusterSupplyChain) ValidateUpdate(_ runtime.Object) error {
-	err := c.validateNewState()
+	err := c.validate()
 	if err != nil {
 		return fmt.Errorf("error validating clustersupplychain [%s]: %w", c.Name, err)
 	}
@@ -46,6 +46,20 @@ func (c *ClusterSupplyChain) ValidateDelete() error {
 	return nil
 }
 
+func (c *ClusterSupplyChain) validate() error {
+	if err := c.validateNewState(); err != nil {
+		return err
+	}
+
+	for _, resource := range c.Spec.Resources {
+		if err := validateClusterScopedTemplateKind(resource.TemplateRef.Kind, "SupplyChain"); err != nil {
+			return fmt.Errorf("error validating resource [%s]: %w", resource.Name, err)
+		}
+	}
+
+	return nil
+}
+
 func (c *ClusterSupplyChain) validateNewState() error {
 	names := make(map[string]bool)
 
@@ -61,6 +75,12 @@ func (c *ClusterSupplyChain) validateNewState() error {
 		return err
 	}
 
+	if c.Spec.Rollout != nil {
+		if err := c.Spec.Rollout.validate(); err != nil {
+			return err
+		}
+	}
+
 	for _, resource := range c.Spec.Resources {
 		if _, ok := names[resource.Name]; ok {
 			return fmt.Errorf("duplicate resource name [%s] found", resource.Name)
@@ -92,11 +112,12 @@ func (c *ClusterSupplyChain) validateNewState() error {
 		for _, option := range resource.TemplateRef.Options {
 			if option.PassThrough != "" {
 				var found bool
-				if resource.TemplateRef.Kind == "ClusterSourceTemplate" {
+				kind := ClusterTemplateKind(resource.TemplateRef.Kind)
+				if kind == "ClusterSourceTemplate" {
 					found = isPassThroughInputFound(resource.Sources, option.PassThrough)
-				} else if resource.TemplateRef.Kind == "ClusterImageTemplate" {
+				} else if kind == "ClusterImageTemplate" {
 					found = isPassThroughInputFound(resource.Images, option.PassThrough)
-				} else if resource.TemplateRef.Kind == "ClusterConfigTemplate" {
+				} else if kind == "ClusterConfigTemplate" {
 					found = isPassThroughInputFound(resource.Configs, option.PassThrough)
 				} else {
 					return fmt.Errorf("error validating resource [%s]: TemplateRef.Kind [%s] is not a known type", resource.Name, resource.TemplateRef.Kind)
@@ -177,7 +198,11 @@ func (c *ClusterSupplyChain) validateResourceRefs(references []ResourceReference
 				ref.Resource,
 			)
 		}
-		if referencedResource.TemplateRef.Kind != targetKind {
+		if referencedResource.TemplateRef.Kind == SupplyChainFragmentKind {
+			// the kind of a fragment's output is only known once the fragment is expanded
+			continue
+		}
+		if ClusterTemplateKind(referencedResource.TemplateRef.Kind) != targetKind {
 			return fmt.Errorf(
 				"resource [%s] providing [%s] must reference a %s",
 				referencedResource.Name,
@@ -190,10 +215,24 @@ func (c *ClusterSupplyChain) validateResourceRefs(references []ResourceReference
 }
 
 func validateSupplyChainTemplateRef(ref SupplyChainTemplateReference) error {
+	if ref.Kind == SupplyChainFragmentKind {
+		if ref.Name == "" || len(ref.Options) > 0 {
+			return fmt.Errorf("templateRef of kind %s must specify templateRef.Name and no templateRef.Options", SupplyChainFragmentKind)
+		}
+		if ref.Revision != 0 {
+			return fmt.Errorf("templateRef of kind %s cannot specify templateRef.Revision", SupplyChainFragmentKind)
+		}
+		return nil
+	}
+
 	if ref.Name != "" && len(ref.Options) > 0 {
 		return fmt.Errorf("exactly one of templateRef.Name or templateRef.Options must be specified, found both")
 	}
 
+	if ref.Revision != 0 && ref.Name == "" {
+		return fmt.Errorf("templateRef.Revision can only be specified together with templateRef.Name")
+	}
+
 	if ref.Name == "" && len(ref.Options) < 2 {
 		if len(ref.Options) == 1 {
 			return fmt.Errorf("templateRef.Options must have more than one option")
@@ -217,8 +256,21 @@ func (c *ClusterSupplyChain) getResourceByName(name string) *SupplyChainResource
 	return nil
 }
 
-func (c *ClusterSupplyChain) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(c).
-		Complete()
+func (c *ClusterSupplyChain) blueprintKind() string {
+	return "clustersupplychain"
+}
+
+func (c *ClusterSupplyChain) blueprintParams() []BlueprintParam {
+	return c.Spec.Params
+}
+
+func (c *ClusterSupplyChain) blueprintResourceRefs() []blueprintResourceRef {
+	return supplyChainResourceRefs(c.Spec.Resources)
+}
+
+func (c *ClusterSupplyChain) SetupWebhookWithManager(mgr ctrl.Manager, impactAnalyzer ImpactAnalyzer) error {
+	return registerWarningValidator(mgr, c, &BlueprintValidator{
+		Client:         mgr.GetAPIReader(),
+		ImpactAnalyzer: impactAnalyzer,
+	})
 }
@@ -15,6 +15,7 @@
 package v1alpha1_test
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -23,6 +24,9 @@ import (
 	. "github.com/onsi/gomega"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 )
@@ -339,9 +343,41 @@ var _ = Describe("Webhook Validation", func() {
 				Entry("Config cannot be a source provider", "ClusterConfigTemplate", "Source", false),
 				Entry("Config cannot be a image provider", "ClusterConfigTemplate", "Image", false),
 				Entry("Config can be a config provider", "ClusterConfigTemplate", "Config", true),
+				Entry("Fragment can be a source provider", "ClusterSupplyChainFragment", "Source", true),
+				Entry("Fragment can be a image provider", "ClusterSupplyChainFragment", "Image", true),
+				Entry("Fragment can be a config provider", "ClusterSupplyChainFragment", "Config", true),
 			)
 		})
 
+		Context("Supply chain with a resource referencing a fragment", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Resources[1].TemplateRef = v1alpha1.SupplyChainTemplateReference{
+					Kind: "ClusterSupplyChainFragment",
+					Name: "build-fragment",
+				}
+			})
+
+			It("creates without error", func() {
+				Expect(supplyChain.ValidateCreate()).NotTo(HaveOccurred())
+			})
+
+			Context("the fragment is referenced with options", func() {
+				BeforeEach(func() {
+					supplyChain.Spec.Resources[1].TemplateRef.Name = ""
+					supplyChain.Spec.Resources[1].TemplateRef.Options = []v1alpha1.TemplateOption{
+						{Name: "build-fragment", Selector: v1alpha1.Selector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"a": "b"}}}},
+						{Name: "other-fragment", Selector: v1alpha1.Selector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"c": "d"}}}},
+					}
+				})
+
+				It("on create, returns an error", func() {
+					Expect(supplyChain.ValidateCreate()).To(MatchError(
+						"error validating clustersupplychain [responsible-ops---default-params]: error validating resource [other-source-provider]: templateRef of kind ClusterSupplyChainFragment must specify templateRef.Name and no templateRef.Options",
+					))
+				})
+			})
+		})
+
 	})
 
 	Describe("OneOf Selector, SelectorMatchExpressions, or SelectorMatchFields", func() {
@@ -1132,3 +1168,298 @@ var _ = Describe("Webhook Validation", func() {
 		})
 	})
 })
+
+var _ = Describe("BlueprintValidator", func() {
+	var (
+		ctx           context.Context
+		supplyChain   *v1alpha1.ClusterSupplyChain
+		clientObjects []client.Object
+		validator     *v1alpha1.BlueprintValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		clientObjects = []client.Object{
+			&v1alpha1.ClusterImageTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "kpack"},
+				Spec: v1alpha1.ImageTemplateSpec{
+					TemplateSpec: v1alpha1.TemplateSpec{
+						Params: v1alpha1.TemplateParams{
+							{
+								Name:   "registry",
+								Schema: &v1alpha1.ParamSchema{Type: "string", Pattern: "^[a-z.]+/[a-z]+$"},
+							},
+						},
+					},
+				},
+			},
+		}
+		supplyChain = &v1alpha1.ClusterSupplyChain{
+			ObjectMeta: metav1.ObjectMeta{Name: "build"},
+			Spec: v1alpha1.SupplyChainSpec{
+				LegacySelector: v1alpha1.LegacySelector{
+					Selector: map[string]string{"app": "web"},
+				},
+				Resources: []v1alpha1.SupplyChainResource{
+					{
+						Name: "image-builder",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{
+							Kind: "ClusterImageTemplate",
+							Name: "kpack",
+						},
+					},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		validator = &v1alpha1.BlueprintValidator{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(clientObjects...).Build(),
+		}
+	})
+
+	Context("params that conform to the template's schema", func() {
+		BeforeEach(func() {
+			supplyChain.Spec.Params = []v1alpha1.BlueprintParam{
+				{Name: "registry", Value: &apiextensionsv1.JSON{Raw: []byte(`"gcr.io/team"`)}},
+			}
+		})
+
+		It("creates without error", func() {
+			Expect(validator.ValidateCreate(ctx, supplyChain)).To(BeEmpty())
+		})
+	})
+
+	Context("a blueprint param that violates the template's schema", func() {
+		BeforeEach(func() {
+			supplyChain.Spec.Params = []v1alpha1.BlueprintParam{
+				{Name: "registry", DefaultValue: &apiextensionsv1.JSON{Raw: []byte(`42`)}},
+			}
+		})
+
+		It("returns an error on create and update", func() {
+			expectedErr := "error validating clustersupplychain [build]: resource [image-builder] does not satisfy template [ClusterImageTemplate/kpack]: param [registry] is invalid: value [42] must be of type string"
+			_, err := validator.ValidateCreate(ctx, supplyChain)
+			Expect(err).To(MatchError(expectedErr))
+			_, err = validator.ValidateUpdate(ctx, nil, supplyChain)
+			Expect(err).To(MatchError(expectedErr))
+		})
+	})
+
+	Context("a resource param that violates the template's schema", func() {
+		BeforeEach(func() {
+			supplyChain.Spec.Resources[0].Params = []v1alpha1.BlueprintParam{
+				{Name: "registry", Value: &apiextensionsv1.JSON{Raw: []byte(`"GCR"`)}},
+			}
+		})
+
+		It("returns an error", func() {
+			_, err := validator.ValidateCreate(ctx, supplyChain)
+			Expect(err).To(MatchError(
+				"error validating clustersupplychain [build]: resource [image-builder] does not satisfy template [ClusterImageTemplate/kpack]: param [registry] is invalid: value [\"GCR\"] must match pattern [^[a-z.]+/[a-z]+$]",
+			))
+		})
+	})
+
+	Context("the referenced template does not exist yet", func() {
+		BeforeEach(func() {
+			clientObjects = nil
+			supplyChain.Spec.Params = []v1alpha1.BlueprintParam{
+				{Name: "registry", Value: &apiextensionsv1.JSON{Raw: []byte(`42`)}},
+			}
+		})
+
+		It("creates without error", func() {
+			Expect(validator.ValidateCreate(ctx, supplyChain)).To(BeEmpty())
+		})
+	})
+
+	Context("the template reads inputs", func() {
+		BeforeEach(func() {
+			clientObjects[0].(*v1alpha1.ClusterImageTemplate).Spec.Template = &runtime.RawExtension{
+				Raw: []byte(`{"apiVersion":"kpack.io/v1alpha2","kind":"Image","spec":{"source":{"blob":{"url":"$(sources.app.url)$"}},"tag":"$(params.registry)$/$(workload.metadata.name)$"}}`),
+			}
+			supplyChain.Spec.Resources = append([]v1alpha1.SupplyChainResource{
+				{
+					Name: "source-provider",
+					TemplateRef: v1alpha1.SupplyChainTemplateReference{
+						Kind: "ClusterSourceTemplate",
+						Name: "git",
+					},
+				},
+			}, supplyChain.Spec.Resources...)
+		})
+
+		Context("the resource provides them", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Resources[1].Sources = []v1alpha1.ResourceReference{
+					{Name: "app", Resource: "source-provider"},
+				}
+			})
+
+			It("creates without error", func() {
+				Expect(validator.ValidateCreate(ctx, supplyChain)).To(BeEmpty())
+			})
+		})
+
+		Context("the resource does not provide a source the template reads", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Resources[1].Sources = []v1alpha1.ResourceReference{
+					{Name: "source", Resource: "source-provider"},
+				}
+			})
+
+			It("returns an error", func() {
+				_, err := validator.ValidateCreate(ctx, supplyChain)
+				Expect(err).To(MatchError(
+					"error validating clustersupplychain [build]: resource [image-builder] does not satisfy template [ClusterImageTemplate/kpack]: template reads source [app] which the resource does not provide",
+				))
+			})
+		})
+	})
+
+	Context("a ytt template reads an input the resource does not provide", func() {
+		BeforeEach(func() {
+			clientObjects[0].(*v1alpha1.ClusterImageTemplate).Spec.Ytt = `
+#@ load("@ytt:data", "data")
+#@ if hasattr(data.values.sources, "app"):
+url: #@ data.values.sources.app.url
+#@ end
+`
+		})
+
+		It("warns rather than returning an error", func() {
+			warnings, err := validator.ValidateCreate(ctx, supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(
+				"resource [image-builder] may not satisfy template [ClusterImageTemplate/kpack]: template reads source [app] which the resource does not provide",
+			))
+		})
+	})
+
+	Context("the template reads the only source of the resource", func() {
+		BeforeEach(func() {
+			clientObjects[0].(*v1alpha1.ClusterImageTemplate).Spec.Template = &runtime.RawExtension{
+				Raw: []byte(`{"apiVersion":"kpack.io/v1alpha2","kind":"Image","spec":{"source":{"blob":{"url":"$(source.url)$"}}}}`),
+			}
+		})
+
+		It("returns an error when the resource has no source", func() {
+			_, err := validator.ValidateCreate(ctx, supplyChain)
+			Expect(err).To(MatchError(
+				"error validating clustersupplychain [build]: resource [image-builder] does not satisfy template [ClusterImageTemplate/kpack]: template reads the only source of the resource, but the resource provides 0",
+			))
+		})
+	})
+
+	Context("the resource pins a revision of the template", func() {
+		BeforeEach(func() {
+			previous := clientObjects[0].(*v1alpha1.ClusterImageTemplate).DeepCopy()
+			previous.Spec.Params[0].Schema = &v1alpha1.ParamSchema{Type: "integer"}
+			revision, err := v1alpha1.NewTemplateRevision(v1alpha1.TemplateRevisionReference{
+				Kind: "ClusterImageTemplate",
+				Name: "kpack",
+			}, 1, previous)
+			Expect(err).NotTo(HaveOccurred())
+			clientObjects = append(clientObjects, revision)
+
+			supplyChain.Spec.Resources[0].TemplateRef.Revision = 1
+			supplyChain.Spec.Params = []v1alpha1.BlueprintParam{
+				{Name: "registry", Value: &apiextensionsv1.JSON{Raw: []byte(`42`)}},
+			}
+		})
+
+		It("validates the resource against the pinned revision", func() {
+			Expect(validator.ValidateCreate(ctx, supplyChain)).To(BeEmpty())
+		})
+
+		Context("the revision does not exist", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Resources[0].TemplateRef.Revision = 2
+			})
+
+			It("returns an error", func() {
+				_, err := validator.ValidateCreate(ctx, supplyChain)
+				Expect(err).To(MatchError(
+					"error validating clustersupplychain [build]: failed to get template [ClusterImageTemplate/kpack] of resource [image-builder]: revision [2] does not exist",
+				))
+			})
+		})
+
+		Context("the resource selects its template among options", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Resources[0].TemplateRef = v1alpha1.SupplyChainTemplateReference{
+					Kind:     "ClusterImageTemplate",
+					Revision: 1,
+					Options: []v1alpha1.TemplateOption{
+						{Name: "kpack", Selector: v1alpha1.Selector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"builder": "kpack"}}}},
+						{Name: "kaniko", Selector: v1alpha1.Selector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"builder": "kaniko"}}}},
+					},
+				}
+			})
+
+			It("returns an error", func() {
+				_, err := validator.ValidateCreate(ctx, supplyChain)
+				Expect(err).To(MatchError(ContainSubstring("templateRef.Revision can only be specified together with templateRef.Name")))
+			})
+		})
+	})
+
+	Context("the supply chain itself is invalid", func() {
+		BeforeEach(func() {
+			supplyChain.Spec.Selector = nil
+		})
+
+		It("returns the error of the supply chain", func() {
+			_, err := validator.ValidateCreate(ctx, supplyChain)
+			Expect(err).To(MatchError(
+				"error validating clustersupplychain [build]: at least one selector, selectorMatchExpression, selectorMatchField must be specified",
+			))
+		})
+	})
+
+	Context("a delivery", func() {
+		var delivery *v1alpha1.Delivery
+
+		BeforeEach(func() {
+			clientObjects = append(clientObjects, &v1alpha1.Template{
+				ObjectMeta: metav1.ObjectMeta{Name: "deploy", Namespace: "apps"},
+				Spec: v1alpha1.TemplateSpec{
+					Params: v1alpha1.TemplateParams{
+						{Name: "replicas", Schema: &v1alpha1.ParamSchema{Type: "integer"}},
+					},
+				},
+			})
+			delivery = &v1alpha1.Delivery{
+				ObjectMeta: metav1.ObjectMeta{Name: "deliver", Namespace: "apps"},
+				Spec: v1alpha1.DeliverySpec{
+					LegacySelector: v1alpha1.LegacySelector{
+						Selector: map[string]string{"app": "web"},
+					},
+					Params: []v1alpha1.BlueprintParam{
+						{Name: "replicas", Value: &apiextensionsv1.JSON{Raw: []byte(`"two"`)}},
+					},
+					Resources: []v1alpha1.DeliveryResource{
+						{
+							Name: "deployer",
+							TemplateRef: v1alpha1.DeliveryTemplateReference{
+								Kind: "Template",
+								Name: "deploy",
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("validates its params against the templates of its namespace", func() {
+			_, err := validator.ValidateCreate(ctx, delivery)
+			Expect(err).To(MatchError(ContainSubstring(
+				"error validating delivery [deliver]: resource [deployer] does not satisfy template [Template/deploy]: param [replicas] is invalid",
+			)))
+		})
+	})
+})
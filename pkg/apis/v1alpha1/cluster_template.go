@@ -21,9 +21,20 @@ package v1alpha1
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// TemplateObject is implemented by every template kind a supply chain or delivery
+// resource can stamp, so that their status can be reconciled alike.
+// +kubebuilder:object:generate=false
+type TemplateObject interface {
+	client.Object
+	GetTemplateSpec() *TemplateSpec
+	GetTemplateStatus() *TemplateStatus
+}
+
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 // +kubebuilder:resource:path=clustertemplates,scope=Cluster,shortName=ct
 
 type ClusterTemplate struct {
@@ -33,6 +44,10 @@ type ClusterTemplate struct {
 	// Spec describes the template.
 	// More info: https://cartographer.sh/docs/latest/reference/template/#clustertemplate
 	Spec TemplateSpec `json:"spec"`
+
+	// Status describes the inputs the template reads.
+	// +optional
+	Status TemplateStatus `json:"status,omitempty"`
 }
 
 type TemplateSpec struct {
@@ -84,6 +99,11 @@ type TemplateSpec struct {
 	// values will increase memory footprint.
 	// If unspecified on immutable/tekton, default behavior will == {maxFailedRuns: 10, maxSuccessfulRuns: 10}
 	RetentionPolicy *RetentionPolicy `json:"retentionPolicy,omitempty"`
+
+	// Rollout stages the adoption of changes to the template by the owners that
+	// stamp it. Without it, every owner adopts a change at once.
+	// +optional
+	Rollout *Rollout `json:"rollout,omitempty"`
 }
 
 // HealthRule specifies rubric for determining the health of a resource.
@@ -148,6 +168,14 @@ type ClusterTemplateList struct {
 	Items           []ClusterTemplate `json:"items"`
 }
 
+func (c *ClusterTemplate) GetTemplateSpec() *TemplateSpec {
+	return &c.Spec
+}
+
+func (c *ClusterTemplate) GetTemplateStatus() *TemplateStatus {
+	return &c.Status
+}
+
 func init() {
 	SchemeBuilder.Register(
 		&ClusterTemplate{},
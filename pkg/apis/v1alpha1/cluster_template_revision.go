@@ -0,0 +1,191 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +versionName=v1alpha1
+// +groupName=carto.run
+// +kubebuilder:object:generate=true
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const TemplateRevisionKind = "ClusterTemplateRevision"
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=clustertemplaterevisions,scope=Cluster,shortName=ctr
+// +kubebuilder:printcolumn:name="Template Kind",type="string",JSONPath=`.spec.templateRef.kind`
+// +kubebuilder:printcolumn:name="Template Namespace",type="string",JSONPath=`.spec.templateRef.namespace`
+// +kubebuilder:printcolumn:name="Template Name",type="string",JSONPath=`.spec.templateRef.name`
+// +kubebuilder:printcolumn:name="Revision",type="integer",JSONPath=`.spec.revision`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// ClusterTemplateRevision is a snapshot of the spec of a template, taken by
+// Cartographer every time the spec changes.
+type ClusterTemplateRevision struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec describes the revision.
+	Spec TemplateRevisionSpec `json:"spec"`
+}
+
+type TemplateRevisionSpec struct {
+	// TemplateRef is the template this is a revision of.
+	TemplateRef TemplateRevisionReference `json:"templateRef"`
+
+	// Revision numbers the changes to the spec of the template, starting at 1.
+	// +kubebuilder:validation:Minimum=1
+	Revision int64 `json:"revision"`
+
+	// Template is the spec of the template at this revision, without its rollout.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Template runtime.RawExtension `json:"template"`
+}
+
+type TemplateRevisionReference struct {
+	// Kind of the template, eg: ClusterSourceTemplate
+	Kind string `json:"kind"`
+
+	// Namespace of the template. Empty for cluster scoped templates.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the template.
+	Name string `json:"name"`
+}
+
+// +kubebuilder:object:root=true
+
+type ClusterTemplateRevisionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterTemplateRevision `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&ClusterTemplateRevision{},
+		&ClusterTemplateRevisionList{},
+	)
+}
+
+const (
+	TemplateRevisionKindLabel      = "carto.run/template-kind"
+	TemplateRevisionNamespaceLabel = "carto.run/template-namespace"
+	TemplateRevisionNameLabel      = "carto.run/template-name"
+	TemplateRevisionRefLabel       = "carto.run/template-ref"
+)
+
+// TemplateRevisionName is the name of a revision of a template.
+func TemplateRevisionName(ref TemplateRevisionReference, revision int64) string {
+	return revisionName(ref.Kind, ref.Namespace, ref.Name, revision)
+}
+
+// TemplateRevisionSelector selects the revisions of the referenced template. The name
+// of a template may not fit in a label value, so it is selected by a hash of the
+// reference.
+func TemplateRevisionSelector(ref TemplateRevisionReference) map[string]string {
+	return map[string]string{
+		TemplateRevisionKindLabel:      ref.Kind,
+		TemplateRevisionNamespaceLabel: ref.Namespace,
+		TemplateRevisionRefLabel:       revisionRefHash(ref.Kind, ref.Namespace, ref.Name),
+	}
+}
+
+// NewTemplateRevision snapshots the spec of template as the given revision.
+func NewTemplateRevision(ref TemplateRevisionReference, revision int64, template TemplateObject) (*ClusterTemplateRevision, error) {
+	snapshot, err := TemplateSnapshot(template)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := TemplateRevisionSelector(ref)
+	if len(validation.IsValidLabelValue(ref.Name)) == 0 {
+		labels[TemplateRevisionNameLabel] = ref.Name
+	}
+
+	return &ClusterTemplateRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   TemplateRevisionName(ref, revision),
+			Labels: labels,
+		},
+		Spec: TemplateRevisionSpec{
+			TemplateRef: ref,
+			Revision:    revision,
+			Template:    runtime.RawExtension{Raw: snapshot},
+		},
+	}, nil
+}
+
+// TemplateSnapshot is the spec of template as recorded in its revisions. The rollout
+// is left out, as changing how a revision is rolled out does not make a new one.
+func TemplateSnapshot(template TemplateObject) ([]byte, error) {
+	return specSnapshot(template, "template")
+}
+
+// Matches reports whether the revision is a snapshot of the current spec of template.
+func (r *ClusterTemplateRevision) Matches(template TemplateObject) (bool, error) {
+	snapshot, err := TemplateSnapshot(template)
+	if err != nil {
+		return false, err
+	}
+
+	matches, err := snapshotsEqual(snapshot, r.Spec.Template.Raw)
+	if err != nil {
+		return false, fmt.Errorf("failed to compare revision [%s]: %w", r.Name, err)
+	}
+	return matches, nil
+}
+
+// Template returns the template at this revision for when the template no longer
+// exists: only its kind, namespace and name are known besides its spec.
+func (r *ClusterTemplateRevision) Template() (TemplateObject, error) {
+	apiTemplate, err := GetAPITemplate(r.Spec.TemplateRef.Kind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore revision [%s]: %w", r.Name, err)
+	}
+
+	template, ok := apiTemplate.(TemplateObject)
+	if !ok {
+		return nil, fmt.Errorf("revision [%s] is not of a template", r.Name)
+	}
+	template.SetNamespace(r.Spec.TemplateRef.Namespace)
+	template.SetName(r.Spec.TemplateRef.Name)
+
+	return r.Restore(template)
+}
+
+// Restore returns template with the spec it had at this revision. Its metadata and
+// status are left as they are.
+func (r *ClusterTemplateRevision) Restore(template TemplateObject) (TemplateObject, error) {
+	restored, err := GetAPITemplate(r.Spec.TemplateRef.Kind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore revision [%s]: %w", r.Name, err)
+	}
+	if err := restoreSpec(template, r.Spec.Template.Raw, restored); err != nil {
+		return nil, fmt.Errorf("failed to restore revision [%s]: %w", r.Name, err)
+	}
+
+	restoredTemplate, ok := restored.(TemplateObject)
+	if !ok {
+		return nil, fmt.Errorf("revision [%s] is not of a template", r.Name)
+	}
+	return restoredTemplate, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/validate-carto-run-v1alpha1-clustertemplaterevision,mutating=false,failurePolicy=fail,sideEffects=none,admissionReviewVersions=v1beta1;v1,groups=carto.run,resources=clustertemplaterevisions,verbs=create;update,versions=v1alpha1,name=template-revision-validator.cartographer.com
+
+var _ webhook.Validator = &ClusterTemplateRevision{}
+
+func (c *ClusterTemplateRevision) ValidateCreate() error {
+	var template map[string]interface{}
+	if err := json.Unmarshal(c.Spec.Template.Raw, &template); err != nil {
+		return fmt.Errorf("invalid template revision: template is not an object: %w", err)
+	}
+	return nil
+}
+
+// ValidateUpdate keeps revisions immutable, so that the objects stamped from a
+// revision can always be traced back to the spec they were stamped from.
+func (c *ClusterTemplateRevision) ValidateUpdate(old runtime.Object) error {
+	oldRevision, ok := old.(*ClusterTemplateRevision)
+	if !ok {
+		return fmt.Errorf("expected a ClusterTemplateRevision but got a %T", old)
+	}
+
+	if oldRevision.Spec.TemplateRef != c.Spec.TemplateRef || oldRevision.Spec.Revision != c.Spec.Revision {
+		return fmt.Errorf("invalid template revision: spec is immutable")
+	}
+
+	unchanged, err := snapshotsEqual(oldRevision.Spec.Template.Raw, c.Spec.Template.Raw)
+	if err != nil {
+		return fmt.Errorf("invalid template revision: %w", err)
+	}
+	if !unchanged {
+		return fmt.Errorf("invalid template revision: spec is immutable")
+	}
+
+	return nil
+}
+
+func (c *ClusterTemplateRevision) ValidateDelete() error {
+	return nil
+}
+
+func (c *ClusterTemplateRevision) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(c).
+		Complete()
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+var _ = Describe("ClusterTemplateRevision webhook", func() {
+	var revision *v1alpha1.ClusterTemplateRevision
+
+	BeforeEach(func() {
+		revision = &v1alpha1.ClusterTemplateRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: "clusterconfigtemplate.app-0123456789abcdef-1"},
+			Spec: v1alpha1.TemplateRevisionSpec{
+				TemplateRef: v1alpha1.TemplateRevisionReference{Kind: "ClusterConfigTemplate", Name: "app"},
+				Revision:    1,
+				Template:    runtime.RawExtension{Raw: []byte(`{"configPath":".data"}`)},
+			},
+		}
+	})
+
+	Describe("#Create", func() {
+		It("accepts a template object", func() {
+			Expect(revision.ValidateCreate()).To(Succeed())
+		})
+
+		Context("the template is not an object", func() {
+			BeforeEach(func() {
+				revision.Spec.Template.Raw = []byte(`"some-string"`)
+			})
+
+			It("returns an error", func() {
+				Expect(revision.ValidateCreate()).To(MatchError(ContainSubstring("invalid template revision: template is not an object")))
+			})
+		})
+	})
+
+	Describe("#Update", func() {
+		var updated *v1alpha1.ClusterTemplateRevision
+
+		BeforeEach(func() {
+			updated = revision.DeepCopy()
+			updated.Labels = map[string]string{"some": "label"}
+		})
+
+		It("accepts changes outside of the spec", func() {
+			Expect(updated.ValidateUpdate(revision)).To(Succeed())
+		})
+
+		Context("the template changes", func() {
+			BeforeEach(func() {
+				updated.Spec.Template.Raw = []byte(`{"configPath":".spec"}`)
+			})
+
+			It("returns an error", func() {
+				Expect(updated.ValidateUpdate(revision)).To(MatchError("invalid template revision: spec is immutable"))
+			})
+		})
+
+		Context("the revision changes", func() {
+			BeforeEach(func() {
+				updated.Spec.Revision = 2
+			})
+
+			It("returns an error", func() {
+				Expect(updated.ValidateUpdate(revision)).To(MatchError("invalid template revision: spec is immutable"))
+			})
+		})
+	})
+})
@@ -20,6 +20,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	crdmarkers "sigs.k8s.io/controller-tools/pkg/crd/markers"
@@ -250,6 +251,60 @@ var _ = Describe("ClusterTemplate", func() {
 				})
 			})
 
+			Context("template reads fields of the owner", func() {
+				It("succeeds when the fields exist", func() {
+					template.Spec.Template = &runtime.RawExtension{Raw: []byte(`{
+						"apiVersion": "v1",
+						"kind": "ConfigMap",
+						"metadata": {"name": "$(workload.metadata.name)$", "labels": {"app": "$(workload.metadata.labels.app)$"}},
+						"data": {
+							"url": "$(workload.spec.source.git.url)$",
+							"cpu": "$(workload.spec.resources.requests.cpu)$",
+							"claim": "$(workload.spec.serviceClaims[0].ref.kind)$",
+							"param": "$(workload.spec.params[?(@.name==\"port\")].value)$",
+							"revision": "$(deliverable.spec.source.git.ref.commit)$",
+							"ready": "$(workload.status.conditions[0].status)$"
+						}
+					}`)}
+
+					Expect(template.ValidateCreate()).To(Succeed())
+				})
+
+				It("returns an error when a field cannot exist", func() {
+					template.Spec.Template = &runtime.RawExtension{Raw: []byte(`{
+						"apiVersion": "v1",
+						"kind": "ConfigMap",
+						"metadata": {"name": "some-name"},
+						"data": {"url": "$(workload.spec.sourec.git.url)$"}
+					}`)}
+
+					Expect(template.ValidateCreate()).
+						To(MatchError("invalid template: template reads [workload.spec.sourec.git.url], which is not a field of a workload"))
+				})
+
+				It("returns an error when a field cannot exist on a deliverable", func() {
+					template.Spec.Template = &runtime.RawExtension{Raw: []byte(`{
+						"apiVersion": "v1",
+						"kind": "ConfigMap",
+						"metadata": {"name": "some-name"},
+						"data": {"env": "$(deliverable.spec.env)$"}
+					}`)}
+
+					Expect(template.ValidateCreate()).
+						To(MatchError("invalid template: template reads [deliverable.spec.env], which is not a field of a deliverable"))
+				})
+
+				It("succeeds when an unknown field is below metadata or params", func() {
+					template.Spec.Template = &runtime.RawExtension{Raw: []byte(`{
+						"apiVersion": "v1",
+						"kind": "ConfigMap",
+						"metadata": {"name": "$(workload.metadata.lables.app)$"}
+					}`)}
+
+					Expect(template.ValidateCreate()).To(Succeed())
+				})
+			})
+
 			Context("template missing", func() {
 				It("succeeds", func() {
 					Expect(template.ValidateCreate()).
@@ -367,6 +422,43 @@ var _ = Describe("ClusterTemplate", func() {
 					})
 				})
 			})
+			Context("params with a schema", func() {
+				BeforeEach(func() {
+					template.Spec.Ytt = "some: ytt"
+					template.Spec.Params = v1alpha1.TemplateParams{
+						{
+							Name:         "replicas",
+							DefaultValue: apiextensionsv1.JSON{Raw: []byte(`1`)},
+							Schema:       &v1alpha1.ParamSchema{Type: "integer"},
+						},
+					}
+				})
+
+				It("succeeds when the default conforms to the schema", func() {
+					Expect(template.ValidateCreate()).To(Succeed())
+				})
+
+				It("returns an error when the default does not conform to the schema", func() {
+					template.Spec.Params[0].DefaultValue = apiextensionsv1.JSON{Raw: []byte(`"one"`)}
+					Expect(template.ValidateCreate()).To(MatchError(
+						`invalid template: param [replicas] is invalid: default value ["one"] must be of type integer`,
+					))
+				})
+
+				It("returns an error when a required param has a default", func() {
+					template.Spec.Params[0].Schema.Required = true
+					Expect(template.ValidateCreate()).To(MatchError(
+						"invalid template: param [replicas] is invalid: a required param cannot have a default",
+					))
+				})
+
+				It("returns an error when the pattern is not a regular expression", func() {
+					template.Spec.Params[0].Schema = &v1alpha1.ParamSchema{Pattern: "(unclosed"}
+					Expect(template.ValidateCreate()).To(MatchError(ContainSubstring(
+						"invalid template: param [replicas] is invalid: schema pattern [(unclosed] is not a valid regular expression",
+					)))
+				})
+			})
 		})
 
 		Describe("#Update", func() {
@@ -36,8 +36,6 @@ func (c *ClusterTemplate) ValidateDelete() error {
 	return nil
 }
 
-func (c *ClusterTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(c).
-		Complete()
+func (c *ClusterTemplate) SetupWebhookWithManager(mgr ctrl.Manager, impactAnalyzer ImpactAnalyzer) error {
+	return registerWarningValidator(mgr, c, &TemplateValidator{ImpactAnalyzer: impactAnalyzer})
 }
@@ -30,6 +30,15 @@ const (
 	FieldSelectorOpDoesNotExist FieldSelectorOperator = "DoesNotExist"
 )
 
+// RestampAnnotation on a workload or deliverable requests that it is realized again.
+// Setting it to a new value, such as the current time, causes the next reconcile to submit
+// every object to the api server, creating immutable objects anew. Each value is acted upon once.
+const RestampAnnotation = "carto.run/restamp"
+
+// PausedAnnotation set to "true" on a workload or deliverable stops its reconciliation until
+// the annotation is removed. The objects it stamped are left as they are.
+const PausedAnnotation = "carto.run/paused"
+
 type OwnerStatus struct {
 	// ObservedGeneration refers to the metadata.Generation of the spec that resulted in
 	// the current `status`.
@@ -39,6 +48,13 @@ type OwnerStatus struct {
 	// of type `Ready`, and follows these Kubernetes conventions:
 	// https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#typical-status-properties
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// LastRestamp is the value of the carto.run/restamp annotation last acted upon.
+	LastRestamp string `json:"lastRestamp,omitempty"`
+
+	// PendingRestamp is the value of the carto.run/restamp annotation acted upon that
+	// realizing the resources failed for, and that is retried until it is the LastRestamp.
+	PendingRestamp string `json:"pendingRestamp,omitempty"`
 }
 
 type TemplateParams []TemplateParam
@@ -51,7 +67,36 @@ type TemplateParam struct {
 	// DefaultValue of the parameter.
 	// Causes the parameter to be optional; If the Owner or Template
 	// does not specify this parameter, this value is used.
+	// +optional
 	DefaultValue apiextensionsv1.JSON `json:"default"`
+
+	// Schema the value of the parameter must conform to. Values
+	// provided by the Blueprint or Owner are validated against it
+	// before the template is stamped.
+	// +optional
+	Schema *ParamSchema `json:"schema,omitempty"`
+}
+
+// ParamSchema is the subset of an OpenAPI schema that can be declared for a
+// template parameter.
+type ParamSchema struct {
+	// Type of the value.
+	// +kubebuilder:validation:Enum=string;number;integer;boolean;object;array
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Enum lists the values the parameter may take.
+	// +optional
+	Enum []apiextensionsv1.JSON `json:"enum,omitempty"`
+
+	// Pattern is a regular expression that string values must match.
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+
+	// Required parameters must be provided by the Blueprint or Owner.
+	// A required parameter cannot have a default.
+	// +optional
+	Required bool `json:"required,omitempty"`
 }
 
 type OwnerParam struct {
@@ -60,7 +105,29 @@ type OwnerParam struct {
 	Name string `json:"name"`
 
 	// Value of the parameter.
-	Value apiextensionsv1.JSON `json:"value"`
+	// Exactly one of value and valueFrom must be specified.
+	// +optional
+	Value apiextensionsv1.JSON `json:"value,omitempty"`
+
+	// ValueFrom sources the value of the parameter from a key of a Secret or
+	// ConfigMap in the owner's namespace, read with the owner's service account
+	// when resources are stamped. The value is passed to templates as a string.
+	// Values read from a Secret are redacted from the owner's status, from
+	// logs and from events.
+	// +optional
+	ValueFrom *ParamValueSource `json:"valueFrom,omitempty"`
+}
+
+// ParamValueSource selects the key of a Secret or ConfigMap holding the value
+// of a parameter. Exactly one of its fields must be specified.
+type ParamValueSource struct {
+	// SecretKeyRef selects a key of a Secret in the owner's namespace.
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// ConfigMapKeyRef selects a key of a ConfigMap in the owner's namespace.
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
 }
 
 type BlueprintParam struct {
@@ -154,12 +221,51 @@ func GetAPITemplate(templateKind string) (client.Object, error) {
 		template = &ClusterTemplate{}
 	case "ClusterDeploymentTemplate":
 		template = &ClusterDeploymentTemplate{}
+	case SupplyChainFragmentKind:
+		template = &ClusterSupplyChainFragment{}
+	case "SourceTemplate":
+		template = &SourceTemplate{}
+	case "ImageTemplate":
+		template = &ImageTemplate{}
+	case "ConfigTemplate":
+		template = &ConfigTemplate{}
+	case "Template":
+		template = &Template{}
+	case "DeploymentTemplate":
+		template = &DeploymentTemplate{}
 	default:
 		return nil, fmt.Errorf("resource does not have valid kind: %s", templateKind)
 	}
 	return template, nil
 }
 
+// namespacedTemplateKinds maps each namespaced template kind to the cluster scoped
+// kind it falls back to when no template of that name exists in the namespace.
+var namespacedTemplateKinds = map[string]string{
+	"SourceTemplate":     "ClusterSourceTemplate",
+	"ImageTemplate":      "ClusterImageTemplate",
+	"ConfigTemplate":     "ClusterConfigTemplate",
+	"Template":           "ClusterTemplate",
+	"DeploymentTemplate": "ClusterDeploymentTemplate",
+}
+
+// IsNamespacedTemplateKind reports whether templateKind is one of the namespaced
+// template kinds, eg: SourceTemplate.
+func IsNamespacedTemplateKind(templateKind string) bool {
+	_, ok := namespacedTemplateKinds[templateKind]
+	return ok
+}
+
+// ClusterTemplateKind returns the cluster scoped counterpart of a namespaced
+// template kind, eg: ClusterSourceTemplate for SourceTemplate. Any other kind is
+// returned unchanged.
+func ClusterTemplateKind(templateKind string) string {
+	if clusterKind, ok := namespacedTemplateKinds[templateKind]; ok {
+		return clusterKind
+	}
+	return templateKind
+}
+
 type TemplateOption struct {
 	// Name of the template to apply
 	// Name or PassThrough must be specified
@@ -222,6 +328,10 @@ type RealizedResource struct {
 	// TemplateRef is a reference to the template used to create the object in StampedRef
 	TemplateRef *corev1.ObjectReference `json:"templateRef,omitempty"`
 
+	// TemplateRevision is the revision of the template used to create the object in
+	// StampedRef, when known. See ClusterTemplateRevision.
+	TemplateRevision int64 `json:"templateRevision,omitempty"`
+
 	// Inputs are references to resources that were used to template the object in StampedRef
 	Inputs []Input `json:"inputs,omitempty"`
 
@@ -20,6 +20,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 )
@@ -37,6 +38,12 @@ var _ = Describe("common", func() {
 			Entry("ClusterImageTemplate", "ClusterImageTemplate", &v1alpha1.ClusterImageTemplate{}),
 			Entry("ClusterConfigTemplate", "ClusterConfigTemplate", &v1alpha1.ClusterConfigTemplate{}),
 			Entry("ClusterTemplate", "ClusterTemplate", &v1alpha1.ClusterTemplate{}),
+			Entry("ClusterSupplyChainFragment", "ClusterSupplyChainFragment", &v1alpha1.ClusterSupplyChainFragment{}),
+			Entry("SourceTemplate", "SourceTemplate", &v1alpha1.SourceTemplate{}),
+			Entry("ImageTemplate", "ImageTemplate", &v1alpha1.ImageTemplate{}),
+			Entry("ConfigTemplate", "ConfigTemplate", &v1alpha1.ConfigTemplate{}),
+			Entry("Template", "Template", &v1alpha1.Template{}),
+			Entry("DeploymentTemplate", "DeploymentTemplate", &v1alpha1.DeploymentTemplate{}),
 		)
 
 		Context("unknown template kind", func() {
@@ -49,4 +56,71 @@ var _ = Describe("common", func() {
 		})
 
 	})
+
+	Describe("TemplateParams.ValidateValues", func() {
+		var params v1alpha1.TemplateParams
+
+		BeforeEach(func() {
+			params = v1alpha1.TemplateParams{
+				{Name: "untyped"},
+				{Name: "replicas", Schema: &v1alpha1.ParamSchema{Type: "integer"}},
+				{Name: "env", Schema: &v1alpha1.ParamSchema{
+					Type: "string",
+					Enum: []apiextensionsv1.JSON{{Raw: []byte(`"dev"`)}, {Raw: []byte(`"prod"`)}},
+				}},
+				{Name: "registry", Schema: &v1alpha1.ParamSchema{Type: "string", Pattern: `^[a-z.]+/[a-z]+$`}},
+				{Name: "service-account", Schema: &v1alpha1.ParamSchema{Type: "string", Required: true}},
+			}
+		})
+
+		jsonValue := func(raw string) apiextensionsv1.JSON {
+			return apiextensionsv1.JSON{Raw: []byte(raw)}
+		}
+
+		It("accepts values that conform to the schemas", func() {
+			Expect(params.ValidateValues(map[string]apiextensionsv1.JSON{
+				"untyped":         jsonValue(`{"any": "thing"}`),
+				"replicas":        jsonValue(`3`),
+				"env":             jsonValue(`"prod"`),
+				"registry":        jsonValue(`"gcr.io/team"`),
+				"service-account": jsonValue(`"builder"`),
+			})).To(Succeed())
+		})
+
+		DescribeTable("rejects values that do not conform, naming the param",
+			func(name string, value string, expectedErr string) {
+				values := map[string]apiextensionsv1.JSON{"service-account": jsonValue(`"builder"`)}
+				values[name] = jsonValue(value)
+				Expect(params.ValidateValues(values)).To(MatchError(expectedErr))
+			},
+			Entry("wrong type", "replicas", `"3"`, `param [replicas] is invalid: value ["3"] must be of type integer`),
+			Entry("not an integer", "replicas", `1.5`, `param [replicas] is invalid: value [1.5] must be of type integer`),
+			Entry("not in enum", "env", `"staging"`, `param [env] is invalid: value ["staging"] must be one of ["dev", "prod"]`),
+			Entry("not matching pattern", "registry", `"GCR.io"`, `param [registry] is invalid: value ["GCR.io"] must match pattern [^[a-z.]+/[a-z]+$]`),
+		)
+
+		It("rejects a missing required param", func() {
+			Expect(params.ValidateValues(map[string]apiextensionsv1.JSON{})).To(MatchError("param [service-account] is required"))
+		})
+
+		It("rejects a null required param", func() {
+			Expect(params.ValidateValues(map[string]apiextensionsv1.JSON{
+				"service-account": jsonValue(`null`),
+			})).To(MatchError("param [service-account] is required"))
+		})
+	})
+
+	Describe("ClusterTemplateKind", func() {
+		DescribeTable("template kinds",
+			func(templateKind string, expectedKind string, namespaced bool) {
+				Expect(v1alpha1.ClusterTemplateKind(templateKind)).To(Equal(expectedKind))
+				Expect(v1alpha1.IsNamespacedTemplateKind(templateKind)).To(Equal(namespaced))
+			},
+			Entry("SourceTemplate", "SourceTemplate", "ClusterSourceTemplate", true),
+			Entry("DeploymentTemplate", "DeploymentTemplate", "ClusterDeploymentTemplate", true),
+			Entry("Template", "Template", "ClusterTemplate", true),
+			Entry("ClusterImageTemplate", "ClusterImageTemplate", "ClusterImageTemplate", false),
+			Entry("ClusterSupplyChainFragment", "ClusterSupplyChainFragment", "ClusterSupplyChainFragment", false),
+		)
+	})
 })
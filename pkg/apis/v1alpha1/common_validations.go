@@ -15,14 +15,20 @@
 package v1alpha1
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
+	"regexp"
 	"strings"
 
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func validateResourceOptions(options []TemplateOption, validPaths map[string]bool, validPrefixes []string) error {
@@ -201,6 +207,17 @@ func (t *TemplateSpec) validate() error {
 		if obj.GetNamespace() != metav1.NamespaceNone {
 			return fmt.Errorf("invalid template: template should not set metadata.namespace on the child object")
 		}
+		if _, err := t.validateOwnerReferences(); err != nil {
+			return err
+		}
+	}
+	if err := t.Params.validate(); err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	if t.Rollout != nil {
+		if err := t.Rollout.validate(); err != nil {
+			return fmt.Errorf("invalid template: %w", err)
+		}
 	}
 	if t.HealthRule != nil {
 		return t.HealthRule.validate()
@@ -245,3 +262,392 @@ func (m *MultiMatchHealthRule) validate() error {
 	}
 	return nil
 }
+
+// validateClusterScopedTemplateKind rejects namespaced template kinds for cluster scoped
+// blueprints, which have no namespace of their own to resolve such a template in.
+func validateClusterScopedTemplateKind(templateKind, namespacedBlueprintKind string) error {
+	if IsNamespacedTemplateKind(templateKind) {
+		return fmt.Errorf("templateRef.Kind [%s] is namespaced and can only be referenced by a %s", templateKind, namespacedBlueprintKind)
+	}
+	return nil
+}
+
+// validateServiceAccountRefNamespace keeps namespaced blueprints from using service
+// accounts of other namespaces.
+func validateServiceAccountRefNamespace(ref ServiceAccountRef, namespace string) error {
+	if ref.Namespace != "" && ref.Namespace != namespace {
+		return fmt.Errorf("serviceAccountRef.namespace [%s] must be empty or the namespace of the blueprint [%s]", ref.Namespace, namespace)
+	}
+	return nil
+}
+
+// validateOwnerParams applies on admission the rules on the source of owner param
+// values that are otherwise only enforced when resources are stamped.
+func validateOwnerParams(params []OwnerParam) error {
+	for _, param := range params {
+		if param.ValueFrom == nil {
+			continue
+		}
+
+		if len(param.Value.Raw) > 0 {
+			return fmt.Errorf("param [%s] must specify only one of value and valueFrom", param.Name)
+		}
+
+		switch {
+		case param.ValueFrom.SecretKeyRef != nil && param.ValueFrom.ConfigMapKeyRef != nil:
+			return fmt.Errorf("param [%s] is invalid: valueFrom must specify only one of secretKeyRef and configMapKeyRef", param.Name)
+		case param.ValueFrom.SecretKeyRef == nil && param.ValueFrom.ConfigMapKeyRef == nil:
+			return fmt.Errorf("param [%s] is invalid: valueFrom must specify one of secretKeyRef and configMapKeyRef", param.Name)
+		}
+	}
+	return nil
+}
+
+func (p TemplateParams) validate() error {
+	for _, param := range p {
+		if param.Schema == nil {
+			continue
+		}
+
+		if param.Schema.Pattern != "" {
+			if _, err := regexp.Compile(param.Schema.Pattern); err != nil {
+				return fmt.Errorf("param [%s] is invalid: schema pattern [%s] is not a valid regular expression: %w", param.Name, param.Schema.Pattern, err)
+			}
+		}
+
+		if isNullParamValue(param.DefaultValue) {
+			continue
+		}
+
+		if param.Schema.Required {
+			return fmt.Errorf("param [%s] is invalid: a required param cannot have a default", param.Name)
+		}
+
+		if err := param.Schema.validateValue(param.DefaultValue); err != nil {
+			return fmt.Errorf("param [%s] is invalid: default %w", param.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateValues checks the values a template will be stamped with against the
+// schemas of its params. Params without a schema accept any value.
+func (p TemplateParams) ValidateValues(values map[string]apiextensionsv1.JSON) error {
+	for _, param := range p {
+		if param.Schema == nil {
+			continue
+		}
+
+		value, ok := values[param.Name]
+		if !ok || isNullParamValue(value) {
+			if param.Schema.Required {
+				return fmt.Errorf("param [%s] is required", param.Name)
+			}
+			continue
+		}
+
+		if err := param.Schema.validateValue(value); err != nil {
+			return fmt.Errorf("param [%s] is invalid: %w", param.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// blueprintResourceRef is a blueprint resource as seen by the validation of the
+// templates it references: the templates it may stamp, the params it passes them and
+// the inputs it provides.
+type blueprintResourceRef struct {
+	resourceName  string
+	kind          string
+	templateNames []string
+	revision      int64
+	params        []BlueprintParam
+	sources       []ResourceReference
+	images        []ResourceReference
+	configs       []ResourceReference
+	deployment    bool
+}
+
+func supplyChainResourceRefs(resources []SupplyChainResource) []blueprintResourceRef {
+	var refs []blueprintResourceRef
+	for _, resource := range resources {
+		refs = append(refs, blueprintResourceRef{
+			resourceName:  resource.Name,
+			kind:          resource.TemplateRef.Kind,
+			templateNames: templateNames(resource.TemplateRef.Name, resource.TemplateRef.Options),
+			revision:      resource.TemplateRef.Revision,
+			params:        resource.Params,
+			sources:       resource.Sources,
+			images:        resource.Images,
+			configs:       resource.Configs,
+		})
+	}
+	return refs
+}
+
+func deliveryResourceRefs(resources []DeliveryResource) []blueprintResourceRef {
+	var refs []blueprintResourceRef
+	for _, resource := range resources {
+		refs = append(refs, blueprintResourceRef{
+			resourceName:  resource.Name,
+			kind:          resource.TemplateRef.Kind,
+			templateNames: templateNames(resource.TemplateRef.Name, resource.TemplateRef.Options),
+			revision:      resource.TemplateRef.Revision,
+			params:        resource.Params,
+			sources:       resource.Sources,
+			deployment:    resource.Deployment != nil,
+		})
+	}
+	return refs
+}
+
+func templateNames(name string, options []TemplateOption) []string {
+	if name != "" {
+		return []string{name}
+	}
+	var names []string
+	for _, option := range options {
+		if option.Name != "" {
+			names = append(names, option.Name)
+		}
+	}
+	return names
+}
+
+// validateBlueprintTemplates checks the templates referenced by the resources of a
+// blueprint: the values the blueprint provides for its params must satisfy their
+// schemas, and the resources must provide the sources, images, configs and deployment
+// the templates read. A resource pinning a revision is checked against that revision,
+// which must exist. Templates that do not exist yet are skipped: they are validated
+// when the blueprint is reconciled. The inputs of ytt templates are only discovered on a
+// best effort basis, so missing ones are returned as warnings rather than errors.
+func validateBlueprintTemplates(ctx context.Context, reader client.Reader, namespace string, blueprintParams []BlueprintParam, refs []blueprintResourceRef) ([]string, error) {
+	var warnings []string
+	for _, ref := range refs {
+		if ref.kind == SupplyChainFragmentKind {
+			continue
+		}
+
+		values := blueprintParamValues(blueprintParams, ref.params)
+
+		for _, name := range ref.templateNames {
+			templateSpec, err := getTemplateSpec(ctx, reader, ref.kind, name, namespace, ref.revision)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get template [%s/%s] of resource [%s]: %w", ref.kind, name, ref.resourceName, err)
+			}
+			if templateSpec == nil {
+				continue
+			}
+
+			if err := templateSpec.Params.validateProvidedValues(values); err != nil {
+				return nil, fmt.Errorf("resource [%s] does not satisfy template [%s/%s]: %w", ref.resourceName, ref.kind, name, err)
+			}
+
+			inputs := templateSpec.DiscoverInputs()
+			if err := ref.validateProvidedInputs(inputs); err != nil {
+				if inputs.BestEffort {
+					warnings = append(warnings, fmt.Sprintf("resource [%s] may not satisfy template [%s/%s]: %s", ref.resourceName, ref.kind, name, err))
+					continue
+				}
+				return nil, fmt.Errorf("resource [%s] does not satisfy template [%s/%s]: %w", ref.resourceName, ref.kind, name, err)
+			}
+		}
+	}
+
+	return warnings, nil
+}
+
+// validateProvidedInputs checks that the resource provides the inputs a template reads.
+// Params are not checked: an owner may provide params no blueprint declares.
+func (r blueprintResourceRef) validateProvidedInputs(inputs *TemplateInputs) error {
+	for _, input := range []struct {
+		kind     string
+		read     []string
+		readOnly bool
+		provided []ResourceReference
+	}{
+		{"source", inputs.Sources, inputs.Source, r.sources},
+		{"image", inputs.Images, inputs.Image, r.images},
+		{"config", inputs.Configs, inputs.Config, r.configs},
+	} {
+		for _, name := range input.read {
+			if !isPassThroughInputFound(input.provided, name) {
+				return fmt.Errorf("template reads %s [%s] which the resource does not provide", input.kind, name)
+			}
+		}
+
+		if input.readOnly && len(input.provided) != 1 {
+			return fmt.Errorf("template reads the only %s of the resource, but the resource provides %d", input.kind, len(input.provided))
+		}
+	}
+
+	if inputs.Deployment && !r.deployment {
+		return fmt.Errorf("template reads the deployment, which the resource does not provide")
+	}
+
+	return nil
+}
+
+func blueprintParamValues(blueprintParams []BlueprintParam, resourceParams []BlueprintParam) map[string]apiextensionsv1.JSON {
+	values := map[string]apiextensionsv1.JSON{}
+	for _, params := range [][]BlueprintParam{blueprintParams, resourceParams} {
+		for _, param := range params {
+			if param.Value != nil {
+				values[param.Name] = *param.Value
+			} else if param.DefaultValue != nil {
+				values[param.Name] = *param.DefaultValue
+			}
+		}
+	}
+	return values
+}
+
+func getTemplateSpec(ctx context.Context, reader client.Reader, kind, name, namespace string, revision int64) (*TemplateSpec, error) {
+	object, kind, err := GetTemplate(ctx, reader, kind, name, namespace)
+	if err != nil || object == nil {
+		return nil, err
+	}
+
+	template, ok := object.(TemplateObject)
+	if !ok {
+		return nil, nil
+	}
+
+	if revision == 0 {
+		return template.GetTemplateSpec(), nil
+	}
+
+	revisionName := TemplateRevisionName(TemplateRevisionReference{Kind: kind, Namespace: template.GetNamespace(), Name: name}, revision)
+	templateRevision := &ClusterTemplateRevision{}
+	if err := reader.Get(ctx, client.ObjectKey{Name: revisionName}, templateRevision); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("revision [%d] does not exist", revision)
+		}
+		return nil, err
+	}
+
+	restored, err := templateRevision.Restore(template)
+	if err != nil {
+		return nil, err
+	}
+	return restored.GetTemplateSpec(), nil
+}
+
+// GetTemplate returns the template of the given kind and name along with its kind, a
+// namespaced kind falling back to the cluster scoped template of the same name, or nil
+// when there is none.
+func GetTemplate(ctx context.Context, reader client.Reader, kind, name, namespace string) (client.Object, string, error) {
+	if IsNamespacedTemplateKind(kind) {
+		if namespace != "" {
+			template, err := getTemplateOfKind(ctx, reader, kind, name, namespace)
+			if err == nil || !kerrors.IsNotFound(err) {
+				return template, kind, err
+			}
+		}
+		kind = ClusterTemplateKind(kind)
+	}
+
+	template, err := getTemplateOfKind(ctx, reader, kind, name, "")
+	if kerrors.IsNotFound(err) {
+		return nil, kind, nil
+	}
+	return template, kind, err
+}
+
+func getTemplateOfKind(ctx context.Context, reader client.Reader, kind, name, namespace string) (client.Object, error) {
+	template, err := GetAPITemplate(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := reader.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// validateProvidedValues is ValidateValues without the check for required params,
+// which an owner may still provide.
+func (p TemplateParams) validateProvidedValues(values map[string]apiextensionsv1.JSON) error {
+	for _, param := range p {
+		value, ok := values[param.Name]
+		if param.Schema == nil || !ok || isNullParamValue(value) {
+			continue
+		}
+
+		if err := param.Schema.validateValue(value); err != nil {
+			return fmt.Errorf("param [%s] is invalid: %w", param.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *ParamSchema) validateValue(value apiextensionsv1.JSON) error {
+	var v interface{}
+	if err := json.Unmarshal(value.Raw, &v); err != nil {
+		return fmt.Errorf("value [%s] is not valid json: %w", string(value.Raw), err)
+	}
+
+	if s.Type != "" && !paramValueHasType(v, s.Type) {
+		return fmt.Errorf("value [%s] must be of type %s", string(value.Raw), s.Type)
+	}
+
+	if len(s.Enum) > 0 {
+		var allowed []string
+		found := false
+		for _, enumValue := range s.Enum {
+			allowed = append(allowed, string(enumValue.Raw))
+			var e interface{}
+			if err := json.Unmarshal(enumValue.Raw, &e); err == nil && reflect.DeepEqual(e, v) {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("value [%s] must be one of [%s]", string(value.Raw), strings.Join(allowed, ", "))
+		}
+	}
+
+	if str, ok := v.(string); ok && s.Pattern != "" {
+		matched, err := regexp.MatchString(s.Pattern, str)
+		if err != nil {
+			return fmt.Errorf("schema pattern [%s] is not a valid regular expression: %w", s.Pattern, err)
+		}
+		if !matched {
+			return fmt.Errorf("value [%s] must match pattern [%s]", string(value.Raw), s.Pattern)
+		}
+	}
+
+	return nil
+}
+
+func paramValueHasType(v interface{}, schemaType string) bool {
+	switch schemaType {
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	case "integer":
+		f, ok := v.(float64)
+		return ok && f == math.Trunc(f)
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "object":
+		_, ok := v.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := v.([]interface{})
+		return ok
+	default:
+		return false
+	}
+}
+
+func isNullParamValue(value apiextensionsv1.JSON) bool {
+	return len(value.Raw) == 0 || string(value.Raw) == "null"
+}
@@ -20,6 +20,8 @@ package v1alpha1
 //   Workload            Deliverable
 //     SupplyChainReady    DeliveryReady
 //     ResourcesSubmitted  ResourcesSubmitted
+//     ParamsUsed
+//     Paused              Paused
 //     Ready               Ready
 
 // -- OWNER ConditionTypes
@@ -30,6 +32,8 @@ const (
 	WorkloadSupplyChainReady = "SupplyChainReady"
 	DeliverableDeliveryReady = "DeliveryReady"
 	OwnerResourcesSubmitted  = "ResourcesSubmitted"
+	WorkloadParamsUsed       = "ParamsUsed"
+	OwnerPaused              = "Paused"
 )
 
 // -- OWNER ConditionType - SupplyChainReady ConditionReasons
@@ -41,6 +45,22 @@ const (
 	MultipleMatchesSupplyChainReadyReason  = "MultipleSupplyChainMatches"
 )
 
+// -- OWNER ConditionType - ParamsUsed ConditionReasons
+// ParamsUsed is informational: it does not affect the Ready condition.
+
+const (
+	ParamsUsedReason       = "ParamsUsed"
+	UnusedParamsReason     = "UnusedParams"
+	OverriddenParamsReason = "OverriddenParams"
+)
+
+// -- OWNER ConditionType - Paused ConditionReasons
+// Paused is only present while reconciliation is paused, and leaves the other conditions as they were.
+
+const (
+	PausedAnnotationReason = "PausedAnnotation"
+)
+
 // -- OWNER ConditionType - DeliveryReady ConditionReasons
 
 const (
@@ -58,6 +78,8 @@ const (
 	TemplateObjectRetrievalFailureResourcesSubmittedReason = "TemplateObjectRetrievalFailure"
 	MissingValueAtPathResourcesSubmittedReason             = "MissingValueAtPath"
 	TemplateStampFailureResourcesSubmittedReason           = "TemplateStampFailure"
+	InvalidParamsResourcesSubmittedReason                  = "InvalidParams"
+	UnresolvableParamsResourcesSubmittedReason             = "UnresolvableParams"
 	TemplateRejectedByAPIServerResourcesSubmittedReason    = "TemplateRejectedByAPIServer"
 	UnknownErrorResourcesSubmittedReason                   = "UnknownError"
 	ResolveTemplateOptionsErrorResourcesSubmittedReason    = "ResolveTemplateOptionsError"
@@ -79,6 +101,7 @@ const (
 	ServiceAccountErrorResourcesSubmittedReason          = "ServiceAccountError"
 	ServiceAccountTokenErrorResourcesSubmittedReason     = "ServiceAccountTokenError"
 	ResourceRealizerBuilderErrorResourcesSubmittedReason = "ResourceRealizerBuilderError"
+	FragmentExpansionErrorResourcesSubmittedReason       = "FragmentExpansionError"
 )
 
 // -----------------------------------------
@@ -0,0 +1,95 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+var _ = Describe("Deliverable Webhook Validation", func() {
+	var deliverable *v1alpha1.Deliverable
+
+	BeforeEach(func() {
+		deliverable = &v1alpha1.Deliverable{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-deliverable", Namespace: "my-namespace"},
+			Spec: v1alpha1.DeliverableSpec{
+				Params: []v1alpha1.OwnerParam{
+					{Name: "replicas", Value: apiextensionsv1.JSON{Raw: []byte(`2`)}},
+					{
+						Name: "token",
+						ValueFrom: &v1alpha1.ParamValueSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"},
+								Key:                  "token",
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	Context("params with a value or a valueFrom", func() {
+		It("creates and updates without error", func() {
+			Expect(deliverable.ValidateCreate()).To(Succeed())
+			Expect(deliverable.ValidateUpdate(nil)).To(Succeed())
+		})
+	})
+
+	Context("a param with both a value and a valueFrom", func() {
+		BeforeEach(func() {
+			deliverable.Spec.Params[1].Value = apiextensionsv1.JSON{Raw: []byte(`"inline"`)}
+		})
+
+		It("returns an error on create and update", func() {
+			expectedErr := "error validating deliverable [my-deliverable]: param [token] must specify only one of value and valueFrom"
+			Expect(deliverable.ValidateCreate()).To(MatchError(expectedErr))
+			Expect(deliverable.ValidateUpdate(nil)).To(MatchError(expectedErr))
+		})
+	})
+
+	Context("a valueFrom with both a secretKeyRef and a configMapKeyRef", func() {
+		BeforeEach(func() {
+			deliverable.Spec.Params[1].ValueFrom.ConfigMapKeyRef = &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+				Key:                  "token",
+			}
+		})
+
+		It("returns an error", func() {
+			Expect(deliverable.ValidateCreate()).To(MatchError(
+				"error validating deliverable [my-deliverable]: param [token] is invalid: valueFrom must specify only one of secretKeyRef and configMapKeyRef",
+			))
+		})
+	})
+
+	Context("a valueFrom with neither a secretKeyRef nor a configMapKeyRef", func() {
+		BeforeEach(func() {
+			deliverable.Spec.Params[1].ValueFrom.SecretKeyRef = nil
+		})
+
+		It("returns an error", func() {
+			Expect(deliverable.ValidateCreate()).To(MatchError(
+				"error validating deliverable [my-deliverable]: param [token] is invalid: valueFrom must specify one of secretKeyRef and configMapKeyRef",
+			))
+		})
+	})
+})
@@ -0,0 +1,74 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/validate-carto-run-v1alpha1-delivery,mutating=false,failurePolicy=fail,sideEffects=none,admissionReviewVersions=v1beta1;v1,groups=carto.run,resources=deliveries,verbs=create;update,versions=v1alpha1,name=namespaced-delivery-validator.cartographer.com
+
+var _ webhook.Validator = &Delivery{}
+
+func (c *Delivery) ValidateCreate() error {
+	err := c.validateNewState()
+	if err != nil {
+		return fmt.Errorf("error validating delivery [%s]: %w", c.Name, err)
+	}
+	return nil
+}
+
+func (c *Delivery) ValidateUpdate(_ runtime.Object) error {
+	err := c.validateNewState()
+	if err != nil {
+		return fmt.Errorf("error validating delivery [%s]: %w", c.Name, err)
+	}
+	return nil
+}
+
+func (c *Delivery) ValidateDelete() error {
+	return nil
+}
+
+// validateNewState applies the same rules as for a ClusterDelivery, except that
+// namespaced template kinds are allowed and service accounts of other namespaces are not.
+func (c *Delivery) validateNewState() error {
+	if err := validateServiceAccountRefNamespace(c.Spec.ServiceAccountRef, c.Namespace); err != nil {
+		return err
+	}
+
+	clusterDelivery := &ClusterDelivery{ObjectMeta: c.ObjectMeta, Spec: c.Spec}
+	return clusterDelivery.validateNewState()
+}
+
+func (c *Delivery) blueprintKind() string {
+	return "delivery"
+}
+
+func (c *Delivery) blueprintParams() []BlueprintParam {
+	return c.Spec.Params
+}
+
+func (c *Delivery) blueprintResourceRefs() []blueprintResourceRef {
+	return deliveryResourceRefs(c.Spec.Resources)
+}
+
+func (c *Delivery) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return registerWarningValidator(mgr, c, &BlueprintValidator{Client: mgr.GetAPIReader()})
+}
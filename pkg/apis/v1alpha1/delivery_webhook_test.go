@@ -0,0 +1,100 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+var _ = Describe("Delivery Webhook Validation", func() {
+	var delivery *v1alpha1.Delivery
+
+	BeforeEach(func() {
+		delivery = &v1alpha1.Delivery{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "team-delivery",
+				Namespace: "team-a",
+			},
+			Spec: v1alpha1.DeliverySpec{
+				LegacySelector: v1alpha1.LegacySelector{
+					Selector: map[string]string{"team": "a"},
+				},
+				Resources: []v1alpha1.DeliveryResource{
+					{
+						Name: "source-provider",
+						TemplateRef: v1alpha1.DeliveryTemplateReference{
+							Kind: "SourceTemplate",
+							Name: "git",
+						},
+					},
+					{
+						Name: "deployer",
+						TemplateRef: v1alpha1.DeliveryTemplateReference{
+							Kind: "ClusterTemplate",
+							Name: "app-deploy",
+						},
+					},
+				},
+			},
+		}
+	})
+
+	Context("well formed delivery mixing namespaced and cluster templates", func() {
+		It("creates without error", func() {
+			Expect(delivery.ValidateCreate()).To(Succeed())
+		})
+
+		It("updates without error", func() {
+			Expect(delivery.ValidateUpdate(nil)).To(Succeed())
+		})
+
+		It("deletes without error", func() {
+			Expect(delivery.ValidateDelete()).To(Succeed())
+		})
+	})
+
+	Context("service account in another namespace", func() {
+		BeforeEach(func() {
+			delivery.Spec.ServiceAccountRef = v1alpha1.ServiceAccountRef{Name: "deployer", Namespace: "team-b"}
+		})
+
+		It("returns an error", func() {
+			Expect(delivery.ValidateCreate()).To(MatchError(
+				"error validating delivery [team-delivery]: serviceAccountRef.namespace [team-b] must be empty or the namespace of the blueprint [team-a]",
+			))
+		})
+	})
+
+	Context("the same resources in a ClusterDelivery", func() {
+		var clusterDelivery *v1alpha1.ClusterDelivery
+
+		BeforeEach(func() {
+			clusterDelivery = &v1alpha1.ClusterDelivery{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-delivery"},
+				Spec:       delivery.Spec,
+			}
+		})
+
+		It("rejects the namespaced template kind", func() {
+			Expect(clusterDelivery.ValidateCreate()).To(MatchError(
+				"error validating clusterdelivery [cluster-delivery]: error validating resource [source-provider]: templateRef.Kind [SourceTemplate] is namespaced and can only be referenced by a Delivery",
+			))
+		})
+	})
+})
@@ -0,0 +1,109 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// revisionName is the name of a revision of an object. The name of the object is cut
+// short when needed to keep within the limit on the length of object names, and a
+// hash of the reference to the object keeps the names of revisions of different
+// objects apart.
+func revisionName(kind, namespace, name string, revision int64) string {
+	prefix := strings.ToLower(kind) + "."
+	if namespace != "" {
+		prefix += namespace + "."
+	}
+	prefix += name
+
+	suffix := fmt.Sprintf("-%s-%d", revisionRefHash(kind, namespace, name), revision)
+	if len(prefix)+len(suffix) > validation.DNS1123SubdomainMaxLength {
+		prefix = strings.TrimRight(prefix[:validation.DNS1123SubdomainMaxLength-len(suffix)], ".-")
+	}
+	return prefix + suffix
+}
+
+// revisionRefHash identifies the object revisions are taken of in their names and
+// labels, where the name of the object itself may not fit.
+func revisionRefHash(kind, namespace, name string) string {
+	sum := sha256.Sum256([]byte(kind + "/" + namespace + "/" + name))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// specSnapshot is the spec of obj as recorded in its revisions. The rollout is left
+// out, as changing how a revision is rolled out does not make a new one.
+func specSnapshot(obj client.Object, noun string) ([]byte, error) {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s [%s]: %w", noun, obj.GetName(), err)
+	}
+
+	var parsed struct {
+		Spec map[string]interface{} `json:"spec"`
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s [%s]: %w", noun, obj.GetName(), err)
+	}
+	delete(parsed.Spec, "rollout")
+
+	snapshot, err := json.Marshal(parsed.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal spec of %s [%s]: %w", noun, obj.GetName(), err)
+	}
+	return snapshot, nil
+}
+
+// snapshotsEqual reports whether two snapshots hold the same spec, however their JSON
+// is laid out.
+func snapshotsEqual(a, b []byte) (bool, error) {
+	var parsedA, parsedB interface{}
+	if err := json.Unmarshal(a, &parsedA); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(b, &parsedB); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(parsedA, parsedB), nil
+}
+
+// restoreSpec decodes obj into into, with the spec of snapshot. Its metadata and
+// status are left as they are.
+func restoreSpec(obj client.Object, snapshot []byte, into client.Object) error {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return err
+	}
+	fields["spec"] = snapshot
+
+	raw, err = json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, into)
+}
@@ -0,0 +1,143 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Rollout stages the adoption of a new revision of a template or supply chain by
+// the owners that use it. Owners that have not adopted the latest revision use the
+// stable revision.
+type Rollout struct {
+	// Canary selects the owners, by their labels, that adopt a new revision first.
+	// +optional
+	Canary *metav1.LabelSelector `json:"canary,omitempty"`
+
+	// Percentage of the owners that adopt a new revision, in addition to the canary.
+	// Raising it adopts the revision in more owners, always picking the same ones for
+	// the same percentage. At 100 the revision becomes the stable revision.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Percentage int `json:"percentage,omitempty"`
+
+	// MaxUnhealthyPercentage halts the rollout of a revision when the percentage of
+	// the owners that adopted it whose ResourcesHealthy condition is False exceeds it.
+	// A halted revision is not used by any owner until the spec changes again.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxUnhealthyPercentage *int `json:"maxUnhealthyPercentage,omitempty"`
+}
+
+// RolloutStatus describes how far the latest revision of a template or supply
+// chain is rolled out.
+type RolloutStatus struct {
+	// LatestRevision is the revision of the current spec.
+	LatestRevision int64 `json:"latestRevision"`
+
+	// StableRevision is the revision owners use until they adopt the latest revision.
+	StableRevision int64 `json:"stableRevision"`
+
+	// HaltedRevision is the latest revision when its rollout was halted.
+	// +optional
+	HaltedRevision int64 `json:"haltedRevision,omitempty"`
+
+	// AdoptedOwners is the number of owners using the template or supply chain that
+	// adopted the latest revision and recorded stamping it.
+	// +optional
+	AdoptedOwners int `json:"adoptedOwners,omitempty"`
+
+	// UnhealthyOwners is the number of owners that adopted the latest revision and
+	// whose ResourcesHealthy condition is False.
+	// +optional
+	UnhealthyOwners int `json:"unhealthyOwners,omitempty"`
+}
+
+// Halted reports whether the rollout of the latest revision was halted.
+func (s *RolloutStatus) Halted() bool {
+	return s.HaltedRevision != 0 && s.HaltedRevision == s.LatestRevision
+}
+
+// Complete reports whether every owner is stamped with the latest revision.
+func (s *RolloutStatus) Complete() bool {
+	return s.StableRevision == s.LatestRevision
+}
+
+// AdoptsLatestRevision reports whether owner is stamped with the current spec of
+// template, rather than the spec of its stable revision.
+func AdoptsLatestRevision(template TemplateObject, owner client.Object) bool {
+	status := template.GetTemplateStatus()
+	current := status.ObservedGeneration == template.GetGeneration()
+	return template.GetTemplateSpec().Rollout.Adopts(status.Rollout, current, owner)
+}
+
+// AdoptsLatestSupplyChainRevision reports whether owner is realized with the current
+// spec of supplyChain, rather than the spec of its stable revision.
+func AdoptsLatestSupplyChainRevision(supplyChain SupplyChainObject, owner client.Object) bool {
+	status := supplyChain.GetSupplyChainStatus()
+	current := status.ObservedGeneration == supplyChain.GetGeneration()
+	return supplyChain.GetSupplyChainSpec().Rollout.Adopts(status.Rollout, current, owner)
+}
+
+// Adopts reports whether owner adopted the latest revision of a rollout with the given
+// status. current tells whether the status was observed for the current spec: until
+// it is, a completed rollout does not extend to the latest revision.
+func (r *Rollout) Adopts(status *RolloutStatus, current bool, owner client.Object) bool {
+	if r == nil || status == nil || status.StableRevision == 0 {
+		return true
+	}
+
+	if status.Complete() && current {
+		return true
+	}
+
+	if status.Halted() {
+		return false
+	}
+
+	if r.Canary != nil {
+		selector, err := metav1.LabelSelectorAsSelector(r.Canary)
+		if err == nil && selector.Matches(labels.Set(owner.GetLabels())) {
+			return true
+		}
+	}
+
+	return ownerPercentile(owner) < r.Percentage
+}
+
+// ownerPercentile spreads owners evenly over [0, 100), always placing an owner at
+// the same percentile.
+func ownerPercentile(owner client.Object) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(owner.GetNamespace() + "/" + owner.GetName()))
+	return int(hash.Sum32() % 100)
+}
+
+func (r *Rollout) validate() error {
+	if r.Canary == nil {
+		return nil
+	}
+	if _, err := metav1.LabelSelectorAsSelector(r.Canary); err != nil {
+		return fmt.Errorf("invalid rollout canary: %w", err)
+	}
+	return nil
+}
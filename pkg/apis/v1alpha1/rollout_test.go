@@ -0,0 +1,299 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1_test
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+var _ = Describe("Template rollout", func() {
+	var template *v1alpha1.ClusterConfigTemplate
+
+	BeforeEach(func() {
+		template = &v1alpha1.ClusterConfigTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Generation: 3},
+			Spec: v1alpha1.ConfigTemplateSpec{
+				TemplateSpec: v1alpha1.TemplateSpec{
+					Template: &runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap"}`)},
+				},
+				ConfigPath: ".data",
+			},
+		}
+	})
+
+	owner := func(name string, labels map[string]string) *v1alpha1.Workload {
+		return &v1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels}}
+	}
+
+	adopting := func(owners []*v1alpha1.Workload) int {
+		count := 0
+		for _, o := range owners {
+			if v1alpha1.AdoptsLatestRevision(template, o) {
+				count++
+			}
+		}
+		return count
+	}
+
+	Describe("AdoptsLatestRevision", func() {
+		It("adopts the latest revision when the template has no rollout", func() {
+			template.Status.Rollout = &v1alpha1.RolloutStatus{LatestRevision: 2, StableRevision: 1}
+			Expect(v1alpha1.AdoptsLatestRevision(template, owner("app", nil))).To(BeTrue())
+		})
+
+		Context("the template has a rollout", func() {
+			var owners []*v1alpha1.Workload
+
+			BeforeEach(func() {
+				template.Spec.Rollout = &v1alpha1.Rollout{
+					Canary: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+				}
+				template.Status.Rollout = &v1alpha1.RolloutStatus{LatestRevision: 2, StableRevision: 1}
+
+				owners = nil
+				for i := 0; i < 200; i++ {
+					owners = append(owners, owner(fmt.Sprintf("app-%d", i), nil))
+				}
+			})
+
+			It("adopts the latest revision in the canary only", func() {
+				Expect(v1alpha1.AdoptsLatestRevision(template, owner("app", map[string]string{"canary": "true"}))).To(BeTrue())
+				Expect(adopting(owners)).To(Equal(0))
+			})
+
+			It("adopts the latest revision in about the percentage of owners", func() {
+				template.Spec.Rollout.Percentage = 50
+				Expect(adopting(owners)).To(BeNumerically("~", 100, 25))
+
+				template.Spec.Rollout.Percentage = 100
+				Expect(adopting(owners)).To(Equal(200))
+			})
+
+			It("keeps the owners that adopted the latest revision when raising the percentage", func() {
+				template.Spec.Rollout.Percentage = 20
+				var adopted []*v1alpha1.Workload
+				for _, o := range owners {
+					if v1alpha1.AdoptsLatestRevision(template, o) {
+						adopted = append(adopted, o)
+					}
+				}
+
+				template.Spec.Rollout.Percentage = 60
+				Expect(adopting(adopted)).To(Equal(len(adopted)))
+			})
+
+			It("adopts the latest revision nowhere once the rollout is halted", func() {
+				template.Status.Rollout.HaltedRevision = 2
+				Expect(v1alpha1.AdoptsLatestRevision(template, owner("app", map[string]string{"canary": "true"}))).To(BeFalse())
+			})
+
+			It("adopts the latest revision everywhere once it is the stable revision", func() {
+				template.Status.ObservedGeneration = 3
+				template.Status.Rollout.StableRevision = 2
+				Expect(adopting(owners)).To(Equal(200))
+			})
+
+			It("adopts the latest revision everywhere before any revision is stable", func() {
+				template.Status.Rollout = nil
+				Expect(adopting(owners)).To(Equal(200))
+			})
+		})
+	})
+
+	Describe("ClusterTemplateRevision", func() {
+		var ref v1alpha1.TemplateRevisionReference
+
+		BeforeEach(func() {
+			ref = v1alpha1.TemplateRevisionReference{Kind: "ClusterConfigTemplate", Name: "app"}
+		})
+
+		It("is named after the template and the revision", func() {
+			Expect(v1alpha1.TemplateRevisionName(ref, 4)).To(MatchRegexp(`^clusterconfigtemplate\.app-[0-9a-f]{16}-4$`))
+			Expect(v1alpha1.TemplateRevisionName(v1alpha1.TemplateRevisionReference{
+				Kind: "ConfigTemplate", Namespace: "team", Name: "app",
+			}, 4)).To(MatchRegexp(`^configtemplate\.team\.app-[0-9a-f]{16}-4$`))
+		})
+
+		It("keeps the names of revisions of templates with long names valid and apart", func() {
+			long := strings.Repeat("a", 253)
+			first := v1alpha1.TemplateRevisionName(v1alpha1.TemplateRevisionReference{Kind: "ClusterConfigTemplate", Name: long}, 12)
+			second := v1alpha1.TemplateRevisionName(v1alpha1.TemplateRevisionReference{Kind: "ClusterConfigTemplate", Name: long[:252]}, 12)
+
+			Expect(validation.IsDNS1123Subdomain(first)).To(BeEmpty())
+			Expect(validation.IsDNS1123Subdomain(second)).To(BeEmpty())
+			Expect(first).NotTo(Equal(second))
+		})
+
+		It("labels the revision with the template it snapshots", func() {
+			revision, err := v1alpha1.NewTemplateRevision(ref, 1, template)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revision.Labels).To(HaveKeyWithValue("carto.run/template-kind", "ClusterConfigTemplate"))
+			Expect(revision.Labels).To(HaveKeyWithValue("carto.run/template-namespace", ""))
+			Expect(revision.Labels).To(HaveKeyWithValue("carto.run/template-name", "app"))
+			Expect(revision.Labels).To(HaveKey("carto.run/template-ref"))
+			for key, value := range v1alpha1.TemplateRevisionSelector(ref) {
+				Expect(revision.Labels).To(HaveKeyWithValue(key, value))
+			}
+
+			ref.Name = strings.Repeat("a", 64)
+			revision, err = v1alpha1.NewTemplateRevision(ref, 1, template)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revision.Labels).NotTo(HaveKey("carto.run/template-name"))
+			for _, value := range revision.Labels {
+				Expect(validation.IsValidLabelValue(value)).To(BeEmpty())
+			}
+		})
+
+		It("matches the spec it snapshots, regardless of the rollout", func() {
+			revision, err := v1alpha1.NewTemplateRevision(ref, 1, template)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revision.Matches(template)).To(BeTrue())
+
+			template.Spec.Rollout = &v1alpha1.Rollout{Percentage: 10}
+			Expect(revision.Matches(template)).To(BeTrue())
+
+			template.Spec.ConfigPath = ".data.config"
+			Expect(revision.Matches(template)).To(BeFalse())
+		})
+
+		It("restores the spec it snapshots", func() {
+			revision, err := v1alpha1.NewTemplateRevision(ref, 1, template)
+			Expect(err).NotTo(HaveOccurred())
+
+			changed := template.DeepCopy()
+			changed.Spec.ConfigPath = ".data.config"
+			changed.Status.Rollout = &v1alpha1.RolloutStatus{LatestRevision: 2, StableRevision: 1}
+
+			restored, err := revision.Restore(changed)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored.(*v1alpha1.ClusterConfigTemplate).Spec).To(Equal(template.Spec))
+			Expect(restored.GetName()).To(Equal("app"))
+			Expect(restored.GetTemplateStatus().Rollout).To(Equal(changed.Status.Rollout))
+		})
+	})
+
+	It("rejects templates with an invalid canary", func() {
+		template.Spec.Rollout = &v1alpha1.Rollout{
+			Canary: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "canary", Operator: "Bogus"}}},
+		}
+		err := template.ValidateCreate()
+		Expect(err).To(MatchError(ContainSubstring("invalid template: invalid rollout canary")))
+	})
+})
+
+var _ = Describe("Supply chain rollout", func() {
+	var supplyChain *v1alpha1.ClusterSupplyChain
+
+	BeforeEach(func() {
+		supplyChain = &v1alpha1.ClusterSupplyChain{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Generation: 3},
+			Spec: v1alpha1.SupplyChainSpec{
+				LegacySelector: v1alpha1.LegacySelector{Selector: map[string]string{"app": "web"}},
+				Resources: []v1alpha1.SupplyChainResource{{
+					Name:        "config",
+					TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterConfigTemplate", Name: "app"},
+				}},
+			},
+		}
+	})
+
+	Describe("AdoptsLatestSupplyChainRevision", func() {
+		canary := &v1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "canary", Namespace: "default", Labels: map[string]string{"canary": "true"}}}
+		other := &v1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"}}
+
+		It("adopts the latest revision when the supply chain has no rollout", func() {
+			Expect(v1alpha1.AdoptsLatestSupplyChainRevision(supplyChain, other)).To(BeTrue())
+		})
+
+		It("adopts the latest revision in the canary only while the rollout is in progress", func() {
+			supplyChain.Spec.Rollout = &v1alpha1.Rollout{Canary: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}}}
+			supplyChain.Status.Rollout = &v1alpha1.RolloutStatus{LatestRevision: 2, StableRevision: 1}
+
+			Expect(v1alpha1.AdoptsLatestSupplyChainRevision(supplyChain, canary)).To(BeTrue())
+			Expect(v1alpha1.AdoptsLatestSupplyChainRevision(supplyChain, other)).To(BeFalse())
+
+			supplyChain.Status.Rollout.HaltedRevision = 2
+			Expect(v1alpha1.AdoptsLatestSupplyChainRevision(supplyChain, canary)).To(BeFalse())
+		})
+	})
+
+	Describe("ClusterSupplyChainRevision", func() {
+		It("is named after the supply chain and the revision", func() {
+			Expect(v1alpha1.SupplyChainRevisionName(v1alpha1.SupplyChainRevisionRef(supplyChain), 4)).
+				To(MatchRegexp(`^clustersupplychain\.app-[0-9a-f]{16}-4$`))
+
+			namespaced := &v1alpha1.SupplyChain{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team"}}
+			Expect(v1alpha1.SupplyChainRevisionRef(namespaced)).To(Equal(v1alpha1.SupplyChainRevisionReference{
+				Kind: "SupplyChain", Namespace: "team", Name: "app",
+			}))
+			Expect(v1alpha1.SupplyChainRevisionName(v1alpha1.SupplyChainRevisionRef(namespaced), 4)).
+				To(MatchRegexp(`^supplychain\.team\.app-[0-9a-f]{16}-4$`))
+		})
+
+		It("labels the revision with the supply chain it snapshots", func() {
+			revision, err := v1alpha1.NewSupplyChainRevision(1, supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revision.Labels).To(HaveKeyWithValue("carto.run/supply-chain-kind", "ClusterSupplyChain"))
+			Expect(revision.Labels).To(HaveKeyWithValue("carto.run/supply-chain-name", "app"))
+			for key, value := range v1alpha1.SupplyChainRevisionSelector(v1alpha1.SupplyChainRevisionRef(supplyChain)) {
+				Expect(revision.Labels).To(HaveKeyWithValue(key, value))
+			}
+		})
+
+		It("matches the spec it snapshots, regardless of the rollout", func() {
+			revision, err := v1alpha1.NewSupplyChainRevision(1, supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revision.Matches(supplyChain)).To(BeTrue())
+
+			supplyChain.Spec.Rollout = &v1alpha1.Rollout{Percentage: 10}
+			Expect(revision.Matches(supplyChain)).To(BeTrue())
+
+			supplyChain.Spec.Resources[0].TemplateRef.Name = "other"
+			Expect(revision.Matches(supplyChain)).To(BeFalse())
+		})
+
+		It("restores the spec it snapshots", func() {
+			revision, err := v1alpha1.NewSupplyChainRevision(1, supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+
+			changed := supplyChain.DeepCopy()
+			changed.Spec.Resources = append(changed.Spec.Resources, v1alpha1.SupplyChainResource{Name: "added"})
+			changed.Status.Rollout = &v1alpha1.RolloutStatus{LatestRevision: 2, StableRevision: 1}
+
+			restored, err := revision.Restore(changed)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*restored.GetSupplyChainSpec()).To(Equal(supplyChain.Spec))
+			Expect(restored.GetName()).To(Equal("app"))
+			Expect(restored.GetSupplyChainStatus().Rollout).To(Equal(changed.Status.Rollout))
+		})
+	})
+
+	It("rejects supply chains with an invalid canary", func() {
+		supplyChain.Spec.Rollout = &v1alpha1.Rollout{
+			Canary: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "canary", Operator: "Bogus"}}},
+		}
+		err := supplyChain.ValidateCreate()
+		Expect(err).To(MatchError(ContainSubstring("invalid rollout canary")))
+	})
+})
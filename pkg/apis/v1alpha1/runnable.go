@@ -53,6 +53,40 @@ type RunnableStatus struct {
 	// will never display an output
 	// +optional
 	Outputs map[string]apiextensionsv1.JSON `json:"outputs,omitempty"`
+
+	// Runs is the history of objects stamped by this runnable, most recent first.
+	// Only runs that have not been garbage collected according to the
+	// RetentionPolicy are listed.
+	// +optional
+	Runs []RunnableRun `json:"runs,omitempty"`
+}
+
+type RunnableRun struct {
+	// StampedRef is a reference to the object stamped for this run.
+	StampedRef *StampedRef `json:"stampedRef"`
+
+	// StartTime is the time at which the stamped object was created.
+	StartTime metav1.Time `json:"startTime"`
+
+	// CompletionTime is the time at which the stamped object's Succeeded
+	// condition resolved to either True or False.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Health is the status of the stamped object's Succeeded condition.
+	// True for a successful run, False for a failed run, and Unknown while
+	// the run is in progress.
+	Health metav1.ConditionStatus `json:"health"`
+
+	// Outputs are the outputs gathered from this run. Only filled for
+	// successful runs.
+	// +optional
+	Outputs map[string]apiextensionsv1.JSON `json:"outputs,omitempty"`
+
+	// Message is the message of the stamped object's Succeeded condition,
+	// usually explaining why a run failed.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 type RunnableSpec struct {
@@ -0,0 +1,125 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+var _ = Describe("SupplyChain Webhook Validation", func() {
+	var supplyChain *v1alpha1.SupplyChain
+
+	BeforeEach(func() {
+		supplyChain = &v1alpha1.SupplyChain{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "team-chain",
+				Namespace: "team-a",
+			},
+			Spec: v1alpha1.SupplyChainSpec{
+				Resources: []v1alpha1.SupplyChainResource{
+					{
+						Name: "source-provider",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{
+							Kind: "SourceTemplate",
+							Name: "git",
+						},
+					},
+					{
+						Name: "image-builder",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{
+							Kind: "ClusterImageTemplate",
+							Name: "kpack",
+						},
+						Sources: []v1alpha1.ResourceReference{
+							{Name: "source", Resource: "source-provider"},
+						},
+					},
+				},
+				LegacySelector: v1alpha1.LegacySelector{
+					Selector: map[string]string{"team": "a"},
+				},
+			},
+		}
+	})
+
+	Context("well formed supply chain mixing namespaced and cluster templates", func() {
+		It("creates without error", func() {
+			Expect(supplyChain.ValidateCreate()).To(Succeed())
+		})
+
+		It("updates without error", func() {
+			Expect(supplyChain.ValidateUpdate(nil)).To(Succeed())
+		})
+
+		It("deletes without error", func() {
+			Expect(supplyChain.ValidateDelete()).To(Succeed())
+		})
+	})
+
+	Context("resource providing sources references a namespaced template of the wrong kind", func() {
+		BeforeEach(func() {
+			supplyChain.Spec.Resources[0].TemplateRef.Kind = "ImageTemplate"
+		})
+
+		It("returns an error", func() {
+			Expect(supplyChain.ValidateCreate()).To(MatchError(
+				"error validating supplychain [team-chain]: invalid sources for resource [image-builder]: resource [source-provider] providing [source] must reference a ClusterSourceTemplate",
+			))
+		})
+	})
+
+	Context("service account in the namespace of the supply chain", func() {
+		BeforeEach(func() {
+			supplyChain.Spec.ServiceAccountRef = v1alpha1.ServiceAccountRef{Name: "builder", Namespace: "team-a"}
+		})
+
+		It("creates without error", func() {
+			Expect(supplyChain.ValidateCreate()).To(Succeed())
+		})
+	})
+
+	Context("service account in another namespace", func() {
+		BeforeEach(func() {
+			supplyChain.Spec.ServiceAccountRef = v1alpha1.ServiceAccountRef{Name: "builder", Namespace: "team-b"}
+		})
+
+		It("returns an error", func() {
+			Expect(supplyChain.ValidateCreate()).To(MatchError(
+				"error validating supplychain [team-chain]: serviceAccountRef.namespace [team-b] must be empty or the namespace of the blueprint [team-a]",
+			))
+		})
+	})
+
+	Context("the same resources in a ClusterSupplyChain", func() {
+		var clusterSupplyChain *v1alpha1.ClusterSupplyChain
+
+		BeforeEach(func() {
+			clusterSupplyChain = &v1alpha1.ClusterSupplyChain{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-chain"},
+				Spec:       supplyChain.Spec,
+			}
+		})
+
+		It("rejects the namespaced template kind", func() {
+			Expect(clusterSupplyChain.ValidateCreate()).To(MatchError(
+				"error validating clustersupplychain [cluster-chain]: error validating resource [source-provider]: templateRef.Kind [SourceTemplate] is namespaced and can only be referenced by a SupplyChain",
+			))
+		})
+	})
+})
@@ -0,0 +1,264 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// TemplateStatus describes what Cartographer discovered about a template.
+type TemplateStatus struct {
+	// ObservedGeneration refers to the metadata.Generation of the spec the inputs
+	// were discovered in.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Inputs the template reads.
+	// +optional
+	Inputs *TemplateInputs `json:"inputs,omitempty"`
+
+	// Rollout describes how far the latest revision of the template is rolled out.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+}
+
+// TemplateInputs are the inputs a template reads, as found by static analysis of the
+// $()$ tags of its template, or of the data values referenced by its ytt.
+type TemplateInputs struct {
+	// OwnerFields are the paths of the fields of the workload or deliverable the
+	// template reads, eg: spec.source.git.url
+	// +optional
+	OwnerFields []string `json:"ownerFields,omitempty"`
+
+	// Params are the names of the params the template reads.
+	// +optional
+	Params []string `json:"params,omitempty"`
+
+	// Sources are the names of the sources the template reads, eg: for
+	// $(sources.app.url)$ the name is app.
+	// +optional
+	Sources []string `json:"sources,omitempty"`
+
+	// Source is true when the template reads the only source of its resource,
+	// eg: $(source.url)$
+	// +optional
+	Source bool `json:"source,omitempty"`
+
+	// Images are the names of the images the template reads.
+	// +optional
+	Images []string `json:"images,omitempty"`
+
+	// Image is true when the template reads the only image of its resource.
+	// +optional
+	Image bool `json:"image,omitempty"`
+
+	// Configs are the names of the configs the template reads.
+	// +optional
+	Configs []string `json:"configs,omitempty"`
+
+	// Config is true when the template reads the only config of its resource.
+	// +optional
+	Config bool `json:"config,omitempty"`
+
+	// Deployment is true when the template reads the deployment of its resource.
+	// +optional
+	Deployment bool `json:"deployment,omitempty"`
+
+	// BestEffort is true when the inputs were discovered in a ytt template. Only
+	// plain references to data.values are found, so the template may read more.
+	// +optional
+	BestEffort bool `json:"bestEffort,omitempty"`
+}
+
+// yttDataValuesPattern matches references to the data values of a ytt template, eg:
+// data.values.workload.spec.source or data.values.params["gitops_url"]
+var yttDataValuesPattern = regexp.MustCompile(`data\.values((?:\.[A-Za-z_][A-Za-z0-9_]*|\[\s*["'][^"']+["']\s*\])+)`)
+
+// DiscoverInputs returns the inputs the template reads. Tags that cannot be parsed are
+// ignored: they fail when the template is stamped.
+func (t *TemplateSpec) DiscoverInputs() *TemplateInputs {
+	builder := newTemplateInputsBuilder()
+
+	if t.Template != nil {
+		var template interface{}
+		if err := json.Unmarshal(t.Template.Raw, &template); err == nil {
+			walkStringLeaves(template, func(leaf string) {
+				for _, tag := range templateTags(leaf) {
+					builder.add(parseInputPath(tag))
+				}
+			})
+		}
+	}
+
+	if t.Ytt != "" {
+		builder.inputs.BestEffort = true
+		for _, match := range yttDataValuesPattern.FindAllStringSubmatch(t.Ytt, -1) {
+			builder.add(parseInputPath(match[1]))
+		}
+	}
+
+	return builder.build()
+}
+
+func walkStringLeaves(value interface{}, visit func(string)) {
+	switch typed := value.(type) {
+	case string:
+		visit(typed)
+	case map[string]interface{}:
+		for _, child := range typed {
+			walkStringLeaves(child, visit)
+		}
+	case []interface{}:
+		for _, child := range typed {
+			walkStringLeaves(child, visit)
+		}
+	}
+}
+
+// templateTags returns the expressions of the $()$ tags in a leaf of a template.
+func templateTags(leaf string) []string {
+	var tags []string
+	for {
+		start := strings.Index(leaf, "$(")
+		if start < 0 {
+			return tags
+		}
+		leaf = leaf[start+2:]
+
+		end := strings.Index(leaf, ")$")
+		if end < 0 {
+			return tags
+		}
+		tags = append(tags, leaf[:end])
+		leaf = leaf[end+2:]
+	}
+}
+
+// parseInputPath splits a jsonpath expression into the keys it reads, stopping at the
+// first index or filter, eg: .workload.spec.env[0].name is [workload spec env].
+func parseInputPath(expression string) []string {
+	expression = strings.TrimSpace(expression)
+	expression = strings.TrimSuffix(strings.TrimPrefix(expression, "{"), "}")
+	expression = strings.TrimPrefix(expression, "$")
+
+	var keys []string
+	for expression != "" {
+		switch {
+		case expression[0] == '.':
+			expression = expression[1:]
+		case strings.HasPrefix(expression, `['`) || strings.HasPrefix(expression, `["`):
+			quote := expression[1]
+			end := strings.IndexByte(expression[2:], quote)
+			if end < 0 {
+				return keys
+			}
+			keys = append(keys, expression[2:2+end])
+			expression = strings.TrimPrefix(expression[2+end+1:], "]")
+		case expression[0] == '[':
+			return keys
+		default:
+			end := strings.IndexAny(expression, ".[")
+			if end < 0 {
+				end = len(expression)
+			}
+			keys = append(keys, strings.TrimSpace(expression[:end]))
+			expression = expression[end:]
+		}
+	}
+	return keys
+}
+
+// +kubebuilder:object:generate=false
+type templateInputsBuilder struct {
+	inputs      TemplateInputs
+	ownerFields map[string]bool
+	params      map[string]bool
+	sources     map[string]bool
+	images      map[string]bool
+	configs     map[string]bool
+}
+
+func newTemplateInputsBuilder() *templateInputsBuilder {
+	return &templateInputsBuilder{
+		ownerFields: map[string]bool{},
+		params:      map[string]bool{},
+		sources:     map[string]bool{},
+		images:      map[string]bool{},
+		configs:     map[string]bool{},
+	}
+}
+
+func (b *templateInputsBuilder) add(keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	switch keys[0] {
+	case "workload", "deliverable":
+		if len(keys) > 1 {
+			b.ownerFields[strings.Join(keys[1:], ".")] = true
+		}
+	case "params":
+		if len(keys) > 1 {
+			b.params[keys[1]] = true
+		}
+	case "sources":
+		if len(keys) > 1 {
+			b.sources[keys[1]] = true
+		}
+	case "images":
+		if len(keys) > 1 {
+			b.images[keys[1]] = true
+		}
+	case "configs":
+		if len(keys) > 1 {
+			b.configs[keys[1]] = true
+		}
+	case "source":
+		b.inputs.Source = true
+	case "image":
+		b.inputs.Image = true
+	case "config":
+		b.inputs.Config = true
+	case "deployment":
+		b.inputs.Deployment = true
+	}
+}
+
+func (b *templateInputsBuilder) build() *TemplateInputs {
+	inputs := b.inputs
+	inputs.OwnerFields = sortedKeys(b.ownerFields)
+	inputs.Params = sortedKeys(b.params)
+	inputs.Sources = sortedKeys(b.sources)
+	inputs.Images = sortedKeys(b.images)
+	inputs.Configs = sortedKeys(b.configs)
+	return &inputs
+}
+
+func sortedKeys(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,99 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+var _ = Describe("DiscoverInputs", func() {
+	var templateSpec *v1alpha1.TemplateSpec
+
+	BeforeEach(func() {
+		templateSpec = &v1alpha1.TemplateSpec{}
+	})
+
+	Context("a template", func() {
+		BeforeEach(func() {
+			templateSpec.Template = &runtime.RawExtension{Raw: []byte(`{
+				"apiVersion": "kpack.io/v1alpha2",
+				"kind": "Image",
+				"metadata": {"name": "$(workload.metadata.name)$-image"},
+				"spec": {
+					"tag": "$(params.registry)$/$(workload.metadata.name)$",
+					"serviceAccountName": "$(.params['service-account'])$",
+					"source": {"blob": {"url": "$(sources.app.url)$"}},
+					"build": {"env": "$(workload.spec.build.env)$"},
+					"cache": "$({.deliverable.spec.params[?(@.name==\"cache\")].value})$",
+					"builder": ["$(images.builder.image)$", "$(config)$"],
+					"revision": "$(source.revision)$",
+					"port": "$(workload.spec.ports[0].port)$",
+					"labels": "$(labels)$"
+				}
+			}`)}
+		})
+
+		It("finds the workload fields, params and inputs its tags read", func() {
+			Expect(templateSpec.DiscoverInputs()).To(Equal(&v1alpha1.TemplateInputs{
+				OwnerFields: []string{"metadata.name", "spec.build.env", "spec.params", "spec.ports"},
+				Params:      []string{"registry", "service-account"},
+				Sources:     []string{"app"},
+				Source:      true,
+				Images:      []string{"builder"},
+				Config:      true,
+			}))
+		})
+	})
+
+	Context("a template that reads no inputs", func() {
+		BeforeEach(func() {
+			templateSpec.Template = &runtime.RawExtension{Raw: []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "data": {"key": "value"}}`)}
+		})
+
+		It("finds no inputs", func() {
+			Expect(templateSpec.DiscoverInputs()).To(Equal(&v1alpha1.TemplateInputs{}))
+		})
+	})
+
+	Context("a ytt template", func() {
+		BeforeEach(func() {
+			templateSpec.Ytt = `
+#@ load("@ytt:data", "data")
+---
+apiVersion: carto.run/v1alpha1
+kind: Deliverable
+metadata:
+  name: #@ data.values.workload.metadata.name
+spec:
+  source:
+    url: #@ data.values.params["gitops_url"]
+    revision: #@ data.values.deployment.revision
+`
+		})
+
+		It("finds the data values it references, best effort", func() {
+			Expect(templateSpec.DiscoverInputs()).To(Equal(&v1alpha1.TemplateInputs{
+				OwnerFields: []string{"metadata.name"},
+				Params:      []string{"gitops_url"},
+				Deployment:  true,
+				BestEffort:  true,
+			}))
+		})
+	})
+})
@@ -0,0 +1,179 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ownerSchema describes the paths of an owner a template may read: those of the
+// tables used to validate selectors, and any other the schema of the owner's type has.
+// +kubebuilder:object:generate=false
+type ownerSchema struct {
+	ownerType     reflect.Type
+	validPaths    map[string]bool
+	validPrefixes []string
+}
+
+var ownerSchemas = map[string]ownerSchema{
+	"workload":    {reflect.TypeOf(Workload{}), ValidWorkloadPaths, ValidWorkloadPrefixes},
+	"deliverable": {reflect.TypeOf(Deliverable{}), ValidDeliverablePaths, ValidDeliverablePrefixes},
+}
+
+// freeFormOwnerPrefixes are the paths below which an owner holds values of any shape,
+// eg: labels, annotations and params. References below them that do not match the
+// schema are only warned about.
+var freeFormOwnerPrefixes = []string{"metadata", "spec.params"}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// validateOwnerReferences checks the paths of the workload or deliverable read by the
+// $()$ tags of the template. A path that cannot exist is an error, unless it is below
+// one of the freeFormOwnerPrefixes, where it is returned as a warning.
+func (t *TemplateSpec) validateOwnerReferences() ([]string, error) {
+	if t.Template == nil {
+		return nil, nil
+	}
+
+	var template interface{}
+	if err := json.Unmarshal(t.Template.Raw, &template); err != nil {
+		return nil, nil
+	}
+
+	var references []string
+	walkStringLeaves(template, func(leaf string) {
+		for _, tag := range templateTags(leaf) {
+			keys := parseInputPath(tag)
+			if len(keys) < 2 {
+				continue
+			}
+			if _, ok := ownerSchemas[keys[0]]; ok {
+				references = append(references, strings.Join(keys, "."))
+			}
+		}
+	})
+	sort.Strings(references)
+
+	var warnings []string
+	for i, reference := range references {
+		if i > 0 && references[i-1] == reference {
+			continue
+		}
+
+		root, path, _ := strings.Cut(reference, ".")
+		if ownerSchemas[root].hasPath(path) {
+			continue
+		}
+
+		if hasAnyPrefix(path, freeFormOwnerPrefixes) {
+			warnings = append(warnings, fmt.Sprintf("template reads [%s], which is not a field of a %s", reference, root))
+			continue
+		}
+
+		return warnings, fmt.Errorf("invalid template: template reads [%s], which is not a field of a %s", reference, root)
+	}
+
+	return warnings, nil
+}
+
+func (s ownerSchema) hasPath(path string) bool {
+	if s.validPaths[path] {
+		return true
+	}
+
+	if typeHasPath(s.ownerType, strings.Split(path, ".")) {
+		return true
+	}
+
+	var prefixes []string
+	for _, prefix := range s.validPrefixes {
+		if !hasAnyPrefix(prefix, freeFormOwnerPrefixes) {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return hasAnyPrefix(path, prefixes)
+}
+
+// typeHasPath reports whether the keys are json fields of t, or of the structs it
+// nests. Maps, and types with their own json encoding, accept any key below them.
+func typeHasPath(t reflect.Type, keys []string) bool {
+	for _, key := range keys {
+		for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			t = t.Elem()
+		}
+
+		if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) {
+			return true
+		}
+
+		switch t.Kind() {
+		case reflect.Map:
+			t = t.Elem()
+		case reflect.Interface:
+			return true
+		case reflect.Struct:
+			field, ok := jsonField(t, key)
+			if !ok {
+				return false
+			}
+			t = field
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func jsonField(t reflect.Type, key string) (reflect.Type, bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+		if name == "" && field.Anonymous {
+			if fieldType, ok := jsonField(field.Type, key); ok {
+				return fieldType, true
+			}
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		if name == key {
+			return field.Type, true
+		}
+	}
+	return nil, false
+}
+
+func hasAnyPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if path == prefix || strings.HasPrefix(path, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,259 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// WarningValidator validates objects on admission like admission.CustomValidator,
+// but may also return warnings, which are shown to the user without denying the
+// request.
+// +kubebuilder:object:generate=false
+type WarningValidator interface {
+	ValidateCreate(ctx context.Context, obj runtime.Object) (warnings []string, err error)
+	ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (warnings []string, err error)
+}
+
+// ImpactAnalyzer summarises, as admission warnings, the effect a change to a supply
+// chain or template would have on the owners reconciled against it. The old object is
+// nil on create.
+// +kubebuilder:object:generate=false
+type ImpactAnalyzer interface {
+	SupplyChainImpact(ctx context.Context, oldSupplyChain, newSupplyChain SupplyChainObject) ([]string, error)
+	TemplateImpact(ctx context.Context, oldTemplate, newTemplate TemplateObject) ([]string, error)
+}
+
+// impactWarnings never fails admission: the change is valid whatever its impact.
+func impactWarnings(warnings []string, err error) []string {
+	if err != nil {
+		return []string{fmt.Sprintf("unable to analyze the impact of this change: %s", err)}
+	}
+	return warnings
+}
+
+// registerWarningValidator serves the validating webhook of obj's kind with validator,
+// on the path the webhook builder would have used.
+func registerWarningValidator(mgr ctrl.Manager, obj runtime.Object, validator WarningValidator) error {
+	gvk, err := apiutil.GVKForObject(obj, mgr.GetScheme())
+	if err != nil {
+		return fmt.Errorf("failed to get kind of webhook object: %w", err)
+	}
+
+	decoder, err := admission.NewDecoder(mgr.GetScheme())
+	if err != nil {
+		return fmt.Errorf("failed to create decoder: %w", err)
+	}
+
+	path := "/validate-" + strings.ReplaceAll(gvk.Group, ".", "-") + "-" + gvk.Version + "-" + strings.ToLower(gvk.Kind)
+	mgr.GetWebhookServer().Register(path, &webhook.Admission{Handler: &warningValidatingHandler{
+		object:    obj,
+		validator: validator,
+		decoder:   decoder,
+	}})
+
+	return nil
+}
+
+// +kubebuilder:object:generate=false
+type warningValidatingHandler struct {
+	object    runtime.Object
+	validator WarningValidator
+	decoder   *admission.Decoder
+}
+
+func (h *warningValidatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	ctx = admission.NewContextWithRequest(ctx, req)
+	obj := h.object.DeepCopyObject()
+
+	var (
+		warnings []string
+		err      error
+	)
+	switch req.Operation {
+	case admissionv1.Create:
+		if err := h.decoder.Decode(req, obj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+
+		warnings, err = h.validator.ValidateCreate(ctx, obj)
+	case admissionv1.Update:
+		oldObj := obj.DeepCopyObject()
+		if err := h.decoder.DecodeRaw(req.Object, obj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if err := h.decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+
+		warnings, err = h.validator.ValidateUpdate(ctx, oldObj, obj)
+	default:
+		return admission.Allowed("")
+	}
+
+	if err != nil {
+		return admission.Denied(err.Error()).WithWarnings(warnings...)
+	}
+
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+// TemplateValidator validates templates of any kind on admission. On top of the
+// validation of the template itself, it warns about the fields of the owner the
+// template reads that may not exist, and about the impact of the change.
+// +kubebuilder:object:generate=false
+type TemplateValidator struct {
+	ImpactAnalyzer ImpactAnalyzer
+}
+
+var _ WarningValidator = &TemplateValidator{}
+
+type validatedTemplate interface {
+	TemplateObject
+	webhook.Validator
+}
+
+func (v *TemplateValidator) ValidateCreate(ctx context.Context, obj runtime.Object) ([]string, error) {
+	template, ok := obj.(validatedTemplate)
+	if !ok {
+		return nil, fmt.Errorf("expected a template but got a %T", obj)
+	}
+
+	if err := template.ValidateCreate(); err != nil {
+		return nil, err
+	}
+
+	return v.warnings(ctx, nil, template)
+}
+
+func (v *TemplateValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) ([]string, error) {
+	template, ok := newObj.(validatedTemplate)
+	if !ok {
+		return nil, fmt.Errorf("expected a template but got a %T", newObj)
+	}
+
+	if err := template.ValidateUpdate(oldObj); err != nil {
+		return nil, err
+	}
+
+	oldTemplate, _ := oldObj.(TemplateObject)
+	return v.warnings(ctx, oldTemplate, template)
+}
+
+func (v *TemplateValidator) warnings(ctx context.Context, oldTemplate, newTemplate TemplateObject) ([]string, error) {
+	warnings, err := newTemplate.GetTemplateSpec().validateOwnerReferences()
+	if err != nil {
+		return nil, err
+	}
+
+	if v.ImpactAnalyzer != nil {
+		warnings = append(warnings, impactWarnings(v.ImpactAnalyzer.TemplateImpact(ctx, oldTemplate, newTemplate))...)
+	}
+	return warnings, nil
+}
+
+// BlueprintValidator validates supply chains and deliveries, of either scope, on
+// admission. On top of the validation of the blueprint itself, it checks what needs a
+// client: the params the blueprint provides must satisfy the schemas of the templates
+// it references, and its resources must provide the inputs those templates read. For
+// supply chains, it also warns about the impact of the change on the workloads selected.
+// +kubebuilder:object:generate=false
+type BlueprintValidator struct {
+	Client         client.Reader
+	ImpactAnalyzer ImpactAnalyzer
+}
+
+var _ WarningValidator = &BlueprintValidator{}
+
+type validatedBlueprint interface {
+	client.Object
+	webhook.Validator
+	blueprintKind() string
+	blueprintParams() []BlueprintParam
+	blueprintResourceRefs() []blueprintResourceRef
+}
+
+func (v *BlueprintValidator) ValidateCreate(ctx context.Context, obj runtime.Object) ([]string, error) {
+	blueprint, ok := obj.(validatedBlueprint)
+	if !ok {
+		return nil, fmt.Errorf("expected a supply chain or delivery but got a %T", obj)
+	}
+
+	if err := blueprint.ValidateCreate(); err != nil {
+		return nil, err
+	}
+
+	warnings, err := v.validateTemplates(ctx, blueprint)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(warnings, v.impactWarnings(ctx, nil, blueprint)...), nil
+}
+
+func (v *BlueprintValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) ([]string, error) {
+	blueprint, ok := newObj.(validatedBlueprint)
+	if !ok {
+		return nil, fmt.Errorf("expected a supply chain or delivery but got a %T", newObj)
+	}
+
+	if err := blueprint.ValidateUpdate(oldObj); err != nil {
+		return nil, err
+	}
+
+	warnings, err := v.validateTemplates(ctx, blueprint)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(warnings, v.impactWarnings(ctx, oldObj, blueprint)...), nil
+}
+
+func (v *BlueprintValidator) validateTemplates(ctx context.Context, blueprint validatedBlueprint) ([]string, error) {
+	if v.Client == nil {
+		return nil, nil
+	}
+
+	warnings, err := validateBlueprintTemplates(ctx, v.Client, blueprint.GetNamespace(), blueprint.blueprintParams(), blueprint.blueprintResourceRefs())
+	if err != nil {
+		return nil, fmt.Errorf("error validating %s [%s]: %w", blueprint.blueprintKind(), blueprint.GetName(), err)
+	}
+	return warnings, nil
+}
+
+func (v *BlueprintValidator) impactWarnings(ctx context.Context, oldObj runtime.Object, blueprint validatedBlueprint) []string {
+	supplyChain, ok := blueprint.(SupplyChainObject)
+	if !ok || v.ImpactAnalyzer == nil {
+		return nil
+	}
+
+	// a nil old object must not become a non-nil SupplyChainObject
+	var oldSupplyChain SupplyChainObject
+	if oldObj != nil {
+		oldSupplyChain, _ = oldObj.(SupplyChainObject)
+	}
+	return impactWarnings(v.ImpactAnalyzer.SupplyChainImpact(ctx, oldSupplyChain, supplyChain))
+}
@@ -0,0 +1,143 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+type fakeImpactAnalyzer struct {
+	warnings []string
+	err      error
+}
+
+func (f *fakeImpactAnalyzer) SupplyChainImpact(context.Context, v1alpha1.SupplyChainObject, v1alpha1.SupplyChainObject) ([]string, error) {
+	return f.warnings, f.err
+}
+
+func (f *fakeImpactAnalyzer) TemplateImpact(context.Context, v1alpha1.TemplateObject, v1alpha1.TemplateObject) ([]string, error) {
+	return f.warnings, f.err
+}
+
+var _ = Describe("TemplateValidator", func() {
+	var (
+		ctx       context.Context
+		validator *v1alpha1.TemplateValidator
+		template  *v1alpha1.ClusterSourceTemplate
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		validator = &v1alpha1.TemplateValidator{}
+		template = &v1alpha1.ClusterSourceTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "git"},
+			Spec: v1alpha1.SourceTemplateSpec{
+				TemplateSpec: v1alpha1.TemplateSpec{
+					Template: &runtime.RawExtension{Raw: []byte(`{
+						"apiVersion": "source.toolkit.fluxcd.io/v1beta1",
+						"kind": "GitRepository",
+						"metadata": {"name": "$(workload.metadata.name)$"},
+						"spec": {"url": "$(workload.spec.source.git.url)$"}
+					}`)},
+				},
+				URLPath:      ".status.artifact.url",
+				RevisionPath: ".status.artifact.revision",
+			},
+		}
+	})
+
+	It("admits a template reading fields that exist without warnings", func() {
+		warnings, err := validator.ValidateCreate(ctx, template)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	Context("the template reads unknown fields below metadata and params", func() {
+		BeforeEach(func() {
+			template.Spec.Template = &runtime.RawExtension{Raw: []byte(`{
+				"apiVersion": "source.toolkit.fluxcd.io/v1beta1",
+				"kind": "GitRepository",
+				"metadata": {"name": "$(workload.metadata.lables.app)$"},
+				"spec": {"interval": "$(workload.spec.params.interval)$"}
+			}`)}
+		})
+
+		It("warns about them on create and update", func() {
+			expectedWarnings := []string{
+				"template reads [workload.metadata.lables.app], which is not a field of a workload",
+				"template reads [workload.spec.params.interval], which is not a field of a workload",
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, template)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(Equal(expectedWarnings))
+
+			warnings, err = validator.ValidateUpdate(ctx, template.DeepCopy(), template)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(Equal(expectedWarnings))
+		})
+	})
+
+	Context("the template is invalid", func() {
+		BeforeEach(func() {
+			template.Spec.Template = &runtime.RawExtension{Raw: []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "data": {"url": "$(workload.spec.sourec.git.url)$"}}`)}
+		})
+
+		It("returns the error of the template", func() {
+			_, err := validator.ValidateCreate(ctx, template)
+			Expect(err).To(MatchError("invalid template: template reads [workload.spec.sourec.git.url], which is not a field of a workload"))
+		})
+	})
+
+	Context("an impact analyzer is configured", func() {
+		var analyzer *fakeImpactAnalyzer
+
+		BeforeEach(func() {
+			analyzer = &fakeImpactAnalyzer{warnings: []string{"this change will restamp 3 workloads"}}
+			validator.ImpactAnalyzer = analyzer
+		})
+
+		It("returns the impact of the change as warnings", func() {
+			warnings, err := validator.ValidateUpdate(ctx, template.DeepCopy(), template)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(Equal([]string{"this change will restamp 3 workloads"}))
+		})
+
+		Context("the analysis fails", func() {
+			BeforeEach(func() {
+				analyzer.err = errors.New("some error")
+			})
+
+			It("admits the change with a warning", func() {
+				warnings, err := validator.ValidateUpdate(ctx, template.DeepCopy(), template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(Equal([]string{"unable to analyze the impact of this change: some error"}))
+			})
+		})
+	})
+
+	It("rejects objects that are not templates", func() {
+		_, err := validator.ValidateCreate(ctx, &v1alpha1.Workload{})
+		Expect(err).To(MatchError("expected a template but got a *v1alpha1.Workload"))
+	})
+})
@@ -142,6 +142,11 @@ type WorkloadStatus struct {
 	// SupplyChainRef is the Supply Chain resource that was used when this status was set.
 	SupplyChainRef ObjectReference `json:"supplyChainRef,omitempty"`
 
+	// SupplyChainRevision is the revision of the Supply Chain that was realized, when
+	// Cartographer recorded one.
+	// +optional
+	SupplyChainRevision int64 `json:"supplyChainRevision,omitempty"`
+
 	// Resources contain references to the objects created by the Supply Chain and the templates used to create them.
 	// It also contains Inputs and Outputs that were passed between the templates as the Supply Chain was processed.
 	Resources []ResourceStatus `json:"resources,omitempty"`
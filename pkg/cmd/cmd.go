@@ -29,6 +29,7 @@ import (
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/controllers"
+	"github.com/vmware-tanzu/cartographer/pkg/impact"
 	"github.com/vmware-tanzu/cartographer/pkg/utils"
 )
 
@@ -118,27 +119,54 @@ func (cmd *Command) registerControllers(mgr manager.Manager) error {
 		return fmt.Errorf("failed to register runnable controller: %w", err)
 	}
 
+	for _, kind := range templateKinds {
+		if err := (&controllers.TemplateReconciler{Kind: kind}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("failed to register %s controller: %w", kind, err)
+		}
+	}
+
 	return nil
 }
 
+// templateKinds are the kinds of template whose inputs and revisions are published on
+// their status.
+var templateKinds = []string{
+	"ClusterSourceTemplate",
+	"ClusterImageTemplate",
+	"ClusterConfigTemplate",
+	"ClusterTemplate",
+	"ClusterDeploymentTemplate",
+	"SourceTemplate",
+	"ImageTemplate",
+	"ConfigTemplate",
+	"Template",
+	"DeploymentTemplate",
+}
+
 func registerWebhooks(mgr manager.Manager) error {
-	if err := (&v1alpha1.ClusterSupplyChain{}).SetupWebhookWithManager(mgr); err != nil {
+	impactAnalyzer := &impact.Analyzer{Client: mgr.GetClient(), Scheme: mgr.GetScheme()}
+
+	if err := (&v1alpha1.ClusterSupplyChain{}).SetupWebhookWithManager(mgr, impactAnalyzer); err != nil {
 		return fmt.Errorf("failed to setup cluster supply chain webhook: %w", err)
 	}
 
+	if err := (&v1alpha1.ClusterSupplyChainFragment{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("failed to setup cluster supply chain fragment webhook: %w", err)
+	}
+
 	if err := (&v1alpha1.ClusterDelivery{}).SetupWebhookWithManager(mgr); err != nil {
 		return fmt.Errorf("failed to setup cluster delivery webhook: %w", err)
 	}
 
-	if err := (&v1alpha1.ClusterConfigTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&v1alpha1.ClusterConfigTemplate{}).SetupWebhookWithManager(mgr, impactAnalyzer); err != nil {
 		return fmt.Errorf("failed to setup cluster config template webhook: %w", err)
 	}
 
-	if err := (&v1alpha1.ClusterDeploymentTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&v1alpha1.ClusterDeploymentTemplate{}).SetupWebhookWithManager(mgr, impactAnalyzer); err != nil {
 		return fmt.Errorf("failed to setup cluster deployment template webhook: %w", err)
 	}
 
-	if err := (&v1alpha1.ClusterImageTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&v1alpha1.ClusterImageTemplate{}).SetupWebhookWithManager(mgr, impactAnalyzer); err != nil {
 		return fmt.Errorf("failed to setup cluster image template webhook: %w", err)
 	}
 
@@ -146,14 +174,58 @@ func registerWebhooks(mgr manager.Manager) error {
 		return fmt.Errorf("failed to setup cluster run template webhook: %w", err)
 	}
 
-	if err := (&v1alpha1.ClusterSourceTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&v1alpha1.ClusterTemplateRevision{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("failed to setup cluster template revision webhook: %w", err)
+	}
+
+	if err := (&v1alpha1.ClusterSupplyChainRevision{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("failed to setup cluster supply chain revision webhook: %w", err)
+	}
+
+	if err := (&v1alpha1.ClusterSourceTemplate{}).SetupWebhookWithManager(mgr, impactAnalyzer); err != nil {
 		return fmt.Errorf("failed to setup cluster source template webhook: %w", err)
 	}
 
-	if err := (&v1alpha1.ClusterTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&v1alpha1.ClusterTemplate{}).SetupWebhookWithManager(mgr, impactAnalyzer); err != nil {
 		return fmt.Errorf("failed to setup cluster template webhook: %w", err)
 	}
 
+	if err := (&v1alpha1.SupplyChain{}).SetupWebhookWithManager(mgr, impactAnalyzer); err != nil {
+		return fmt.Errorf("failed to setup supply chain webhook: %w", err)
+	}
+
+	if err := (&v1alpha1.Delivery{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("failed to setup delivery webhook: %w", err)
+	}
+
+	if err := (&v1alpha1.Workload{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("failed to setup workload webhook: %w", err)
+	}
+
+	if err := (&v1alpha1.Deliverable{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("failed to setup deliverable webhook: %w", err)
+	}
+
+	if err := (&v1alpha1.ConfigTemplate{}).SetupWebhookWithManager(mgr, impactAnalyzer); err != nil {
+		return fmt.Errorf("failed to setup config template webhook: %w", err)
+	}
+
+	if err := (&v1alpha1.DeploymentTemplate{}).SetupWebhookWithManager(mgr, impactAnalyzer); err != nil {
+		return fmt.Errorf("failed to setup deployment template webhook: %w", err)
+	}
+
+	if err := (&v1alpha1.ImageTemplate{}).SetupWebhookWithManager(mgr, impactAnalyzer); err != nil {
+		return fmt.Errorf("failed to setup image template webhook: %w", err)
+	}
+
+	if err := (&v1alpha1.SourceTemplate{}).SetupWebhookWithManager(mgr, impactAnalyzer); err != nil {
+		return fmt.Errorf("failed to setup source template webhook: %w", err)
+	}
+
+	if err := (&v1alpha1.Template{}).SetupWebhookWithManager(mgr, impactAnalyzer); err != nil {
+		return fmt.Errorf("failed to setup template webhook: %w", err)
+	}
+
 	return nil
 }
 
@@ -34,6 +34,9 @@ const Positive Polarity = "Positive"
 // Negative Polarity means a "False" ConditionStatus is a success
 const Negative Polarity = "Negative"
 
+// Informational Polarity means the condition is reported without affecting the top level condition
+const Informational Polarity = "Informational"
+
 //counterfeiter:generate . ConditionManager
 
 // ConditionManager supports collecting condition statuses for your controller
@@ -94,7 +97,7 @@ func (c *conditionManager) Add(condition metav1.Condition, polarity Polarity) {
 		c.status = metav1.ConditionFalse
 		c.reason = condition.Reason
 		c.message = condition.Message
-	} else if condition.Status == metav1.ConditionUnknown {
+	} else if polarity != Informational && condition.Status == metav1.ConditionUnknown {
 		if c.status == metav1.ConditionTrue {
 			c.status = metav1.ConditionUnknown
 			c.reason = condition.Reason
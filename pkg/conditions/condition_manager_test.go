@@ -425,6 +425,43 @@ var _ = Describe("conditionManager", func() {
 
 	})
 
+	Context("with an informational condition", func() {
+		BeforeEach(func() {
+			manager = conditions.NewConditionManager("HappyParent", []metav1.Condition{})
+			manager.AddPositive(metav1.Condition{
+				Type:   "Goodness",
+				Status: metav1.ConditionTrue,
+			})
+			manager.Add(metav1.Condition{
+				Type:    "Advice",
+				Status:  metav1.ConditionFalse,
+				Reason:  "SomeReason",
+				Message: "some verbose message",
+			}, conditions.Informational)
+		})
+
+		It("returns the condition without affecting the parent", func() {
+			result, _ := manager.Finalize()
+
+			Expect(manager.IsSuccessful()).To(BeTrue())
+			Expect(result).To(HaveLen(3))
+			Expect(result).To(ContainElements(
+				MatchFields(IgnoreExtras,
+					Fields{
+						"Type":   Equal("Advice"),
+						"Status": Equal(metav1.ConditionFalse),
+					},
+				),
+				MatchFields(IgnoreExtras,
+					Fields{
+						"Type":   Equal("HappyParent"),
+						"Status": Equal(metav1.ConditionTrue),
+					},
+				),
+			))
+		})
+	})
+
 	Context("with previous conditions", func() {
 		var (
 			firstConditions   []metav1.Condition
@@ -106,6 +106,10 @@ func AddConditionForResourceSubmittedDeliverable(conditionManager *ConditionMana
 		(*conditionManager).AddPositive(TemplateObjectRetrievalFailureCondition(isOwner, typedErr))
 	case cerrors.StampError:
 		(*conditionManager).AddPositive(TemplateStampFailureCondition(isOwner, typedErr))
+	case cerrors.ParamValidationError:
+		(*conditionManager).AddPositive(InvalidParamsCondition(isOwner, typedErr))
+	case cerrors.ResolveParamsError:
+		(*conditionManager).AddPositive(UnresolvableParamsCondition(isOwner, typedErr))
 	case cerrors.ApplyStampedObjectError:
 		(*conditionManager).AddPositive(TemplateRejectedByAPIServerCondition(isOwner, typedErr))
 	case cerrors.RetrieveOutputError:
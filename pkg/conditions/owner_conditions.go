@@ -98,6 +98,24 @@ func TemplateStampFailureCondition(isOwner bool, err error) metav1.Condition {
 	}
 }
 
+func InvalidParamsCondition(isOwner bool, err error) metav1.Condition {
+	return metav1.Condition{
+		Type:    getConditionType(isOwner),
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.InvalidParamsResourcesSubmittedReason,
+		Message: err.Error(),
+	}
+}
+
+func UnresolvableParamsCondition(isOwner bool, err error) metav1.Condition {
+	return metav1.Condition{
+		Type:    getConditionType(isOwner),
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.UnresolvableParamsResourcesSubmittedReason,
+		Message: err.Error(),
+	}
+}
+
 func TemplateRejectedByAPIServerCondition(isOwner bool, err error) metav1.Condition {
 	return metav1.Condition{
 		Type:    getConditionType(isOwner),
@@ -179,3 +197,23 @@ func ResourceRealizerBuilderErrorCondition(err error) metav1.Condition {
 		Message: err.Error(),
 	}
 }
+
+func FragmentExpansionErrorCondition(err error) metav1.Condition {
+	return metav1.Condition{
+		Type:    v1alpha1.OwnerResourcesSubmitted,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.FragmentExpansionErrorResourcesSubmittedReason,
+		Message: err.Error(),
+	}
+}
+
+// -- Owner.Status.Conditions - Paused
+
+func PausedCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:    v1alpha1.OwnerPaused,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1alpha1.PausedAnnotationReason,
+		Message: fmt.Sprintf("reconciliation is paused by the %s annotation", v1alpha1.PausedAnnotation),
+	}
+}
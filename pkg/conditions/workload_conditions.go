@@ -16,6 +16,7 @@ package conditions
 
 import (
 	"fmt"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -51,12 +52,12 @@ func SupplyChainNotFoundCondition(labels map[string]string) metav1.Condition {
 	}
 }
 
-func TooManySupplyChainMatchesCondition() metav1.Condition {
+func TooManySupplyChainMatchesCondition(supplyChainNames []string) metav1.Condition {
 	return metav1.Condition{
 		Type:    v1alpha1.WorkloadSupplyChainReady,
 		Status:  metav1.ConditionFalse,
 		Reason:  v1alpha1.MultipleMatchesSupplyChainReadyReason,
-		Message: "workload may only match a single supply chain's selector",
+		Message: fmt.Sprintf("workload may only match a single supply chain's selector, matched supply chains with equal specificity and priority: %v", supplyChainNames),
 	}
 }
 
@@ -78,12 +79,45 @@ func MissingPassThroughInputCondition(input string, resource string) metav1.Cond
 	}
 }
 
+// -- Workload.Status.Conditions - ParamsUsed
+
+func ParamsUsedCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:   v1alpha1.WorkloadParamsUsed,
+		Status: metav1.ConditionTrue,
+		Reason: v1alpha1.ParamsUsedReason,
+	}
+}
+
+func UnusedParamsCondition(unused []string, overridden []string) metav1.Condition {
+	var messages []string
+	reason := v1alpha1.OverriddenParamsReason
+	if len(unused) > 0 {
+		reason = v1alpha1.UnusedParamsReason
+		messages = append(messages, fmt.Sprintf("params %v are not used by any resource of the supply chain", unused))
+	}
+	if len(overridden) > 0 {
+		messages = append(messages, fmt.Sprintf("params %v are overridden by values set in the supply chain", overridden))
+	}
+
+	return metav1.Condition{
+		Type:    v1alpha1.WorkloadParamsUsed,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: strings.Join(messages, "; "),
+	}
+}
+
 func AddConditionForResourceSubmittedWorkload(conditionManager *ConditionManager, isOwner bool, err error) {
 	switch typedErr := err.(type) {
 	case cerrors.GetTemplateError:
 		(*conditionManager).AddPositive(TemplateObjectRetrievalFailureCondition(isOwner, typedErr))
 	case cerrors.StampError:
 		(*conditionManager).AddPositive(TemplateStampFailureCondition(isOwner, typedErr))
+	case cerrors.ParamValidationError:
+		(*conditionManager).AddPositive(InvalidParamsCondition(isOwner, typedErr))
+	case cerrors.ResolveParamsError:
+		(*conditionManager).AddPositive(UnresolvableParamsCondition(isOwner, typedErr))
 	case cerrors.ApplyStampedObjectError:
 		(*conditionManager).AddPositive(TemplateRejectedByAPIServerCondition(isOwner, typedErr))
 	case cerrors.ListCreatedObjectsError:
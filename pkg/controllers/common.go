@@ -19,13 +19,21 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/conditions"
+	"github.com/vmware-tanzu/cartographer/pkg/events"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/statuses"
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
+	"github.com/vmware-tanzu/cartographer/pkg/tracker/dependency"
 )
 
 //go:generate go run -modfile ../../hack/tools/go.mod github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -68,13 +76,13 @@ func immutableEquivalenceTest(realizedResource v1alpha1.ResourceStatus, prevReso
 		realizedResource.Name == prevResource.Name, nil
 }
 
-func getEquivalenceTest(ctx context.Context, repo repository.Repository, prevResource v1alpha1.ResourceStatus) (
+func getEquivalenceTest(ctx context.Context, repo repository.Repository, prevResource v1alpha1.ResourceStatus, namespace string) (
 	func(v1alpha1.ResourceStatus, v1alpha1.ResourceStatus, context.Context, repository.Repository) (bool, error),
 	error,
 ) {
 	log := logr.FromContextOrDiscard(ctx)
 
-	apiTemplate, err := repo.GetTemplate(ctx, prevResource.TemplateRef.Name, prevResource.TemplateRef.Kind)
+	apiTemplate, err := repo.GetTemplate(ctx, prevResource.TemplateRef.Name, prevResource.TemplateRef.Kind, namespace)
 	if err != nil {
 		log.Error(err, "unable to get api template")
 		return nil, fmt.Errorf("unable to get api template [%s/%s]: %w", prevResource.TemplateRef.Kind, prevResource.TemplateRef.Name, err)
@@ -91,3 +99,144 @@ func getEquivalenceTest(ctx context.Context, repo repository.Repository, prevRes
 		return mutableEquivalenceTest, nil
 	}
 }
+
+// trackTemplate tracks the template of the given kind and name for dependent. A namespaced
+// template kind is tracked in namespace and also as the cluster scoped kind it falls back
+// to, so that creating or deleting either one reconciles dependent.
+func trackTemplate(tracker dependency.DependencyTracker, kind, name, namespace string, dependent types.NamespacedName) {
+	if v1alpha1.IsNamespacedTemplateKind(kind) {
+		tracker.Track(dependency.Key{
+			GroupKind: schema.GroupKind{
+				Group: v1alpha1.SchemeGroupVersion.Group,
+				Kind:  kind,
+			},
+			NamespacedName: types.NamespacedName{
+				Namespace: namespace,
+				Name:      name,
+			},
+		}, dependent)
+	}
+
+	tracker.Track(dependency.Key{
+		GroupKind: schema.GroupKind{
+			Group: v1alpha1.SchemeGroupVersion.Group,
+			Kind:  v1alpha1.ClusterTemplateKind(kind),
+		},
+		NamespacedName: types.NamespacedName{
+			Name: name,
+		},
+	}, dependent)
+}
+
+// trackParamSources tracks the Secrets and ConfigMaps the params source their values from,
+// so that changing them reconciles the dependent.
+func trackParamSources(tracker dependency.DependencyTracker, params []v1alpha1.OwnerParam, namespace string, dependent types.NamespacedName) {
+	for _, param := range params {
+		if param.ValueFrom == nil {
+			continue
+		}
+
+		var kind, name string
+		if param.ValueFrom.SecretKeyRef != nil {
+			kind, name = "Secret", param.ValueFrom.SecretKeyRef.Name
+		} else if param.ValueFrom.ConfigMapKeyRef != nil {
+			kind, name = "ConfigMap", param.ValueFrom.ConfigMapKeyRef.Name
+		} else {
+			continue
+		}
+
+		tracker.Track(dependency.Key{
+			GroupKind: schema.GroupKind{
+				Group: corev1.SchemeGroupVersion.Group,
+				Kind:  kind,
+			},
+			NamespacedName: types.NamespacedName{
+				Namespace: namespace,
+				Name:      name,
+			},
+		}, dependent)
+	}
+}
+
+func isPaused(owner client.Object) bool {
+	return owner.GetAnnotations()[v1alpha1.PausedAnnotation] == "true"
+}
+
+// pauseOwner adds the Paused condition to the status of a paused owner, leaving its other
+// conditions as they were, and reports whether the status changed. Pausing is recorded as
+// an event.
+func pauseOwner(ctx context.Context, status *v1alpha1.OwnerStatus) bool {
+	if meta.IsStatusConditionTrue(status.Conditions, v1alpha1.OwnerPaused) {
+		return false
+	}
+
+	meta.SetStatusCondition(&status.Conditions, conditions.PausedCondition())
+	events.FromContextOrDie(ctx).Eventf(events.NormalType, events.PausedReason, "Paused reconciliation")
+	return true
+}
+
+// reportResumed records an event when the status of an owner that is no longer paused
+// still has the Paused condition. Reconciling drops the condition.
+func reportResumed(ctx context.Context, status v1alpha1.OwnerStatus) {
+	if wasPaused(status) {
+		events.FromContextOrDie(ctx).Eventf(events.NormalType, events.ResumedReason, "Resumed reconciliation")
+	}
+}
+
+// wasPaused reports whether the status of an owner has the Paused condition. The condition
+// manager drops it without reporting a change, so the status must be updated to clear it.
+func wasPaused(status v1alpha1.OwnerStatus) bool {
+	return meta.FindStatusCondition(status.Conditions, v1alpha1.OwnerPaused) != nil
+}
+
+// pendingRestamp returns the value of the restamp annotation of the owner when it has not
+// been acted upon, and an empty string otherwise.
+func pendingRestamp(owner client.Object, status v1alpha1.OwnerStatus) string {
+	restamp := owner.GetAnnotations()[v1alpha1.RestampAnnotation]
+	if restamp == status.LastRestamp {
+		return ""
+	}
+	return restamp
+}
+
+// restamp returns a context in which the resources of the owner are restamped, bypassing
+// the repository cache, when the owner requests it. Restamping is recorded as an event. A restamp
+// that is pending, as realizing the resources for it failed, is retried without recording it again
+// or creating immutable objects anew, by bypassing the cache of mutable objects only.
+func restamp(ctx context.Context, owner client.Object, status v1alpha1.OwnerStatus) context.Context {
+	token := pendingRestamp(owner, status)
+	if token == "" {
+		return ctx
+	}
+
+	if token == status.PendingRestamp {
+		logr.FromContextOrDiscard(ctx).Info("retrying restamping resources", "restamp", token)
+		return repository.BypassMutableCache(ctx)
+	}
+
+	logr.FromContextOrDiscard(ctx).Info("restamping resources", "restamp", token)
+	events.FromContextOrDie(ctx).Eventf(events.NormalType, events.RestampedReason, "Restamping resources for [%s]", token)
+	return repository.BypassCache(ctx)
+}
+
+// recordRestamp records the restamp requested by an owner whose resources were realized as the
+// last restamp when realizing them succeeded, or as pending otherwise, so that it is retried.
+// It reports whether the status changed.
+func recordRestamp(owner client.Object, status *v1alpha1.OwnerStatus, realizeErr error) bool {
+	token := pendingRestamp(owner, *status)
+	if token == "" {
+		return false
+	}
+
+	if realizeErr == nil {
+		status.LastRestamp = token
+		status.PendingRestamp = ""
+		return true
+	}
+
+	if status.PendingRestamp == token {
+		return false
+	}
+	status.PendingRestamp = token
+	return true
+}
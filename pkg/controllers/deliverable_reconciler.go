@@ -31,6 +31,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/cluster-api/controllers/external"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	crtcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -47,6 +48,7 @@ import (
 	realizerclient "github.com/vmware-tanzu/cartographer/pkg/realizer/client"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/healthcheck"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/statuses"
+	"github.com/vmware-tanzu/cartographer/pkg/redact"
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/satoken"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
@@ -90,7 +92,21 @@ func (r *DeliverableReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 		return ctrl.Result{}, nil
 	}
-	ctx = events.NewContext(ctx, events.FromEventRecorder(r.EventRecorder, deliverable, r.RESTMapper, log))
+	redactor := redact.NewRedactor()
+	ctx = redact.NewContext(ctx, redactor)
+	ctx = events.NewContext(ctx, events.Redacting(events.FromEventRecorder(r.EventRecorder, deliverable, r.RESTMapper, log), redactor))
+
+	if isPaused(deliverable) {
+		log.Info("deliverable is paused")
+		if pauseOwner(ctx, &deliverable.Status.OwnerStatus) {
+			if err := r.Repo.StatusUpdate(ctx, deliverable); err != nil {
+				log.Error(err, "failed to update status for deliverable")
+				return ctrl.Result{}, fmt.Errorf("failed to update status for deliverable: %w", err)
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+	reportResumed(ctx, deliverable.Status.OwnerStatus)
 
 	conditionManager := r.ConditionManagerBuilder(v1alpha1.OwnerReady, deliverable.Status.Conditions)
 
@@ -99,23 +115,24 @@ func (r *DeliverableReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return r.completeReconciliation(ctx, deliverable, nil, conditionManager, err)
 	}
 
-	log = log.WithValues("delivery", delivery.Name)
+	log = log.WithValues("delivery", delivery.GetName())
 	ctx = logr.NewContext(ctx, log)
 
 	deliveryGVK, err := utils.GetObjectGVK(delivery, r.Repo.GetScheme())
 	if err != nil {
 		log.Error(err, "failed to get object gvk for delivery")
 		return r.completeReconciliation(ctx, deliverable, nil, conditionManager, cerrors.NewUnhandledError(
-			fmt.Errorf("failed to get object gvk for delivery [%s]: %w", delivery.Name, err)))
+			fmt.Errorf("failed to get object gvk for delivery [%s]: %w", delivery.GetName(), err)))
 	}
 
 	deliverable.Status.DeliveryRef.Kind = deliveryGVK.Kind
-	deliverable.Status.DeliveryRef.Name = delivery.Name
+	deliverable.Status.DeliveryRef.Namespace = delivery.GetNamespace()
+	deliverable.Status.DeliveryRef.Name = delivery.GetName()
 
 	if !r.isDeliveryReady(delivery) {
 		conditionManager.AddPositive(conditions.MissingReadyInDeliveryCondition(getDeliveryReadyCondition(delivery)))
 		log.Info("delivery is not in ready state")
-		return r.completeReconciliation(ctx, deliverable, nil, conditionManager, fmt.Errorf("delivery [%s] is not in ready state", delivery.Name))
+		return r.completeReconciliation(ctx, deliverable, nil, conditionManager, fmt.Errorf("delivery [%s] is not in ready state", delivery.GetName()))
 	}
 	conditionManager.AddPositive(conditions.DeliveryReadyCondition())
 
@@ -133,8 +150,8 @@ func (r *DeliverableReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return r.completeReconciliation(ctx, deliverable, nil, conditionManager, fmt.Errorf("failed to get token for service account [%s]: %w", fmt.Sprintf("%s/%s", serviceAccountNS, serviceAccountName), err))
 	}
 
-	contextGenerator := realizer.NewContextGenerator(deliverable, deliverable.Spec.Params, delivery.Spec.Params)
-	resourceRealizer, err := r.ResourceRealizerBuilder(saToken, deliverable, contextGenerator, r.Repo, buildDeliverableResourceLabeler(deliverable, delivery))
+	contextGenerator := realizer.NewContextGenerator(deliverable, deliverable.Spec.Params, delivery.GetDeliverySpec().Params)
+	resourceRealizer, err := r.ResourceRealizerBuilder(saToken, deliverable, contextGenerator, r.Repo, BuildDeliverableResourceLabeler(deliverable, delivery))
 
 	if err != nil {
 		conditionManager.AddPositive(conditions.ResourceRealizerBuilderErrorCondition(err))
@@ -144,7 +161,7 @@ func (r *DeliverableReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	var reconcileErr error
 	resourceStatuses := statuses.NewResourceStatuses(deliverable.Status.Resources, conditions.AddConditionForResourceSubmittedDeliverable)
 
-	err = r.Realizer.Realize(ctx, resourceRealizer, delivery.Name, realizer.MakeDeliveryOwnerResources(delivery), resourceStatuses)
+	err = r.Realizer.Realize(restamp(ctx, deliverable, deliverable.Status.OwnerStatus), resourceRealizer, delivery.GetName(), realizer.MakeDeliveryOwnerResources(delivery), resourceStatuses)
 	if err != nil {
 		conditions.AddConditionForResourceSubmittedDeliverable(&conditionManager, true, err)
 		log.V(logger.DEBUG).Info("failed to realize")
@@ -163,7 +180,7 @@ func (r *DeliverableReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	r.trackDependencies(deliverable, resourceStatuses.GetCurrent(), serviceAccountName, serviceAccountNS)
 
-	cleanupErr := r.cleanupOrphanedObjects(ctx, deliverable.Status.Resources, resourceStatuses.GetCurrent())
+	cleanupErr := r.cleanupOrphanedObjects(ctx, deliverable.Namespace, deliverable.Status.Resources, resourceStatuses.GetCurrent())
 	if cleanupErr != nil {
 		log.Error(cleanupErr, "failed to cleanup orphaned objects")
 	}
@@ -192,11 +209,15 @@ func (r *DeliverableReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 func (r *DeliverableReconciler) completeReconciliation(ctx context.Context, deliverable *v1alpha1.Deliverable, resourceStatuses statuses.ResourceStatuses, conditionManager conditions.ConditionManager, err error) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
+	resumed := wasPaused(deliverable.Status.OwnerStatus)
 	var changed bool
 	deliverable.Status.Conditions, changed = conditionManager.Finalize()
 
 	var updateErr error
-	if changed || (deliverable.Status.ObservedGeneration != deliverable.Generation) || (resourceStatuses != nil && resourceStatuses.IsChanged()) {
+	// resources are only realized, and so only restamped, when there are resource statuses
+	restamped := resourceStatuses != nil && recordRestamp(deliverable, &deliverable.Status.OwnerStatus, err)
+
+	if changed || resumed || (deliverable.Status.ObservedGeneration != deliverable.Generation) || (resourceStatuses != nil && resourceStatuses.IsChanged()) || restamped {
 		if resourceStatuses != nil {
 			deliverable.Status.Resources = resourceStatuses.GetCurrent()
 		}
@@ -220,12 +241,12 @@ func (r *DeliverableReconciler) completeReconciliation(ctx context.Context, deli
 	return ctrl.Result{}, nil
 }
 
-func (r *DeliverableReconciler) isDeliveryReady(delivery *v1alpha1.ClusterDelivery) bool {
+func (r *DeliverableReconciler) isDeliveryReady(delivery v1alpha1.DeliveryObject) bool {
 	readyCondition := getDeliveryReadyCondition(delivery)
 	return readyCondition.Status == "True"
 }
 
-func buildDeliverableResourceLabeler(owner, blueprint client.Object) realizer.ResourceLabeler {
+func BuildDeliverableResourceLabeler(owner, blueprint client.Object) realizer.ResourceLabeler {
 	return func(resource realizer.OwnerResource, reader templates.Reader) templates.Labels {
 		return templates.Labels{
 			"carto.run/deliverable-name":      owner.GetName(),
@@ -259,27 +280,23 @@ func (r *DeliverableReconciler) trackDependencies(deliverable *v1alpha1.Delivera
 		Name:      deliverable.Name,
 	})
 
+	trackParamSources(r.DependencyTracker, deliverable.Spec.Params, deliverable.Namespace, types.NamespacedName{
+		Namespace: deliverable.Namespace,
+		Name:      deliverable.Name,
+	})
+
 	for _, resource := range realizedResources {
 		if resource.TemplateRef == nil {
 			continue
 		}
-		r.DependencyTracker.Track(dependency.Key{
-			GroupKind: schema.GroupKind{
-				Group: v1alpha1.SchemeGroupVersion.Group,
-				Kind:  resource.TemplateRef.Kind,
-			},
-			NamespacedName: types.NamespacedName{
-				Namespace: "",
-				Name:      resource.TemplateRef.Name,
-			},
-		}, types.NamespacedName{
+		trackTemplate(r.DependencyTracker, resource.TemplateRef.Kind, resource.TemplateRef.Name, deliverable.Namespace, types.NamespacedName{
 			Namespace: deliverable.Namespace,
 			Name:      deliverable.Name,
 		})
 	}
 }
 
-func (r *DeliverableReconciler) cleanupOrphanedObjects(ctx context.Context, previousResources, realizedResources []v1alpha1.ResourceStatus) error {
+func (r *DeliverableReconciler) cleanupOrphanedObjects(ctx context.Context, namespace string, previousResources, realizedResources []v1alpha1.ResourceStatus) error {
 	log := logr.FromContextOrDiscard(ctx)
 
 	var orphanedObjs []*corev1.ObjectReference
@@ -292,7 +309,7 @@ func (r *DeliverableReconciler) cleanupOrphanedObjects(ctx context.Context, prev
 		}
 		orphaned := true
 
-		equivalenceTest, err = getEquivalenceTest(ctx, r.Repo, prevResource)
+		equivalenceTest, err = getEquivalenceTest(ctx, r.Repo, prevResource, namespace)
 		if err != nil {
 			if kerrors.IsNotFound(err) {
 				orphanedObjs = append(orphanedObjs, prevResource.StampedRef.ObjectReference)
@@ -338,8 +355,8 @@ func (r *DeliverableReconciler) cleanupOrphanedObjects(ctx context.Context, prev
 	return nil
 }
 
-func getDeliveryReadyCondition(delivery *v1alpha1.ClusterDelivery) metav1.Condition {
-	for _, condition := range delivery.Status.Conditions {
+func getDeliveryReadyCondition(delivery v1alpha1.DeliveryObject) metav1.Condition {
+	for _, condition := range delivery.GetDeliveryStatus().Conditions {
 		if condition.Type == "Ready" {
 			return condition
 		}
@@ -347,7 +364,7 @@ func getDeliveryReadyCondition(delivery *v1alpha1.ClusterDelivery) metav1.Condit
 	return metav1.Condition{}
 }
 
-func (r *DeliverableReconciler) getDeliveriesForDeliverable(ctx context.Context, deliverable *v1alpha1.Deliverable, conditionManager conditions.ConditionManager) (*v1alpha1.ClusterDelivery, error) {
+func (r *DeliverableReconciler) getDeliveriesForDeliverable(ctx context.Context, deliverable *v1alpha1.Deliverable, conditionManager conditions.ConditionManager) (v1alpha1.DeliveryObject, error) {
 	log := logr.FromContextOrDiscard(ctx)
 	if len(deliverable.Labels) == 0 {
 		conditionManager.AddPositive(conditions.DeliverableMissingLabelsCondition())
@@ -374,17 +391,17 @@ func (r *DeliverableReconciler) getDeliveriesForDeliverable(ctx context.Context,
 	if len(deliveries) > 1 {
 		conditionManager.AddPositive(conditions.TooManyDeliveryMatchesCondition())
 		log.Info("more than one delivery selected for deliverable",
-			"deliveries", getDeliveryNames(deliveries))
+			"deliveries", GetDeliveryNames(deliveries))
 		return nil, fmt.Errorf("more than one delivery selected for deliverable [%s/%s]: %+v",
-			deliverable.Namespace, deliverable.Name, getDeliveryNames(deliveries))
+			deliverable.Namespace, deliverable.Name, GetDeliveryNames(deliveries))
 	}
 
 	delivery := deliveries[0]
-	log.V(logger.DEBUG).Info("delivery matched for deliverable", "delivery", delivery.Name)
+	log.V(logger.DEBUG).Info("delivery matched for deliverable", "delivery", delivery.GetName())
 	return delivery, nil
 }
 
-func getDeliveryNames(objs []*v1alpha1.ClusterDelivery) []string {
+func GetDeliveryNames(objs []v1alpha1.DeliveryObject) []string {
 	var names []string
 	for _, obj := range objs {
 		names = append(names, obj.GetName())
@@ -393,16 +410,17 @@ func getDeliveryNames(objs []*v1alpha1.ClusterDelivery) []string {
 	return names
 }
 
-func getServiceAccountNameAndNamespaceForDeliverable(deliverable *v1alpha1.Deliverable, delivery *v1alpha1.ClusterDelivery) (string, string) {
+func getServiceAccountNameAndNamespaceForDeliverable(deliverable *v1alpha1.Deliverable, delivery v1alpha1.DeliveryObject) (string, string) {
 	serviceAccountName := "default"
 	serviceAccountNS := deliverable.Namespace
 
+	serviceAccountRef := delivery.GetDeliverySpec().ServiceAccountRef
 	if deliverable.Spec.ServiceAccountName != "" {
 		serviceAccountName = deliverable.Spec.ServiceAccountName
-	} else if delivery.Spec.ServiceAccountRef.Name != "" {
-		serviceAccountName = delivery.Spec.ServiceAccountRef.Name
-		if delivery.Spec.ServiceAccountRef.Namespace != "" {
-			serviceAccountNS = delivery.Spec.ServiceAccountRef.Namespace
+	} else if serviceAccountRef.Name != "" {
+		serviceAccountName = serviceAccountRef.Name
+		if serviceAccountRef.Namespace != "" {
+			serviceAccountNS = serviceAccountRef.Namespace
 		}
 	}
 
@@ -447,6 +465,7 @@ func (r *DeliverableReconciler) SetupWithManager(mgr ctrl.Manager, concurrency i
 
 	watches := map[client.Object]handler.MapFunc{
 		&v1alpha1.ClusterDelivery{}:  m.ClusterDeliveryToDeliverableRequests,
+		&v1alpha1.Delivery{}:         m.DeliveryToDeliverableRequests,
 		&corev1.ServiceAccount{}:     m.ServiceAccountToDeliverableRequests,
 		&rbacv1.Role{}:               m.RoleToDeliverableRequests,
 		&rbacv1.RoleBinding{}:        m.RoleBindingToDeliverableRequests,
@@ -468,6 +487,16 @@ func (r *DeliverableReconciler) SetupWithManager(mgr ctrl.Manager, concurrency i
 		)
 	}
 
+	// only the metadata of Secrets and ConfigMaps is cached: their values are read with
+	// the owner's service account when resources are stamped
+	for _, paramSource := range []client.Object{&corev1.Secret{}, &corev1.ConfigMap{}} {
+		builder = builder.Watches(
+			&source.Kind{Type: paramSource},
+			enqueuer.EnqueueTracked(paramSource, r.DependencyTracker, mgr.GetScheme()),
+			ctrlbuilder.OnlyMetadata,
+		)
+	}
+
 	controller, err := builder.Build(r)
 
 	if err != nil {
@@ -43,6 +43,8 @@ import (
 	"github.com/vmware-tanzu/cartographer/pkg/controllers"
 	"github.com/vmware-tanzu/cartographer/pkg/controllers/controllersfakes"
 	cerrors "github.com/vmware-tanzu/cartographer/pkg/errors"
+	"github.com/vmware-tanzu/cartographer/pkg/events"
+	"github.com/vmware-tanzu/cartographer/pkg/events/eventsfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/realizerfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/statuses"
@@ -249,7 +251,7 @@ var _ = Describe("DeliverableReconciler", func() {
 					},
 				},
 			}
-			repo.GetDeliveriesForDeliverableReturns([]*v1alpha1.ClusterDelivery{&delivery}, nil)
+			repo.GetDeliveriesForDeliverableReturns([]v1alpha1.DeliveryObject{&delivery}, nil)
 
 			resourceStatuses = statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedDeliverable)
 			resourceStatuses.Add(
@@ -468,6 +470,120 @@ var _ = Describe("DeliverableReconciler", func() {
 			Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(conditions.ResourcesSubmittedCondition(true)))
 		})
 
+		Context("the deliverable is paused", func() {
+			var fakeEventRecorder *eventsfakes.FakeEventRecorder
+
+			BeforeEach(func() {
+				fakeEventRecorder = &eventsfakes.FakeEventRecorder{}
+				reconciler.EventRecorder = fakeEventRecorder
+
+				dl.Annotations = map[string]string{v1alpha1.PausedAnnotation: "true"}
+			})
+
+			It("does not realize the resources", func() {
+				_, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(repo.GetDeliveriesForDeliverableCallCount()).To(Equal(0))
+				Expect(rlzr.RealizeCallCount()).To(Equal(0))
+			})
+
+			It("adds the Paused condition and emits a paused event", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+				_, updatedDeliverable := repo.StatusUpdateArgsForCall(0)
+				Expect(updatedDeliverable.(*v1alpha1.Deliverable).Status.Conditions).To(ContainElement(
+					MatchFields(IgnoreExtras, Fields{"Type": Equal(v1alpha1.OwnerPaused), "Status": Equal(metav1.ConditionTrue)}),
+				))
+
+				Expect(fakeEventRecorder.EventfCallCount()).To(Equal(1))
+				_, _, reason, _, _ := fakeEventRecorder.EventfArgsForCall(0)
+				Expect(reason).To(Equal(events.PausedReason))
+			})
+		})
+
+		Context("the deliverable was paused and no longer is, with its other conditions unchanged", func() {
+			BeforeEach(func() {
+				reconciler.EventRecorder = &eventsfakes.FakeEventRecorder{}
+
+				readyCondition := metav1.Condition{Type: v1alpha1.OwnerReady, Status: metav1.ConditionTrue, Reason: "Ready"}
+				dl.Status.ObservedGeneration = dl.Generation
+				dl.Status.Conditions = []metav1.Condition{readyCondition, conditions.PausedCondition()}
+				conditionManager.FinalizeReturns([]metav1.Condition{readyCondition}, false)
+				rlzr.RealizeReturns(nil)
+			})
+
+			It("drops the Paused condition", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+				_, updatedDeliverable := repo.StatusUpdateArgsForCall(0)
+				Expect(updatedDeliverable.(*v1alpha1.Deliverable).Status.Conditions).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"Type": Equal(v1alpha1.OwnerReady), "Status": Equal(metav1.ConditionTrue)}),
+				))
+			})
+		})
+
+		Context("the deliverable requests a restamp", func() {
+			var fakeEventRecorder *eventsfakes.FakeEventRecorder
+
+			BeforeEach(func() {
+				fakeEventRecorder = &eventsfakes.FakeEventRecorder{}
+				reconciler.EventRecorder = fakeEventRecorder
+
+				dl.Annotations = map[string]string{v1alpha1.RestampAnnotation: "2026-10-19T12:30:00Z"}
+			})
+
+			It("realizes the resources bypassing the cache and records the restamp", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				realizeCtx, _, _, _, _ := rlzr.RealizeArgsForCall(0)
+				Expect(repository.IsCacheBypassed(realizeCtx)).To(BeTrue())
+
+				_, updatedDeliverable := repo.StatusUpdateArgsForCall(0)
+				Expect(updatedDeliverable.(*v1alpha1.Deliverable).Status.LastRestamp).To(Equal("2026-10-19T12:30:00Z"))
+
+				Expect(fakeEventRecorder.EventfCallCount()).To(Equal(1))
+				_, _, reason, _, _ := fakeEventRecorder.EventfArgsForCall(0)
+				Expect(reason).To(Equal(events.RestampedReason))
+			})
+
+			Context("and realizing the resources fails", func() {
+				BeforeEach(func() {
+					rlzr.RealizeReturns(errors.New("some error"))
+				})
+
+				It("records the restamp as pending rather than acted upon", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+
+					Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+					_, updatedDeliverable := repo.StatusUpdateArgsForCall(0)
+					Expect(updatedDeliverable.(*v1alpha1.Deliverable).Status.LastRestamp).To(BeEmpty())
+					Expect(updatedDeliverable.(*v1alpha1.Deliverable).Status.PendingRestamp).To(Equal("2026-10-19T12:30:00Z"))
+				})
+			})
+
+			Context("that is pending as realizing the resources failed before", func() {
+				BeforeEach(func() {
+					dl.Status.PendingRestamp = "2026-10-19T12:30:00Z"
+				})
+
+				It("retries the restamp without emitting the event or creating immutable objects again", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+
+					realizeCtx, _, _, _, _ := rlzr.RealizeArgsForCall(0)
+					Expect(repository.IsCacheBypassed(realizeCtx)).To(BeFalse())
+					Expect(repository.IsMutableCacheBypassed(realizeCtx)).To(BeTrue())
+					Expect(fakeEventRecorder.EventfCallCount()).To(Equal(0))
+
+					_, updatedDeliverable := repo.StatusUpdateArgsForCall(0)
+					Expect(updatedDeliverable.(*v1alpha1.Deliverable).Status.LastRestamp).To(Equal("2026-10-19T12:30:00Z"))
+					Expect(updatedDeliverable.(*v1alpha1.Deliverable).Status.PendingRestamp).To(BeEmpty())
+				})
+			})
+		})
+
 		It("watches the stampedObjects kinds", func() {
 			_, _ = reconciler.Reconcile(ctx, req)
 			Expect(stampedTracker.WatchCallCount()).To(Equal(2))
@@ -545,7 +661,7 @@ var _ = Describe("DeliverableReconciler", func() {
 						Message: "some informative message",
 					},
 				}
-				repo.GetDeliveriesForDeliverableReturns([]*v1alpha1.ClusterDelivery{&delivery}, nil)
+				repo.GetDeliveriesForDeliverableReturns([]v1alpha1.DeliveryObject{&delivery}, nil)
 			})
 
 			It("does not return an error", func() {
@@ -692,6 +808,34 @@ var _ = Describe("DeliverableReconciler", func() {
 				})
 			})
 
+			Context("of type ParamValidationError", func() {
+				var paramValidationError cerrors.ParamValidationError
+				BeforeEach(func() {
+					paramValidationError = cerrors.ParamValidationError{
+						Err:           errors.New("param [registry] is required"),
+						ResourceName:  "some-name",
+						BlueprintName: "some-delivery",
+						BlueprintType: cerrors.Delivery,
+						TemplateName:  "some-template",
+						TemplateKind:  "ClusterDeploymentTemplate",
+					}
+
+					rlzr.RealizeStub = func(ctx context.Context, resourceRealizer realizer.ResourceRealizer, blueprintName string, resources []realizer.OwnerResource, statuses statuses.ResourceStatuses) error {
+						return paramValidationError
+					}
+				})
+
+				It("calls the condition manager to report the invalid param", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(conditions.InvalidParamsCondition(true, paramValidationError)))
+				})
+
+				It("does not return an error", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
 			Context("of type ApplyStampedObjectError", func() {
 				var stampedObjectError cerrors.ApplyStampedObjectError
 				BeforeEach(func() {
@@ -1482,7 +1626,7 @@ var _ = Describe("DeliverableReconciler", func() {
 				Version: "alphabeta1",
 				Kind:    "MyThing",
 			})
-			repo.GetDeliveriesForDeliverableReturns([]*v1alpha1.ClusterDelivery{&delivery, &delivery}, nil)
+			repo.GetDeliveriesForDeliverableReturns([]v1alpha1.DeliveryObject{&delivery, &delivery}, nil)
 		})
 
 		It("does not return an error", func() {
@@ -1567,7 +1711,7 @@ var _ = Describe("DeliverableReconciler", func() {
 					},
 				},
 			}
-			repo.GetDeliveriesForDeliverableReturns([]*v1alpha1.ClusterDelivery{&delivery}, nil)
+			repo.GetDeliveriesForDeliverableReturns([]v1alpha1.DeliveryObject{&delivery}, nil)
 
 			rlzr.RealizeReturns(nil)
 
@@ -19,9 +19,9 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -47,7 +47,7 @@ func (r *DeliveryReconiler) Reconcile(ctx context.Context, req reconcile.Request
 	log = log.WithValues("delivery", req.NamespacedName)
 	ctx = logr.NewContext(ctx, log)
 
-	delivery, err := r.Repo.GetDelivery(ctx, req.Name)
+	delivery, err := r.Repo.GetDelivery(ctx, req.Name, req.Namespace)
 	if err != nil {
 		log.Error(err, "failed to get delivery")
 		return ctrl.Result{}, fmt.Errorf("failed to get delivery [%s]: %w", req.NamespacedName, err)
@@ -58,20 +58,20 @@ func (r *DeliveryReconiler) Reconcile(ctx context.Context, req reconcile.Request
 		return ctrl.Result{}, nil
 	}
 
-	conditionManager := conditions.NewConditionManager(v1alpha1.BlueprintReady, delivery.Status.Conditions)
+	conditionManager := conditions.NewConditionManager(v1alpha1.BlueprintReady, delivery.GetDeliveryStatus().Conditions)
 
 	err = r.reconcileDelivery(ctx, delivery, conditionManager)
 
 	return r.completeReconciliation(ctx, delivery, conditionManager, err)
 }
 
-func (r *DeliveryReconiler) reconcileDelivery(ctx context.Context, delivery *v1alpha1.ClusterDelivery, conditionManager conditions.ConditionManager) error {
+func (r *DeliveryReconiler) reconcileDelivery(ctx context.Context, delivery v1alpha1.DeliveryObject, conditionManager conditions.ConditionManager) error {
 	log := logr.FromContextOrDiscard(ctx)
 	var resourcesNotFound []string
 
-	for _, resource := range delivery.Spec.Resources {
+	for _, resource := range delivery.GetDeliverySpec().Resources {
 		if resource.TemplateRef.Name != "" {
-			found, err := r.validateResource(ctx, delivery, resource.TemplateRef.Name, resource.TemplateRef.Kind)
+			found, err := r.validateResource(ctx, delivery, resource.TemplateRef.Name, resource.TemplateRef.Kind, resource.TemplateRef.Revision)
 			if err != nil {
 				log.Error(err, "failed to get delivery cluster template", "template",
 					fmt.Sprintf("%s/%s", resource.TemplateRef.Kind, resource.TemplateRef.Name))
@@ -84,7 +84,7 @@ func (r *DeliveryReconiler) reconcileDelivery(ctx context.Context, delivery *v1a
 		} else {
 			for _, option := range resource.TemplateRef.Options {
 				if option.Name != "" {
-					found, err := r.validateResource(ctx, delivery, option.Name, resource.TemplateRef.Kind)
+					found, err := r.validateResource(ctx, delivery, option.Name, resource.TemplateRef.Kind, resource.TemplateRef.Revision)
 					if err != nil {
 						log.Error(err, "failed to get delivery cluster template", "template",
 							fmt.Sprintf("%s/%s", resource.TemplateRef.Kind, resource.TemplateRef.Name))
@@ -108,36 +108,40 @@ func (r *DeliveryReconiler) reconcileDelivery(ctx context.Context, delivery *v1a
 	return nil
 }
 
-func (r *DeliveryReconiler) validateResource(ctx context.Context, delivery *v1alpha1.ClusterDelivery, templateName, templateKind string) (bool, error) {
-	template, err := r.Repo.GetTemplate(ctx, templateName, templateKind)
+func (r *DeliveryReconiler) validateResource(ctx context.Context, delivery v1alpha1.DeliveryObject, templateName, templateKind string, revision int64) (bool, error) {
+	template, err := r.Repo.GetTemplate(ctx, templateName, templateKind, delivery.GetNamespace())
 	if err != nil {
 		return false, err
 	}
 
-	r.DependencyTracker.Track(dependency.Key{
-		GroupKind: schema.GroupKind{
-			Group: v1alpha1.SchemeGroupVersion.Group,
-			Kind:  templateKind,
-		},
-		NamespacedName: types.NamespacedName{
-			Name: templateName,
-		},
-	}, types.NamespacedName{
-		Namespace: delivery.Namespace,
-		Name:      delivery.Name,
+	trackTemplate(r.DependencyTracker, templateKind, templateName, delivery.GetNamespace(), types.NamespacedName{
+		Namespace: delivery.GetNamespace(),
+		Name:      delivery.GetName(),
 	})
+
+	// a resource pinned to a revision is still realized once its template is deleted
+	if template == nil && revision != 0 {
+		templateRevision, err := r.Repo.GetRevisionOfTemplate(ctx, templateName, templateKind, delivery.GetNamespace(), revision)
+		if err != nil {
+			return false, err
+		}
+		return templateRevision != nil, nil
+	}
+
 	return template != nil, nil
 }
 
-func (r *DeliveryReconiler) completeReconciliation(ctx context.Context, delivery *v1alpha1.ClusterDelivery, conditionManager conditions.ConditionManager, err error) (ctrl.Result, error) {
+func (r *DeliveryReconiler) completeReconciliation(ctx context.Context, delivery v1alpha1.DeliveryObject, conditionManager conditions.ConditionManager, err error) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
+	status := delivery.GetDeliveryStatus()
+
 	var changed bool
-	delivery.Status.Conditions, changed = conditionManager.Finalize()
+	status.Conditions, changed = conditionManager.Finalize()
 
 	var updateErr error
-	if changed || (delivery.Status.ObservedGeneration != delivery.Generation) {
-		delivery.Status.ObservedGeneration = delivery.Generation
+	if changed || (status.ObservedGeneration != delivery.GetGeneration()) {
+		status.ObservedGeneration = delivery.GetGeneration()
 		updateErr = r.Repo.StatusUpdate(ctx, delivery)
 		if updateErr != nil {
 			log.Error(err, "failed to update status for delivery")
@@ -167,8 +171,11 @@ func (r *DeliveryReconiler) SetupWithManager(mgr ctrl.Manager) error {
 		mgr.GetLogger().WithName("tracker-delivery"),
 	)
 
+	// namespaced deliveries are reconciled by the same controller: their requests are
+	// told apart from those of cluster deliveries by having a namespace
 	builder := ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.ClusterDelivery{})
+		For(&v1alpha1.ClusterDelivery{}).
+		Watches(&source.Kind{Type: &v1alpha1.Delivery{}}, &handler.EnqueueRequestForObject{})
 
 	for _, template := range v1alpha1.ValidDeliveryTemplates {
 		builder = builder.Watches(
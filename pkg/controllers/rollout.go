@@ -0,0 +1,94 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+// revisionHistoryLimit is the number of revisions kept for each template or supply
+// chain, besides its stable and latest revisions.
+const revisionHistoryLimit = 10
+
+// rolloutOwner is an owner of a rollout along with the revision it recorded as stamped
+type rolloutOwner struct {
+	object   client.Object
+	revision int64
+}
+
+// measureRollout measures how far the latest revision is rolled out to owners,
+// halting the rollout when too many of the owners that adopted it are unhealthy, and
+// promoting it to the stable revision once every owner adopts it. An owner counts as
+// adopting the latest revision once it recorded stamping it, so that an owner unhealthy
+// on an earlier revision does not halt the rollout. listOwners is only called while the
+// rollout is in progress.
+func measureRollout(rollout *v1alpha1.Rollout, previous *v1alpha1.RolloutStatus, latest int64, listOwners func() ([]rolloutOwner, error)) (*v1alpha1.RolloutStatus, error) {
+	status := &v1alpha1.RolloutStatus{LatestRevision: latest, StableRevision: latest}
+	if rollout == nil || previous == nil || previous.StableRevision == 0 {
+		return status, nil
+	}
+
+	status.StableRevision = previous.StableRevision
+	if status.Complete() {
+		return status, nil
+	}
+
+	if previous.HaltedRevision == latest {
+		status.HaltedRevision = latest
+		status.AdoptedOwners = previous.AdoptedOwners
+		status.UnhealthyOwners = previous.UnhealthyOwners
+		return status, nil
+	}
+
+	owners, err := listOwners()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, owner := range owners {
+		if owner.revision != latest || !rollout.Adopts(status, false, owner.object) {
+			continue
+		}
+		status.AdoptedOwners++
+		if meta.IsStatusConditionFalse(ownerConditions(owner.object), v1alpha1.ResourcesHealthy) {
+			status.UnhealthyOwners++
+		}
+	}
+
+	if maxUnhealthy := rollout.MaxUnhealthyPercentage; maxUnhealthy != nil && status.UnhealthyOwners*100 > *maxUnhealthy*status.AdoptedOwners {
+		status.HaltedRevision = latest
+		return status, nil
+	}
+
+	if rollout.Percentage >= 100 {
+		status.StableRevision = latest
+	}
+
+	return status, nil
+}
+
+func ownerConditions(owner client.Object) []metav1.Condition {
+	switch owner := owner.(type) {
+	case *v1alpha1.Workload:
+		return owner.Status.Conditions
+	case *v1alpha1.Deliverable:
+		return owner.Status.Conditions
+	}
+	return nil
+}
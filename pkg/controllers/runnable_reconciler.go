@@ -25,6 +25,8 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
@@ -103,25 +105,29 @@ func (r *RunnableReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	serviceAccount, err := r.Repo.GetServiceAccount(ctx, serviceAccountName, req.Namespace)
 	if err != nil {
 		conditionManager.AddPositive(conditions.RunnableServiceAccountNotFoundCondition(err))
-		return r.completeReconciliation(ctx, runnable, nil, conditionManager, fmt.Errorf("failed to get service account [%s]: %w", fmt.Sprintf("%s/%s", req.Namespace, serviceAccountName), err))
+		return r.completeReconciliation(ctx, runnable, nil, runnable.Status.Runs, conditionManager, fmt.Errorf("failed to get service account [%s]: %w", fmt.Sprintf("%s/%s", req.Namespace, serviceAccountName), err))
 	}
 
 	saToken, err := r.TokenManager.GetServiceAccountToken(serviceAccount)
 	if err != nil {
 		conditionManager.AddPositive(conditions.RunnableServiceAccountTokenErrorCondition(err))
 		log.Info("failed to get token for service account", "service account", fmt.Sprintf("%s/%s", req.Namespace, serviceAccountName))
-		return r.completeReconciliation(ctx, runnable, nil, conditionManager, fmt.Errorf("failed to get token for service account [%s]: %w", fmt.Sprintf("%s/%s", req.Namespace, serviceAccountName), err))
+		return r.completeReconciliation(ctx, runnable, nil, runnable.Status.Runs, conditionManager, fmt.Errorf("failed to get token for service account [%s]: %w", fmt.Sprintf("%s/%s", req.Namespace, serviceAccountName), err))
 	}
 
 	runnableClient, discoveryClient, err := r.ClientBuilder(saToken, true)
 	if err != nil {
 		conditionManager.AddPositive(conditions.ClientBuilderErrorCondition(err))
-		return r.completeReconciliation(ctx, runnable, nil, conditionManager, cerrors.NewUnhandledError(fmt.Errorf("failed to build resource realizer: %w", err)))
+		return r.completeReconciliation(ctx, runnable, nil, runnable.Status.Runs, conditionManager, cerrors.NewUnhandledError(fmt.Errorf("failed to build resource realizer: %w", err)))
 	}
 
-	stampedObject, outputs, err := r.Realizer.Realize(ctx, runnable, r.Repo, r.RepositoryBuilder(runnableClient, r.RunnableCache), discoveryClient)
+	stampedObject, outputs, runs, err := r.Realizer.Realize(ctx, runnable, r.Repo, r.RepositoryBuilder(runnableClient, r.RunnableCache), discoveryClient)
 	if err != nil {
 		log.V(logger.DEBUG).Info("failed to realize")
+		if runs == nil {
+			// the realizer failed before reading the runs, which are left as they were
+			runs = runnable.Status.Runs
+		}
 		switch typedErr := err.(type) {
 		case cerrors.RunnableGetRunTemplateError:
 			conditionManager.AddPositive(conditions.RunTemplateMissingCondition(typedErr))
@@ -155,6 +161,9 @@ func (r *RunnableReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	if stampedObject != nil {
 		stampedCondition := utils.ExtractConditions(stampedObject).ConditionWithType("Succeeded")
 		if stampedCondition != nil {
+			if message := failureMessageOfRun(runs, stampedObject); message != "" && stampedCondition.Status == metav1.ConditionFalse {
+				stampedCondition.Message = message
+			}
 			conditionManager.AddPositive(conditions.StampedObjectConditionKnown(stampedCondition))
 			stampedObjectStatusPresent = true
 		}
@@ -170,16 +179,17 @@ func (r *RunnableReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		conditionManager.AddPositive(conditions.StampedObjectConditionUnknown())
 	}
 
-	return r.completeReconciliation(ctx, runnable, outputs, conditionManager, err)
+	return r.completeReconciliation(ctx, runnable, outputs, runs, conditionManager, err)
 }
 
-func (r *RunnableReconciler) completeReconciliation(ctx context.Context, runnable *v1alpha1.Runnable, outputs map[string]apiextensionsv1.JSON, conditionManager conditions.ConditionManager, err error) (ctrl.Result, error) {
+func (r *RunnableReconciler) completeReconciliation(ctx context.Context, runnable *v1alpha1.Runnable, outputs map[string]apiextensionsv1.JSON, runs []v1alpha1.RunnableRun, conditionManager conditions.ConditionManager, err error) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 	var changed bool
 	runnable.Status.Conditions, changed = conditionManager.Finalize()
 
-	if changed || (runnable.Status.ObservedGeneration != runnable.Generation) || !reflect.DeepEqual(runnable.Status.Outputs, outputs) {
+	if changed || (runnable.Status.ObservedGeneration != runnable.Generation) || !reflect.DeepEqual(runnable.Status.Outputs, outputs) || !reflect.DeepEqual(runnable.Status.Runs, runs) {
 		runnable.Status.Outputs = outputs
+		runnable.Status.Runs = runs
 		runnable.Status.ObservedGeneration = runnable.Generation
 		statusUpdateError := r.Repo.StatusUpdate(ctx, runnable)
 		if statusUpdateError != nil {
@@ -198,6 +208,15 @@ func (r *RunnableReconciler) completeReconciliation(ctx context.Context, runnabl
 	return ctrl.Result{}, nil
 }
 
+func failureMessageOfRun(runs []v1alpha1.RunnableRun, stampedObject *unstructured.Unstructured) string {
+	for _, run := range runs {
+		if run.StampedRef != nil && run.StampedRef.ObjectReference != nil && run.StampedRef.Name == stampedObject.GetName() {
+			return run.Message
+		}
+	}
+	return ""
+}
+
 func (r *RunnableReconciler) trackDependencies(runnable *v1alpha1.Runnable, serviceAccountName string) {
 	r.DependencyTracker.ClearTracked(types.NamespacedName{
 		Namespace: runnable.Namespace,
@@ -249,7 +249,7 @@ var _ = Describe("Reconcile", func() {
 					Version: "alphabeta1",
 					Kind:    "MyThing",
 				})
-				rlzr.RealizeReturns(stampedObject, nil, nil)
+				rlzr.RealizeReturns(stampedObject, nil, nil, nil)
 
 				_, _ = reconciler.Reconcile(ctx, request)
 				Expect(stampedTracker.WatchCallCount()).To(Equal(1))
@@ -263,7 +263,7 @@ var _ = Describe("Reconcile", func() {
 		Context("watching causes an error", func() {
 			BeforeEach(func() {
 				stampedObject := &unstructured.Unstructured{}
-				rlzr.RealizeReturns(stampedObject, nil, nil)
+				rlzr.RealizeReturns(stampedObject, nil, nil, nil)
 
 				stampedTracker.WatchReturns(errors.New("could not watch"))
 			})
@@ -328,7 +328,7 @@ var _ = Describe("Reconcile", func() {
 
 		Context("no outputs were returned from the realizer", func() {
 			BeforeEach(func() {
-				rlzr.RealizeReturns(nil, nil, nil)
+				rlzr.RealizeReturns(nil, nil, nil, nil)
 			})
 
 			It("fetches the runnable", func() {
@@ -365,7 +365,7 @@ var _ = Describe("Reconcile", func() {
 			BeforeEach(func() {
 				rlzr.RealizeReturns(nil, templates.Outputs{
 					"an-output": apiextensionsv1.JSON{Raw: []byte(`"the value"`)},
-				}, nil)
+				}, nil, nil)
 			})
 
 			It("Updates the status with the outputs", func() {
@@ -405,9 +405,107 @@ var _ = Describe("Reconcile", func() {
 			})
 		})
 
+		Context("runs are returned from the realizer", func() {
+			var runs []v1alpha1.RunnableRun
+
+			BeforeEach(func() {
+				runs = []v1alpha1.RunnableRun{
+					{
+						StampedRef: &v1alpha1.StampedRef{
+							ObjectReference: &corev1.ObjectReference{
+								Kind: "TestObj",
+								Name: "my-run-abcde",
+							},
+						},
+						Health:  metav1.ConditionFalse,
+						Message: "tests failed",
+					},
+				}
+				rlzr.RealizeReturns(nil, nil, runs, nil)
+			})
+
+			It("updates the status with the runs", func() {
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+				_, obj := repo.StatusUpdateArgsForCall(0)
+				statusObject, ok := obj.(*v1alpha1.Runnable)
+				Expect(ok).To(BeTrue())
+
+				Expect(statusObject.Status.Runs).To(Equal(runs))
+			})
+
+			It("reports the run's message when the stamped object failed", func() {
+				stampedObject := &unstructured.Unstructured{}
+				stampedObject.SetName("my-run-abcde")
+				stampedObject.Object["status"] = map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Succeeded", "status": "False", "reason": "Failed", "message": "generic failure"},
+					},
+				}
+				rlzr.RealizeReturns(stampedObject, nil, runs, nil)
+
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				var addedConditions []metav1.Condition
+				for i := 0; i < conditionManager.AddPositiveCallCount(); i++ {
+					addedConditions = append(addedConditions, conditionManager.AddPositiveArgsForCall(i))
+				}
+				Expect(addedConditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":    Equal(v1alpha1.StampedObjectCondition),
+					"Status":  Equal(metav1.ConditionFalse),
+					"Message": Equal("tests failed"),
+				})))
+			})
+		})
+
+		Context("the runnable has runs in its status", func() {
+			BeforeEach(func() {
+				rb.Status.Runs = []v1alpha1.RunnableRun{
+					{
+						StampedRef: &v1alpha1.StampedRef{
+							ObjectReference: &corev1.ObjectReference{
+								Kind: "TestObj",
+								Name: "my-run-abcde",
+							},
+						},
+						Health: metav1.ConditionTrue,
+					},
+				}
+			})
+
+			It("clears them when the realizer retains no runs", func() {
+				rlzr.RealizeReturns(nil, nil, nil, nil)
+
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+				_, obj := repo.StatusUpdateArgsForCall(0)
+				Expect(obj.(*v1alpha1.Runnable).Status.Runs).To(BeEmpty())
+			})
+
+			It("keeps them when the realizer fails before reading the runs", func() {
+				runs := rb.Status.Runs
+				rlzr.RealizeReturns(nil, nil, nil, cerrors.RunnableStampError{
+					Err:         errors.New("some error"),
+					TemplateRef: &v1alpha1.TemplateReference{Kind: "ClusterRunTemplate", Name: "my-run-template"},
+				})
+
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+				_, obj := repo.StatusUpdateArgsForCall(0)
+				Expect(obj.(*v1alpha1.Runnable).Status.Runs).To(Equal(runs))
+			})
+		})
+
 		Context("updating the status fails", func() {
 			BeforeEach(func() {
-				rlzr.RealizeReturns(nil, nil, nil)
+				rlzr.RealizeReturns(nil, nil, nil, nil)
 				repo.StatusUpdateReturns(errors.New("bad status update error"))
 			})
 
@@ -427,7 +525,7 @@ var _ = Describe("Reconcile", func() {
 
 		Context("the realizer returns an error", func() {
 			BeforeEach(func() {
-				rlzr.RealizeReturns(nil, nil, nil)
+				rlzr.RealizeReturns(nil, nil, nil, nil)
 			})
 
 			It("Starts and Finishes cleanly", func() {
@@ -452,7 +550,7 @@ var _ = Describe("Reconcile", func() {
 						Err:         errors.New("some error"),
 						TemplateRef: &v1alpha1.TemplateReference{Kind: "ClusterRunTemplate", Name: "my-run-template"},
 					}
-					rlzr.RealizeReturns(nil, nil, err)
+					rlzr.RealizeReturns(nil, nil, nil, err)
 				})
 
 				It("calls the condition manager to report", func() {
@@ -480,7 +578,7 @@ var _ = Describe("Reconcile", func() {
 							MatchingLabels: map[string]string{"foo": "bar", "moo": "cow"},
 						},
 					}
-					rlzr.RealizeReturns(nil, nil, err)
+					rlzr.RealizeReturns(nil, nil, nil, err)
 				})
 
 				It("calls the condition manager to report", func() {
@@ -509,7 +607,7 @@ var _ = Describe("Reconcile", func() {
 						Err:         errors.New("some error"),
 						TemplateRef: &v1alpha1.TemplateReference{Kind: "ClusterRunTemplate", Name: "my-run-template"},
 					}
-					rlzr.RealizeReturns(nil, nil, err)
+					rlzr.RealizeReturns(nil, nil, nil, err)
 				})
 
 				It("does not try to watch the stampedObjects", func() {
@@ -546,7 +644,7 @@ var _ = Describe("Reconcile", func() {
 						StampedObject: &unstructured.Unstructured{},
 						TemplateRef:   &v1alpha1.TemplateReference{Kind: "ClusterRunTemplate", Name: "my-run-template"},
 					}
-					rlzr.RealizeReturns(nil, nil, err)
+					rlzr.RealizeReturns(nil, nil, nil, err)
 				})
 
 				It("calls the condition manager to report", func() {
@@ -584,7 +682,7 @@ var _ = Describe("Reconcile", func() {
 						TemplateRef:   &v1alpha1.TemplateReference{Kind: "ClusterRunTemplate", Name: "my-run-template"},
 					}
 
-					rlzr.RealizeReturns(nil, nil, stampedObjectError)
+					rlzr.RealizeReturns(nil, nil, nil, stampedObjectError)
 				})
 
 				It("calls the condition manager to report", func() {
@@ -609,7 +707,7 @@ var _ = Describe("Reconcile", func() {
 						Namespace: "some-ns",
 						Labels:    map[string]string{"hi": "bye"},
 					}
-					rlzr.RealizeReturns(nil, nil, err)
+					rlzr.RealizeReturns(nil, nil, nil, err)
 				})
 
 				It("calls the condition manager to report", func() {
@@ -644,7 +742,7 @@ var _ = Describe("Reconcile", func() {
 						StampedObject:     stampedObject,
 						QualifiedResource: "mything.thing.io",
 					}
-					rlzr.RealizeReturns(nil, nil, err)
+					rlzr.RealizeReturns(nil, nil, nil, err)
 				})
 
 				It("calls the condition manager to report", func() {
@@ -670,7 +768,7 @@ var _ = Describe("Reconcile", func() {
 				var err error
 				BeforeEach(func() {
 					err = errors.New("some error")
-					rlzr.RealizeReturns(nil, nil, err)
+					rlzr.RealizeReturns(nil, nil, nil, err)
 				})
 
 				It("calls the condition manager to report", func() {
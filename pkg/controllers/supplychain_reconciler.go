@@ -17,12 +17,16 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
@@ -38,8 +42,12 @@ type Timer interface {
 	Now() metav1.Time
 }
 
+// SupplyChainReconciler validates the templates supply chains reference. It snapshots
+// every change to their spec in a ClusterSupplyChainRevision, and tracks how far the
+// latest revision is rolled out to the workloads realizing them.
 type SupplyChainReconciler struct {
 	Repo                    repository.Repository
+	Client                  client.Client
 	ConditionManagerBuilder conditions.ConditionManagerBuilder
 	DependencyTracker       dependency.DependencyTracker
 }
@@ -52,33 +60,184 @@ func (r *SupplyChainReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	log = log.WithValues("supply chain", req.NamespacedName)
 	ctx = logr.NewContext(ctx, log)
 
-	supplyChain, err := r.Repo.GetSupplyChain(ctx, req.Name)
+	supplyChain, err := r.Repo.GetSupplyChain(ctx, req.Name, req.Namespace)
 	if err != nil {
 		log.Error(err, "failed to get supply chain")
 		return ctrl.Result{}, fmt.Errorf("failed to get supply chain [%s]: %w", req.NamespacedName, err)
 	}
 
+	// namespaced supply chains are told apart from cluster supply chains by their namespace
+	ref := v1alpha1.SupplyChainRevisionReference{Kind: "ClusterSupplyChain", Name: req.Name}
+	if req.Namespace != "" {
+		ref = v1alpha1.SupplyChainRevisionReference{Kind: "SupplyChain", Namespace: req.Namespace, Name: req.Name}
+	}
+
+	revisions, err := r.Repo.ListSupplyChainRevisions(ctx, ref)
+	if err != nil {
+		log.Error(err, "failed to list supply chain revisions")
+		return ctrl.Result{}, fmt.Errorf("failed to list revisions of supply chain [%s]: %w", req.NamespacedName, err)
+	}
+
 	if supplyChain == nil {
 		log.Info("supply chain no longer exists")
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, r.deleteRevisions(ctx, revisions)
+	}
+
+	revisions, err = r.ensureLatestRevision(ctx, supplyChain, revisions)
+	if err != nil {
+		log.Error(err, "failed to create supply chain revision")
+		return ctrl.Result{}, fmt.Errorf("failed to create revision of supply chain [%s]: %w", req.NamespacedName, err)
 	}
 
-	conditionManager := r.ConditionManagerBuilder(v1alpha1.BlueprintReady, supplyChain.Status.Conditions)
+	status := supplyChain.GetSupplyChainStatus()
+	rollout, err := measureRollout(supplyChain.GetSupplyChainSpec().Rollout, status.Rollout, revisions[len(revisions)-1].Spec.Revision, func() ([]rolloutOwner, error) {
+		workloads, err := r.workloadsRealizing(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+
+		owners := make([]rolloutOwner, len(workloads))
+		for i, workload := range workloads {
+			owners[i] = rolloutOwner{object: workload, revision: workload.(*v1alpha1.Workload).Status.SupplyChainRevision}
+		}
+		return owners, nil
+	})
+	if err != nil {
+		log.Error(err, "failed to get rollout status")
+		return ctrl.Result{}, fmt.Errorf("failed to get rollout status of supply chain [%s]: %w", req.NamespacedName, err)
+	}
+
+	if err := r.pruneRevisions(ctx, ref, revisions, rollout); err != nil {
+		log.Error(err, "failed to prune supply chain revisions")
+		return ctrl.Result{}, fmt.Errorf("failed to prune revisions of supply chain [%s]: %w", req.NamespacedName, err)
+	}
+
+	if rollout.Halted() && (status.Rollout == nil || !status.Rollout.Halted()) {
+		log.Info("halted rollout of supply chain revision", "revision", rollout.LatestRevision,
+			"adopted owners", rollout.AdoptedOwners, "unhealthy owners", rollout.UnhealthyOwners)
+	}
+	rolloutChanged := !reflect.DeepEqual(status.Rollout, rollout)
+	status.Rollout = rollout
+
+	conditionManager := r.ConditionManagerBuilder(v1alpha1.BlueprintReady, status.Conditions)
 
 	err = r.reconcileSupplyChain(ctx, supplyChain, conditionManager)
 
-	return r.completeReconciliation(ctx, supplyChain, conditionManager, err)
+	return r.completeReconciliation(ctx, supplyChain, conditionManager, rolloutChanged, err)
+}
+
+// ensureLatestRevision creates a revision of the supply chain when its spec changed
+// since its latest revision.
+func (r *SupplyChainReconciler) ensureLatestRevision(ctx context.Context, supplyChain v1alpha1.SupplyChainObject, revisions []v1alpha1.ClusterSupplyChainRevision) ([]v1alpha1.ClusterSupplyChainRevision, error) {
+	next := int64(1)
+	if len(revisions) > 0 {
+		latest := revisions[len(revisions)-1]
+		matches, err := latest.Matches(supplyChain)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			return revisions, nil
+		}
+		next = latest.Spec.Revision + 1
+	}
+
+	revision, err := v1alpha1.NewSupplyChainRevision(next, supplyChain)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.Client.Create(ctx, revision); err != nil {
+		return nil, err
+	}
+
+	logr.FromContextOrDiscard(ctx).Info("created supply chain revision", "revision", revision.Name)
+	return append(revisions, *revision), nil
+}
+
+// workloadsRealizing lists the workloads that selected the referenced supply chain.
+func (r *SupplyChainReconciler) workloadsRealizing(ctx context.Context, ref v1alpha1.SupplyChainRevisionReference) ([]client.Object, error) {
+	workloads := &v1alpha1.WorkloadList{}
+	if err := r.Client.List(ctx, workloads, client.InNamespace(ref.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list workloads: %w", err)
+	}
+
+	var owners []client.Object
+	for i := range workloads.Items {
+		workload := &workloads.Items[i]
+		if realizes(workload, ref) {
+			owners = append(owners, workload)
+		}
+	}
+	return owners, nil
+}
+
+func realizes(workload *v1alpha1.Workload, ref v1alpha1.SupplyChainRevisionReference) bool {
+	supplyChainRef := workload.Status.SupplyChainRef
+	return supplyChainRef.Kind == ref.Kind && supplyChainRef.Namespace == ref.Namespace && supplyChainRef.Name == ref.Name
 }
 
-func (r *SupplyChainReconciler) completeReconciliation(ctx context.Context, supplyChain *v1alpha1.ClusterSupplyChain, conditionManager conditions.ConditionManager, err error) (ctrl.Result, error) {
+// pruneRevisions deletes the revisions beyond the history limit, other than the stable
+// and latest revisions and those a workload recorded as realized on its status.
+func (r *SupplyChainReconciler) pruneRevisions(ctx context.Context, ref v1alpha1.SupplyChainRevisionReference, revisions []v1alpha1.ClusterSupplyChainRevision, rollout *v1alpha1.RolloutStatus) error {
+	if len(revisions) <= revisionHistoryLimit {
+		return nil
+	}
+
+	workloads, err := r.workloadsRealizing(ctx, ref)
+	if err != nil {
+		return err
+	}
+	referenced := map[int64]bool{}
+	for _, workload := range workloads {
+		referenced[workload.(*v1alpha1.Workload).Status.SupplyChainRevision] = true
+	}
+
+	var prunable []v1alpha1.ClusterSupplyChainRevision
+	for i := 0; i < len(revisions)-revisionHistoryLimit; i++ {
+		revision := revisions[i].Spec.Revision
+		if revision == rollout.StableRevision || revision == rollout.LatestRevision || referenced[revision] {
+			continue
+		}
+		prunable = append(prunable, revisions[i])
+	}
+
+	return r.deleteRevisions(ctx, prunable)
+}
+
+func (r *SupplyChainReconciler) deleteRevisions(ctx context.Context, revisions []v1alpha1.ClusterSupplyChainRevision) error {
+	for i := range revisions {
+		if err := r.Client.Delete(ctx, &revisions[i]); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete supply chain revision [%s]: %w", revisions[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// supplyChainRealizedBy enqueues the supply chain a workload selected, so that its
+// rollout follows the health of the workload.
+func supplyChainRealizedBy(workload client.Object) []reconcile.Request {
+	ref := workload.(*v1alpha1.Workload).Status.SupplyChainRef
+	switch ref.Kind {
+	case "ClusterSupplyChain":
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: ref.Name}}}
+	case "SupplyChain":
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}}}
+	}
+	return nil
+}
+
+func (r *SupplyChainReconciler) completeReconciliation(ctx context.Context, supplyChain v1alpha1.SupplyChainObject, conditionManager conditions.ConditionManager, rolloutChanged bool, err error) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
+	status := supplyChain.GetSupplyChainStatus()
+
 	var changed bool
-	supplyChain.Status.Conditions, changed = conditionManager.Finalize()
+	status.Conditions, changed = conditionManager.Finalize()
 
 	var updateErr error
-	if changed || (supplyChain.Status.ObservedGeneration != supplyChain.Generation) {
-		supplyChain.Status.ObservedGeneration = supplyChain.Generation
+	if changed || rolloutChanged || (status.ObservedGeneration != supplyChain.GetGeneration()) {
+		status.ObservedGeneration = supplyChain.GetGeneration()
 		updateErr = r.Repo.StatusUpdate(ctx, supplyChain)
 		if updateErr != nil {
 			log.Error(err, "failed to update status for supply chain")
@@ -97,13 +256,13 @@ func (r *SupplyChainReconciler) completeReconciliation(ctx context.Context, supp
 	return ctrl.Result{}, nil
 }
 
-func (r *SupplyChainReconciler) reconcileSupplyChain(ctx context.Context, chain *v1alpha1.ClusterSupplyChain, conditionManager conditions.ConditionManager) error {
+func (r *SupplyChainReconciler) reconcileSupplyChain(ctx context.Context, chain v1alpha1.SupplyChainObject, conditionManager conditions.ConditionManager) error {
 	log := logr.FromContextOrDiscard(ctx)
 	var resourcesNotFound []string
 
-	for _, resource := range chain.Spec.Resources {
+	for _, resource := range chain.GetSupplyChainSpec().Resources {
 		if resource.TemplateRef.Name != "" {
-			found, err := r.validateResource(ctx, chain, resource.TemplateRef.Name, resource.TemplateRef.Kind)
+			found, err := r.validateResource(ctx, chain, resource.TemplateRef.Name, resource.TemplateRef.Kind, resource.TemplateRef.Revision)
 			if err != nil {
 				log.Error(err, "failed to get cluster template", "template",
 					fmt.Sprintf("%s/%s", resource.TemplateRef.Kind, resource.TemplateRef.Name))
@@ -116,7 +275,7 @@ func (r *SupplyChainReconciler) reconcileSupplyChain(ctx context.Context, chain
 		} else {
 			for _, option := range resource.TemplateRef.Options {
 				if option.Name != "" {
-					found, err := r.validateResource(ctx, chain, option.Name, resource.TemplateRef.Kind)
+					found, err := r.validateResource(ctx, chain, option.Name, resource.TemplateRef.Kind, resource.TemplateRef.Revision)
 					if err != nil {
 						log.Error(err, "failed to get cluster template", "template",
 							fmt.Sprintf("%s/%s", resource.TemplateRef.Kind, resource.TemplateRef.Name))
@@ -140,25 +299,26 @@ func (r *SupplyChainReconciler) reconcileSupplyChain(ctx context.Context, chain
 	return nil
 }
 
-func (r *SupplyChainReconciler) validateResource(ctx context.Context, supplyChain *v1alpha1.ClusterSupplyChain, templateName, templateKind string) (bool, error) {
-	template, err := r.Repo.GetTemplate(ctx, templateName, templateKind)
+func (r *SupplyChainReconciler) validateResource(ctx context.Context, supplyChain v1alpha1.SupplyChainObject, templateName, templateKind string, revision int64) (bool, error) {
+	template, err := r.Repo.GetTemplate(ctx, templateName, templateKind, supplyChain.GetNamespace())
 	if err != nil {
 		return false, err
 	}
 
-	r.DependencyTracker.Track(dependency.Key{
-		GroupKind: schema.GroupKind{
-			Group: v1alpha1.SchemeGroupVersion.Group,
-			Kind:  templateKind,
-		},
-		NamespacedName: types.NamespacedName{
-			Name: templateName,
-		},
-	}, types.NamespacedName{
-		Namespace: supplyChain.Namespace,
-		Name:      supplyChain.Name,
+	trackTemplate(r.DependencyTracker, templateKind, templateName, supplyChain.GetNamespace(), types.NamespacedName{
+		Namespace: supplyChain.GetNamespace(),
+		Name:      supplyChain.GetName(),
 	})
 
+	// a resource pinned to a revision is still realized once its template is deleted
+	if template == nil && revision != 0 {
+		templateRevision, err := r.Repo.GetRevisionOfTemplate(ctx, templateName, templateKind, supplyChain.GetNamespace(), revision)
+		if err != nil {
+			return false, err
+		}
+		return templateRevision != nil, nil
+	}
+
 	return template != nil, nil
 }
 
@@ -168,14 +328,19 @@ func (r *SupplyChainReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		repository.NewCache(mgr.GetLogger().WithName("supply-chain-repo-cache")),
 	)
 
+	r.Client = mgr.GetClient()
 	r.ConditionManagerBuilder = conditions.NewConditionManager
 	r.DependencyTracker = dependency.NewDependencyTracker(
 		2*utils.DefaultResyncTime,
 		mgr.GetLogger().WithName("tracker-supply-chain"),
 	)
 
+	// namespaced supply chains are reconciled by the same controller: their requests are
+	// told apart from those of cluster supply chains by having a namespace
 	builder := ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.ClusterSupplyChain{})
+		For(&v1alpha1.ClusterSupplyChain{}).
+		Watches(&source.Kind{Type: &v1alpha1.SupplyChain{}}, &handler.EnqueueRequestForObject{}).
+		Watches(&source.Kind{Type: &v1alpha1.Workload{}}, handler.EnqueueRequestsFromMapFunc(supplyChainRealizedBy))
 
 	for _, template := range v1alpha1.ValidSupplyChainTemplates {
 		builder = builder.Watches(
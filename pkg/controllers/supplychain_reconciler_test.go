@@ -17,6 +17,7 @@ package controllers_test
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo"
@@ -26,6 +27,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -49,8 +52,28 @@ var _ = Describe("SupplyChainReconciler", func() {
 		dependencyTracker  *dependencyfakes.FakeDependencyTracker
 		sc                 *v1alpha1.ClusterSupplyChain
 		expectedConditions []metav1.Condition
+		objects            []client.Object
+		cl                 client.Client
 	)
 
+	revisionOf := func(supplyChain *v1alpha1.ClusterSupplyChain, revision int64) v1alpha1.ClusterSupplyChainRevision {
+		rev, err := v1alpha1.NewSupplyChainRevision(revision, supplyChain)
+		Expect(err).NotTo(HaveOccurred())
+		return *rev
+	}
+
+	listRevisions := func() []v1alpha1.ClusterSupplyChainRevision {
+		list := &v1alpha1.ClusterSupplyChainRevisionList{}
+		Expect(cl.List(ctx, list)).To(Succeed())
+		return list.Items
+	}
+
+	updatedStatus := func() v1alpha1.SupplyChainStatus {
+		Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+		_, updated := repo.StatusUpdateArgsForCall(0)
+		return updated.(*v1alpha1.ClusterSupplyChain).Status
+	}
+
 	BeforeEach(func() {
 		out = NewBuffer()
 		logger := zap.New(zap.WriteTo(out))
@@ -80,6 +103,7 @@ var _ = Describe("SupplyChainReconciler", func() {
 
 		sc = &v1alpha1.ClusterSupplyChain{
 			ObjectMeta: metav1.ObjectMeta{
+				Name:       "my-supply-chain",
 				Generation: 1,
 			},
 			Spec: v1alpha1.SupplyChainSpec{
@@ -107,6 +131,14 @@ var _ = Describe("SupplyChainReconciler", func() {
 		}
 
 		repo.GetTemplateReturns(&v1alpha1.ClusterTemplate{}, nil)
+		objects = nil
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		cl = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+		reconciler.Client = cl
 	})
 
 	It("logs that it's begun", func() {
@@ -163,6 +195,140 @@ var _ = Describe("SupplyChainReconciler", func() {
 		Expect(err).NotTo(HaveOccurred())
 	})
 
+	It("snapshots the spec of the supply chain in its first revision", func() {
+		_, err := reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		revisions := listRevisions()
+		Expect(revisions).To(HaveLen(1))
+		Expect(revisions[0].Spec.Revision).To(BeEquivalentTo(1))
+		Expect(revisions[0].Matches(sc)).To(BeTrue())
+
+		Expect(updatedStatus().Rollout).To(Equal(&v1alpha1.RolloutStatus{LatestRevision: 1, StableRevision: 1}))
+	})
+
+	Context("when the spec of the supply chain has not changed since its latest revision", func() {
+		BeforeEach(func() {
+			revision := revisionOf(sc, 3)
+			objects = append(objects, &revision)
+			repo.ListSupplyChainRevisionsReturns([]v1alpha1.ClusterSupplyChainRevision{revision}, nil)
+			sc.Status.ObservedGeneration = 1
+			sc.Status.Rollout = &v1alpha1.RolloutStatus{LatestRevision: 3, StableRevision: 3}
+			conditionManager.FinalizeReturns(expectedConditions, false)
+		})
+
+		It("neither creates a revision nor updates the status", func() {
+			_, err := reconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(listRevisions()).To(HaveLen(1))
+			Expect(repo.StatusUpdateCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the supply chain rolls out its changes", func() {
+		var workloads []*v1alpha1.Workload
+
+		BeforeEach(func() {
+			req.Namespace = ""
+			maxUnhealthy := 50
+			sc.Spec.Rollout = &v1alpha1.Rollout{
+				Canary:                 &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+				MaxUnhealthyPercentage: &maxUnhealthy,
+			}
+			sc.Status.Rollout = &v1alpha1.RolloutStatus{LatestRevision: 1, StableRevision: 1}
+
+			previous := sc.DeepCopy()
+			previous.Spec.Resources = []v1alpha1.SupplyChainResource{{Name: "previous"}}
+			revision := revisionOf(previous, 1)
+			objects = append(objects, &revision)
+			repo.ListSupplyChainRevisionsReturns([]v1alpha1.ClusterSupplyChainRevision{revision}, nil)
+
+			workloads = nil
+			for _, name := range []string{"canary", "other"} {
+				workload := &v1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "my-namespace", Labels: map[string]string{name: "true"}},
+					Status: v1alpha1.WorkloadStatus{
+						SupplyChainRef: v1alpha1.ObjectReference{Kind: "ClusterSupplyChain", Name: "my-supply-chain"},
+					},
+				}
+				workloads = append(workloads, workload)
+			}
+			workloads[0].Status.SupplyChainRevision = 2
+			workloads[1].Status.SupplyChainRevision = 1
+		})
+
+		JustBeforeEach(func() {
+			for _, workload := range workloads {
+				Expect(cl.Create(ctx, workload)).To(Succeed())
+			}
+		})
+
+		It("creates the next revision and keeps the previous one stable", func() {
+			_, err := reconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			revisions := listRevisions()
+			Expect(revisions).To(HaveLen(2))
+			Expect(updatedStatus().Rollout).To(Equal(&v1alpha1.RolloutStatus{LatestRevision: 2, StableRevision: 1, AdoptedOwners: 1}))
+		})
+
+		Context("when the canary is unhealthy", func() {
+			BeforeEach(func() {
+				workloads[0].Status.Conditions = []metav1.Condition{{Type: v1alpha1.ResourcesHealthy, Status: metav1.ConditionFalse}}
+			})
+
+			It("halts the rollout", func() {
+				_, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(updatedStatus().Rollout.Halted()).To(BeTrue())
+				Expect(out).To(Say("halted rollout of supply chain revision"))
+			})
+
+			Context("before it realizes the latest revision", func() {
+				BeforeEach(func() {
+					workloads[0].Status.SupplyChainRevision = 1
+				})
+
+				It("does not count it against the latest revision", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(updatedStatus().Rollout).To(Equal(&v1alpha1.RolloutStatus{LatestRevision: 2, StableRevision: 1}))
+				})
+			})
+		})
+
+		Context("when the history is longer than the limit", func() {
+			BeforeEach(func() {
+				var revisions []v1alpha1.ClusterSupplyChainRevision
+				objects = nil
+				for i := int64(1); i <= 12; i++ {
+					previous := sc.DeepCopy()
+					previous.Spec.Resources = []v1alpha1.SupplyChainResource{{Name: fmt.Sprintf("previous-%d", i)}}
+					revision := revisionOf(previous, i)
+					revisions = append(revisions, revision)
+					objects = append(objects, &revision)
+				}
+				repo.ListSupplyChainRevisionsReturns(revisions, nil)
+				sc.Status.Rollout = &v1alpha1.RolloutStatus{LatestRevision: 12, StableRevision: 1}
+				workloads[1].Status.SupplyChainRevision = 2
+			})
+
+			It("prunes the oldest revisions no workload realizes", func() {
+				_, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+
+				var kept []int64
+				for _, revision := range listRevisions() {
+					kept = append(kept, revision.Spec.Revision)
+				}
+				Expect(kept).To(ConsistOf(int64(1), int64(2), int64(4), int64(5), int64(6), int64(7), int64(8), int64(9), int64(10), int64(11), int64(12), int64(13)))
+			})
+		})
+	})
+
 	Context("all referenced templates exist", func() {
 		var (
 			firstTemplate  *v1alpha1.ClusterSourceTemplate
@@ -287,6 +453,24 @@ var _ = Describe("SupplyChainReconciler", func() {
 			_, err := reconciler.Reconcile(ctx, req)
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		Context("but the resource pins a revision of the template that still exists", func() {
+			BeforeEach(func() {
+				sc.Spec.Resources[0].TemplateRef.Revision = 2
+				repo.GetRevisionOfTemplateReturns(&v1alpha1.ClusterTemplateRevision{}, nil)
+			})
+
+			It("adds a positive templates found condition", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(repo.GetRevisionOfTemplateCallCount()).To(Equal(1))
+				_, name, kind, _, revision := repo.GetRevisionOfTemplateArgsForCall(0)
+				Expect(name).To(Equal("some-name"))
+				Expect(kind).To(Equal("some-kind"))
+				Expect(revision).To(BeEquivalentTo(2))
+				Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(conditions.TemplatesFoundCondition()))
+			})
+		})
 	})
 
 	Context("when the update fails", func() {
@@ -305,6 +489,9 @@ var _ = Describe("SupplyChainReconciler", func() {
 	Context("when the supply chain has been deleted from the apiServer", func() {
 		BeforeEach(func() {
 			repo.GetSupplyChainReturns(nil, nil)
+			revision := revisionOf(sc, 1)
+			objects = append(objects, &revision)
+			repo.ListSupplyChainRevisionsReturns([]v1alpha1.ClusterSupplyChainRevision{revision}, nil)
 		})
 
 		It("does not return an error", func() {
@@ -312,6 +499,13 @@ var _ = Describe("SupplyChainReconciler", func() {
 
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("deletes the revisions of the supply chain", func() {
+			_, err := reconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(listRevisions()).To(BeEmpty())
+		})
 	})
 
 	Context("when the client errors", func() {
@@ -0,0 +1,432 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+	"github.com/vmware-tanzu/cartographer/pkg/utils"
+)
+
+// TemplateReconciler publishes on the status of templates of one kind the inputs
+// they read, so that template authors and UIs can tell what an owner must provide.
+// It snapshots every change to their spec in a ClusterTemplateRevision, and tracks
+// how far the latest revision is rolled out to the owners stamping them.
+type TemplateReconciler struct {
+	Repo   repository.Repository
+	Client client.Client
+	Kind   string
+}
+
+func (r *TemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	log.Info("started")
+	defer log.Info("finished")
+
+	log = log.WithValues("template", req.NamespacedName, "kind", r.Kind)
+	ctx = logr.NewContext(ctx, log)
+
+	ref := v1alpha1.TemplateRevisionReference{Kind: r.Kind, Namespace: req.Namespace, Name: req.Name}
+	revisions, err := r.Repo.ListTemplateRevisions(ctx, ref)
+	if err != nil {
+		log.Error(err, "failed to list template revisions")
+		return ctrl.Result{}, fmt.Errorf("failed to list revisions of template [%s/%s]: %w", r.Kind, req.NamespacedName, err)
+	}
+
+	obj, err := r.Repo.GetTemplate(ctx, req.Name, r.Kind, req.Namespace)
+	if err != nil {
+		log.Error(err, "failed to get template")
+		return ctrl.Result{}, fmt.Errorf("failed to get template [%s/%s]: %w", r.Kind, req.NamespacedName, err)
+	}
+
+	// a namespaced kind falls back to the cluster scoped template of the same name
+	template, ok := obj.(v1alpha1.TemplateObject)
+	if !ok || template.GetNamespace() != req.Namespace {
+		log.Info("template no longer exists")
+		return r.deleteUnreferencedRevisions(ctx, req, revisions)
+	}
+
+	revisions, err = r.ensureLatestRevision(ctx, template, ref, revisions)
+	if err != nil {
+		log.Error(err, "failed to create template revision")
+		return ctrl.Result{}, fmt.Errorf("failed to create revision of template [%s/%s]: %w", r.Kind, req.NamespacedName, err)
+	}
+
+	rollout, err := measureRollout(template.GetTemplateSpec().Rollout, template.GetTemplateStatus().Rollout, revisions[len(revisions)-1].Spec.Revision, func() ([]rolloutOwner, error) {
+		return r.ownersStamping(ctx, template)
+	})
+	if err != nil {
+		log.Error(err, "failed to get rollout status")
+		return ctrl.Result{}, fmt.Errorf("failed to get rollout status of template [%s/%s]: %w", r.Kind, req.NamespacedName, err)
+	}
+
+	if err := r.pruneRevisions(ctx, template, revisions, rollout); err != nil {
+		log.Error(err, "failed to prune template revisions")
+		return ctrl.Result{}, fmt.Errorf("failed to prune revisions of template [%s/%s]: %w", r.Kind, req.NamespacedName, err)
+	}
+
+	status := template.GetTemplateStatus()
+	inputs := template.GetTemplateSpec().DiscoverInputs()
+	if status.ObservedGeneration == template.GetGeneration() && reflect.DeepEqual(status.Inputs, inputs) && reflect.DeepEqual(status.Rollout, rollout) {
+		return ctrl.Result{}, nil
+	}
+
+	if rollout.Halted() && (status.Rollout == nil || !status.Rollout.Halted()) {
+		log.Info("halted rollout of template revision", "revision", rollout.LatestRevision,
+			"adopted owners", rollout.AdoptedOwners, "unhealthy owners", rollout.UnhealthyOwners)
+	}
+
+	status.ObservedGeneration = template.GetGeneration()
+	status.Inputs = inputs
+	status.Rollout = rollout
+	if err := r.Repo.StatusUpdate(ctx, template); err != nil {
+		log.Error(err, "failed to update status for template")
+		return ctrl.Result{}, fmt.Errorf("failed to update status for template [%s/%s]: %w", r.Kind, req.NamespacedName, err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// ensureLatestRevision creates a revision of the template when its spec changed since
+// its latest revision.
+func (r *TemplateReconciler) ensureLatestRevision(ctx context.Context, template v1alpha1.TemplateObject, ref v1alpha1.TemplateRevisionReference, revisions []v1alpha1.ClusterTemplateRevision) ([]v1alpha1.ClusterTemplateRevision, error) {
+	next := int64(1)
+	if len(revisions) > 0 {
+		latest := revisions[len(revisions)-1]
+		matches, err := latest.Matches(template)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			return revisions, nil
+		}
+		next = latest.Spec.Revision + 1
+	}
+
+	revision, err := v1alpha1.NewTemplateRevision(ref, next, template)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.Client.Create(ctx, revision); err != nil {
+		return nil, err
+	}
+
+	logr.FromContextOrDiscard(ctx).Info("created template revision", "revision", revision.Name)
+	return append(revisions, *revision), nil
+}
+
+// ownersStamping lists the workloads and deliverables that stamped the template, along
+// with the revision of the template they stamped.
+func (r *TemplateReconciler) ownersStamping(ctx context.Context, template v1alpha1.TemplateObject) ([]rolloutOwner, error) {
+	var owners []rolloutOwner
+
+	workloads := &v1alpha1.WorkloadList{}
+	if err := r.Client.List(ctx, workloads, client.InNamespace(template.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("failed to list workloads: %w", err)
+	}
+	for i := range workloads.Items {
+		workload := &workloads.Items[i]
+		resource, err := r.resourceStamping(ctx, template, workload.Namespace, workload.Status.Resources)
+		if err != nil {
+			return nil, err
+		}
+		if resource != nil {
+			owners = append(owners, rolloutOwner{object: workload, revision: resource.TemplateRevision})
+		}
+	}
+
+	deliverables := &v1alpha1.DeliverableList{}
+	if err := r.Client.List(ctx, deliverables, client.InNamespace(template.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("failed to list deliverables: %w", err)
+	}
+	for i := range deliverables.Items {
+		deliverable := &deliverables.Items[i]
+		resource, err := r.resourceStamping(ctx, template, deliverable.Namespace, deliverable.Status.Resources)
+		if err != nil {
+			return nil, err
+		}
+		if resource != nil {
+			owners = append(owners, rolloutOwner{object: deliverable, revision: resource.TemplateRevision})
+		}
+	}
+
+	return owners, nil
+}
+
+// resourceStamping returns the resource of an owner in namespace that stamped the
+// template, or nil when none did. A resource referencing the namespaced kind of a cluster
+// scoped template stamps it unless a namespaced template of the same name shadows it.
+func (r *TemplateReconciler) resourceStamping(ctx context.Context, template v1alpha1.TemplateObject, namespace string, resources []v1alpha1.ResourceStatus) (*v1alpha1.ResourceStatus, error) {
+	for i := range resources {
+		resource := &resources[i]
+		if resource.TemplateRef == nil || resource.TemplateRef.Name != template.GetName() {
+			continue
+		}
+		if resource.TemplateRef.Kind == r.Kind {
+			return resource, nil
+		}
+		if !v1alpha1.IsNamespacedTemplateKind(resource.TemplateRef.Kind) || v1alpha1.ClusterTemplateKind(resource.TemplateRef.Kind) != r.Kind {
+			continue
+		}
+
+		stamped, err := r.Repo.GetTemplate(ctx, template.GetName(), resource.TemplateRef.Kind, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if stamped != nil && stamped.GetNamespace() == "" {
+			return resource, nil
+		}
+	}
+	return nil, nil
+}
+
+// pruneRevisions deletes the revisions beyond the history limit, other than the stable
+// and latest revisions and those still referenced: pinned by a blueprint resource or
+// recorded as stamped on the status of an owner.
+func (r *TemplateReconciler) pruneRevisions(ctx context.Context, template v1alpha1.TemplateObject, revisions []v1alpha1.ClusterTemplateRevision, rollout *v1alpha1.RolloutStatus) error {
+	if len(revisions) <= revisionHistoryLimit {
+		return nil
+	}
+
+	referenced, err := r.referencedRevisions(ctx, template)
+	if err != nil {
+		return err
+	}
+
+	var prunable []v1alpha1.ClusterTemplateRevision
+	for i := 0; i < len(revisions)-revisionHistoryLimit; i++ {
+		revision := revisions[i].Spec.Revision
+		if revision == rollout.StableRevision || revision == rollout.LatestRevision || referenced[revision] {
+			continue
+		}
+		prunable = append(prunable, revisions[i])
+	}
+
+	return r.deleteRevisions(ctx, prunable)
+}
+
+// referencedRevisions returns the revisions of the template pinned by the resources of
+// blueprints, or stamped by owners. A reference to the namespaced kind of a cluster
+// scoped template is counted even when a namespaced template shadows it.
+func (r *TemplateReconciler) referencedRevisions(ctx context.Context, template v1alpha1.TemplateObject) (map[int64]bool, error) {
+	referenced := map[int64]bool{}
+	reference := func(kind, name string, revision int64) {
+		if revision == 0 || name != template.GetName() {
+			return
+		}
+		if kind == r.Kind || (template.GetNamespace() == "" && v1alpha1.ClusterTemplateKind(kind) == r.Kind) {
+			referenced[revision] = true
+		}
+	}
+
+	var supplyChainResources []v1alpha1.SupplyChainResource
+	var deliveryResources []v1alpha1.DeliveryResource
+
+	supplyChains := &v1alpha1.SupplyChainList{}
+	if err := r.Client.List(ctx, supplyChains, client.InNamespace(template.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("failed to list supply chains: %w", err)
+	}
+	for _, supplyChain := range supplyChains.Items {
+		supplyChainResources = append(supplyChainResources, supplyChain.Spec.Resources...)
+	}
+
+	deliveries := &v1alpha1.DeliveryList{}
+	if err := r.Client.List(ctx, deliveries, client.InNamespace(template.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("failed to list deliveries: %w", err)
+	}
+	for _, delivery := range deliveries.Items {
+		deliveryResources = append(deliveryResources, delivery.Spec.Resources...)
+	}
+
+	if template.GetNamespace() == "" {
+		clusterSupplyChains := &v1alpha1.ClusterSupplyChainList{}
+		if err := r.Client.List(ctx, clusterSupplyChains); err != nil {
+			return nil, fmt.Errorf("failed to list cluster supply chains: %w", err)
+		}
+		for _, supplyChain := range clusterSupplyChains.Items {
+			supplyChainResources = append(supplyChainResources, supplyChain.Spec.Resources...)
+		}
+
+		fragments := &v1alpha1.ClusterSupplyChainFragmentList{}
+		if err := r.Client.List(ctx, fragments); err != nil {
+			return nil, fmt.Errorf("failed to list supply chain fragments: %w", err)
+		}
+		for _, fragment := range fragments.Items {
+			supplyChainResources = append(supplyChainResources, fragment.Spec.Resources...)
+		}
+
+		clusterDeliveries := &v1alpha1.ClusterDeliveryList{}
+		if err := r.Client.List(ctx, clusterDeliveries); err != nil {
+			return nil, fmt.Errorf("failed to list cluster deliveries: %w", err)
+		}
+		for _, delivery := range clusterDeliveries.Items {
+			deliveryResources = append(deliveryResources, delivery.Spec.Resources...)
+		}
+	}
+
+	for _, resource := range supplyChainResources {
+		reference(resource.TemplateRef.Kind, resource.TemplateRef.Name, resource.TemplateRef.Revision)
+	}
+	for _, resource := range deliveryResources {
+		reference(resource.TemplateRef.Kind, resource.TemplateRef.Name, resource.TemplateRef.Revision)
+	}
+
+	var ownerResources []v1alpha1.ResourceStatus
+
+	workloads := &v1alpha1.WorkloadList{}
+	if err := r.Client.List(ctx, workloads, client.InNamespace(template.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("failed to list workloads: %w", err)
+	}
+	for _, workload := range workloads.Items {
+		ownerResources = append(ownerResources, workload.Status.Resources...)
+	}
+
+	deliverables := &v1alpha1.DeliverableList{}
+	if err := r.Client.List(ctx, deliverables, client.InNamespace(template.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("failed to list deliverables: %w", err)
+	}
+	for _, deliverable := range deliverables.Items {
+		ownerResources = append(ownerResources, deliverable.Status.Resources...)
+	}
+
+	for _, resource := range ownerResources {
+		if resource.TemplateRef != nil {
+			reference(resource.TemplateRef.Kind, resource.TemplateRef.Name, resource.TemplateRevision)
+		}
+	}
+
+	return referenced, nil
+}
+
+// deleteUnreferencedRevisions deletes the revisions of a deleted template, other than
+// those still referenced: resources pinned to a revision are stamped from it until they
+// are unpinned, so the revisions they reference are checked again periodically.
+func (r *TemplateReconciler) deleteUnreferencedRevisions(ctx context.Context, req ctrl.Request, revisions []v1alpha1.ClusterTemplateRevision) (ctrl.Result, error) {
+	if len(revisions) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	obj, err := v1alpha1.GetAPITemplate(r.Kind)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	deleted := obj.(v1alpha1.TemplateObject)
+	deleted.SetNamespace(req.Namespace)
+	deleted.SetName(req.Name)
+
+	referenced, err := r.referencedRevisions(ctx, deleted)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get referenced revisions of template [%s/%s]: %w", r.Kind, req.NamespacedName, err)
+	}
+
+	var unreferenced []v1alpha1.ClusterTemplateRevision
+	for _, revision := range revisions {
+		if !referenced[revision.Spec.Revision] {
+			unreferenced = append(unreferenced, revision)
+		}
+	}
+
+	if err := r.deleteRevisions(ctx, unreferenced); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if len(unreferenced) < len(revisions) {
+		logr.FromContextOrDiscard(ctx).Info("kept referenced revisions of deleted template", "revisions", len(revisions)-len(unreferenced))
+		return ctrl.Result{RequeueAfter: utils.DefaultResyncTime}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *TemplateReconciler) deleteRevisions(ctx context.Context, revisions []v1alpha1.ClusterTemplateRevision) error {
+	for i := range revisions {
+		if err := r.Client.Delete(ctx, &revisions[i]); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete template revision [%s]: %w", revisions[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// templatesStampedBy enqueues the templates of the reconciled kind an owner stamped,
+// so that their rollout follows the health of the owner.
+func (r *TemplateReconciler) templatesStampedBy(resources func(client.Object) []v1alpha1.ResourceStatus) handler.MapFunc {
+	return func(owner client.Object) []reconcile.Request {
+		var requests []reconcile.Request
+		seen := map[types.NamespacedName]bool{}
+		for _, resource := range resources(owner) {
+			if resource.TemplateRef == nil {
+				continue
+			}
+
+			var name types.NamespacedName
+			switch {
+			case resource.TemplateRef.Kind == r.Kind && v1alpha1.IsNamespacedTemplateKind(r.Kind):
+				name = types.NamespacedName{Namespace: owner.GetNamespace(), Name: resource.TemplateRef.Name}
+			case resource.TemplateRef.Kind == r.Kind, v1alpha1.ClusterTemplateKind(resource.TemplateRef.Kind) == r.Kind:
+				name = types.NamespacedName{Name: resource.TemplateRef.Name}
+			default:
+				continue
+			}
+
+			if !seen[name] {
+				seen[name] = true
+				requests = append(requests, reconcile.Request{NamespacedName: name})
+			}
+		}
+		return requests
+	}
+}
+
+func (r *TemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Repo = repository.NewRepository(
+		mgr.GetClient(),
+		repository.NewCache(mgr.GetLogger().WithName("template-repo-cache")),
+	)
+	r.Client = mgr.GetClient()
+
+	template, err := v1alpha1.GetAPITemplate(r.Kind)
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(template).
+		Watches(
+			&source.Kind{Type: &v1alpha1.Workload{}},
+			handler.EnqueueRequestsFromMapFunc(r.templatesStampedBy(func(owner client.Object) []v1alpha1.ResourceStatus {
+				return owner.(*v1alpha1.Workload).Status.Resources
+			})),
+		).
+		Watches(
+			&source.Kind{Type: &v1alpha1.Deliverable{}},
+			handler.EnqueueRequestsFromMapFunc(r.templatesStampedBy(func(owner client.Object) []v1alpha1.ResourceStatus {
+				return owner.(*v1alpha1.Deliverable).Status.Resources
+			})),
+		).
+		Complete(r)
+}
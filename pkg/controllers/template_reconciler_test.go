@@ -0,0 +1,536 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/controllers"
+	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
+)
+
+var _ = Describe("TemplateReconciler", func() {
+	var (
+		out        *Buffer
+		reconciler controllers.TemplateReconciler
+		ctx        context.Context
+		req        reconcile.Request
+		repo       *repositoryfakes.FakeRepository
+		template   *v1alpha1.SourceTemplate
+		objects    []client.Object
+		cl         client.Client
+	)
+
+	ref := v1alpha1.TemplateRevisionReference{Kind: "SourceTemplate", Namespace: "my-namespace", Name: "git"}
+
+	revisionOf := func(template *v1alpha1.SourceTemplate, revision int64) v1alpha1.ClusterTemplateRevision {
+		rev, err := v1alpha1.NewTemplateRevision(ref, revision, template)
+		Expect(err).NotTo(HaveOccurred())
+		return *rev
+	}
+
+	revisionName := func(revision int64) string {
+		return v1alpha1.TemplateRevisionName(ref, revision)
+	}
+
+	listRevisions := func() []v1alpha1.ClusterTemplateRevision {
+		list := &v1alpha1.ClusterTemplateRevisionList{}
+		Expect(cl.List(ctx, list)).To(Succeed())
+		return list.Items
+	}
+
+	updatedStatus := func() v1alpha1.TemplateStatus {
+		Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+		_, updated := repo.StatusUpdateArgsForCall(0)
+		return updated.(*v1alpha1.SourceTemplate).Status
+	}
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		logger := zap.New(zap.WriteTo(out))
+		ctx = logr.NewContext(context.Background(), logger)
+
+		repo = &repositoryfakes.FakeRepository{}
+
+		template = &v1alpha1.SourceTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "git",
+				Namespace:  "my-namespace",
+				Generation: 2,
+			},
+			Spec: v1alpha1.SourceTemplateSpec{
+				TemplateSpec: v1alpha1.TemplateSpec{
+					Template: &runtime.RawExtension{
+						Raw: []byte(`{"apiVersion":"source.toolkit.fluxcd.io/v1beta1","kind":"GitRepository","spec":{"url":"$(workload.spec.source.git.url)$","interval":"$(params.interval)$"}}`),
+					},
+				},
+			},
+		}
+		repo.GetTemplateReturns(template, nil)
+		objects = nil
+
+		reconciler = controllers.TemplateReconciler{
+			Repo: repo,
+			Kind: "SourceTemplate",
+		}
+
+		req = reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: "git", Namespace: "my-namespace"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		cl = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+		reconciler.Client = cl
+	})
+
+	It("gets the template of its kind", func() {
+		_, err := reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(repo.GetTemplateCallCount()).To(Equal(1))
+		_, name, kind, namespace := repo.GetTemplateArgsForCall(0)
+		Expect(name).To(Equal("git"))
+		Expect(kind).To(Equal("SourceTemplate"))
+		Expect(namespace).To(Equal("my-namespace"))
+	})
+
+	It("publishes the inputs the template reads on its status", func() {
+		_, err := reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+		_, updated := repo.StatusUpdateArgsForCall(0)
+		Expect(updated.(*v1alpha1.SourceTemplate).Status).To(Equal(v1alpha1.TemplateStatus{
+			ObservedGeneration: 2,
+			Inputs: &v1alpha1.TemplateInputs{
+				OwnerFields: []string{"spec.source.git.url"},
+				Params:      []string{"interval"},
+			},
+			Rollout: &v1alpha1.RolloutStatus{
+				LatestRevision: 1,
+				StableRevision: 1,
+			},
+		}))
+	})
+
+	It("snapshots the spec of the template in its first revision", func() {
+		_, err := reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(repo.ListTemplateRevisionsCallCount()).To(Equal(1))
+		_, ref := repo.ListTemplateRevisionsArgsForCall(0)
+		Expect(ref).To(Equal(v1alpha1.TemplateRevisionReference{Kind: "SourceTemplate", Namespace: "my-namespace", Name: "git"}))
+
+		revisions := listRevisions()
+		Expect(revisions).To(HaveLen(1))
+		Expect(revisions[0].Name).To(Equal(revisionName(1)))
+		Expect(revisions[0].Spec.Revision).To(BeEquivalentTo(1))
+		Expect(revisions[0].Matches(template)).To(BeTrue())
+	})
+
+	Context("the spec did not change since the latest revision", func() {
+		BeforeEach(func() {
+			repo.ListTemplateRevisionsReturns([]v1alpha1.ClusterTemplateRevision{revisionOf(template, 3)}, nil)
+		})
+
+		It("does not create a revision", func() {
+			_, err := reconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(listRevisions()).To(BeEmpty())
+			Expect(updatedStatus().Rollout).To(Equal(&v1alpha1.RolloutStatus{LatestRevision: 3, StableRevision: 3}))
+		})
+	})
+
+	Context("the spec changed since the latest revision", func() {
+		var previous *v1alpha1.SourceTemplate
+
+		BeforeEach(func() {
+			previous = template.DeepCopy()
+			repo.ListTemplateRevisionsReturns([]v1alpha1.ClusterTemplateRevision{revisionOf(previous, 1)}, nil)
+
+			template.Spec.URLPath = ".status.artifact.url"
+			template.Status.Rollout = &v1alpha1.RolloutStatus{LatestRevision: 1, StableRevision: 1}
+		})
+
+		It("creates the next revision", func() {
+			_, err := reconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			revisions := listRevisions()
+			Expect(revisions).To(HaveLen(1))
+			Expect(revisions[0].Name).To(Equal(revisionName(2)))
+			Expect(revisions[0].Matches(template)).To(BeTrue())
+		})
+
+		It("makes it the stable revision when there is no rollout", func() {
+			_, err := reconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(updatedStatus().Rollout).To(Equal(&v1alpha1.RolloutStatus{LatestRevision: 2, StableRevision: 2}))
+		})
+
+		Context("the template has a rollout", func() {
+			var maxUnhealthy int
+
+			owner := func(name string, labels map[string]string, healthy metav1.ConditionStatus, revision int64) *v1alpha1.Workload {
+				return &v1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "my-namespace", Labels: labels},
+					Status: v1alpha1.WorkloadStatus{
+						OwnerStatus: v1alpha1.OwnerStatus{
+							Conditions: []metav1.Condition{{Type: v1alpha1.ResourcesHealthy, Status: healthy}},
+						},
+						Resources: []v1alpha1.ResourceStatus{{
+							RealizedResource: v1alpha1.RealizedResource{
+								Name:             "source",
+								TemplateRef:      &corev1.ObjectReference{Kind: "SourceTemplate", Name: "git"},
+								TemplateRevision: revision,
+							},
+						}},
+					},
+				}
+			}
+
+			BeforeEach(func() {
+				maxUnhealthy = 50
+				template.Spec.Rollout = &v1alpha1.Rollout{
+					Canary:                 &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+					MaxUnhealthyPercentage: &maxUnhealthy,
+				}
+				objects = []client.Object{
+					owner("canary", map[string]string{"canary": "true"}, metav1.ConditionTrue, 2),
+					owner("other", nil, metav1.ConditionFalse, 1),
+				}
+			})
+
+			It("keeps the previous stable revision while the canary adopts the latest", func() {
+				_, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(updatedStatus().Rollout).To(Equal(&v1alpha1.RolloutStatus{
+					LatestRevision: 2,
+					StableRevision: 1,
+					AdoptedOwners:  1,
+				}))
+			})
+
+			Context("too many of the owners that adopted the latest revision are unhealthy", func() {
+				BeforeEach(func() {
+					objects = []client.Object{
+						owner("canary", map[string]string{"canary": "true"}, metav1.ConditionFalse, 2),
+						owner("other-canary", map[string]string{"canary": "true"}, metav1.ConditionTrue, 2),
+						owner("unhealthy-canary", map[string]string{"canary": "true"}, metav1.ConditionFalse, 2),
+					}
+				})
+
+				It("halts the rollout", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+
+					rollout := updatedStatus().Rollout
+					Expect(rollout).To(Equal(&v1alpha1.RolloutStatus{
+						LatestRevision:  2,
+						StableRevision:  1,
+						HaltedRevision:  2,
+						AdoptedOwners:   3,
+						UnhealthyOwners: 2,
+					}))
+					Expect(rollout.Halted()).To(BeTrue())
+					Expect(out).To(Say(`"msg":"halted rollout of template revision"`))
+				})
+			})
+
+			Context("a canary that is unhealthy has not stamped the latest revision yet", func() {
+				BeforeEach(func() {
+					objects = []client.Object{
+						owner("canary", map[string]string{"canary": "true"}, metav1.ConditionFalse, 1),
+						owner("other-canary", map[string]string{"canary": "true"}, metav1.ConditionTrue, 2),
+					}
+				})
+
+				It("does not count it against the latest revision", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+
+					rollout := updatedStatus().Rollout
+					Expect(rollout).To(Equal(&v1alpha1.RolloutStatus{
+						LatestRevision: 2,
+						StableRevision: 1,
+						AdoptedOwners:  1,
+					}))
+					Expect(rollout.Halted()).To(BeFalse())
+				})
+			})
+
+			Context("every owner adopts the latest revision", func() {
+				BeforeEach(func() {
+					template.Spec.Rollout.Percentage = 100
+					maxUnhealthy = 100
+					objects = []client.Object{
+						owner("canary", map[string]string{"canary": "true"}, metav1.ConditionTrue, 2),
+						owner("other", nil, metav1.ConditionFalse, 2),
+					}
+				})
+
+				It("promotes it to the stable revision", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(updatedStatus().Rollout).To(Equal(&v1alpha1.RolloutStatus{
+						LatestRevision:  2,
+						StableRevision:  2,
+						AdoptedOwners:   2,
+						UnhealthyOwners: 1,
+					}))
+				})
+			})
+		})
+	})
+
+	Context("the template has more revisions than the history limit", func() {
+		BeforeEach(func() {
+			var revisions []v1alpha1.ClusterTemplateRevision
+			for i := int64(1); i <= 12; i++ {
+				revision := revisionOf(template, i)
+				objects = append(objects, revision.DeepCopy())
+				revisions = append(revisions, revision)
+			}
+			repo.ListTemplateRevisionsReturns(revisions, nil)
+
+			template.Spec.Rollout = &v1alpha1.Rollout{}
+			template.Status.Rollout = &v1alpha1.RolloutStatus{LatestRevision: 12, StableRevision: 1}
+		})
+
+		It("prunes the oldest revisions except the stable one", func() {
+			_, err := reconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			var names []string
+			for _, revision := range listRevisions() {
+				names = append(names, revision.Name)
+			}
+			Expect(names).To(HaveLen(11))
+			Expect(names).To(ContainElement(revisionName(1)))
+			Expect(names).NotTo(ContainElement(revisionName(2)))
+		})
+
+		Context("older revisions are still referenced", func() {
+			BeforeEach(func() {
+				var revisions []v1alpha1.ClusterTemplateRevision
+				for i := int64(1); i <= 14; i++ {
+					revision := revisionOf(template, i)
+					if i > 12 {
+						objects = append(objects, revision.DeepCopy())
+					}
+					revisions = append(revisions, revision)
+				}
+				repo.ListTemplateRevisionsReturns(revisions, nil)
+				template.Status.Rollout.LatestRevision = 14
+
+				objects = append(objects,
+					&v1alpha1.SupplyChain{
+						ObjectMeta: metav1.ObjectMeta{Name: "pinned", Namespace: "my-namespace"},
+						Spec: v1alpha1.SupplyChainSpec{
+							Resources: []v1alpha1.SupplyChainResource{
+								{
+									Name:        "source-provider",
+									TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "SourceTemplate", Name: "git", Revision: 2},
+								},
+							},
+						},
+					},
+					&v1alpha1.Workload{
+						ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "my-namespace"},
+						Status: v1alpha1.WorkloadStatus{
+							Resources: []v1alpha1.ResourceStatus{
+								{
+									RealizedResource: v1alpha1.RealizedResource{
+										Name:             "source-provider",
+										TemplateRef:      &corev1.ObjectReference{Kind: "SourceTemplate", Name: "git"},
+										TemplateRevision: 3,
+									},
+								},
+							},
+						},
+					},
+				)
+			})
+
+			It("keeps the revisions pinned by blueprints and stamped for owners", func() {
+				_, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+
+				var names []string
+				for _, revision := range listRevisions() {
+					names = append(names, revision.Name)
+				}
+				Expect(names).To(HaveLen(13))
+				Expect(names).To(ContainElements(
+					revisionName(1),
+					revisionName(2),
+					revisionName(3),
+				))
+				Expect(names).NotTo(ContainElement(revisionName(4)))
+			})
+		})
+	})
+
+	Context("the status is up to date", func() {
+		BeforeEach(func() {
+			template.Status = v1alpha1.TemplateStatus{
+				ObservedGeneration: 2,
+				Inputs: &v1alpha1.TemplateInputs{
+					OwnerFields: []string{"spec.source.git.url"},
+					Params:      []string{"interval"},
+				},
+				Rollout: &v1alpha1.RolloutStatus{
+					LatestRevision: 1,
+					StableRevision: 1,
+				},
+			}
+			repo.ListTemplateRevisionsReturns([]v1alpha1.ClusterTemplateRevision{revisionOf(template, 1)}, nil)
+		})
+
+		It("does not update the status", func() {
+			_, err := reconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(repo.StatusUpdateCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("the namespaced template no longer exists and the cluster template is returned instead", func() {
+		BeforeEach(func() {
+			repo.GetTemplateReturns(&v1alpha1.ClusterSourceTemplate{ObjectMeta: metav1.ObjectMeta{Name: "git"}}, nil)
+		})
+
+		It("does not update any status", func() {
+			_, err := reconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(repo.StatusUpdateCallCount()).To(Equal(0))
+			Expect(out).To(Say(`"msg":"template no longer exists"`))
+		})
+
+		Context("the template had revisions", func() {
+			BeforeEach(func() {
+				revision := revisionOf(template, 1)
+				objects = []client.Object{revision.DeepCopy()}
+				repo.ListTemplateRevisionsReturns([]v1alpha1.ClusterTemplateRevision{revision}, nil)
+			})
+
+			It("deletes them", func() {
+				result, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(listRevisions()).To(BeEmpty())
+				Expect(result.RequeueAfter).To(BeZero())
+			})
+
+			Context("some of them are still referenced", func() {
+				BeforeEach(func() {
+					var revisions []v1alpha1.ClusterTemplateRevision
+					objects = nil
+					for i := int64(1); i <= 3; i++ {
+						revision := revisionOf(template, i)
+						objects = append(objects, revision.DeepCopy())
+						revisions = append(revisions, revision)
+					}
+					repo.ListTemplateRevisionsReturns(revisions, nil)
+
+					objects = append(objects,
+						&v1alpha1.SupplyChain{
+							ObjectMeta: metav1.ObjectMeta{Name: "pinned", Namespace: "my-namespace"},
+							Spec: v1alpha1.SupplyChainSpec{
+								Resources: []v1alpha1.SupplyChainResource{
+									{
+										Name:        "source-provider",
+										TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "SourceTemplate", Name: "git", Revision: 1},
+									},
+								},
+							},
+						},
+						&v1alpha1.Workload{
+							ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "my-namespace"},
+							Status: v1alpha1.WorkloadStatus{
+								Resources: []v1alpha1.ResourceStatus{
+									{
+										RealizedResource: v1alpha1.RealizedResource{
+											Name:             "source-provider",
+											TemplateRef:      &corev1.ObjectReference{Kind: "SourceTemplate", Name: "git"},
+											TemplateRevision: 2,
+										},
+									},
+								},
+							},
+						},
+					)
+				})
+
+				It("keeps the referenced revisions and checks them again later", func() {
+					result, err := reconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+
+					var names []string
+					for _, revision := range listRevisions() {
+						names = append(names, revision.Name)
+					}
+					Expect(names).To(ConsistOf(revisionName(1), revisionName(2)))
+					Expect(result.RequeueAfter).NotTo(BeZero())
+				})
+			})
+		})
+	})
+
+	Context("getting the template fails", func() {
+		BeforeEach(func() {
+			repo.GetTemplateReturns(nil, errors.New("some error"))
+		})
+
+		It("returns an error", func() {
+			_, err := reconciler.Reconcile(ctx, req)
+			Expect(err).To(MatchError("failed to get template [SourceTemplate/my-namespace/git]: some error"))
+		})
+	})
+
+	Context("updating the status fails", func() {
+		BeforeEach(func() {
+			repo.StatusUpdateReturns(errors.New("some error"))
+		})
+
+		It("returns an error", func() {
+			_, err := reconciler.Reconcile(ctx, req)
+			Expect(err).To(MatchError("failed to update status for template [SourceTemplate/my-namespace/git]: some error"))
+		})
+	})
+})
@@ -31,6 +31,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/cluster-api/controllers/external"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	crtcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -47,6 +48,7 @@ import (
 	realizerclient "github.com/vmware-tanzu/cartographer/pkg/realizer/client"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/healthcheck"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/statuses"
+	"github.com/vmware-tanzu/cartographer/pkg/redact"
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/satoken"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
@@ -90,7 +92,21 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 
 		return ctrl.Result{}, nil
 	}
-	ctx = events.NewContext(ctx, events.FromEventRecorder(r.EventRecorder, workload, r.RESTMapper, log))
+	redactor := redact.NewRedactor()
+	ctx = redact.NewContext(ctx, redactor)
+	ctx = events.NewContext(ctx, events.Redacting(events.FromEventRecorder(r.EventRecorder, workload, r.RESTMapper, log), redactor))
+
+	if isPaused(workload) {
+		log.Info("workload is paused")
+		if pauseOwner(ctx, &workload.Status.OwnerStatus) {
+			if err := r.Repo.StatusUpdate(ctx, workload); err != nil {
+				log.Error(err, "failed to update status for workload")
+				return ctrl.Result{}, fmt.Errorf("failed to update status for workload: %w", err)
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+	reportResumed(ctx, workload.Status.OwnerStatus)
 
 	conditionManager := r.ConditionManagerBuilder(v1alpha1.OwnerReady, workload.Status.Conditions)
 
@@ -99,27 +115,34 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return r.completeReconciliation(ctx, workload, nil, conditionManager, err)
 	}
 
-	log = log.WithValues("supply chain", supplyChain.Name)
+	log = log.WithValues("supply chain", supplyChain.GetName())
 	ctx = logr.NewContext(ctx, log)
 
 	supplyChainGVK, err := utils.GetObjectGVK(supplyChain, r.Repo.GetScheme())
 	if err != nil {
 		log.Error(err, "failed to get object gvk for supply chain")
 		return r.completeReconciliation(ctx, workload, nil, conditionManager, cerrors.NewUnhandledError(
-			fmt.Errorf("failed to get object gvk for supply chain [%s]: %w", supplyChain.Name, err)),
+			fmt.Errorf("failed to get object gvk for supply chain [%s]: %w", supplyChain.GetName(), err)),
 		)
 	}
 
 	workload.Status.SupplyChainRef.Kind = supplyChainGVK.Kind
-	workload.Status.SupplyChainRef.Name = supplyChain.Name
+	workload.Status.SupplyChainRef.Namespace = supplyChain.GetNamespace()
+	workload.Status.SupplyChainRef.Name = supplyChain.GetName()
 
 	if !r.isSupplyChainReady(supplyChain) {
 		conditionManager.AddPositive(conditions.MissingReadyInSupplyChainCondition(getSupplyChainReadyCondition(supplyChain)))
 		log.Info("supply chain is not in ready state")
-		return r.completeReconciliation(ctx, workload, nil, conditionManager, fmt.Errorf("supply chain [%s] is not in ready state", supplyChain.Name))
+		return r.completeReconciliation(ctx, workload, nil, conditionManager, fmt.Errorf("supply chain [%s] is not in ready state", supplyChain.GetName()))
 	}
 	conditionManager.AddPositive(conditions.SupplyChainReadyCondition())
 
+	supplyChain, err = r.supplyChainRevision(ctx, workload, supplyChain)
+	if err != nil {
+		log.Error(err, "failed to get stable revision of supply chain")
+		return r.completeReconciliation(ctx, workload, nil, conditionManager, cerrors.NewUnhandledError(err))
+	}
+
 	serviceAccountName, serviceAccountNS := getServiceAccountNameAndNamespaceForWorkload(workload, supplyChain)
 
 	serviceAccount, err := r.Repo.GetServiceAccount(ctx, serviceAccountName, serviceAccountNS)
@@ -135,7 +158,7 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return r.completeReconciliation(ctx, workload, nil, conditionManager, fmt.Errorf("failed to get token for service account [%s]: %w", fmt.Sprintf("%s/%s", serviceAccountNS, serviceAccountName), err))
 	}
 
-	contextGenerator := realizer.NewContextGenerator(workload, workload.Spec.Params, supplyChain.Spec.Params)
+	contextGenerator := realizer.NewContextGenerator(workload, workload.Spec.Params, supplyChain.GetSupplyChainSpec().Params)
 	resourceRealizer, err := r.ResourceRealizerBuilder(saToken, workload, contextGenerator, r.Repo, BuildWorkloadResourceLabeler(workload, supplyChain))
 	if err != nil {
 		conditionManager.AddPositive(conditions.ResourceRealizerBuilderErrorCondition(err))
@@ -144,16 +167,33 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			fmt.Errorf("failed to build resource realizer: %w", err)))
 	}
 
+	var fragmentNames []string
+	ownerResources, err := realizer.MakeSupplychainOwnerResources(supplyChain, func(name string) (*v1alpha1.ClusterSupplyChainFragment, error) {
+		fragmentNames = append(fragmentNames, name)
+		fragment, err := r.Repo.GetSupplyChainFragment(ctx, name)
+		if err != nil {
+			return nil, cerrors.NewUnhandledError(err)
+		}
+		return fragment, nil
+	})
+	if err != nil {
+		conditionManager.AddPositive(conditions.FragmentExpansionErrorCondition(err))
+		log.Info("failed to expand supply chain fragments", "error", err.Error())
+		r.trackDependencies(workload, nil, fragmentNames, serviceAccountName, serviceAccountNS)
+		return r.completeReconciliation(ctx, workload, nil, conditionManager, err)
+	}
+
 	var reconcileErr error
 	resourceStatuses := statuses.NewResourceStatuses(workload.Status.Resources, conditions.AddConditionForResourceSubmittedWorkload)
 
-	err = r.Realizer.Realize(ctx, resourceRealizer, supplyChain.Name, realizer.MakeSupplychainOwnerResources(supplyChain), resourceStatuses)
+	err = r.Realizer.Realize(restamp(ctx, workload, workload.Status.OwnerStatus), resourceRealizer, supplyChain.GetName(), ownerResources, resourceStatuses)
 	if err != nil {
 		conditions.AddConditionForResourceSubmittedWorkload(&conditionManager, true, err)
 		log.V(logger.DEBUG).Info("failed to realize")
 		reconcileErr = cerrors.WrapUnhandledError(err)
 	} else {
 		conditionManager.AddPositive(conditions.ResourcesSubmittedCondition(true))
+		r.reportParamUsage(ctx, workload, contextGenerator, conditionManager)
 		if log.V(logger.DEBUG).Enabled() {
 			for _, resource := range resourceStatuses.GetCurrent() {
 				log.V(logger.DEBUG).Info("realized object",
@@ -164,9 +204,9 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 
 	conditionManager.AddPositive(healthcheck.OwnerHealthCondition(resourceStatuses.GetCurrent(), workload.Status.Conditions))
 
-	r.trackDependencies(workload, resourceStatuses.GetCurrent(), serviceAccountName, serviceAccountNS)
+	r.trackDependencies(workload, resourceStatuses.GetCurrent(), fragmentNames, serviceAccountName, serviceAccountNS)
 
-	cleanupErr := r.cleanupOrphanedObjects(ctx, workload.Status.Resources, resourceStatuses.GetCurrent())
+	cleanupErr := r.cleanupOrphanedObjects(ctx, workload.Namespace, workload.Status.Resources, resourceStatuses.GetCurrent())
 	if cleanupErr != nil {
 		log.Error(cleanupErr, "failed to cleanup orphaned objects")
 	}
@@ -195,11 +235,15 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 
 func (r *WorkloadReconciler) completeReconciliation(ctx context.Context, workload *v1alpha1.Workload, resourceStatuses statuses.ResourceStatuses, conditionManager conditions.ConditionManager, err error) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
+	resumed := wasPaused(workload.Status.OwnerStatus)
 	var changed bool
 	workload.Status.Conditions, changed = conditionManager.Finalize()
 	var updateErr error
 
-	if changed || (workload.Status.ObservedGeneration != workload.Generation) || (resourceStatuses != nil && resourceStatuses.IsChanged()) {
+	// resources are only realized, and so only restamped, when there are resource statuses
+	restamped := resourceStatuses != nil && recordRestamp(workload, &workload.Status.OwnerStatus, err)
+
+	if changed || resumed || (workload.Status.ObservedGeneration != workload.Generation) || (resourceStatuses != nil && resourceStatuses.IsChanged()) || restamped {
 		if resourceStatuses != nil {
 			workload.Status.Resources = resourceStatuses.GetCurrent()
 		}
@@ -223,7 +267,65 @@ func (r *WorkloadReconciler) completeReconciliation(ctx context.Context, workloa
 	return ctrl.Result{}, nil
 }
 
-func (r *WorkloadReconciler) isSupplyChainReady(supplyChain *v1alpha1.ClusterSupplyChain) bool {
+type ownerParamUsage interface {
+	UnusedOwnerParams() []string
+	OverriddenOwnerParams() []string
+}
+
+// reportParamUsage warns about workload params that no resource of the supply chain used,
+// typically because of a typo in their name, or that the supply chain overrides with a value.
+// The warning does not affect the Ready condition.
+func (r *WorkloadReconciler) reportParamUsage(ctx context.Context, workload *v1alpha1.Workload, usage ownerParamUsage, conditionManager conditions.ConditionManager) {
+	unused, overridden := usage.UnusedOwnerParams(), usage.OverriddenOwnerParams()
+	if len(unused) == 0 && len(overridden) == 0 {
+		conditionManager.Add(conditions.ParamsUsedCondition(), conditions.Informational)
+		return
+	}
+
+	condition := conditions.UnusedParamsCondition(unused, overridden)
+	conditionManager.Add(condition, conditions.Informational)
+
+	previousCondition := meta.FindStatusCondition(workload.Status.Conditions, v1alpha1.WorkloadParamsUsed)
+	if previousCondition != nil && previousCondition.Message == condition.Message {
+		return
+	}
+
+	rec := events.FromContextOrDie(ctx)
+	if len(unused) > 0 {
+		rec.Eventf(events.WarningType, events.UnusedParamsReason, "Params %v are not used by any resource of the supply chain", unused)
+	}
+	if len(overridden) > 0 {
+		rec.Eventf(events.WarningType, events.OverriddenParamsReason, "Params %v are overridden by values set in the supply chain", overridden)
+	}
+}
+
+// supplyChainRevision returns the supply chain as the workload realizes it: with its
+// current spec once the workload adopted its latest revision, or else with the spec
+// of its stable revision. The revision is recorded on the status of the workload.
+func (r *WorkloadReconciler) supplyChainRevision(ctx context.Context, workload *v1alpha1.Workload, supplyChain v1alpha1.SupplyChainObject) (v1alpha1.SupplyChainObject, error) {
+	rollout := supplyChain.GetSupplyChainStatus().Rollout
+	if v1alpha1.AdoptsLatestSupplyChainRevision(supplyChain, workload) {
+		workload.Status.SupplyChainRevision = 0
+		if rollout != nil {
+			workload.Status.SupplyChainRevision = rollout.LatestRevision
+		}
+		return supplyChain, nil
+	}
+
+	name := v1alpha1.SupplyChainRevisionName(v1alpha1.SupplyChainRevisionRef(supplyChain), rollout.StableRevision)
+	revision, err := r.Repo.GetSupplyChainRevision(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get supply chain revision [%s]: %w", name, err)
+	}
+	if revision == nil {
+		return nil, fmt.Errorf("supply chain revision [%s] not found", name)
+	}
+
+	workload.Status.SupplyChainRevision = rollout.StableRevision
+	return revision.Restore(supplyChain)
+}
+
+func (r *WorkloadReconciler) isSupplyChainReady(supplyChain v1alpha1.SupplyChainObject) bool {
 	supplyChainReadyCondition := getSupplyChainReadyCondition(supplyChain)
 	return supplyChainReadyCondition.Status == "True"
 }
@@ -242,8 +344,8 @@ func BuildWorkloadResourceLabeler(owner, blueprint client.Object) realizer.Resou
 	}
 }
 
-func getSupplyChainReadyCondition(supplyChain *v1alpha1.ClusterSupplyChain) metav1.Condition {
-	for _, condition := range supplyChain.Status.Conditions {
+func getSupplyChainReadyCondition(supplyChain v1alpha1.SupplyChainObject) metav1.Condition {
+	for _, condition := range supplyChain.GetSupplyChainStatus().Conditions {
 		if condition.Type == "Ready" {
 			return condition
 		}
@@ -251,7 +353,7 @@ func getSupplyChainReadyCondition(supplyChain *v1alpha1.ClusterSupplyChain) meta
 	return metav1.Condition{}
 }
 
-func (r *WorkloadReconciler) getSupplyChainsForWorkload(ctx context.Context, workload *v1alpha1.Workload, conditionManager conditions.ConditionManager) (*v1alpha1.ClusterSupplyChain, error) {
+func (r *WorkloadReconciler) getSupplyChainsForWorkload(ctx context.Context, workload *v1alpha1.Workload, conditionManager conditions.ConditionManager) (v1alpha1.SupplyChainObject, error) {
 	log := logr.FromContextOrDiscard(ctx)
 	if len(workload.Labels) == 0 {
 		conditionManager.AddPositive(conditions.WorkloadMissingLabelsCondition())
@@ -276,18 +378,18 @@ func (r *WorkloadReconciler) getSupplyChainsForWorkload(ctx context.Context, wor
 	}
 
 	if len(supplyChains) > 1 {
-		conditionManager.AddPositive(conditions.TooManySupplyChainMatchesCondition())
+		conditionManager.AddPositive(conditions.TooManySupplyChainMatchesCondition(GetSupplyChainNames(supplyChains)))
 		log.Info("more than one supply chain selected for workload",
 			"supply chains", GetSupplyChainNames(supplyChains))
 		return nil, fmt.Errorf("more than one supply chain selected for workload [%s/%s]: %+v",
 			workload.Namespace, workload.Name, GetSupplyChainNames(supplyChains))
 	}
 
-	log.V(logger.DEBUG).Info("supply chain matched for workload", "supply chain", supplyChains[0].Name)
+	log.V(logger.DEBUG).Info("supply chain matched for workload", "supply chain", supplyChains[0].GetName())
 	return supplyChains[0], nil
 }
 
-func (r *WorkloadReconciler) trackDependencies(workload *v1alpha1.Workload, realizedResources []v1alpha1.ResourceStatus, serviceAccountName, serviceAccountNS string) {
+func (r *WorkloadReconciler) trackDependencies(workload *v1alpha1.Workload, realizedResources []v1alpha1.ResourceStatus, fragmentNames []string, serviceAccountName, serviceAccountNS string) {
 	r.DependencyTracker.ClearTracked(types.NamespacedName{
 		Namespace: workload.Namespace,
 		Name:      workload.Name,
@@ -307,18 +409,15 @@ func (r *WorkloadReconciler) trackDependencies(workload *v1alpha1.Workload, real
 		Name:      workload.Name,
 	})
 
-	for _, resource := range realizedResources {
-		if resource.TemplateRef == nil {
-			continue
-		}
+	for _, fragmentName := range fragmentNames {
 		r.DependencyTracker.Track(
 			dependency.Key{
 				GroupKind: schema.GroupKind{
 					Group: v1alpha1.SchemeGroupVersion.Group,
-					Kind:  resource.TemplateRef.Kind,
+					Kind:  v1alpha1.SupplyChainFragmentKind,
 				},
 				NamespacedName: types.NamespacedName{
-					Name: resource.TemplateRef.Name,
+					Name: fragmentName,
 				},
 			},
 			types.NamespacedName{
@@ -327,9 +426,26 @@ func (r *WorkloadReconciler) trackDependencies(workload *v1alpha1.Workload, real
 			},
 		)
 	}
+
+	trackParamSources(r.DependencyTracker, workload.Spec.Params, workload.Namespace, types.NamespacedName{
+		Namespace: workload.Namespace,
+		Name:      workload.Name,
+	})
+
+	for _, resource := range realizedResources {
+		if resource.TemplateRef == nil {
+			continue
+		}
+		trackTemplate(r.DependencyTracker, resource.TemplateRef.Kind, resource.TemplateRef.Name, workload.Namespace,
+			types.NamespacedName{
+				Namespace: workload.Namespace,
+				Name:      workload.Name,
+			},
+		)
+	}
 }
 
-func (r *WorkloadReconciler) cleanupOrphanedObjects(ctx context.Context, previousResources, realizedResources []v1alpha1.ResourceStatus) error {
+func (r *WorkloadReconciler) cleanupOrphanedObjects(ctx context.Context, namespace string, previousResources, realizedResources []v1alpha1.ResourceStatus) error {
 	log := logr.FromContextOrDiscard(ctx)
 
 	var orphanedObjs []*corev1.ObjectReference
@@ -342,7 +458,7 @@ func (r *WorkloadReconciler) cleanupOrphanedObjects(ctx context.Context, previou
 		}
 		orphaned := true
 
-		equivalenceTest, err = getEquivalenceTest(ctx, r.Repo, prevResource)
+		equivalenceTest, err = getEquivalenceTest(ctx, r.Repo, prevResource, namespace)
 		if err != nil {
 			if kerrors.IsNotFound(err) {
 				orphanedObjs = append(orphanedObjs, prevResource.StampedRef.ObjectReference)
@@ -388,7 +504,7 @@ func (r *WorkloadReconciler) cleanupOrphanedObjects(ctx context.Context, previou
 	return nil
 }
 
-func GetSupplyChainNames(objs []*v1alpha1.ClusterSupplyChain) []string {
+func GetSupplyChainNames(objs []v1alpha1.SupplyChainObject) []string {
 	var names []string
 	for _, obj := range objs {
 		names = append(names, obj.GetName())
@@ -397,16 +513,17 @@ func GetSupplyChainNames(objs []*v1alpha1.ClusterSupplyChain) []string {
 	return names
 }
 
-func getServiceAccountNameAndNamespaceForWorkload(workload *v1alpha1.Workload, supplyChain *v1alpha1.ClusterSupplyChain) (string, string) {
+func getServiceAccountNameAndNamespaceForWorkload(workload *v1alpha1.Workload, supplyChain v1alpha1.SupplyChainObject) (string, string) {
 	serviceAccountName := "default"
 	serviceAccountNS := workload.Namespace
 
+	serviceAccountRef := supplyChain.GetSupplyChainSpec().ServiceAccountRef
 	if workload.Spec.ServiceAccountName != "" {
 		serviceAccountName = workload.Spec.ServiceAccountName
-	} else if supplyChain.Spec.ServiceAccountRef.Name != "" {
-		serviceAccountName = supplyChain.Spec.ServiceAccountRef.Name
-		if supplyChain.Spec.ServiceAccountRef.Namespace != "" {
-			serviceAccountNS = supplyChain.Spec.ServiceAccountRef.Namespace
+	} else if serviceAccountRef.Name != "" {
+		serviceAccountName = serviceAccountRef.Name
+		if serviceAccountRef.Namespace != "" {
+			serviceAccountNS = serviceAccountRef.Namespace
 		}
 	}
 
@@ -452,6 +569,7 @@ func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager, concurrency int)
 
 	watches := map[client.Object]handler.MapFunc{
 		&v1alpha1.ClusterSupplyChain{}: m.ClusterSupplyChainToWorkloadRequests,
+		&v1alpha1.SupplyChain{}:        m.SupplyChainToWorkloadRequests,
 		&corev1.ServiceAccount{}:       m.ServiceAccountToWorkloadRequests,
 		&rbacv1.Role{}:                 m.RoleToWorkloadRequests,
 		&rbacv1.RoleBinding{}:          m.RoleBindingToWorkloadRequests,
@@ -473,6 +591,16 @@ func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager, concurrency int)
 		)
 	}
 
+	// only the metadata of Secrets and ConfigMaps is cached: their values are read with
+	// the owner's service account when resources are stamped
+	for _, paramSource := range []client.Object{&corev1.Secret{}, &corev1.ConfigMap{}} {
+		builder = builder.Watches(
+			&source.Kind{Type: paramSource},
+			enqueuer.EnqueueTracked(paramSource, r.DependencyTracker, mgr.GetScheme()),
+			ctrlbuilder.OnlyMetadata,
+		)
+	}
+
 	controller, err := builder.Build(r)
 	if err != nil {
 		return fmt.Errorf("failed to build controller for workload: %w", err)
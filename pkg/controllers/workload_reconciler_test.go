@@ -26,6 +26,7 @@ import (
 	. "github.com/onsi/gomega/gbytes"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,6 +44,8 @@ import (
 	"github.com/vmware-tanzu/cartographer/pkg/controllers"
 	"github.com/vmware-tanzu/cartographer/pkg/controllers/controllersfakes"
 	cerrors "github.com/vmware-tanzu/cartographer/pkg/errors"
+	"github.com/vmware-tanzu/cartographer/pkg/events"
+	"github.com/vmware-tanzu/cartographer/pkg/events/eventsfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/realizerfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/statuses"
@@ -249,7 +252,7 @@ var _ = Describe("WorkloadReconciler", func() {
 					},
 				},
 			}
-			repo.GetSupplyChainsForWorkloadReturns([]*v1alpha1.ClusterSupplyChain{&supplyChain}, nil)
+			repo.GetSupplyChainsForWorkloadReturns([]v1alpha1.SupplyChainObject{&supplyChain}, nil)
 
 			resourceStatuses = statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
 			resourceStatuses.Add(
@@ -470,6 +473,258 @@ var _ = Describe("WorkloadReconciler", func() {
 			Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(conditions.ResourcesSubmittedCondition(true)))
 		})
 
+		It("calls the condition manager to report the params are used", func() {
+			_, _ = reconciler.Reconcile(ctx, req)
+			condition, polarity := conditionManager.AddArgsForCall(0)
+			Expect(condition).To(Equal(conditions.ParamsUsedCondition()))
+			Expect(polarity).To(Equal(conditions.Informational))
+		})
+
+		Context("the workload sets params that no resource uses", func() {
+			var fakeEventRecorder *eventsfakes.FakeEventRecorder
+
+			BeforeEach(func() {
+				fakeEventRecorder = &eventsfakes.FakeEventRecorder{}
+				reconciler.EventRecorder = fakeEventRecorder
+
+				wl.Spec.Params = []v1alpha1.OwnerParam{
+					{Name: "gitops_branch", Value: apiextensionsv1.JSON{Raw: []byte(`"main"`)}},
+				}
+			})
+
+			It("calls the condition manager to report the unused params", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				condition, polarity := conditionManager.AddArgsForCall(0)
+				Expect(condition).To(Equal(conditions.UnusedParamsCondition([]string{"gitops_branch"}, nil)))
+				Expect(polarity).To(Equal(conditions.Informational))
+			})
+
+			It("still reports the resources have been submitted", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(conditions.ResourcesSubmittedCondition(true)))
+			})
+
+			It("emits a warning event", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				Expect(fakeEventRecorder.EventfCallCount()).To(Equal(1))
+				obj, eventType, reason, messageFmt, args := fakeEventRecorder.EventfArgsForCall(0)
+				Expect(obj).To(Equal(wl))
+				Expect(eventType).To(Equal("Warning"))
+				Expect(reason).To(Equal(events.UnusedParamsReason))
+				Expect(fmt.Sprintf(messageFmt, args...)).To(Equal("Params [gitops_branch] are not used by any resource of the supply chain"))
+			})
+
+			Context("and the workload already reports them", func() {
+				BeforeEach(func() {
+					wl.Status.Conditions = []metav1.Condition{
+						conditions.UnusedParamsCondition([]string{"gitops_branch"}, nil),
+					}
+				})
+
+				It("does not emit the event again", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(fakeEventRecorder.EventfCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("the workload is paused", func() {
+			var fakeEventRecorder *eventsfakes.FakeEventRecorder
+
+			BeforeEach(func() {
+				fakeEventRecorder = &eventsfakes.FakeEventRecorder{}
+				reconciler.EventRecorder = fakeEventRecorder
+
+				wl.Annotations = map[string]string{v1alpha1.PausedAnnotation: "true"}
+				wl.Status.Conditions = []metav1.Condition{
+					{Type: v1alpha1.OwnerReady, Status: metav1.ConditionTrue, Reason: "Ready"},
+				}
+			})
+
+			It("does not realize the resources", func() {
+				_, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(repo.GetSupplyChainsForWorkloadCallCount()).To(Equal(0))
+				Expect(rlzr.RealizeCallCount()).To(Equal(0))
+				Expect(conditionManager.FinalizeCallCount()).To(Equal(0))
+			})
+
+			It("adds the Paused condition, keeping the others", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+				_, updatedWorkload := repo.StatusUpdateArgsForCall(0)
+				Expect(updatedWorkload.(*v1alpha1.Workload).Status.Conditions).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"Type": Equal(v1alpha1.OwnerReady), "Status": Equal(metav1.ConditionTrue)}),
+					MatchFields(IgnoreExtras, Fields{"Type": Equal(v1alpha1.OwnerPaused), "Status": Equal(metav1.ConditionTrue), "Reason": Equal(v1alpha1.PausedAnnotationReason)}),
+				))
+			})
+
+			It("emits a paused event", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(fakeEventRecorder.EventfCallCount()).To(Equal(1))
+				_, eventType, reason, _, _ := fakeEventRecorder.EventfArgsForCall(0)
+				Expect(eventType).To(Equal("Normal"))
+				Expect(reason).To(Equal(events.PausedReason))
+			})
+
+			Context("and the workload already reports it", func() {
+				BeforeEach(func() {
+					wl.Status.Conditions = append(wl.Status.Conditions, conditions.PausedCondition())
+				})
+
+				It("neither updates the status nor emits the event again", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+
+					Expect(repo.StatusUpdateCallCount()).To(Equal(0))
+					Expect(fakeEventRecorder.EventfCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("the workload was paused and no longer is", func() {
+			var fakeEventRecorder *eventsfakes.FakeEventRecorder
+
+			BeforeEach(func() {
+				fakeEventRecorder = &eventsfakes.FakeEventRecorder{}
+				reconciler.EventRecorder = fakeEventRecorder
+
+				wl.Status.Conditions = []metav1.Condition{conditions.PausedCondition()}
+			})
+
+			It("realizes the resources", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(rlzr.RealizeCallCount()).To(Equal(1))
+			})
+
+			It("emits a resumed event", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(fakeEventRecorder.EventfCallCount()).To(Equal(1))
+				_, eventType, reason, _, _ := fakeEventRecorder.EventfArgsForCall(0)
+				Expect(eventType).To(Equal("Normal"))
+				Expect(reason).To(Equal(events.ResumedReason))
+			})
+
+			Context("and its other conditions are unchanged", func() {
+				BeforeEach(func() {
+					readyCondition := metav1.Condition{Type: v1alpha1.OwnerReady, Status: metav1.ConditionTrue, Reason: "Ready"}
+					wl.Status.ObservedGeneration = wl.Generation
+					wl.Status.Conditions = []metav1.Condition{readyCondition, conditions.PausedCondition()}
+					conditionManager.FinalizeReturns([]metav1.Condition{readyCondition}, false)
+					rlzr.RealizeReturns(nil)
+				})
+
+				It("drops the Paused condition", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+
+					Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+					_, updatedWorkload := repo.StatusUpdateArgsForCall(0)
+					Expect(updatedWorkload.(*v1alpha1.Workload).Status.Conditions).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"Type": Equal(v1alpha1.OwnerReady), "Status": Equal(metav1.ConditionTrue)}),
+					))
+				})
+			})
+		})
+
+		Context("the workload requests a restamp", func() {
+			var fakeEventRecorder *eventsfakes.FakeEventRecorder
+
+			BeforeEach(func() {
+				fakeEventRecorder = &eventsfakes.FakeEventRecorder{}
+				reconciler.EventRecorder = fakeEventRecorder
+
+				wl.Annotations = map[string]string{v1alpha1.RestampAnnotation: "2026-10-19T12:30:00Z"}
+			})
+
+			It("realizes the resources bypassing the cache", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(rlzr.RealizeCallCount()).To(Equal(1))
+				realizeCtx, _, _, _, _ := rlzr.RealizeArgsForCall(0)
+				Expect(repository.IsCacheBypassed(realizeCtx)).To(BeTrue())
+			})
+
+			It("records the restamp as acted upon", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+				_, updatedWorkload := repo.StatusUpdateArgsForCall(0)
+				Expect(updatedWorkload.(*v1alpha1.Workload).Status.LastRestamp).To(Equal("2026-10-19T12:30:00Z"))
+			})
+
+			It("emits a restamped event", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(fakeEventRecorder.EventfCallCount()).To(Equal(1))
+				_, eventType, reason, messageFmt, args := fakeEventRecorder.EventfArgsForCall(0)
+				Expect(eventType).To(Equal("Normal"))
+				Expect(reason).To(Equal(events.RestampedReason))
+				Expect(fmt.Sprintf(messageFmt, args...)).To(Equal("Restamping resources for [2026-10-19T12:30:00Z]"))
+			})
+
+			Context("and realizing the resources fails", func() {
+				BeforeEach(func() {
+					rlzr.RealizeReturns(errors.New("some error"))
+				})
+
+				It("records the restamp as pending rather than acted upon", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+
+					Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+					_, updatedWorkload := repo.StatusUpdateArgsForCall(0)
+					Expect(updatedWorkload.(*v1alpha1.Workload).Status.LastRestamp).To(BeEmpty())
+					Expect(updatedWorkload.(*v1alpha1.Workload).Status.PendingRestamp).To(Equal("2026-10-19T12:30:00Z"))
+				})
+			})
+
+			Context("that is pending as realizing the resources failed before", func() {
+				BeforeEach(func() {
+					wl.Status.PendingRestamp = "2026-10-19T12:30:00Z"
+				})
+
+				It("realizes the resources bypassing the cache of mutable objects only", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+
+					realizeCtx, _, _, _, _ := rlzr.RealizeArgsForCall(0)
+					Expect(repository.IsCacheBypassed(realizeCtx)).To(BeFalse())
+					Expect(repository.IsMutableCacheBypassed(realizeCtx)).To(BeTrue())
+				})
+
+				It("does not emit a restamped event again", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+
+					Expect(fakeEventRecorder.EventfCallCount()).To(Equal(0))
+				})
+
+				It("records the restamp as acted upon once realizing the resources succeeds", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+
+					Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+					_, updatedWorkload := repo.StatusUpdateArgsForCall(0)
+					Expect(updatedWorkload.(*v1alpha1.Workload).Status.LastRestamp).To(Equal("2026-10-19T12:30:00Z"))
+					Expect(updatedWorkload.(*v1alpha1.Workload).Status.PendingRestamp).To(BeEmpty())
+				})
+			})
+
+			Context("that was already acted upon", func() {
+				BeforeEach(func() {
+					wl.Status.LastRestamp = "2026-10-19T12:30:00Z"
+				})
+
+				It("realizes the resources using the cache", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+
+					realizeCtx, _, _, _, _ := rlzr.RealizeArgsForCall(0)
+					Expect(repository.IsCacheBypassed(realizeCtx)).To(BeFalse())
+					Expect(fakeEventRecorder.EventfCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		It("watches the stampedObjects kinds", func() {
 			_, _ = reconciler.Reconcile(ctx, req)
 			Expect(stampedTracker.WatchCallCount()).To(Equal(2))
@@ -525,6 +780,37 @@ var _ = Describe("WorkloadReconciler", func() {
 			Expect(secondTemplateKey.String()).To(Equal("my-config-kind.carto.run//my-config-template"))
 		})
 
+		Context("the workload has params sourced from a Secret and a ConfigMap", func() {
+			BeforeEach(func() {
+				reconciler.EventRecorder = &eventsfakes.FakeEventRecorder{}
+				wl.Spec.Params = []v1alpha1.OwnerParam{
+					{Name: "registry_password", ValueFrom: &v1alpha1.ParamValueSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "registry-credentials"},
+							Key:                  "password",
+						},
+					}},
+					{Name: "registry", ValueFrom: &v1alpha1.ParamValueSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+							Key:                  "registry",
+						},
+					}},
+				}
+			})
+
+			It("watches the Secret and ConfigMap", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(dependencyTracker.TrackCallCount()).To(Equal(5))
+				secretKey, _ := dependencyTracker.TrackArgsForCall(1)
+				Expect(secretKey.String()).To(Equal("Secret/my-namespace/registry-credentials"))
+
+				configMapKey, _ := dependencyTracker.TrackArgsForCall(2)
+				Expect(configMapKey.String()).To(Equal("ConfigMap/my-namespace/settings"))
+			})
+		})
+
 		Context("but getting the object GVK fails", func() {
 			BeforeEach(func() {
 				repo.GetSchemeReturns(runtime.NewScheme())
@@ -546,7 +832,7 @@ var _ = Describe("WorkloadReconciler", func() {
 						Message: "some informative message",
 					},
 				}
-				repo.GetSupplyChainsForWorkloadReturns([]*v1alpha1.ClusterSupplyChain{&supplyChain}, nil)
+				repo.GetSupplyChainsForWorkloadReturns([]v1alpha1.SupplyChainObject{&supplyChain}, nil)
 			})
 
 			It("does not return an error", func() {
@@ -576,6 +862,169 @@ var _ = Describe("WorkloadReconciler", func() {
 			})
 		})
 
+		Context("the supply chain rolls out a change the workload has not adopted", func() {
+			BeforeEach(func() {
+				stable := supplyChain.DeepCopy()
+				stable.Spec.Resources = []v1alpha1.SupplyChainResource{{
+					Name:        "stable-resource",
+					TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterTemplate", Name: "stable-template"},
+				}}
+				revision, err := v1alpha1.NewSupplyChainRevision(1, stable)
+				Expect(err).NotTo(HaveOccurred())
+				repo.GetSupplyChainRevisionReturns(revision, nil)
+
+				supplyChain.Spec.Resources = []v1alpha1.SupplyChainResource{{
+					Name:        "latest-resource",
+					TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterTemplate", Name: "latest-template"},
+				}}
+				supplyChain.Spec.Rollout = &v1alpha1.Rollout{Percentage: 0}
+				supplyChain.Status.Rollout = &v1alpha1.RolloutStatus{LatestRevision: 2, StableRevision: 1}
+				repo.GetSupplyChainsForWorkloadReturns([]v1alpha1.SupplyChainObject{&supplyChain}, nil)
+			})
+
+			It("realizes the stable revision of the supply chain", func() {
+				_, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(repo.GetSupplyChainRevisionCallCount()).To(Equal(1))
+				_, name := repo.GetSupplyChainRevisionArgsForCall(0)
+				Expect(name).To(Equal(v1alpha1.SupplyChainRevisionName(v1alpha1.SupplyChainRevisionRef(&supplyChain), 1)))
+
+				_, _, _, resources, _ := rlzr.RealizeArgsForCall(0)
+				Expect(resources).To(HaveLen(1))
+				Expect(resources[0].Name).To(Equal("stable-resource"))
+			})
+
+			It("records the stable revision on the status", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				_, updatedWorkload := repo.StatusUpdateArgsForCall(0)
+				Expect(updatedWorkload.(*v1alpha1.Workload).Status.SupplyChainRevision).To(BeEquivalentTo(1))
+			})
+
+			Context("but the workload is a canary", func() {
+				BeforeEach(func() {
+					supplyChain.Spec.Rollout.Canary = &metav1.LabelSelector{MatchLabels: workloadLabels}
+				})
+
+				It("realizes the latest revision of the supply chain", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(repo.GetSupplyChainRevisionCallCount()).To(Equal(0))
+					_, _, _, resources, _ := rlzr.RealizeArgsForCall(0)
+					Expect(resources[0].Name).To(Equal("latest-resource"))
+
+					_, updatedWorkload := repo.StatusUpdateArgsForCall(0)
+					Expect(updatedWorkload.(*v1alpha1.Workload).Status.SupplyChainRevision).To(BeEquivalentTo(2))
+				})
+			})
+
+			Context("but the stable revision does not exist", func() {
+				BeforeEach(func() {
+					repo.GetSupplyChainRevisionReturns(nil, nil)
+				})
+
+				It("returns an unhandled error", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).To(MatchError(ContainSubstring("not found")))
+					Expect(rlzr.RealizeCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("the supply chain includes a fragment", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Resources = []v1alpha1.SupplyChainResource{
+					{
+						Name: "build",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{
+							Kind: "ClusterSupplyChainFragment",
+							Name: "my-fragment",
+						},
+					},
+				}
+				repo.GetSupplyChainsForWorkloadReturns([]v1alpha1.SupplyChainObject{&supplyChain}, nil)
+				repo.GetSupplyChainFragmentReturns(&v1alpha1.ClusterSupplyChainFragment{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-fragment"},
+					Spec: v1alpha1.SupplyChainFragmentSpec{
+						Resources: []v1alpha1.SupplyChainResource{
+							{
+								Name: "image",
+								TemplateRef: v1alpha1.SupplyChainTemplateReference{
+									Kind: "ClusterImageTemplate",
+									Name: "my-image-template",
+								},
+							},
+						},
+					},
+				}, nil)
+			})
+
+			It("realizes the resources of the fragment", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(repo.GetSupplyChainFragmentCallCount()).To(Equal(1))
+				_, fragmentName := repo.GetSupplyChainFragmentArgsForCall(0)
+				Expect(fragmentName).To(Equal("my-fragment"))
+
+				_, _, _, resources, _ := rlzr.RealizeArgsForCall(0)
+				Expect(resources).To(HaveLen(1))
+				Expect(resources[0].Name).To(Equal("build-image"))
+				Expect(resources[0].TemplateRef.Name).To(Equal("my-image-template"))
+			})
+
+			It("watches the fragment", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(dependencyTracker.TrackCallCount()).To(Equal(4))
+				fragmentKey, _ := dependencyTracker.TrackArgsForCall(1)
+				Expect(fragmentKey.String()).To(Equal("ClusterSupplyChainFragment.carto.run//my-fragment"))
+			})
+
+			Context("but the fragment does not exist", func() {
+				BeforeEach(func() {
+					repo.GetSupplyChainFragmentReturns(nil, nil)
+				})
+
+				It("calls the condition manager to report", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(conditions.FragmentExpansionErrorCondition(
+						errors.New("failed to expand fragments of supply chain [some-supply-chain]: fragment [my-fragment] for resource [build] not found"),
+					)))
+				})
+
+				It("does not return an error", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("does not realize", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(rlzr.RealizeCallCount()).To(Equal(0))
+				})
+
+				It("still watches the fragment", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+
+					Expect(dependencyTracker.TrackCallCount()).To(Equal(2))
+					fragmentKey, _ := dependencyTracker.TrackArgsForCall(1)
+					Expect(fragmentKey.String()).To(Equal("ClusterSupplyChainFragment.carto.run//my-fragment"))
+				})
+			})
+
+			Context("but getting the fragment fails", func() {
+				BeforeEach(func() {
+					repo.GetSupplyChainFragmentReturns(nil, errors.New("some error"))
+				})
+
+				It("returns an unhandled error and requeues", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).To(MatchError(ContainSubstring("failed to get fragment [my-fragment] for resource [build]: some error")))
+				})
+			})
+		})
+
 		Context("but the realizer returns an error", func() {
 			Context("of type GetTemplateError", func() {
 				var templateError error
@@ -700,6 +1149,34 @@ var _ = Describe("WorkloadReconciler", func() {
 				})
 			})
 
+			Context("of type ParamValidationError", func() {
+				var paramValidationError cerrors.ParamValidationError
+				BeforeEach(func() {
+					paramValidationError = cerrors.ParamValidationError{
+						Err:           errors.New("param [registry] is required"),
+						ResourceName:  "some-name",
+						BlueprintName: supplyChainName,
+						BlueprintType: cerrors.SupplyChain,
+						TemplateName:  "some-template",
+						TemplateKind:  "ClusterImageTemplate",
+					}
+
+					rlzr.RealizeStub = func(ctx context.Context, resourceRealizer realizer.ResourceRealizer, blueprintName string, resources []realizer.OwnerResource, statuses statuses.ResourceStatuses) error {
+						return paramValidationError
+					}
+				})
+
+				It("calls the condition manager to report the invalid param", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(conditions.InvalidParamsCondition(true, paramValidationError)))
+				})
+
+				It("does not return an error", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
 			Context("of type ApplyStampedObjectError", func() {
 				var stampedObjectError cerrors.ApplyStampedObjectError
 				BeforeEach(func() {
@@ -1275,12 +1752,12 @@ var _ = Describe("WorkloadReconciler", func() {
 			supplyChain := v1alpha1.ClusterSupplyChain{
 				ObjectMeta: metav1.ObjectMeta{Name: "my-supply-chain"},
 			}
-			repo.GetSupplyChainsForWorkloadReturns([]*v1alpha1.ClusterSupplyChain{&supplyChain, &supplyChain}, nil)
+			repo.GetSupplyChainsForWorkloadReturns([]v1alpha1.SupplyChainObject{&supplyChain, &supplyChain}, nil)
 		})
 
 		It("calls the condition manager to report too mane supply chains matched", func() {
 			_, _ = reconciler.Reconcile(ctx, req)
-			Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(conditions.TooManySupplyChainMatchesCondition()))
+			Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(conditions.TooManySupplyChainMatchesCondition([]string{"my-supply-chain", "my-supply-chain"})))
 		})
 
 		It("does not return an error", func() {
@@ -1357,7 +1834,7 @@ var _ = Describe("WorkloadReconciler", func() {
 					},
 				},
 			}
-			repo.GetSupplyChainsForWorkloadReturns([]*v1alpha1.ClusterSupplyChain{&supplyChain}, nil)
+			repo.GetSupplyChainsForWorkloadReturns([]v1alpha1.SupplyChainObject{&supplyChain}, nil)
 
 			rlzr.RealizeReturns(nil)
 
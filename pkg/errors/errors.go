@@ -124,6 +124,42 @@ func (e StampError) Error() string {
 	).Error()
 }
 
+type ParamValidationError struct {
+	Err           error
+	ResourceName  string
+	TemplateName  string
+	TemplateKind  string
+	BlueprintName string
+	BlueprintType string
+}
+
+func (e ParamValidationError) Error() string {
+	return fmt.Errorf("invalid params for resource [%s] for template [%s/%s] in %s [%s]: %w",
+		e.ResourceName,
+		e.TemplateKind,
+		e.TemplateName,
+		e.BlueprintType,
+		e.BlueprintName,
+		e.Err,
+	).Error()
+}
+
+type ResolveParamsError struct {
+	Err           error
+	ResourceName  string
+	BlueprintName string
+	BlueprintType string
+}
+
+func (e ResolveParamsError) Error() string {
+	return fmt.Errorf("unable to resolve params for resource [%s] in %s [%s]: %w",
+		e.ResourceName,
+		e.BlueprintType,
+		e.BlueprintName,
+		e.Err,
+	).Error()
+}
+
 type RetrieveOutputError struct {
 	Err               error
 	ResourceName      string
@@ -212,7 +248,7 @@ func IsUnhandledErrorType(err error) bool {
 		} else {
 			return false
 		}
-	case StampError, RetrieveOutputError, ResolveTemplateOptionError, TemplateOptionsMatchError:
+	case StampError, ParamValidationError, ResolveParamsError, RetrieveOutputError, ResolveTemplateOptionError, TemplateOptionsMatchError:
 		return false
 	default:
 		return true
@@ -15,8 +15,14 @@
 package events
 
 const NormalType = "Normal"
+const WarningType = "Warning"
 
 const StampedObjectAppliedReason = "StampedObjectApplied"
 const StampedObjectRemovedReason = "StampedObjectRemoved"
 const ResourceOutputChangedReason = "ResourceOutputChanged"
 const ResourceHealthyStatusChangedReason = "ResourceHealthyStatusChanged"
+const UnusedParamsReason = "UnusedParams"
+const OverriddenParamsReason = "OverriddenParams"
+const PausedReason = "Paused"
+const ResumedReason = "Resumed"
+const RestampedReason = "Restamped"
@@ -16,6 +16,7 @@ package events
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -25,6 +26,7 @@ import (
 	"k8s.io/client-go/tools/record"
 
 	"github.com/vmware-tanzu/cartographer/pkg/logger"
+	"github.com/vmware-tanzu/cartographer/pkg/redact"
 	"github.com/vmware-tanzu/cartographer/pkg/utils"
 )
 
@@ -57,6 +59,15 @@ func FromEventRecorder(rec record.EventRecorder, ownerObj runtime.Object, mapper
 	}
 }
 
+// Redacting returns an OwnerEventRecorder that removes the values known to the redactor
+// from the messages of the events it records.
+func Redacting(rec OwnerEventRecorder, redactor *redact.Redactor) OwnerEventRecorder {
+	return redactingEventRecorder{
+		rec:      rec,
+		redactor: redactor,
+	}
+}
+
 // contextKey is how we find OwnerEventRecorder in a context.Context.
 type contextKey struct{}
 
@@ -93,6 +104,42 @@ func (o ownerEventRecorder) AnnotatedEventf(annotations map[string]string, event
 	o.rec.AnnotatedEventf(o.obj, annotations, eventtype, reason, messageFmt, args...)
 }
 
+type redactingEventRecorder struct {
+	rec      OwnerEventRecorder
+	redactor *redact.Redactor
+}
+
+func (r redactingEventRecorder) Event(eventtype, reason, message string) {
+	r.rec.Event(eventtype, reason, r.redactor.String(message))
+}
+
+func (r redactingEventRecorder) Eventf(eventtype, reason, messageFmt string, args ...interface{}) {
+	r.rec.Eventf(eventtype, reason, r.redactor.String(messageFmt), r.redactArgs(args)...)
+}
+
+func (r redactingEventRecorder) AnnotatedEventf(annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.rec.AnnotatedEventf(annotations, eventtype, reason, r.redactor.String(messageFmt), r.redactArgs(args)...)
+}
+
+func (r redactingEventRecorder) ResourceEventf(eventtype, reason, messageFmt string, resource *unstructured.Unstructured, args ...interface{}) {
+	r.rec.ResourceEventf(eventtype, reason, r.redactor.String(messageFmt), resource, r.redactArgs(args)...)
+}
+
+// redactArgs replaces the args whose formatted value holds a sensitive value with
+// their redacted formatting.
+func (r redactingEventRecorder) redactArgs(args []interface{}) []interface{} {
+	redacted := make([]interface{}, len(args))
+	for i, arg := range args {
+		formatted := fmt.Sprint(arg)
+		if redactedArg := r.redactor.String(formatted); redactedArg != formatted {
+			redacted[i] = redactedArg
+		} else {
+			redacted[i] = arg
+		}
+	}
+	return redacted
+}
+
 // FromContextOrDie returns a OwnerEventRecorder from ctx.  If no OwnerEventRecorder is found, this
 // panics
 func FromContextOrDie(ctx context.Context) OwnerEventRecorder {
@@ -27,6 +27,7 @@ import (
 
 	"github.com/vmware-tanzu/cartographer/pkg/events"
 	"github.com/vmware-tanzu/cartographer/pkg/events/eventsfakes"
+	"github.com/vmware-tanzu/cartographer/pkg/redact"
 )
 
 var _ = Describe("OwnerRecorder", func() {
@@ -114,4 +115,36 @@ var _ = Describe("OwnerRecorder", func() {
 			Expect(eventMessageArgs).To(BeEmpty())
 		})
 	})
+
+	Describe("Redacting", func() {
+		var redactor *redact.Redactor
+
+		BeforeEach(func() {
+			redactor = redact.NewRedactor()
+			rec = events.Redacting(rec, redactor)
+		})
+
+		It("redacts sensitive values from the message and its args", func() {
+			redactor.Add("s3cr3t")
+
+			rec.Eventf("Warning", "Leaked", "token s3cr3t in %s, %d", "arg s3cr3t", 1)
+
+			Expect(fakeRecorder.EventfCallCount()).To(Equal(1))
+			_, _, _, messageFormat, eventMessageArgs := fakeRecorder.EventfArgsForCall(0)
+			Expect(messageFormat).To(Equal("token [REDACTED] in %s, %d"))
+			Expect(eventMessageArgs).To(Equal([]interface{}{"arg [REDACTED]", 1}))
+		})
+
+		It("redacts values added after it was created", func() {
+			rec.Event("Warning", "Leaked", "token s3cr3t")
+			redactor.Add("s3cr3t")
+			rec.Event("Warning", "Leaked", "token s3cr3t")
+
+			Expect(fakeRecorder.EventCallCount()).To(Equal(2))
+			_, _, _, firstMessage := fakeRecorder.EventArgsForCall(0)
+			_, _, _, secondMessage := fakeRecorder.EventArgsForCall(1)
+			Expect(firstMessage).To(Equal("token s3cr3t"))
+			Expect(secondMessage).To(Equal("token [REDACTED]"))
+		})
+	})
 })
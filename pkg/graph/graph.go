@@ -0,0 +1,271 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package graph builds graphs of the resources of supply chains and deliveries, and of
+// the resources realized for workloads and deliverables, to render them as DOT or Mermaid.
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+// previewLength is the length output previews are cut to in a node
+const previewLength = 60
+
+// Graph is a directed graph of resources, each edge going from the resource providing
+// an input to the resource reading it
+type Graph struct {
+	Name  string
+	Nodes []*Node
+	Edges []*Edge
+
+	ids map[string]string
+}
+
+// Node is a resource, or a template option of a resource
+type Node struct {
+	ID    string
+	Label string
+	// Details are shown in the node under the label
+	Details []string
+	// Health is the status of the Healthy condition of a realized resource, empty for a blueprint
+	Health metav1.ConditionStatus
+	// Option is true for the template option of a resource
+	Option bool
+}
+
+// Edge is an input read by a resource, or the template option a resource may stamp
+type Edge struct {
+	From  string
+	To    string
+	Label string
+	// Option is true for the edge from a resource to one of its template options
+	Option bool
+}
+
+// blueprintResource is a supply chain or delivery resource as seen by the graph
+type blueprintResource struct {
+	name     string
+	kind     string
+	template string
+	revision int64
+	options  []v1alpha1.TemplateOption
+	inputs   []input
+}
+
+type input struct {
+	kind     string
+	name     string
+	resource string
+}
+
+// ForSupplyChain returns the graph of the resources of a supply chain, with their template options
+func ForSupplyChain(supplyChain v1alpha1.SupplyChainObject) *Graph {
+	var resources []blueprintResource
+	for _, resource := range supplyChain.GetSupplyChainSpec().Resources {
+		resources = append(resources, blueprintResource{
+			name:     resource.Name,
+			kind:     resource.TemplateRef.Kind,
+			template: resource.TemplateRef.Name,
+			revision: resource.TemplateRef.Revision,
+			options:  resource.TemplateRef.Options,
+			inputs: append(append(
+				inputs("source", resource.Sources),
+				inputs("image", resource.Images)...),
+				inputs("config", resource.Configs)...),
+		})
+	}
+
+	return forBlueprint(supplyChain.GetName(), resources)
+}
+
+// ForDelivery returns the graph of the resources of a delivery, with their template options
+func ForDelivery(delivery v1alpha1.DeliveryObject) *Graph {
+	var resources []blueprintResource
+	for _, resource := range delivery.GetDeliverySpec().Resources {
+		resourceInputs := inputs("source", resource.Sources)
+		if resource.Deployment != nil {
+			resourceInputs = append(resourceInputs, input{kind: "deployment", resource: resource.Deployment.Resource})
+		}
+		resources = append(resources, blueprintResource{
+			name:     resource.Name,
+			kind:     resource.TemplateRef.Kind,
+			template: resource.TemplateRef.Name,
+			revision: resource.TemplateRef.Revision,
+			options:  resource.TemplateRef.Options,
+			inputs:   resourceInputs,
+		})
+	}
+
+	return forBlueprint(delivery.GetName(), resources)
+}
+
+func inputs(kind string, references []v1alpha1.ResourceReference) []input {
+	var resourceInputs []input
+	for _, reference := range references {
+		resourceInputs = append(resourceInputs, input{kind: kind, name: reference.Name, resource: reference.Resource})
+	}
+	return resourceInputs
+}
+
+func forBlueprint(name string, resources []blueprintResource) *Graph {
+	g := &Graph{Name: name}
+
+	for _, resource := range resources {
+		node := &Node{ID: g.nodeID(resource.name), Label: resource.name}
+		if resource.template != "" {
+			node.Details = append(node.Details, templateDetail(resource.kind, resource.template, resource.revision))
+		} else {
+			node.Details = append(node.Details, resource.kind)
+		}
+		g.Nodes = append(g.Nodes, node)
+
+		for _, option := range resource.options {
+			optionNode := &Node{ID: g.nodeID(resource.name, option.Name, option.PassThrough), Option: true}
+			if option.PassThrough != "" {
+				optionNode.Label = fmt.Sprintf("pass through %s", option.PassThrough)
+			} else {
+				optionNode.Label = option.Name
+			}
+			optionNode.Details = selectorDetails(option.Selector)
+			g.Nodes = append(g.Nodes, optionNode)
+			g.Edges = append(g.Edges, &Edge{From: node.ID, To: optionNode.ID, Option: true})
+		}
+
+		for _, in := range resource.inputs {
+			label := in.kind
+			if in.name != "" {
+				label = fmt.Sprintf("%s: %s", in.kind, in.name)
+			}
+			g.Edges = append(g.Edges, &Edge{From: g.nodeID(in.resource), To: node.ID, Label: label})
+		}
+	}
+
+	return g
+}
+
+// ForOwner returns the graph of the resources realized for a workload or deliverable, with their
+// health, template, stamped object and outputs
+func ForOwner(name string, resources []v1alpha1.ResourceStatus) *Graph {
+	g := &Graph{Name: name}
+
+	for _, resource := range resources {
+		node := &Node{ID: g.nodeID(resource.Name), Label: resource.Name, Health: metav1.ConditionUnknown}
+
+		for _, condition := range resource.Conditions {
+			if condition.Type == v1alpha1.ResourceHealthy {
+				node.Health = condition.Status
+			}
+		}
+
+		if resource.TemplateRef != nil {
+			node.Details = append(node.Details, templateDetail(resource.TemplateRef.Kind, resource.TemplateRef.Name, resource.TemplateRevision))
+		}
+
+		if resource.StampedRef != nil && resource.StampedRef.ObjectReference != nil {
+			ref := resource.StampedRef
+			stamped := ref.Kind
+			if ref.Resource != "" {
+				stamped = ref.Resource
+			}
+			if ref.Namespace != "" {
+				stamped = fmt.Sprintf("%s %s/%s", stamped, ref.Namespace, ref.Name)
+			} else {
+				stamped = fmt.Sprintf("%s %s", stamped, ref.Name)
+			}
+			node.Details = append(node.Details, fmt.Sprintf("stamped: %s", stamped))
+		}
+
+		for _, output := range resource.Outputs {
+			node.Details = append(node.Details, fmt.Sprintf("%s: %s", output.Name, preview(output.Preview)))
+		}
+
+		g.Nodes = append(g.Nodes, node)
+
+		for _, in := range resource.Inputs {
+			g.Edges = append(g.Edges, &Edge{From: g.nodeID(in.Name), To: node.ID})
+		}
+	}
+
+	return g
+}
+
+func templateDetail(kind, name string, revision int64) string {
+	if revision != 0 {
+		return fmt.Sprintf("%s/%s (revision %d)", kind, name, revision)
+	}
+	return fmt.Sprintf("%s/%s", kind, name)
+}
+
+// selectorDetails describes the requirements of a selector, one per line
+func selectorDetails(selector v1alpha1.Selector) []string {
+	var details []string
+
+	keys := make([]string, 0, len(selector.MatchLabels))
+	for key := range selector.MatchLabels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		details = append(details, fmt.Sprintf("%s = %s", key, selector.MatchLabels[key]))
+	}
+
+	for _, requirement := range selector.MatchExpressions {
+		details = append(details, requirementDetail(requirement.Key, string(requirement.Operator), requirement.Values))
+	}
+
+	for _, requirement := range selector.MatchFields {
+		details = append(details, requirementDetail(requirement.Key, string(requirement.Operator), requirement.Values))
+	}
+
+	return details
+}
+
+func requirementDetail(key, operator string, values []string) string {
+	if len(values) == 0 {
+		return fmt.Sprintf("%s %s", key, operator)
+	}
+	return fmt.Sprintf("%s %s [%s]", key, operator, strings.Join(values, ", "))
+}
+
+// preview flattens an output preview to a single line, cut to previewLength
+func preview(value string) string {
+	flattened := []rune(strings.Join(strings.Fields(value), " "))
+	if len(flattened) > previewLength {
+		return string(flattened[:previewLength-3]) + "..."
+	}
+	return string(flattened)
+}
+
+// nodeID returns the identifier of the node of a resource, or of one of its options, numbering
+// nodes in the order they are first referred to so that identifiers are valid in DOT and Mermaid
+func (g *Graph) nodeID(parts ...string) string {
+	key := strings.Join(parts, "/")
+	if id, ok := g.ids[key]; ok {
+		return id
+	}
+
+	if g.ids == nil {
+		g.ids = map[string]string{}
+	}
+	id := fmt.Sprintf("n%d", len(g.ids))
+	g.ids[key] = id
+	return id
+}
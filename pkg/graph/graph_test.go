@@ -0,0 +1,166 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/graph"
+)
+
+var _ = Describe("Graph", func() {
+	Describe("ForSupplyChain", func() {
+		var supplyChain *v1alpha1.ClusterSupplyChain
+
+		BeforeEach(func() {
+			supplyChain = &v1alpha1.ClusterSupplyChain{
+				ObjectMeta: metav1.ObjectMeta{Name: "source-to-url"},
+				Spec: v1alpha1.SupplyChainSpec{
+					Resources: []v1alpha1.SupplyChainResource{
+						{
+							Name:        "source-provider",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterSourceTemplate", Name: "git", Revision: 2},
+						},
+						{
+							Name: "image-builder",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{
+								Kind: "ClusterImageTemplate",
+								Options: []v1alpha1.TemplateOption{
+									{
+										Name:     "kpack",
+										Selector: v1alpha1.Selector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"builder": "kpack"}}},
+									},
+									{
+										PassThrough: "prebuilt",
+										Selector: v1alpha1.Selector{MatchFields: []v1alpha1.FieldSelectorRequirement{
+											{Key: "spec.image", Operator: v1alpha1.FieldSelectorOpExists},
+										}},
+									},
+								},
+							},
+							Sources: []v1alpha1.ResourceReference{{Name: "source", Resource: "source-provider"}},
+						},
+					},
+				},
+			}
+		})
+
+		It("renders the resources, their inputs and their options as DOT", func() {
+			Expect(graph.ForSupplyChain(supplyChain).DOT()).To(Equal(`digraph "source-to-url" {
+  rankdir=LR;
+  node [shape=box];
+  n0 [label="source-provider\nClusterSourceTemplate/git (revision 2)"];
+  n1 [label="image-builder\nClusterImageTemplate"];
+  n2 [label="kpack\nbuilder = kpack", style=dashed];
+  n3 [label="pass through prebuilt\nspec.image Exists", style=dashed];
+  n1 -> n2 [style=dashed, arrowhead=none];
+  n1 -> n3 [style=dashed, arrowhead=none];
+  n0 -> n1 [label="source: source"];
+}
+`))
+		})
+
+		It("renders the resources, their inputs and their options as Mermaid", func() {
+			Expect(graph.ForSupplyChain(supplyChain).Mermaid()).To(Equal(`flowchart LR
+  n0["source-provider<br/>ClusterSourceTemplate/git (revision 2)"]
+  n1["image-builder<br/>ClusterImageTemplate"]
+  n2(["kpack<br/>builder = kpack"])
+  n3(["pass through prebuilt<br/>spec.image Exists"])
+  n1 -.- n2
+  n1 -.- n3
+  n0 -->|"source: source"| n1
+`))
+		})
+	})
+
+	Describe("ForDelivery", func() {
+		It("draws the deployment a resource reads", func() {
+			delivery := &v1alpha1.ClusterDelivery{
+				ObjectMeta: metav1.ObjectMeta{Name: "delivery"},
+				Spec: v1alpha1.DeliverySpec{
+					Resources: []v1alpha1.DeliveryResource{
+						{Name: "source", TemplateRef: v1alpha1.DeliveryTemplateReference{Kind: "ClusterSourceTemplate", Name: "source"}},
+						{
+							Name:        "deployer",
+							TemplateRef: v1alpha1.DeliveryTemplateReference{Kind: "ClusterDeploymentTemplate", Name: "app-deploy"},
+							Deployment:  &v1alpha1.DeploymentReference{Resource: "source"},
+						},
+					},
+				},
+			}
+
+			g := graph.ForDelivery(delivery)
+			Expect(g.Edges).To(ConsistOf(&graph.Edge{From: "n0", To: "n1", Label: "deployment"}))
+		})
+	})
+
+	Describe("ForOwner", func() {
+		var resources []v1alpha1.ResourceStatus
+
+		BeforeEach(func() {
+			resources = []v1alpha1.ResourceStatus{
+				{
+					RealizedResource: v1alpha1.RealizedResource{
+						Name:        "source-provider",
+						TemplateRef: &corev1.ObjectReference{Kind: "ClusterSourceTemplate", Name: "git"},
+						StampedRef: &v1alpha1.StampedRef{
+							ObjectReference: &corev1.ObjectReference{Kind: "GitRepository", Namespace: "dev", Name: "my-app"},
+							Resource:        "gitrepositories.source.toolkit.fluxcd.io",
+						},
+						Outputs: []v1alpha1.Output{
+							{Name: "url", Preview: "http://source-controller.flux-system.svc.cluster.local./gitrepository/dev/my-app/abcdef.tar.gz\n"},
+						},
+					},
+					Conditions: []metav1.Condition{{Type: "Healthy", Status: metav1.ConditionTrue}},
+				},
+				{
+					RealizedResource: v1alpha1.RealizedResource{
+						Name:        "image-builder",
+						TemplateRef: &corev1.ObjectReference{Kind: "ClusterImageTemplate", Name: "kpack"},
+						Inputs:      []v1alpha1.Input{{Name: "source-provider"}},
+					},
+					Conditions: []metav1.Condition{{Type: "Healthy", Status: metav1.ConditionFalse}},
+				},
+				{
+					RealizedResource: v1alpha1.RealizedResource{Name: "config"},
+				},
+			}
+		})
+
+		It("renders the realized resources with their health, stamped objects and output previews", func() {
+			Expect(graph.ForOwner("my-app", resources).DOT()).To(Equal(`digraph "my-app" {
+  rankdir=LR;
+  node [shape=box];
+  n0 [label="source-provider\nClusterSourceTemplate/git\nstamped: gitrepositories.source.toolkit.fluxcd.io dev/my-app\nurl: http://source-controller.flux-system.svc.cluster.local./g...", color="#2e7d32"];
+  n1 [label="image-builder\nClusterImageTemplate/kpack", color="#c62828"];
+  n2 [label="config", color="#ef6c00"];
+  n0 -> n1;
+}
+`))
+		})
+
+		It("styles the nodes by health in Mermaid", func() {
+			Expect(graph.ForOwner("my-app", resources).Mermaid()).To(HaveSuffix(`  n0 --> n1
+  style n0 stroke:#2e7d32,stroke-width:2px
+  style n1 stroke:#c62828,stroke-width:2px
+  style n2 stroke:#ef6c00,stroke-width:2px
+`))
+		})
+	})
+})
@@ -0,0 +1,118 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// healthColors are the colors of the nodes of realized resources, by health
+var healthColors = map[metav1.ConditionStatus]string{
+	metav1.ConditionTrue:    "#2e7d32",
+	metav1.ConditionFalse:   "#c62828",
+	metav1.ConditionUnknown: "#ef6c00",
+}
+
+// DOT renders the graph in the Graphviz DOT language
+func (g *Graph) DOT() string {
+	var out strings.Builder
+
+	out.WriteString(fmt.Sprintf("digraph %s {\n", dotString(g.Name)))
+	out.WriteString("  rankdir=LR;\n")
+	out.WriteString("  node [shape=box];\n")
+
+	for _, node := range g.Nodes {
+		attributes := []string{fmt.Sprintf("label=%s", dotString(strings.Join(append([]string{node.Label}, node.Details...), "\n")))}
+		if node.Option {
+			attributes = append(attributes, "style=dashed")
+		}
+		if color, ok := healthColors[node.Health]; ok {
+			attributes = append(attributes, fmt.Sprintf("color=%s", dotString(color)))
+		}
+		out.WriteString(fmt.Sprintf("  %s [%s];\n", node.ID, strings.Join(attributes, ", ")))
+	}
+
+	for _, edge := range g.Edges {
+		var attributes []string
+		if edge.Label != "" {
+			attributes = append(attributes, fmt.Sprintf("label=%s", dotString(edge.Label)))
+		}
+		if edge.Option {
+			attributes = append(attributes, "style=dashed", "arrowhead=none")
+		}
+
+		if len(attributes) > 0 {
+			out.WriteString(fmt.Sprintf("  %s -> %s [%s];\n", edge.From, edge.To, strings.Join(attributes, ", ")))
+		} else {
+			out.WriteString(fmt.Sprintf("  %s -> %s;\n", edge.From, edge.To))
+		}
+	}
+
+	out.WriteString("}\n")
+	return out.String()
+}
+
+func dotString(value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+	return fmt.Sprintf(`"%s"`, escaped)
+}
+
+// Mermaid renders the graph as a Mermaid flowchart
+func (g *Graph) Mermaid() string {
+	var out strings.Builder
+
+	out.WriteString("flowchart LR\n")
+
+	for _, node := range g.Nodes {
+		label := mermaidString(append([]string{node.Label}, node.Details...))
+		if node.Option {
+			out.WriteString(fmt.Sprintf("  %s([%s])\n", node.ID, label))
+		} else {
+			out.WriteString(fmt.Sprintf("  %s[%s]\n", node.ID, label))
+		}
+	}
+
+	for _, edge := range g.Edges {
+		switch {
+		case edge.Option:
+			out.WriteString(fmt.Sprintf("  %s -.- %s\n", edge.From, edge.To))
+		case edge.Label != "":
+			out.WriteString(fmt.Sprintf("  %s -->|%s| %s\n", edge.From, mermaidString([]string{edge.Label}), edge.To))
+		default:
+			out.WriteString(fmt.Sprintf("  %s --> %s\n", edge.From, edge.To))
+		}
+	}
+
+	for _, node := range g.Nodes {
+		if color, ok := healthColors[node.Health]; ok {
+			out.WriteString(fmt.Sprintf("  style %s stroke:%s,stroke-width:2px\n", node.ID, color))
+		}
+	}
+
+	return out.String()
+}
+
+// mermaidString quotes lines as a single label, escaping the characters Mermaid would interpret
+func mermaidString(lines []string) string {
+	escaper := strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;", "|", "#124;")
+	escaped := make([]string, len(lines))
+	for i, line := range lines {
+		escaped[i] = escaper.Replace(line)
+	}
+	return fmt.Sprintf(`"%s"`, strings.Join(escaped, "<br/>"))
+}
@@ -0,0 +1,364 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impact
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+	"github.com/vmware-tanzu/cartographer/pkg/selector"
+)
+
+// Analyzer evaluates changes to supply chains and templates against the workloads
+// and deliverables on the cluster, so that admission can warn about their impact.
+type Analyzer struct {
+	Client client.Reader
+	Scheme *runtime.Scheme
+}
+
+var _ v1alpha1.ImpactAnalyzer = &Analyzer{}
+
+func (a *Analyzer) SupplyChainImpact(ctx context.Context, oldSupplyChain, newSupplyChain v1alpha1.SupplyChainObject) ([]string, error) {
+	if oldSupplyChain != nil && reflect.DeepEqual(oldSupplyChain.GetSupplyChainSpec(), newSupplyChain.GetSupplyChainSpec()) {
+		return nil, nil
+	}
+
+	gvk, err := apiutil.GVKForObject(newSupplyChain, a.Scheme)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kind of supply chain: %w", err)
+	}
+
+	workloads := &v1alpha1.WorkloadList{}
+	if err := a.Client.List(ctx, workloads, client.InNamespace(newSupplyChain.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("failed to list workloads: %w", err)
+	}
+
+	selected, err := a.selectedWorkloads(ctx, newSupplyChain, workloads.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	var reconciled []*v1alpha1.Workload
+	for i := range workloads.Items {
+		ref := workloads.Items[i].Status.SupplyChainRef
+		if ref.Kind == gvk.Kind && ref.Name == newSupplyChain.GetName() && ref.Namespace == newSupplyChain.GetNamespace() {
+			reconciled = append(reconciled, &workloads.Items[i])
+		}
+	}
+
+	var warnings []string
+	if len(selected) > 0 {
+		if oldSupplyChain == nil {
+			warnings = append(warnings, fmt.Sprintf("this supply chain will stamp %d workloads", len(selected)))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("this change will restamp %d workloads", len(selected)))
+		}
+	}
+
+	if deselected := len(reconciled) - len(intersection(reconciled, selected)); deselected > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d workloads reconciled by this supply chain will no longer be selected by it", deselected))
+	}
+
+	if oldSupplyChain != nil {
+		warnings = append(warnings, removedResourceWarnings(oldSupplyChain, newSupplyChain, intersection(reconciled, selected))...)
+	}
+
+	warnings = append(warnings, optionWarnings(oldSupplyChain, newSupplyChain, selected)...)
+
+	return warnings, nil
+}
+
+// selectedWorkloads returns the workloads that will be reconciled against supplyChain
+// once it replaces the supply chain of the same name.
+func (a *Analyzer) selectedWorkloads(ctx context.Context, supplyChain v1alpha1.SupplyChainObject, workloads []v1alpha1.Workload) ([]*v1alpha1.Workload, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	candidates := []v1alpha1.SupplyChainObject{supplyChain}
+	namespacedSupplyChains := map[string][]v1alpha1.SupplyChainObject{}
+
+	if supplyChain.GetNamespace() == "" {
+		list := &v1alpha1.ClusterSupplyChainList{}
+		if err := a.Client.List(ctx, list); err != nil {
+			return nil, fmt.Errorf("failed to list cluster supply chains: %w", err)
+		}
+		for i := range list.Items {
+			if list.Items[i].Name != supplyChain.GetName() {
+				candidates = append(candidates, &list.Items[i])
+			}
+		}
+
+		// namespaced supply chains take precedence over cluster supply chains
+		namespacedList := &v1alpha1.SupplyChainList{}
+		if err := a.Client.List(ctx, namespacedList); err != nil {
+			return nil, fmt.Errorf("failed to list supply chains: %w", err)
+		}
+		for i := range namespacedList.Items {
+			namespace := namespacedList.Items[i].Namespace
+			namespacedSupplyChains[namespace] = append(namespacedSupplyChains[namespace], &namespacedList.Items[i])
+		}
+	} else {
+		list := &v1alpha1.SupplyChainList{}
+		if err := a.Client.List(ctx, list, client.InNamespace(supplyChain.GetNamespace())); err != nil {
+			return nil, fmt.Errorf("failed to list supply chains: %w", err)
+		}
+		for i := range list.Items {
+			if list.Items[i].Name != supplyChain.GetName() {
+				candidates = append(candidates, &list.Items[i])
+			}
+		}
+	}
+
+	var selected []*v1alpha1.Workload
+	for i := range workloads {
+		workload := &workloads[i]
+
+		if shadowing, err := repository.GetSelectedSupplyChain(namespacedSupplyChains[workload.Namespace], workload, log); err != nil || len(shadowing) > 0 {
+			continue
+		}
+
+		// a selector error is reported on the workload once it is reconciled
+		matches, err := repository.GetSelectedSupplyChain(candidates, workload, log)
+		if err != nil || len(matches) != 1 {
+			continue
+		}
+
+		if matches[0].GetName() == supplyChain.GetName() {
+			selected = append(selected, workload)
+		}
+	}
+
+	return selected, nil
+}
+
+func removedResourceWarnings(oldSupplyChain, newSupplyChain v1alpha1.SupplyChainObject, workloads []*v1alpha1.Workload) []string {
+	var warnings []string
+	for _, resource := range oldSupplyChain.GetSupplyChainSpec().Resources {
+		if hasResource(newSupplyChain.GetSupplyChainSpec().Resources, resource.Name) {
+			continue
+		}
+
+		isFragment := resource.TemplateRef.Kind == v1alpha1.SupplyChainFragmentKind
+		stamping := 0
+		for _, workload := range workloads {
+			for _, status := range workload.Status.Resources {
+				if status.StampedRef == nil {
+					continue
+				}
+				if status.Name == resource.Name || (isFragment && strings.HasPrefix(status.Name, resource.Name+"-")) {
+					stamping++
+					break
+				}
+			}
+		}
+
+		if stamping > 0 {
+			warnings = append(warnings, fmt.Sprintf("resource [%s] removed: its stamped objects will be deleted from %d workloads", resource.Name, stamping))
+		}
+	}
+	return warnings
+}
+
+// optionWarnings warns about the changed template options no selected workload matches.
+func optionWarnings(oldSupplyChain, newSupplyChain v1alpha1.SupplyChainObject, workloads []*v1alpha1.Workload) []string {
+	if len(workloads) == 0 {
+		return nil
+	}
+
+	var warnings []string
+	for _, resource := range newSupplyChain.GetSupplyChainSpec().Resources {
+		options := resource.TemplateRef.Options
+		if len(options) == 0 {
+			continue
+		}
+
+		if oldSupplyChain != nil {
+			for _, oldResource := range oldSupplyChain.GetSupplyChainSpec().Resources {
+				if oldResource.Name == resource.Name && reflect.DeepEqual(oldResource.TemplateRef.Options, options) {
+					options = nil
+				}
+			}
+			if options == nil {
+				continue
+			}
+		}
+
+		matched := make([]bool, len(options))
+		for _, workload := range workloads {
+			indices, err := selector.BestSelectorMatchIndices(workload, v1alpha1.TemplateOptionSelectors(options))
+			if err == nil && len(indices) == 1 {
+				matched[indices[0]] = true
+			}
+		}
+
+		for i, option := range options {
+			if matched[i] {
+				continue
+			}
+			optionName := option.Name
+			if optionName == "" {
+				optionName = "passThrough"
+			}
+			warnings = append(warnings, fmt.Sprintf("option [%s] of resource [%s] now matches no workloads", optionName, resource.Name))
+		}
+	}
+	return warnings
+}
+
+func (a *Analyzer) TemplateImpact(ctx context.Context, oldTemplate, newTemplate v1alpha1.TemplateObject) ([]string, error) {
+	if oldTemplate != nil && reflect.DeepEqual(oldTemplate.GetTemplateSpec(), newTemplate.GetTemplateSpec()) {
+		return nil, nil
+	}
+
+	gvk, err := apiutil.GVKForObject(newTemplate, a.Scheme)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kind of template: %w", err)
+	}
+
+	usage := &templateUsage{
+		client:    a.Client,
+		kind:      gvk.Kind,
+		name:      newTemplate.GetName(),
+		namespace: newTemplate.GetNamespace(),
+		shadowed:  map[string]bool{},
+	}
+
+	workloads := &v1alpha1.WorkloadList{}
+	if err := a.Client.List(ctx, workloads, client.InNamespace(usage.namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list workloads: %w", err)
+	}
+
+	restampedWorkloads := 0
+	for _, workload := range workloads.Items {
+		used, err := usage.usedBy(ctx, workload.Namespace, workload.Status.Resources)
+		if err != nil {
+			return nil, err
+		}
+		if used {
+			restampedWorkloads++
+		}
+	}
+
+	deliverables := &v1alpha1.DeliverableList{}
+	if err := a.Client.List(ctx, deliverables, client.InNamespace(usage.namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list deliverables: %w", err)
+	}
+
+	restampedDeliverables := 0
+	for _, deliverable := range deliverables.Items {
+		used, err := usage.usedBy(ctx, deliverable.Namespace, deliverable.Status.Resources)
+		if err != nil {
+			return nil, err
+		}
+		if used {
+			restampedDeliverables++
+		}
+	}
+
+	var warnings []string
+	if restampedWorkloads > 0 {
+		warnings = append(warnings, fmt.Sprintf("this change will restamp %d workloads", restampedWorkloads))
+	}
+	if restampedDeliverables > 0 {
+		warnings = append(warnings, fmt.Sprintf("this change will restamp %d deliverables", restampedDeliverables))
+	}
+	return warnings, nil
+}
+
+// templateUsage finds the owners that stamp a template. Resources referencing a
+// namespaced kind stamp the cluster template of the same name, unless it is shadowed
+// by a namespaced template in the owner's namespace.
+type templateUsage struct {
+	client    client.Reader
+	kind      string
+	name      string
+	namespace string
+	shadowed  map[string]bool
+}
+
+func (u *templateUsage) usedBy(ctx context.Context, namespace string, resources []v1alpha1.ResourceStatus) (bool, error) {
+	for _, resource := range resources {
+		ref := resource.TemplateRef
+		if ref == nil || ref.Name != u.name {
+			continue
+		}
+
+		if ref.Kind == u.kind {
+			return true, nil
+		}
+
+		if u.namespace == "" && v1alpha1.IsNamespacedTemplateKind(ref.Kind) && v1alpha1.ClusterTemplateKind(ref.Kind) == u.kind {
+			shadowed, err := u.isShadowed(ctx, ref.Kind, namespace)
+			if err != nil {
+				return false, err
+			}
+			if !shadowed {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (u *templateUsage) isShadowed(ctx context.Context, namespacedKind, namespace string) (bool, error) {
+	key := namespacedKind + "/" + namespace
+	if shadowed, ok := u.shadowed[key]; ok {
+		return shadowed, nil
+	}
+
+	template, err := v1alpha1.GetAPITemplate(namespacedKind)
+	if err != nil {
+		return false, err
+	}
+
+	err = u.client.Get(ctx, client.ObjectKey{Name: u.name, Namespace: namespace}, template)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get template [%s/%s/%s]: %w", namespacedKind, namespace, u.name, err)
+	}
+
+	u.shadowed[key] = err == nil
+	return u.shadowed[key], nil
+}
+
+func hasResource(resources []v1alpha1.SupplyChainResource, name string) bool {
+	for _, resource := range resources {
+		if resource.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func intersection(workloads, others []*v1alpha1.Workload) []*v1alpha1.Workload {
+	var result []*v1alpha1.Workload
+	for _, workload := range workloads {
+		for _, other := range others {
+			if workload == other {
+				result = append(result, workload)
+				break
+			}
+		}
+	}
+	return result
+}
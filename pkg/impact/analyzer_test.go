@@ -0,0 +1,242 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impact_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/impact"
+)
+
+func workload(name string, labels map[string]string, supplyChainName string, resources ...v1alpha1.ResourceStatus) *v1alpha1.Workload {
+	w := &v1alpha1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+	}
+	if supplyChainName != "" {
+		w.Status.SupplyChainRef = v1alpha1.ObjectReference{Kind: "ClusterSupplyChain", Name: supplyChainName}
+	}
+	w.Status.Resources = resources
+	return w
+}
+
+func stampedResource(name, templateKind, templateName string) v1alpha1.ResourceStatus {
+	return v1alpha1.ResourceStatus{
+		RealizedResource: v1alpha1.RealizedResource{
+			Name:       name,
+			StampedRef: &v1alpha1.StampedRef{ObjectReference: &corev1.ObjectReference{Kind: "ConfigMap", Name: name}},
+			TemplateRef: &corev1.ObjectReference{
+				Kind: templateKind,
+				Name: templateName,
+			},
+		},
+	}
+}
+
+var _ = Describe("Analyzer", func() {
+	var (
+		ctx           context.Context
+		clientObjects []client.Object
+		analyzer      *impact.Analyzer
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		clientObjects = nil
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		analyzer = &impact.Analyzer{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(clientObjects...).Build(),
+			Scheme: scheme,
+		}
+	})
+
+	Describe("SupplyChainImpact", func() {
+		var oldSupplyChain, newSupplyChain *v1alpha1.ClusterSupplyChain
+
+		BeforeEach(func() {
+			oldSupplyChain = &v1alpha1.ClusterSupplyChain{
+				ObjectMeta: metav1.ObjectMeta{Name: "web"},
+				Spec: v1alpha1.SupplyChainSpec{
+					LegacySelector: v1alpha1.LegacySelector{Selector: map[string]string{"type": "web"}},
+					Resources: []v1alpha1.SupplyChainResource{
+						{Name: "source", TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterSourceTemplate", Name: "git"}},
+						{Name: "config", TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterConfigTemplate", Name: "app"}},
+					},
+				},
+			}
+			newSupplyChain = oldSupplyChain.DeepCopy()
+
+			clientObjects = []client.Object{
+				oldSupplyChain.DeepCopy(),
+				workload("first", map[string]string{"type": "web"}, "web",
+					stampedResource("source", "ClusterSourceTemplate", "git"),
+					stampedResource("config", "ClusterConfigTemplate", "app"),
+				),
+				workload("second", map[string]string{"type": "web"}, "web",
+					stampedResource("source", "ClusterSourceTemplate", "git"),
+				),
+				workload("other", map[string]string{"type": "worker"}, ""),
+			}
+		})
+
+		It("does not warn when the spec is unchanged", func() {
+			Expect(analyzer.SupplyChainImpact(ctx, oldSupplyChain, newSupplyChain)).To(BeEmpty())
+		})
+
+		Context("the spec changes", func() {
+			BeforeEach(func() {
+				newSupplyChain.Spec.Params = []v1alpha1.BlueprintParam{
+					{Name: "registry", Value: &apiextensionsv1.JSON{Raw: []byte(`"gcr.io"`)}},
+				}
+			})
+
+			It("warns about the workloads it restamps", func() {
+				Expect(analyzer.SupplyChainImpact(ctx, oldSupplyChain, newSupplyChain)).To(Equal([]string{
+					"this change will restamp 2 workloads",
+				}))
+			})
+
+			It("warns about the workloads it will stamp when created", func() {
+				Expect(analyzer.SupplyChainImpact(ctx, nil, newSupplyChain)).To(Equal([]string{
+					"this supply chain will stamp 2 workloads",
+				}))
+			})
+		})
+
+		Context("a resource is removed", func() {
+			BeforeEach(func() {
+				newSupplyChain.Spec.Resources = newSupplyChain.Spec.Resources[:1]
+			})
+
+			It("warns about the workloads its stamped objects will be deleted from", func() {
+				Expect(analyzer.SupplyChainImpact(ctx, oldSupplyChain, newSupplyChain)).To(Equal([]string{
+					"this change will restamp 2 workloads",
+					"resource [config] removed: its stamped objects will be deleted from 1 workloads",
+				}))
+			})
+		})
+
+		Context("the selector no longer matches workloads reconciled by the supply chain", func() {
+			BeforeEach(func() {
+				newSupplyChain.Spec.Selector = map[string]string{"type": "worker"}
+			})
+
+			It("warns about the workloads no longer selected", func() {
+				Expect(analyzer.SupplyChainImpact(ctx, oldSupplyChain, newSupplyChain)).To(Equal([]string{
+					"this change will restamp 1 workloads",
+					"2 workloads reconciled by this supply chain will no longer be selected by it",
+				}))
+			})
+		})
+
+		Context("a namespaced supply chain selects the workloads", func() {
+			BeforeEach(func() {
+				newSupplyChain.Spec.Params = []v1alpha1.BlueprintParam{
+					{Name: "registry", Value: &apiextensionsv1.JSON{Raw: []byte(`"gcr.io"`)}},
+				}
+				clientObjects = append(clientObjects, &v1alpha1.SupplyChain{
+					ObjectMeta: metav1.ObjectMeta{Name: "team-web", Namespace: "default"},
+					Spec: v1alpha1.SupplyChainSpec{
+						LegacySelector: v1alpha1.LegacySelector{Selector: map[string]string{"type": "web"}},
+					},
+				})
+			})
+
+			It("does not count them", func() {
+				Expect(analyzer.SupplyChainImpact(ctx, oldSupplyChain, newSupplyChain)).To(Equal([]string{
+					"2 workloads reconciled by this supply chain will no longer be selected by it",
+				}))
+			})
+		})
+
+		Context("a template option selector matches no workloads", func() {
+			BeforeEach(func() {
+				newSupplyChain.Spec.Resources[1].TemplateRef = v1alpha1.SupplyChainTemplateReference{
+					Kind: "ClusterConfigTemplate",
+					Options: []v1alpha1.TemplateOption{
+						{Name: "app", Selector: v1alpha1.Selector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"type": "web"}}}},
+						{Name: "gpu", Selector: v1alpha1.Selector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"gpu": "true"}}}},
+					},
+				}
+			})
+
+			It("warns about the option", func() {
+				Expect(analyzer.SupplyChainImpact(ctx, oldSupplyChain, newSupplyChain)).To(Equal([]string{
+					"this change will restamp 2 workloads",
+					"option [gpu] of resource [config] now matches no workloads",
+				}))
+			})
+		})
+	})
+
+	Describe("TemplateImpact", func() {
+		var oldTemplate, newTemplate *v1alpha1.ClusterConfigTemplate
+
+		BeforeEach(func() {
+			oldTemplate = &v1alpha1.ClusterConfigTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "app"},
+				Spec: v1alpha1.ConfigTemplateSpec{
+					TemplateSpec: v1alpha1.TemplateSpec{
+						Template: &runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap"}`)},
+					},
+					ConfigPath: ".data",
+				},
+			}
+			newTemplate = oldTemplate.DeepCopy()
+			newTemplate.Spec.Template = &runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","data":{"app":"true"}}`)}
+
+			shadowedWorkload := workload("shadowed", nil, "web", stampedResource("config", "ConfigTemplate", "app"))
+			shadowedWorkload.Namespace = "team"
+
+			clientObjects = []client.Object{
+				workload("first", nil, "web", stampedResource("config", "ClusterConfigTemplate", "app")),
+				workload("fallback", nil, "web", stampedResource("config", "ConfigTemplate", "app")),
+				workload("other", nil, "web", stampedResource("config", "ClusterConfigTemplate", "other")),
+				shadowedWorkload,
+				&v1alpha1.ConfigTemplate{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team"}},
+				&v1alpha1.Deliverable{
+					ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+					Status: v1alpha1.DeliverableStatus{
+						Resources: []v1alpha1.ResourceStatus{stampedResource("config", "ClusterConfigTemplate", "app")},
+					},
+				},
+			}
+		})
+
+		It("warns about the workloads and deliverables it restamps", func() {
+			Expect(analyzer.TemplateImpact(ctx, oldTemplate, newTemplate)).To(Equal([]string{
+				"this change will restamp 2 workloads",
+				"this change will restamp 1 deliverables",
+			}))
+		})
+
+		It("does not warn when the spec is unchanged", func() {
+			Expect(analyzer.TemplateImpact(ctx, oldTemplate, oldTemplate.DeepCopy())).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,277 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+)
+
+// blueprint is a supply chain, delivery or supply chain fragment as seen by the checks
+// of the templates its resources reference
+type blueprint struct {
+	object    Object
+	selector  *v1alpha1.Selector
+	params    []v1alpha1.BlueprintParam
+	resources []blueprintResource
+}
+
+type blueprintResource struct {
+	name         string
+	kind         string
+	templateName string
+	options      []v1alpha1.TemplateOption
+	params       []v1alpha1.BlueprintParam
+}
+
+func newBlueprint(object Object) *blueprint {
+	bp := &blueprint{object: object}
+
+	switch typed := object.Object.(type) {
+	case v1alpha1.SupplyChainObject:
+		spec := typed.GetSupplyChainSpec()
+		selector := repository.LegacySelectorToSelector(typed.GetSelectors())
+		bp.selector = &selector
+		bp.params = spec.Params
+		bp.resources = supplyChainResources(spec.Resources)
+	case v1alpha1.DeliveryObject:
+		spec := typed.GetDeliverySpec()
+		selector := repository.LegacySelectorToSelector(typed.GetSelectors())
+		bp.selector = &selector
+		bp.params = spec.Params
+		for _, resource := range spec.Resources {
+			bp.resources = append(bp.resources, blueprintResource{
+				name:         resource.Name,
+				kind:         resource.TemplateRef.Kind,
+				templateName: resource.TemplateRef.Name,
+				options:      resource.TemplateRef.Options,
+				params:       resource.Params,
+			})
+		}
+	case *v1alpha1.ClusterSupplyChainFragment:
+		// the owners a fragment selects are those of the supply chains including it
+		bp.resources = supplyChainResources(typed.Spec.Resources)
+	default:
+		return nil
+	}
+
+	return bp
+}
+
+func supplyChainResources(resources []v1alpha1.SupplyChainResource) []blueprintResource {
+	var blueprintResources []blueprintResource
+	for _, resource := range resources {
+		blueprintResources = append(blueprintResources, blueprintResource{
+			name:         resource.Name,
+			kind:         resource.TemplateRef.Kind,
+			templateName: resource.TemplateRef.Name,
+			options:      resource.TemplateRef.Options,
+			params:       resource.Params,
+		})
+	}
+	return blueprintResources
+}
+
+func (r blueprintResource) templateNames() []string {
+	if r.templateName != "" {
+		return []string{r.templateName}
+	}
+
+	var names []string
+	for _, option := range r.options {
+		if option.Name != "" {
+			names = append(names, option.Name)
+		}
+	}
+	return names
+}
+
+// lintBlueprint checks that the templates referenced by the resources of the blueprint exist,
+// that the params provided are read and the params read are provided, and that every
+// template option may be selected
+func (l *linter) lintBlueprint(ctx context.Context, bp *blueprint) ([]Finding, error) {
+	var findings []Finding
+
+	blueprintParamsUsed := map[string]bool{}
+	allTemplatesFound := true
+
+	for _, resource := range bp.resources {
+		findings = append(findings, bp.lintOptions(resource)...)
+
+		for _, name := range resource.templateNames() {
+			template, kind, err := v1alpha1.GetTemplate(ctx, l.reader, resource.kind, name, bp.object.GetNamespace())
+			if err != nil {
+				return nil, fmt.Errorf("get template [%s/%s] of resource [%s]: %w", resource.kind, name, resource.name, err)
+			}
+
+			if template == nil {
+				allTemplatesFound = false
+				findings = append(findings, bp.object.finding(SeverityError, RuleTemplateExists, l.missingTemplateMessage(resource, name)))
+				continue
+			}
+
+			templateObject, ok := template.(v1alpha1.TemplateObject)
+			if !ok {
+				// fragments are checked on their own
+				continue
+			}
+
+			used, paramFindings := bp.lintParams(resource, kind, templateObject)
+			findings = append(findings, paramFindings...)
+			for param := range used {
+				blueprintParamsUsed[param] = true
+			}
+		}
+	}
+
+	if allTemplatesFound {
+		for _, param := range bp.params {
+			if !blueprintParamsUsed[param.Name] {
+				findings = append(findings, bp.object.finding(SeverityWarning, RuleParamUnused,
+					fmt.Sprintf("param [%s] is neither declared nor read by any template of the %s", param.Name, strings.ToLower(bp.object.Kind()))))
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+// lintParams checks the params the resource provides against those the template declares
+// and reads, returning the names of the params the template declares or reads
+func (bp *blueprint) lintParams(resource blueprintResource, kind string, template v1alpha1.TemplateObject) (map[string]bool, []Finding) {
+	var findings []Finding
+	templateRef := fmt.Sprintf("%s/%s", kind, template.GetName())
+	spec := template.GetTemplateSpec()
+	inputs := spec.DiscoverInputs()
+
+	used := map[string]bool{}
+	for _, param := range inputs.Params {
+		used[param] = true
+	}
+
+	defaulted := map[string]bool{}
+	required := map[string]bool{}
+	for _, param := range spec.Params {
+		used[param.Name] = true
+		if !isNullParamValue(param.DefaultValue) {
+			defaulted[param.Name] = true
+		}
+		if param.Schema != nil && param.Schema.Required {
+			required[param.Name] = true
+		}
+	}
+
+	provided := map[string]bool{}
+	for _, params := range [][]v1alpha1.BlueprintParam{bp.params, resource.params} {
+		for _, param := range params {
+			provided[param.Name] = true
+		}
+	}
+
+	// the params a ytt template reads are only found on a best effort basis
+	if !inputs.BestEffort {
+		for _, param := range resource.params {
+			if !used[param.Name] {
+				findings = append(findings, bp.object.finding(SeverityWarning, RuleParamUnused,
+					fmt.Sprintf("resource [%s] provides param [%s], which template [%s] neither declares nor reads", resource.name, param.Name, templateRef)))
+			}
+		}
+	}
+
+	var unset []string
+	for param := range used {
+		read := contains(inputs.Params, param)
+		if (read || required[param]) && !defaulted[param] && !provided[param] {
+			unset = append(unset, param)
+		}
+	}
+	sort.Strings(unset)
+
+	for _, param := range unset {
+		verb := "reads"
+		if required[param] {
+			verb = "requires"
+		}
+		findings = append(findings, bp.object.finding(SeverityWarning, RuleParamUnset,
+			fmt.Sprintf("template [%s] of resource [%s] %s param [%s], which has no default and is not provided by the %s: every owner must provide it", templateRef, resource.name, verb, param, strings.ToLower(bp.object.Kind()))))
+	}
+
+	return used, findings
+}
+
+// lintOptions reports the options of the resource that no owner selected by the blueprint can match
+func (bp *blueprint) lintOptions(resource blueprintResource) []Finding {
+	var findings []Finding
+
+	for _, option := range resource.options {
+		optionName := option.Name
+		if optionName == "" {
+			optionName = "passThrough"
+		}
+
+		selectors := []v1alpha1.Selector{option.Selector}
+		if bp.selector != nil {
+			selectors = append(selectors, *bp.selector)
+		}
+
+		if reason := unsatisfiable(selectors...); reason != "" {
+			findings = append(findings, bp.object.finding(SeverityWarning, RuleOptionUnreachable,
+				fmt.Sprintf("option [%s] of resource [%s] can never be selected: %s", optionName, resource.name, reason)))
+		}
+	}
+
+	return findings
+}
+
+// missingTemplateMessage points at the objects of other kinds with the name of the missing template
+func (l *linter) missingTemplateMessage(resource blueprintResource, name string) string {
+	message := fmt.Sprintf("resource [%s] references template [%s/%s], which does not exist", resource.name, resource.kind, name)
+
+	var kinds []string
+	for _, object := range l.objects {
+		if object.GetName() != name {
+			continue
+		}
+		if _, err := v1alpha1.GetAPITemplate(object.Kind()); err == nil {
+			kinds = append(kinds, object.Kind())
+		}
+	}
+
+	if len(kinds) > 0 {
+		message = fmt.Sprintf("%s, but a %s of that name does", message, strings.Join(kinds, " and a "))
+	}
+
+	return message
+}
+
+func isNullParamValue(value apiextensionsv1.JSON) bool {
+	return len(value.Raw) == 0 || string(value.Raw) == "null"
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint checks Cartographer objects read from files, without a cluster. It runs
+// the validations of the admission webhooks against the other objects read, and adds
+// checks that admission cannot make because the objects are applied one at a time.
+package lint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+// Severity of a finding. Errors are what admission or reconciliation would reject,
+// warnings are likely mistakes that do not fail on their own.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Rules findings are reported under
+const (
+	RuleLoad              = "load"
+	RuleWebhook           = "webhook"
+	RuleTemplateExists    = "template-exists"
+	RuleParamUnused       = "param-unused"
+	RuleParamUnset        = "param-unset"
+	RuleOptionUnreachable = "option-unreachable"
+)
+
+// Finding is a problem found in an object, or in a file that could not be read as objects
+type Finding struct {
+	Severity  Severity `json:"severity"`
+	Rule      string   `json:"rule"`
+	File      string   `json:"file,omitempty"`
+	Kind      string   `json:"kind,omitempty"`
+	Namespace string   `json:"namespace,omitempty"`
+	Name      string   `json:"name,omitempty"`
+	Message   string   `json:"message"`
+}
+
+// Object is a Cartographer object and the file it was read from
+type Object struct {
+	client.Object
+	File string
+}
+
+// Kind of the object, as read from its file
+func (o Object) Kind() string {
+	return o.GetObjectKind().GroupVersionKind().Kind
+}
+
+func (o Object) finding(severity Severity, rule string, message string) Finding {
+	return Finding{
+		Severity:  severity,
+		Rule:      rule,
+		File:      o.File,
+		Kind:      o.Kind(),
+		Namespace: o.GetNamespace(),
+		Name:      o.GetName(),
+		Message:   message,
+	}
+}
+
+// Lint checks objects against each other, as if they were all applied to a cluster
+// holding nothing else. Findings are sorted by file, then object.
+func Lint(ctx context.Context, objects []Object) ([]Finding, error) {
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("add to scheme: %w", err)
+	}
+
+	var findings []Finding
+
+	unique := map[string]Object{}
+	var clientObjects []client.Object
+	var linted []Object
+	for _, object := range objects {
+		key := fmt.Sprintf("%s/%s/%s", object.Kind(), object.GetNamespace(), object.GetName())
+		if first, ok := unique[key]; ok {
+			findings = append(findings, object.finding(SeverityError, RuleLoad, fmt.Sprintf("duplicates the object read from [%s]", first.File)))
+			continue
+		}
+		unique[key] = object
+		clientObjects = append(clientObjects, object.DeepCopyObject().(client.Object))
+		linted = append(linted, object)
+	}
+
+	l := &linter{
+		reader:  fake.NewClientBuilder().WithScheme(scheme).WithObjects(clientObjects...).Build(),
+		objects: linted,
+	}
+
+	for _, object := range linted {
+		warnings, err := validate(ctx, l.reader, object.Object)
+		if err != nil {
+			findings = append(findings, object.finding(SeverityError, RuleWebhook, err.Error()))
+		}
+		for _, warning := range warnings {
+			findings = append(findings, object.finding(SeverityWarning, RuleWebhook, warning))
+		}
+
+		if bp := newBlueprint(object); bp != nil {
+			blueprintFindings, err := l.lintBlueprint(ctx, bp)
+			if err != nil {
+				return nil, fmt.Errorf("lint %s [%s]: %w", object.Kind(), object.GetName(), err)
+			}
+			findings = append(findings, blueprintFindings...)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		if findings[i].Kind != findings[j].Kind {
+			return findings[i].Kind < findings[j].Kind
+		}
+		if findings[i].Namespace != findings[j].Namespace {
+			return findings[i].Namespace < findings[j].Namespace
+		}
+		return findings[i].Name < findings[j].Name
+	})
+
+	return findings, nil
+}
+
+// validate runs the validation of the admission webhook of the object's kind, with the
+// client the webhook would be set up with
+func validate(ctx context.Context, reader client.Reader, obj client.Object) ([]string, error) {
+	switch typed := obj.(type) {
+	case *v1alpha1.ClusterSupplyChain, *v1alpha1.SupplyChain, *v1alpha1.ClusterDelivery, *v1alpha1.Delivery:
+		return (&v1alpha1.BlueprintValidator{Client: reader}).ValidateCreate(ctx, typed)
+	case *v1alpha1.ClusterSupplyChainFragment:
+		return nil, (&v1alpha1.ClusterSupplyChainFragmentValidator{Client: reader}).ValidateCreate(ctx, typed)
+	case v1alpha1.TemplateObject:
+		return (&v1alpha1.TemplateValidator{}).ValidateCreate(ctx, typed)
+	case webhook.Validator:
+		return nil, typed.ValidateCreate()
+	}
+
+	return nil, nil
+}
+
+type linter struct {
+	reader  client.Reader
+	objects []Object
+}
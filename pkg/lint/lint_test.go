@@ -0,0 +1,230 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/lint"
+)
+
+func typeMeta(kind string) metav1.TypeMeta {
+	return metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: kind}
+}
+
+func configTemplate(name string, template string, params ...v1alpha1.TemplateParam) *v1alpha1.ClusterConfigTemplate {
+	return &v1alpha1.ClusterConfigTemplate{
+		TypeMeta:   typeMeta("ClusterConfigTemplate"),
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.ConfigTemplateSpec{
+			TemplateSpec: v1alpha1.TemplateSpec{
+				Template: &runtime.RawExtension{Raw: []byte(template)},
+				Params:   params,
+			},
+			ConfigPath: ".data",
+		},
+	}
+}
+
+func jsonValue(raw string) *apiextensionsv1.JSON {
+	return &apiextensionsv1.JSON{Raw: []byte(raw)}
+}
+
+var _ = Describe("Lint", func() {
+	var (
+		ctx         context.Context
+		supplyChain *v1alpha1.ClusterSupplyChain
+		objects     []lint.Object
+		findings    []lint.Finding
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		supplyChain = &v1alpha1.ClusterSupplyChain{
+			TypeMeta:   typeMeta("ClusterSupplyChain"),
+			ObjectMeta: metav1.ObjectMeta{Name: "my-supply-chain"},
+			Spec: v1alpha1.SupplyChainSpec{
+				LegacySelector: v1alpha1.LegacySelector{Selector: map[string]string{"app": "web"}},
+				Resources: []v1alpha1.SupplyChainResource{
+					{
+						Name:        "config-provider",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterConfigTemplate", Name: "my-config"},
+					},
+				},
+			},
+		}
+		objects = []lint.Object{
+			{Object: supplyChain, File: "supply-chain.yaml"},
+			{Object: configTemplate("my-config", `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "$(workload.metadata.name)$"}}`), File: "template.yaml"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		findings, err = lint.Lint(ctx, objects)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when the objects are consistent", func() {
+		It("finds nothing", func() {
+			Expect(findings).To(BeEmpty())
+		})
+	})
+
+	Context("when the webhook rejects an object", func() {
+		BeforeEach(func() {
+			supplyChain.Spec.Resources = append(supplyChain.Spec.Resources, supplyChain.Spec.Resources[0])
+		})
+
+		It("reports the webhook error for the object", func() {
+			Expect(findings).To(ContainElement(lint.Finding{
+				Severity: lint.SeverityError,
+				Rule:     lint.RuleWebhook,
+				File:     "supply-chain.yaml",
+				Kind:     "ClusterSupplyChain",
+				Name:     "my-supply-chain",
+				Message:  "error validating clustersupplychain [my-supply-chain]: duplicate resource name [config-provider] found",
+			}))
+		})
+	})
+
+	Context("when a template reads an input the resource does not provide", func() {
+		BeforeEach(func() {
+			objects[1].Object = configTemplate("my-config", `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "$(images.app.image)$"}}`)
+		})
+
+		It("reports the error the webhook finds with the other objects", func() {
+			Expect(findings).To(HaveLen(1))
+			Expect(findings[0].Rule).To(Equal(lint.RuleWebhook))
+			Expect(findings[0].Message).To(ContainSubstring("template reads image [app] which the resource does not provide"))
+		})
+	})
+
+	Context("when a referenced template does not exist", func() {
+		BeforeEach(func() {
+			supplyChain.Spec.Resources[0].TemplateRef.Kind = "ClusterSourceTemplate"
+		})
+
+		It("reports the missing template and the kinds of the templates of that name", func() {
+			Expect(findings).To(ConsistOf(lint.Finding{
+				Severity: lint.SeverityError,
+				Rule:     lint.RuleTemplateExists,
+				File:     "supply-chain.yaml",
+				Kind:     "ClusterSupplyChain",
+				Name:     "my-supply-chain",
+				Message:  "resource [config-provider] references template [ClusterSourceTemplate/my-config], which does not exist, but a ClusterConfigTemplate of that name does",
+			}))
+		})
+	})
+
+	Context("when the params provided are not read", func() {
+		BeforeEach(func() {
+			supplyChain.Spec.Params = []v1alpha1.BlueprintParam{{Name: "blueprint-param", Value: jsonValue(`"a"`)}}
+			supplyChain.Spec.Resources[0].Params = []v1alpha1.BlueprintParam{{Name: "resource-param", Value: jsonValue(`"b"`)}}
+		})
+
+		It("warns about each of them", func() {
+			Expect(findings).To(HaveLen(2))
+			Expect(findings[0].Severity).To(Equal(lint.SeverityWarning))
+			Expect(findings[0].Rule).To(Equal(lint.RuleParamUnused))
+			Expect(findings[0].Message).To(Equal("resource [config-provider] provides param [resource-param], which template [ClusterConfigTemplate/my-config] neither declares nor reads"))
+			Expect(findings[1].Rule).To(Equal(lint.RuleParamUnused))
+			Expect(findings[1].Message).To(Equal("param [blueprint-param] is neither declared nor read by any template of the clustersupplychain"))
+		})
+	})
+
+	Context("when the params read are not provided", func() {
+		BeforeEach(func() {
+			objects[1].Object = configTemplate("my-config",
+				`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "$(params.name)$"}, "data": {"port": "$(params.port)$"}}`,
+				v1alpha1.TemplateParam{Name: "port", DefaultValue: apiextensionsv1.JSON{Raw: []byte(`"8080"`)}},
+				v1alpha1.TemplateParam{Name: "replicas", Schema: &v1alpha1.ParamSchema{Required: true}},
+			)
+		})
+
+		It("warns about those without a default", func() {
+			Expect(findings).To(HaveLen(2))
+			Expect(findings[0].Rule).To(Equal(lint.RuleParamUnset))
+			Expect(findings[0].Message).To(Equal("template [ClusterConfigTemplate/my-config] of resource [config-provider] reads param [name], which has no default and is not provided by the clustersupplychain: every owner must provide it"))
+			Expect(findings[1].Rule).To(Equal(lint.RuleParamUnset))
+			Expect(findings[1].Message).To(Equal("template [ClusterConfigTemplate/my-config] of resource [config-provider] requires param [replicas], which has no default and is not provided by the clustersupplychain: every owner must provide it"))
+		})
+
+		Context("and the supply chain provides them", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Params = []v1alpha1.BlueprintParam{{Name: "name", DefaultValue: jsonValue(`"app"`)}}
+				supplyChain.Spec.Resources[0].Params = []v1alpha1.BlueprintParam{{Name: "replicas", Value: jsonValue(`2`)}}
+			})
+
+			It("finds nothing", func() {
+				Expect(findings).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("when a template option can never be selected", func() {
+		BeforeEach(func() {
+			objects = append(objects, lint.Object{Object: configTemplate("other-config", `{"apiVersion": "v1", "kind": "ConfigMap"}`), File: "template.yaml"})
+			supplyChain.Spec.Resources[0].TemplateRef = v1alpha1.SupplyChainTemplateReference{
+				Kind: "ClusterConfigTemplate",
+				Options: []v1alpha1.TemplateOption{
+					{
+						Name:     "my-config",
+						Selector: v1alpha1.Selector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}}},
+					},
+					{
+						Name: "other-config",
+						Selector: v1alpha1.Selector{MatchFields: []v1alpha1.FieldSelectorRequirement{
+							{Key: "spec.env", Operator: v1alpha1.FieldSelectorOpIn, Values: []string{"prod"}},
+							{Key: "spec.env", Operator: v1alpha1.FieldSelectorOpDoesNotExist},
+						}},
+					},
+				},
+			}
+		})
+
+		It("warns about the option, with the requirements that conflict", func() {
+			Expect(findings).To(HaveLen(2))
+			Expect(findings[0].Rule).To(Equal(lint.RuleOptionUnreachable))
+			Expect(findings[0].Message).To(Equal("option [my-config] of resource [config-provider] can never be selected: label [app] has no value satisfying every requirement"))
+			Expect(findings[1].Rule).To(Equal(lint.RuleOptionUnreachable))
+			Expect(findings[1].Message).To(Equal("option [other-config] of resource [config-provider] can never be selected: field [spec.env] must both exist and not exist"))
+		})
+	})
+
+	Context("when an object is read twice", func() {
+		BeforeEach(func() {
+			objects = append(objects, lint.Object{Object: supplyChain.DeepCopy(), File: "copy.yaml"})
+		})
+
+		It("reports the duplicate", func() {
+			Expect(findings).To(ConsistOf(lint.Finding{
+				Severity: lint.SeverityError,
+				Rule:     lint.RuleLoad,
+				File:     "copy.yaml",
+				Kind:     "ClusterSupplyChain",
+				Name:     "my-supply-chain",
+				Message:  "duplicates the object read from [supply-chain.yaml]",
+			}))
+		})
+	})
+})
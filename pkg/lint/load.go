@@ -0,0 +1,168 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+// namespacedKinds are the kinds of the namespaced objects that are not templates
+var namespacedKinds = map[string]bool{
+	"SupplyChain": true,
+	"Delivery":    true,
+	"Workload":    true,
+	"Deliverable": true,
+	"Runnable":    true,
+}
+
+// Load reads the Cartographer objects in the yaml and json files at paths, descending into
+// directories. Documents of other API groups, or that are not objects, are skipped. Documents
+// that cannot be read as Cartographer objects are reported as findings. Namespaced objects
+// without a namespace are put in the default namespace, as kubectl would.
+func Load(paths ...string) ([]Object, []Finding, error) {
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		return nil, nil, fmt.Errorf("add to scheme: %w", err)
+	}
+
+	var (
+		objects  []Object
+		findings []Finding
+	)
+
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(file string, entry os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if entry.IsDir() || !isManifest(file) {
+				return nil
+			}
+
+			fileObjects, fileFindings, err := loadFile(scheme, file)
+			if err != nil {
+				return err
+			}
+
+			objects = append(objects, fileObjects...)
+			findings = append(findings, fileFindings...)
+			return nil
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("read [%s]: %w", path, err)
+		}
+	}
+
+	return objects, findings, nil
+}
+
+func isManifest(file string) bool {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+func loadFile(scheme *runtime.Scheme, file string) ([]Object, []Finding, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	var (
+		objects  []Object
+		findings []Finding
+	)
+
+	decoder := utilyaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		document := map[string]interface{}{}
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			return objects, findings, nil
+		}
+		if err != nil {
+			// the rest of the file cannot be split into documents
+			findings = append(findings, Finding{Severity: SeverityError, Rule: RuleLoad, File: file, Message: fmt.Sprintf("not valid yaml: %s", err)})
+			return objects, findings, nil
+		}
+
+		unknown := &unstructured.Unstructured{Object: document}
+		gvk := unknown.GroupVersionKind()
+		if gvk.Group != v1alpha1.SchemeGroupVersion.Group || gvk.Kind == "" {
+			continue
+		}
+
+		object, err := toObject(scheme, unknown)
+		if err != nil {
+			findings = append(findings, Finding{
+				Severity:  SeverityError,
+				Rule:      RuleLoad,
+				File:      file,
+				Kind:      gvk.Kind,
+				Namespace: unknown.GetNamespace(),
+				Name:      unknown.GetName(),
+				Message:   err.Error(),
+			})
+			continue
+		}
+
+		if object.GetNamespace() == "" && (v1alpha1.IsNamespacedTemplateKind(gvk.Kind) || namespacedKinds[gvk.Kind]) {
+			object.SetNamespace("default")
+		}
+
+		objects = append(objects, Object{Object: object, File: file})
+	}
+}
+
+func toObject(scheme *runtime.Scheme, unknown *unstructured.Unstructured) (client.Object, error) {
+	gvk := unknown.GroupVersionKind()
+	typed, err := scheme.New(gvk)
+	if err != nil {
+		return nil, fmt.Errorf("unknown kind [%s]", gvk.String())
+	}
+
+	object, ok := typed.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("kind [%s] is not an object", gvk.String())
+	}
+
+	data, err := json.Marshal(unknown.Object)
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %w", err)
+	}
+
+	if err = json.Unmarshal(data, object); err != nil {
+		return nil, fmt.Errorf("not a valid %s: %w", gvk.Kind, err)
+	}
+
+	return object, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/lint"
+)
+
+var _ = Describe("Load", func() {
+	var directory string
+
+	BeforeEach(func() {
+		var err error
+		directory, err = os.MkdirTemp("", "lint")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(os.MkdirAll(filepath.Join(directory, "templates"), 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(directory, "supply-chain.yaml"), []byte(`
+apiVersion: carto.run/v1alpha1
+kind: SupplyChain
+metadata:
+  name: my-supply-chain
+spec:
+  selector:
+    app: web
+  resources: []
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-cartographer
+`), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(directory, "templates", "template.yml"), []byte(`
+apiVersion: carto.run/v1alpha1
+kind: ClusterConfigTemplate
+metadata:
+  name: my-config
+spec:
+  configPath: .data
+---
+apiVersion: carto.run/v1alpha1
+kind: ClusterUnknownTemplate
+metadata:
+  name: unknown
+`), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(directory, "notes.txt"), []byte("not: [yaml"), 0644)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(directory)).To(Succeed())
+	})
+
+	It("reads the Cartographer objects of the yaml files in the directory and its subdirectories", func() {
+		objects, findings, err := lint.Load(directory)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(objects).To(HaveLen(2))
+		Expect(objects[0].File).To(Equal(filepath.Join(directory, "supply-chain.yaml")))
+		Expect(objects[0].Object).To(BeAssignableToTypeOf(&v1alpha1.SupplyChain{}))
+		Expect(objects[0].GetNamespace()).To(Equal("default"))
+		Expect(objects[1].File).To(Equal(filepath.Join(directory, "templates", "template.yml")))
+		Expect(objects[1].Object).To(BeAssignableToTypeOf(&v1alpha1.ClusterConfigTemplate{}))
+		Expect(objects[1].GetNamespace()).To(BeEmpty())
+
+		Expect(findings).To(ConsistOf(lint.Finding{
+			Severity: lint.SeverityError,
+			Rule:     lint.RuleLoad,
+			File:     filepath.Join(directory, "templates", "template.yml"),
+			Kind:     "ClusterUnknownTemplate",
+			Name:     "unknown",
+			Message:  "unknown kind [carto.run/v1alpha1, Kind=ClusterUnknownTemplate]",
+		}))
+	})
+
+	Context("when a file is not valid yaml", func() {
+		BeforeEach(func() {
+			Expect(os.WriteFile(filepath.Join(directory, "broken.yaml"), []byte("kind: [ClusterSupplyChain"), 0644)).To(Succeed())
+		})
+
+		It("reports the file", func() {
+			_, findings, err := lint.Load(directory)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(findings).To(ContainElement(And(
+				HaveField("Rule", lint.RuleLoad),
+				HaveField("File", filepath.Join(directory, "broken.yaml")),
+			)))
+		})
+	})
+})
@@ -0,0 +1,146 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+// constraint gathers the requirements of selectors on a single label or field
+type constraint struct {
+	// in is nil until a requirement restricts the values
+	in     map[string]bool
+	notIn  map[string]bool
+	exists bool
+	absent bool
+}
+
+func (c *constraint) restrict(values []string) {
+	restricted := map[string]bool{}
+	for _, value := range values {
+		if c.in == nil || c.in[value] {
+			restricted[value] = true
+		}
+	}
+	c.in = restricted
+}
+
+func (c *constraint) exclude(values []string) {
+	if c.notIn == nil {
+		c.notIn = map[string]bool{}
+	}
+	for _, value := range values {
+		c.notIn[value] = true
+	}
+}
+
+// reason is why no value satisfies the constraint, or empty when one does
+func (c *constraint) reason() string {
+	if c.exists && c.absent {
+		return "must both exist and not exist"
+	}
+
+	if c.in == nil {
+		return ""
+	}
+
+	for value := range c.in {
+		if !c.notIn[value] {
+			return ""
+		}
+	}
+	return "has no value satisfying every requirement"
+}
+
+// unsatisfiable returns why no object can match all the selectors at once, or empty when one may
+func unsatisfiable(selectors ...v1alpha1.Selector) string {
+	labels := map[string]*constraint{}
+	fields := map[string]*constraint{}
+
+	get := func(constraints map[string]*constraint, key string) *constraint {
+		if constraints[key] == nil {
+			constraints[key] = &constraint{}
+		}
+		return constraints[key]
+	}
+
+	for _, selector := range selectors {
+		for key, value := range selector.MatchLabels {
+			c := get(labels, key)
+			c.exists = true
+			c.restrict([]string{value})
+		}
+
+		for _, requirement := range selector.MatchExpressions {
+			c := get(labels, requirement.Key)
+			switch requirement.Operator {
+			case metav1.LabelSelectorOpIn:
+				c.exists = true
+				c.restrict(requirement.Values)
+			case metav1.LabelSelectorOpNotIn:
+				// a label that does not exist is not in any values
+				c.exclude(requirement.Values)
+			case metav1.LabelSelectorOpExists:
+				c.exists = true
+			case metav1.LabelSelectorOpDoesNotExist:
+				c.absent = true
+			}
+		}
+
+		for _, requirement := range selector.MatchFields {
+			c := get(fields, requirement.Key)
+			switch requirement.Operator {
+			case v1alpha1.FieldSelectorOpIn:
+				c.exists = true
+				c.restrict(requirement.Values)
+			case v1alpha1.FieldSelectorOpNotIn:
+				// unlike labels, a field that does not exist matches no requirement but DoesNotExist
+				c.exists = true
+				c.exclude(requirement.Values)
+			case v1alpha1.FieldSelectorOpExists:
+				c.exists = true
+			case v1alpha1.FieldSelectorOpDoesNotExist:
+				c.absent = true
+			}
+		}
+	}
+
+	for _, group := range []struct {
+		name        string
+		constraints map[string]*constraint
+	}{
+		{"label", labels},
+		{"field", fields},
+	} {
+		keys := make([]string, 0, len(group.constraints))
+		for key := range group.constraints {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if reason := group.constraints[key].reason(); reason != "" {
+				return fmt.Sprintf("%s [%s] %s", group.name, key, reason)
+			}
+		}
+	}
+
+	return ""
+}
@@ -135,6 +135,57 @@ var _ = Describe("MapFunctions", func() {
 		})
 	})
 
+	Describe("SupplyChainToWorkloadRequests", func() {
+		var supplyChain *v1alpha1.SupplyChain
+		BeforeEach(func() {
+			supplyChain = &v1alpha1.SupplyChain{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "team-supplyChain",
+					Namespace: "team-a",
+				},
+			}
+		})
+
+		Context("workloads in the namespace", func() {
+			BeforeEach(func() {
+				fakeClient.ListStub = func(ctx context.Context, list client.ObjectList, options ...client.ListOption) error {
+					listVal := reflect.Indirect(reflect.ValueOf(list))
+
+					existingVal := reflect.Indirect(reflect.ValueOf(v1alpha1.WorkloadList{Items: []v1alpha1.Workload{
+						{ObjectMeta: metav1.ObjectMeta{Name: "first-workload", Namespace: "team-a"}},
+					}}))
+					listVal.Set(existingVal)
+					return nil
+				}
+			})
+
+			It("lists workloads in the namespace of the supplyChain only", func() {
+				result := m.SupplyChainToWorkloadRequests(supplyChain)
+				Expect(result).To(Equal([]reconcile.Request{
+					{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "first-workload"}},
+				}))
+
+				Expect(fakeClient.ListCallCount()).To(Equal(1))
+				_, _, options := fakeClient.ListArgsForCall(0)
+				Expect(options).To(Equal([]client.ListOption{client.InNamespace("team-a")}))
+			})
+		})
+
+		Context("client returns an error", func() {
+			BeforeEach(func() {
+				fakeClient.ListReturns(fmt.Errorf("some-error"))
+			})
+			It("logs an error to the client", func() {
+				result := m.SupplyChainToWorkloadRequests(supplyChain)
+				Expect(result).To(BeEmpty())
+
+				Expect(fakeLogger.ErrorCallCount()).To(Equal(1))
+				_, secondArg, _ := fakeLogger.ErrorArgsForCall(0)
+				Expect(secondArg).To(Equal("supply chain to workload requests: client list workloads"))
+			})
+		})
+	})
+
 	Describe("ServiceAccountToWorkloadRequests", func() {
 		var serviceAccount *corev1.ServiceAccount
 		BeforeEach(func() {
@@ -584,6 +635,57 @@ var _ = Describe("MapFunctions", func() {
 		})
 	})
 
+	Describe("DeliveryToDeliverableRequests", func() {
+		var delivery *v1alpha1.Delivery
+		BeforeEach(func() {
+			delivery = &v1alpha1.Delivery{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "team-delivery",
+					Namespace: "team-a",
+				},
+			}
+		})
+
+		Context("deliverables in the namespace", func() {
+			BeforeEach(func() {
+				fakeClient.ListStub = func(ctx context.Context, list client.ObjectList, options ...client.ListOption) error {
+					listVal := reflect.Indirect(reflect.ValueOf(list))
+
+					existingVal := reflect.Indirect(reflect.ValueOf(v1alpha1.DeliverableList{Items: []v1alpha1.Deliverable{
+						{ObjectMeta: metav1.ObjectMeta{Name: "first-deliverable", Namespace: "team-a"}},
+					}}))
+					listVal.Set(existingVal)
+					return nil
+				}
+			})
+
+			It("lists deliverables in the namespace of the delivery only", func() {
+				result := m.DeliveryToDeliverableRequests(delivery)
+				Expect(result).To(Equal([]reconcile.Request{
+					{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "first-deliverable"}},
+				}))
+
+				Expect(fakeClient.ListCallCount()).To(Equal(1))
+				_, _, options := fakeClient.ListArgsForCall(0)
+				Expect(options).To(Equal([]client.ListOption{client.InNamespace("team-a")}))
+			})
+		})
+
+		Context("client returns an error", func() {
+			BeforeEach(func() {
+				fakeClient.ListReturns(fmt.Errorf("some-error"))
+			})
+			It("logs an error to the client", func() {
+				result := m.DeliveryToDeliverableRequests(delivery)
+				Expect(result).To(BeEmpty())
+
+				Expect(fakeLogger.ErrorCallCount()).To(Equal(1))
+				_, secondArg, _ := fakeLogger.ErrorArgsForCall(0)
+				Expect(secondArg).To(Equal("delivery to deliverable requests: client list deliverables"))
+			})
+		})
+	})
+
 	Describe("ServiceAccountToDeliverableRequests", func() {
 		var serviceAccount *corev1.ServiceAccount
 		BeforeEach(func() {
@@ -0,0 +1,131 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plugin is the kubectl carto plugin, which inspects the workloads on a cluster
+// through the Cartographer API types.
+package plugin
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+var (
+	version     = "development"
+	kubeconfig  string
+	kubeContext string
+	namespace   string
+)
+
+var rootCmd = &cobra.Command{
+	Use:     "kubectl-carto",
+	Version: version,
+	Short:   "kubectl carto - inspect Cartographer workloads",
+	Long: `kubectl carto is a kubectl plugin to inspect the workloads on a cluster and the resources
+their supply chains realize.
+
+Read more at cartographer.sh`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+var workloadCmd = &cobra.Command{
+	Use:   "workload",
+	Short: "inspect and act on workloads",
+}
+
+// Execute runs the plugin with the arguments of the process
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+// clientConfig is the kubeconfig selected by the flags of the plugin, as kubectl would select it
+func clientConfig() clientcmd.ClientConfig {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{
+		CurrentContext: kubeContext,
+		Context:        clientcmdapi.Context{Namespace: namespace},
+	})
+}
+
+// restConfig returns the config of the cluster and the namespace to inspect
+func restConfig() (*rest.Config, string, error) {
+	config := clientConfig()
+
+	restConfig, err := config.ClientConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("load kubeconfig: %w", err)
+	}
+
+	ns, _, err := config.Namespace()
+	if err != nil {
+		return nil, "", fmt.Errorf("get namespace: %w", err)
+	}
+
+	return restConfig, ns, nil
+}
+
+func newScheme() (*runtime.Scheme, error) {
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("add cartographer to scheme: %w", err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("add core to scheme: %w", err)
+	}
+	return scheme, nil
+}
+
+// newClient returns a client of the cluster and the namespace to inspect
+func newClient() (client.WithWatch, string, error) {
+	config, ns, err := restConfig()
+	if err != nil {
+		return nil, "", err
+	}
+
+	scheme, err := newScheme()
+	if err != nil {
+		return nil, "", err
+	}
+
+	c, err := client.NewWithWatch(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, "", fmt.Errorf("create client: %w", err)
+	}
+
+	return c, ns, nil
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "path to the kubeconfig, defaults to KUBECONFIG or ~/.kube/config")
+	rootCmd.PersistentFlags().StringVar(&kubeContext, "context", "", "kubeconfig context to use")
+	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "", "namespace of the workload, defaults to the namespace of the kubeconfig context")
+
+	rootCmd.AddCommand(workloadCmd)
+}
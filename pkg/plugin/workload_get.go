@@ -0,0 +1,191 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+// previewLength is the length output previews are cut to
+const previewLength = 80
+
+var workloadGetCmd = &cobra.Command{
+	Use:   "get <name>",
+	Short: "show the supply chain of a workload and the resources it realized",
+	Long: `the get command shows the supply chain selected for a workload and, for each resource realized for it,
+the template stamped after option selection, the stamped object, its health and its outputs.`,
+	Example: "kubectl carto workload get my-app --namespace dev",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, ns, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		return PrintWorkload(cmd.Context(), c, ns, args[0], os.Stdout)
+	},
+}
+
+func getWorkload(ctx context.Context, reader client.Reader, namespace, name string) (*v1alpha1.Workload, error) {
+	workload := &v1alpha1.Workload{}
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, workload); err != nil {
+		return nil, fmt.Errorf("get workload [%s/%s]: %w", namespace, name, err)
+	}
+	return workload, nil
+}
+
+// PrintWorkload writes the supply chain of the workload and the resources realized for it to out
+func PrintWorkload(ctx context.Context, reader client.Reader, namespace, name string, out io.Writer) error {
+	workload, err := getWorkload(ctx, reader, namespace, name)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintf(w, "Workload:\t%s/%s\n", workload.Namespace, workload.Name)
+
+	supplyChainRef := workload.Status.SupplyChainRef
+	if supplyChainRef.Name != "" {
+		fmt.Fprintf(w, "Supply chain:\t%s/%s\n", supplyChainRef.Kind, supplyChainRef.Name)
+	} else {
+		fmt.Fprintf(w, "Supply chain:\t<none>\n")
+	}
+
+	if ready := meta.FindStatusCondition(workload.Status.Conditions, v1alpha1.OwnerReady); ready != nil {
+		fmt.Fprintf(w, "Ready:\t%s\n", conditionSummary(ready))
+	} else {
+		fmt.Fprintf(w, "Ready:\tUnknown\n")
+	}
+
+	if workload.Status.ObservedGeneration != workload.Generation {
+		fmt.Fprintf(w, "Stale:\tstatus is of generation %d, the workload is at generation %d\n", workload.Status.ObservedGeneration, workload.Generation)
+	}
+
+	if len(workload.Status.Resources) == 0 {
+		fmt.Fprintf(w, "\nNo resources realized\n")
+		return w.Flush()
+	}
+
+	options := resourceOptionCounts(ctx, reader, supplyChainRef)
+
+	fmt.Fprintf(w, "\nRESOURCE\tTEMPLATE\tSTAMPED\tHEALTHY\n")
+	for _, resource := range workload.Status.Resources {
+		template := "-"
+		if resource.TemplateRef != nil {
+			template = fmt.Sprintf("%s/%s", resource.TemplateRef.Kind, resource.TemplateRef.Name)
+			if resource.TemplateRevision != 0 {
+				template = fmt.Sprintf("%s@%d", template, resource.TemplateRevision)
+			}
+		}
+		if count := options[resource.Name]; count > 0 {
+			template = fmt.Sprintf("%s (selected from %d options)", template, count)
+		}
+
+		health := "Unknown"
+		if healthy := meta.FindStatusCondition(resource.Conditions, v1alpha1.ResourceHealthy); healthy != nil {
+			health = string(healthy.Status)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", resource.Name, template, stampedRef(resource.StampedRef), health)
+	}
+
+	var hasOutputs bool
+	for _, resource := range workload.Status.Resources {
+		for _, output := range resource.Outputs {
+			if !hasOutputs {
+				fmt.Fprintf(w, "\nRESOURCE\tOUTPUT\tPREVIEW\n")
+				hasOutputs = true
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", resource.Name, output.Name, preview(output.Preview))
+		}
+	}
+
+	return w.Flush()
+}
+
+// resourceOptionCounts returns the number of template options of each resource of the supply chain
+// that have any. The supply chain is optional to the output: failing to get it returns no counts.
+func resourceOptionCounts(ctx context.Context, reader client.Reader, ref v1alpha1.ObjectReference) map[string]int {
+	var supplyChain v1alpha1.SupplyChainObject
+	switch ref.Kind {
+	case "ClusterSupplyChain":
+		supplyChain = &v1alpha1.ClusterSupplyChain{}
+	case "SupplyChain":
+		supplyChain = &v1alpha1.SupplyChain{}
+	default:
+		return nil
+	}
+
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, supplyChain); err != nil {
+		return nil
+	}
+
+	counts := map[string]int{}
+	for _, resource := range supplyChain.GetSupplyChainSpec().Resources {
+		if len(resource.TemplateRef.Options) > 0 {
+			counts[resource.Name] = len(resource.TemplateRef.Options)
+		}
+	}
+	return counts
+}
+
+func stampedRef(ref *v1alpha1.StampedRef) string {
+	if ref == nil || ref.ObjectReference == nil {
+		return "-"
+	}
+
+	kind := ref.Resource
+	if kind == "" {
+		kind = ref.Kind
+	}
+	return fmt.Sprintf("%s/%s", kind, ref.Name)
+}
+
+func conditionSummary(condition *metav1.Condition) string {
+	summary := string(condition.Status)
+	if condition.Reason != "" {
+		summary = fmt.Sprintf("%s %s", summary, condition.Reason)
+	}
+	if condition.Message != "" {
+		summary = fmt.Sprintf("%s: %s", summary, condition.Message)
+	}
+	return summary
+}
+
+// preview flattens an output preview to a single line, cut to previewLength
+func preview(value string) string {
+	flattened := []rune(strings.Join(strings.Fields(value), " "))
+	if len(flattened) > previewLength {
+		return string(flattened[:previewLength-3]) + "..."
+	}
+	return string(flattened)
+}
+
+func init() {
+	workloadCmd.AddCommand(workloadGetCmd)
+}
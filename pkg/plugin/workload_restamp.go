@@ -0,0 +1,129 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+var (
+	restampWait        bool
+	restampWaitTimeout time.Duration
+)
+
+var workloadRestampCmd = &cobra.Command{
+	Use:   "restamp <name>",
+	Short: "request that a workload is realized again",
+	Long: fmt.Sprintf(`the restamp command sets the %s annotation of a workload to the current time,
+which causes Cartographer to reconcile the workload and realize its supply chain again. Every
+stamped object is applied again, skipping the cache of the objects Cartographer last applied,
+even when it is unchanged.
+
+Once the resources are realized, Cartographer records the time in the status.lastRestamp field of
+the workload. With --wait, the command waits until then.`, v1alpha1.RestampAnnotation),
+	Example: `kubectl carto workload restamp my-app --namespace dev
+kubectl carto workload restamp my-app --namespace dev --wait --wait-timeout 2m`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, ns, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := RestampWorkload(cmd.Context(), c, ns, args[0], now, os.Stdout); err != nil {
+			return err
+		}
+		if !restampWait {
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), restampWaitTimeout)
+		defer cancel()
+		return WaitForRestamp(ctx, c, ns, args[0], now, time.Second, os.Stdout)
+	},
+}
+
+// RestampWorkload patches the restamp annotation of the workload to the time now
+func RestampWorkload(ctx context.Context, c client.Client, namespace, name string, now time.Time, out io.Writer) error {
+	workload, err := getWorkload(ctx, c, namespace, name)
+	if err != nil {
+		return err
+	}
+
+	token := restampToken(now)
+
+	patch := client.MergeFrom(workload.DeepCopy())
+	annotations := workload.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[v1alpha1.RestampAnnotation] = token
+	workload.SetAnnotations(annotations)
+
+	if err := c.Patch(ctx, workload, patch); err != nil {
+		return fmt.Errorf("patch workload [%s/%s]: %w", namespace, name, err)
+	}
+
+	fmt.Fprintf(out, "workload %s/%s restamped at %s\n", namespace, name, token)
+	return nil
+}
+
+// WaitForRestamp polls the workload every interval until Cartographer records that it realized
+// the resources of the workload for the restamp requested at the time now, or ctx is done
+func WaitForRestamp(ctx context.Context, reader client.Reader, namespace, name string, now time.Time, interval time.Duration, out io.Writer) error {
+	token := restampToken(now)
+
+	err := wait.PollImmediateUntilWithContext(ctx, interval, func(ctx context.Context) (bool, error) {
+		workload, err := getWorkload(ctx, reader, namespace, name)
+		if err != nil {
+			return false, err
+		}
+		return workload.Status.LastRestamp == token, nil
+	})
+	if errors.Is(err, wait.ErrWaitTimeout) {
+		return fmt.Errorf("workload [%s/%s] was not realized for restamp [%s] in time", namespace, name, token)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "workload %s/%s realized for restamp at %s\n", namespace, name, token)
+	return nil
+}
+
+// restampToken is the time of the restamp to the nanosecond, so that restamps made within a second
+// of each other are told apart
+func restampToken(now time.Time) string {
+	return now.UTC().Format(time.RFC3339Nano)
+}
+
+func init() {
+	workloadRestampCmd.Flags().BoolVar(&restampWait, "wait", false, "wait until the resources of the workload are realized for the restamp")
+	workloadRestampCmd.Flags().DurationVar(&restampWaitTimeout, "wait-timeout", 5*time.Minute, "how long to wait with --wait before failing")
+
+	workloadCmd.AddCommand(workloadRestampCmd)
+}
@@ -0,0 +1,232 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+var workloadTailCmd = &cobra.Command{
+	Use:   "tail <name>",
+	Short: "follow the events and condition changes of a workload",
+	Long: `the tail command prints the events recorded for a workload and every change to its conditions,
+and to the conditions of the resources realized for it, until interrupted.`,
+	Example: "kubectl carto workload tail my-app --namespace dev",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, ns, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+
+		return TailWorkload(ctx, c, ns, args[0], os.Stdout)
+	},
+}
+
+// watchReopenBackoff spaces out opening again the watches the server keeps closing
+var watchReopenBackoff = wait.Backoff{Duration: 100 * time.Millisecond, Factor: 2, Cap: 30 * time.Second, Steps: math.MaxInt32}
+
+// TailWorkload prints the events and condition changes of the workload to out until ctx is done.
+// Watches closed by the server are opened again, backing off while they keep closing.
+func TailWorkload(ctx context.Context, c client.WithWatch, namespace, name string, out io.Writer) error {
+	eventSelector := fields.SelectorFromSet(fields.Set{
+		"involvedObject.kind": "Workload",
+		"involvedObject.name": name,
+	})
+	workloadSelector := fields.OneTermEqualSelector("metadata.name", name)
+
+	var eventWatch, workloadWatch watch.Interface
+	defer func() {
+		if eventWatch != nil {
+			eventWatch.Stop()
+		}
+		if workloadWatch != nil {
+			workloadWatch.Stop()
+		}
+	}()
+
+	seenEvents := map[types.UID]int32{}
+	var previous *v1alpha1.Workload
+	backoff := watchReopenBackoff
+
+	for {
+		var err error
+		if eventWatch == nil {
+			eventWatch, err = c.Watch(ctx, &corev1.EventList{}, client.InNamespace(namespace), client.MatchingFieldsSelector{Selector: eventSelector})
+			if err != nil {
+				return fmt.Errorf("watch events of workload [%s/%s]: %w", namespace, name, err)
+			}
+		}
+		if workloadWatch == nil {
+			workloadWatch, err = c.Watch(ctx, &v1alpha1.WorkloadList{}, client.InNamespace(namespace), client.MatchingFieldsSelector{Selector: workloadSelector})
+			if err != nil {
+				return fmt.Errorf("watch workload [%s/%s]: %w", namespace, name, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case result, ok := <-eventWatch.ResultChan():
+			if !ok {
+				eventWatch = nil
+				if !waitToReopen(ctx, &backoff) {
+					return nil
+				}
+				continue
+			}
+			backoff = watchReopenBackoff
+			event, isEvent := result.Object.(*corev1.Event)
+			if !isEvent || result.Type == watch.Deleted {
+				continue
+			}
+			if count, seen := seenEvents[event.UID]; seen && count == event.Count {
+				continue
+			}
+			seenEvents[event.UID] = event.Count
+			fmt.Fprintln(out, FormatEvent(event))
+
+		case result, ok := <-workloadWatch.ResultChan():
+			if !ok {
+				workloadWatch = nil
+				if !waitToReopen(ctx, &backoff) {
+					return nil
+				}
+				continue
+			}
+			backoff = watchReopenBackoff
+			workload, isWorkload := result.Object.(*v1alpha1.Workload)
+			if !isWorkload {
+				continue
+			}
+			if result.Type == watch.Deleted {
+				fmt.Fprintf(out, "%s workload deleted\n", timestamp(time.Now()))
+				return nil
+			}
+			for _, change := range ConditionChanges(previous, workload) {
+				fmt.Fprintf(out, "%s %s\n", timestamp(time.Now()), change)
+			}
+			previous = workload
+		}
+	}
+}
+
+// waitToReopen waits for the next step of the backoff before a closed watch is opened again.
+// It returns false when ctx is done first.
+func waitToReopen(ctx context.Context, backoff *wait.Backoff) bool {
+	timer := time.NewTimer(backoff.Step())
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// FormatEvent returns a line describing the event, as of its last occurrence
+func FormatEvent(event *corev1.Event) string {
+	when := event.LastTimestamp.Time
+	if when.IsZero() {
+		when = event.EventTime.Time
+	}
+	if when.IsZero() {
+		when = event.CreationTimestamp.Time
+	}
+
+	line := fmt.Sprintf("%s %s %s: %s", timestamp(when), event.Type, event.Reason, event.Message)
+	if event.Count > 1 {
+		line = fmt.Sprintf("%s (x%d)", line, event.Count)
+	}
+	return line
+}
+
+// ConditionChanges returns a line for every condition of the workload, or of one of its resources,
+// whose status, reason or message differs from the previous workload. With no previous workload
+// every condition is reported.
+func ConditionChanges(previous, current *v1alpha1.Workload) []string {
+	var changes []string
+
+	var previousConditions []metav1.Condition
+	if previous != nil {
+		previousConditions = previous.Status.Conditions
+	}
+	changes = append(changes, diffConditions("workload", previousConditions, current.Status.Conditions)...)
+
+	previousResources := map[string][]metav1.Condition{}
+	if previous != nil {
+		for _, resource := range previous.Status.Resources {
+			previousResources[resource.Name] = resource.Conditions
+		}
+	}
+	for _, resource := range current.Status.Resources {
+		changes = append(changes, diffConditions(fmt.Sprintf("resource [%s]", resource.Name), previousResources[resource.Name], resource.Conditions)...)
+	}
+
+	return changes
+}
+
+func diffConditions(subject string, previous, current []metav1.Condition) []string {
+	previousByType := map[string]metav1.Condition{}
+	for _, condition := range previous {
+		previousByType[condition.Type] = condition
+	}
+
+	var changes []string
+	for _, condition := range current {
+		before, found := previousByType[condition.Type]
+		if found && before.Status == condition.Status && before.Reason == condition.Reason && before.Message == condition.Message {
+			continue
+		}
+
+		change := fmt.Sprintf("%s %s: %s", subject, condition.Type, conditionSummary(&condition))
+		if found && before.Status != condition.Status {
+			change = fmt.Sprintf("%s %s: %s -> %s", subject, condition.Type, before.Status, conditionSummary(&condition))
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+func timestamp(t time.Time) string {
+	return t.Local().Format(time.RFC3339)
+}
+
+func init() {
+	workloadCmd.AddCommand(workloadTailCmd)
+}
@@ -0,0 +1,295 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin_test
+
+import (
+	"bytes"
+	"context"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/plugin"
+)
+
+var _ = Describe("Workload", func() {
+	var (
+		ctx      context.Context
+		out      *bytes.Buffer
+		workload *v1alpha1.Workload
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		out = &bytes.Buffer{}
+
+		workload = &v1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "dev", Name: "my-app", UID: "workload-uid", Generation: 2},
+			Status: v1alpha1.WorkloadStatus{
+				OwnerStatus: v1alpha1.OwnerStatus{
+					ObservedGeneration: 2,
+					Conditions: []metav1.Condition{
+						{Type: v1alpha1.OwnerReady, Status: metav1.ConditionFalse, Reason: "MissingValueAtPath", Message: "waiting to read value"},
+					},
+				},
+				SupplyChainRef: v1alpha1.ObjectReference{Kind: "ClusterSupplyChain", Name: "source-to-url"},
+				Resources: []v1alpha1.ResourceStatus{
+					{
+						RealizedResource: v1alpha1.RealizedResource{
+							Name: "source-provider",
+							StampedRef: &v1alpha1.StampedRef{
+								ObjectReference: &corev1.ObjectReference{APIVersion: "source.toolkit.fluxcd.io/v1beta2", Kind: "GitRepository", Namespace: "dev", Name: "my-app"},
+								Resource:        "gitrepositories.source.toolkit.fluxcd.io",
+							},
+							TemplateRef: &corev1.ObjectReference{Kind: "ClusterSourceTemplate", Name: "git"},
+							Outputs:     []v1alpha1.Output{{Name: "url", Preview: "http://source-controller/my-app.tar.gz\n"}},
+						},
+						Conditions: []metav1.Condition{
+							{Type: v1alpha1.ResourceHealthy, Status: metav1.ConditionTrue, Reason: "OutputsAvailable"},
+						},
+					},
+					{
+						RealizedResource: v1alpha1.RealizedResource{
+							Name: "image-builder",
+							StampedRef: &v1alpha1.StampedRef{
+								ObjectReference: &corev1.ObjectReference{APIVersion: "kpack.io/v1alpha2", Kind: "Image", Namespace: "dev", Name: "my-app"},
+							},
+							TemplateRef: &corev1.ObjectReference{Kind: "ClusterImageTemplate", Name: "kpack"},
+						},
+						Conditions: []metav1.Condition{
+							{Type: v1alpha1.ResourceHealthy, Status: metav1.ConditionFalse, Reason: "OutputsNotAvailable"},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	newClient := func(objects ...client.Object) client.WithWatch {
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	}
+
+	Describe("PrintWorkload", func() {
+		It("prints the supply chain, the realized resources and their outputs", func() {
+			supplyChain := &v1alpha1.ClusterSupplyChain{
+				ObjectMeta: metav1.ObjectMeta{Name: "source-to-url"},
+				Spec: v1alpha1.SupplyChainSpec{
+					Resources: []v1alpha1.SupplyChainResource{
+						{Name: "source-provider", TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterSourceTemplate", Name: "git"}},
+						{Name: "image-builder", TemplateRef: v1alpha1.SupplyChainTemplateReference{
+							Kind: "ClusterImageTemplate",
+							Options: []v1alpha1.TemplateOption{
+								{Name: "kpack", Selector: v1alpha1.Selector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"builder": "kpack"}}}},
+								{Name: "kaniko", Selector: v1alpha1.Selector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"builder": "kaniko"}}}},
+							},
+						}},
+					},
+				},
+			}
+
+			Expect(plugin.PrintWorkload(ctx, newClient(workload, supplyChain), "dev", "my-app", out)).To(Succeed())
+
+			output := out.String()
+			Expect(output).To(ContainSubstring("Workload:       dev/my-app"))
+			Expect(output).To(ContainSubstring("Supply chain:   ClusterSupplyChain/source-to-url"))
+			Expect(output).To(ContainSubstring("Ready:          False MissingValueAtPath: waiting to read value"))
+			Expect(output).To(MatchRegexp(`source-provider +ClusterSourceTemplate/git +gitrepositories.source.toolkit.fluxcd.io/my-app +True`))
+			Expect(output).To(MatchRegexp(`image-builder +ClusterImageTemplate/kpack \(selected from 2 options\) +Image/my-app +False`))
+			Expect(output).To(MatchRegexp(`source-provider +url +http://source-controller/my-app.tar.gz\n`))
+			Expect(output).NotTo(ContainSubstring("Stale"))
+		})
+
+		It("prints the resources when the supply chain cannot be read", func() {
+			Expect(plugin.PrintWorkload(ctx, newClient(workload), "dev", "my-app", out)).To(Succeed())
+
+			Expect(out.String()).To(MatchRegexp(`image-builder +ClusterImageTemplate/kpack +Image/my-app +False`))
+		})
+
+		It("reports a stale status", func() {
+			workload.Generation = 3
+
+			Expect(plugin.PrintWorkload(ctx, newClient(workload), "dev", "my-app", out)).To(Succeed())
+
+			Expect(out.String()).To(ContainSubstring("status is of generation 2, the workload is at generation 3"))
+		})
+
+		It("errors when the workload does not exist", func() {
+			err := plugin.PrintWorkload(ctx, newClient(), "dev", "my-app", out)
+
+			Expect(err).To(MatchError(ContainSubstring("get workload [dev/my-app]")))
+		})
+	})
+
+	Describe("PrintTree", func() {
+		object := func(apiVersion, kind, name, uid, ownerUID string) metav1.PartialObjectMetadata {
+			return metav1.PartialObjectMetadata{
+				TypeMeta: metav1.TypeMeta{APIVersion: apiVersion, Kind: kind},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       "dev",
+					Name:            name,
+					UID:             types.UID(uid),
+					OwnerReferences: []metav1.OwnerReference{{UID: types.UID(ownerUID)}},
+				},
+			}
+		}
+
+		It("nests the stamped objects and the objects they own under the workload", func() {
+			objects := []metav1.PartialObjectMetadata{
+				object("kpack.io/v1alpha2", "Build", "my-app-build-1", "build-uid", "image-uid"),
+				object("v1", "Pod", "my-app-build-1-pod", "pod-uid", "build-uid"),
+				object("kpack.io/v1alpha2", "Image", "my-app", "image-uid", "workload-uid"),
+				object("source.toolkit.fluxcd.io/v1beta2", "GitRepository", "my-app", "git-uid", "workload-uid"),
+				object("v1", "ConfigMap", "unrelated", "unrelated-uid", "other-uid"),
+			}
+
+			plugin.PrintTree(workload, objects, out)
+
+			Expect(out.String()).To(Equal(`Workload/my-app
+├─ [source-provider] GitRepository.source.toolkit.fluxcd.io/my-app
+└─ [image-builder] Image.kpack.io/my-app
+   └─ Build.kpack.io/my-app-build-1
+      └─ Pod/my-app-build-1-pod
+`))
+		})
+	})
+
+	Describe("RestampWorkload", func() {
+		It("sets the restamp annotation to the time", func() {
+			c := newClient(workload)
+			now := time.Date(2026, 10, 19, 12, 30, 0, 0, time.UTC)
+
+			Expect(plugin.RestampWorkload(ctx, c, "dev", "my-app", now, out)).To(Succeed())
+
+			restamped := &v1alpha1.Workload{}
+			Expect(c.Get(ctx, client.ObjectKey{Namespace: "dev", Name: "my-app"}, restamped)).To(Succeed())
+			Expect(restamped.Annotations).To(HaveKeyWithValue(v1alpha1.RestampAnnotation, "2026-10-19T12:30:00Z"))
+			Expect(out.String()).To(Equal("workload dev/my-app restamped at 2026-10-19T12:30:00Z\n"))
+		})
+
+		It("tells apart restamps made within the same second", func() {
+			c := newClient(workload)
+			now := time.Date(2026, 10, 19, 12, 30, 0, 500000000, time.UTC)
+
+			Expect(plugin.RestampWorkload(ctx, c, "dev", "my-app", now, out)).To(Succeed())
+
+			restamped := &v1alpha1.Workload{}
+			Expect(c.Get(ctx, client.ObjectKey{Namespace: "dev", Name: "my-app"}, restamped)).To(Succeed())
+			Expect(restamped.Annotations).To(HaveKeyWithValue(v1alpha1.RestampAnnotation, "2026-10-19T12:30:00.5Z"))
+		})
+	})
+
+	Describe("WaitForRestamp", func() {
+		var now time.Time
+
+		BeforeEach(func() {
+			now = time.Date(2026, 10, 19, 12, 30, 0, 0, time.UTC)
+		})
+
+		It("returns once the workload records the restamp", func() {
+			workload.Status.LastRestamp = "2026-10-19T12:30:00Z"
+			c := newClient(workload)
+
+			Expect(plugin.WaitForRestamp(ctx, c, "dev", "my-app", now, time.Millisecond, out)).To(Succeed())
+			Expect(out.String()).To(Equal("workload dev/my-app realized for restamp at 2026-10-19T12:30:00Z\n"))
+		})
+
+		It("fails when the workload does not record the restamp in time", func() {
+			workload.Status.LastRestamp = "2026-10-19T12:00:00Z"
+			c := newClient(workload)
+
+			timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer cancel()
+
+			err := plugin.WaitForRestamp(timeoutCtx, c, "dev", "my-app", now, time.Millisecond, out)
+			Expect(err).To(MatchError("workload [dev/my-app] was not realized for restamp [2026-10-19T12:30:00Z] in time"))
+			Expect(out.String()).To(BeEmpty())
+		})
+	})
+
+	Describe("TailWorkload", func() {
+		It("backs off opening again the watches the server keeps closing", func() {
+			c := &closingWatchClient{WithWatch: newClient(workload)}
+
+			timeoutCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+			defer cancel()
+
+			Expect(plugin.TailWorkload(timeoutCtx, c, "dev", "my-app", out)).To(Succeed())
+			Expect(atomic.LoadInt32(&c.watches)).To(BeNumerically("<=", 6))
+		})
+	})
+
+	Describe("FormatEvent", func() {
+		It("describes the event", func() {
+			event := &corev1.Event{
+				Type:          corev1.EventTypeNormal,
+				Reason:        "StampedObjectApplied",
+				Message:       "Created object [images.kpack.io/my-app]",
+				LastTimestamp: metav1.NewTime(time.Date(2026, 10, 19, 12, 30, 0, 0, time.UTC)),
+				Count:         3,
+			}
+
+			Expect(plugin.FormatEvent(event)).To(HaveSuffix(" Normal StampedObjectApplied: Created object [images.kpack.io/my-app] (x3)"))
+		})
+	})
+
+	Describe("ConditionChanges", func() {
+		It("reports every condition of a workload not seen before", func() {
+			Expect(plugin.ConditionChanges(nil, workload)).To(Equal([]string{
+				"workload Ready: False MissingValueAtPath: waiting to read value",
+				"resource [source-provider] Healthy: True OutputsAvailable",
+				"resource [image-builder] Healthy: False OutputsNotAvailable",
+			}))
+		})
+
+		It("reports only the conditions that changed", func() {
+			current := workload.DeepCopy()
+			current.Status.Conditions[0] = metav1.Condition{Type: v1alpha1.OwnerReady, Status: metav1.ConditionTrue, Reason: "Ready"}
+			current.Status.Resources[1].Conditions[0] = metav1.Condition{Type: v1alpha1.ResourceHealthy, Status: metav1.ConditionTrue, Reason: "OutputsAvailable"}
+
+			Expect(plugin.ConditionChanges(workload, current)).To(Equal([]string{
+				"workload Ready: False -> True Ready",
+				"resource [image-builder] Healthy: False -> True OutputsAvailable",
+			}))
+		})
+
+		It("reports nothing when the conditions are unchanged", func() {
+			Expect(plugin.ConditionChanges(workload, workload.DeepCopy())).To(BeEmpty())
+		})
+	})
+})
+
+// closingWatchClient opens watches that are closed straight away, as by a server ending them
+type closingWatchClient struct {
+	client.WithWatch
+	watches int32
+}
+
+func (c *closingWatchClient) Watch(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+	atomic.AddInt32(&c.watches, 1)
+	return watch.NewEmptyWatch(), nil
+}
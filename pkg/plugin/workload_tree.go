@@ -0,0 +1,203 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/rest"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+var workloadTreeCmd = &cobra.Command{
+	Use:   "tree <name>",
+	Short: "show the objects stamped for a workload and the objects they own",
+	Long: `the tree command walks from a workload to the objects stamped for each of its resources, and from
+those to every object in the namespace they own, directly or transitively.`,
+	Example: "kubectl carto workload tree my-app --namespace dev",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, ns, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		workload, err := getWorkload(cmd.Context(), c, ns, args[0])
+		if err != nil {
+			return err
+		}
+
+		config, _, err := restConfig()
+		if err != nil {
+			return err
+		}
+
+		objects, err := listNamespacedObjects(cmd.Context(), config, ns)
+		if err != nil {
+			return err
+		}
+
+		PrintTree(workload, objects, os.Stdout)
+		return nil
+	},
+}
+
+// treeNode is an object in the tree of a workload
+type treeNode struct {
+	object   metav1.PartialObjectMetadata
+	resource string
+	children []*treeNode
+}
+
+// buildTree nests objects under the workload, and under each other, by their owner references
+func buildTree(workload *v1alpha1.Workload, objects []metav1.PartialObjectMetadata) []*treeNode {
+	owned := map[types.UID][]metav1.PartialObjectMetadata{}
+	for _, object := range objects {
+		for _, owner := range object.OwnerReferences {
+			owned[owner.UID] = append(owned[owner.UID], object)
+		}
+	}
+
+	visited := map[types.UID]bool{workload.UID: true}
+	var build func(uid types.UID) []*treeNode
+	build = func(uid types.UID) []*treeNode {
+		var nodes []*treeNode
+		for _, object := range owned[uid] {
+			if visited[object.UID] {
+				continue
+			}
+			visited[object.UID] = true
+			nodes = append(nodes, &treeNode{object: object})
+		}
+		sort.SliceStable(nodes, func(i, j int) bool {
+			return objectName(nodes[i].object) < objectName(nodes[j].object)
+		})
+		for _, node := range nodes {
+			node.children = build(node.object.UID)
+		}
+		return nodes
+	}
+
+	roots := build(workload.UID)
+	for _, root := range roots {
+		root.resource = stampingResource(workload, root.object)
+	}
+	return roots
+}
+
+// stampingResource returns the name of the workload resource that stamped the object, if any
+func stampingResource(workload *v1alpha1.Workload, object metav1.PartialObjectMetadata) string {
+	for _, resource := range workload.Status.Resources {
+		ref := resource.StampedRef
+		if ref == nil || ref.ObjectReference == nil {
+			continue
+		}
+		if ref.Kind == object.Kind && ref.APIVersion == object.APIVersion && ref.Name == object.Name {
+			return resource.Name
+		}
+	}
+	return ""
+}
+
+// PrintTree writes the tree of the workload to out
+func PrintTree(workload *v1alpha1.Workload, objects []metav1.PartialObjectMetadata, out io.Writer) {
+	fmt.Fprintf(out, "Workload/%s\n", workload.Name)
+
+	var printNodes func(nodes []*treeNode, prefix string)
+	printNodes = func(nodes []*treeNode, prefix string) {
+		for i, node := range nodes {
+			branch, indent := "├─ ", "│  "
+			if i == len(nodes)-1 {
+				branch, indent = "└─ ", "   "
+			}
+
+			line := objectName(node.object)
+			if node.resource != "" {
+				line = fmt.Sprintf("[%s] %s", node.resource, line)
+			}
+			fmt.Fprintf(out, "%s%s%s\n", prefix, branch, line)
+
+			printNodes(node.children, prefix+indent)
+		}
+	}
+
+	printNodes(buildTree(workload, objects), "")
+}
+
+func objectName(object metav1.PartialObjectMetadata) string {
+	gv, _ := schema.ParseGroupVersion(object.APIVersion)
+	if gv.Group == "" {
+		return fmt.Sprintf("%s/%s", object.Kind, object.Name)
+	}
+	return fmt.Sprintf("%s.%s/%s", object.Kind, gv.Group, object.Name)
+}
+
+// listNamespacedObjects lists the metadata of every object in the namespace of a listable kind.
+// Kinds that cannot be discovered or listed, for example for lack of permission, are skipped.
+func listNamespacedObjects(ctx context.Context, config *rest.Config, namespace string) ([]metav1.PartialObjectMetadata, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("create discovery client: %w", err)
+	}
+
+	resourceLists, err := discovery.ServerPreferredNamespacedResources(discoveryClient)
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, fmt.Errorf("discover resources: %w", err)
+	}
+	resourceLists = discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: []string{"list"}}, resourceLists)
+
+	metadataClient, err := metadata.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("create metadata client: %w", err)
+	}
+
+	var objects []metav1.PartialObjectMetadata
+	for _, resourceList := range resourceLists {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			continue
+		}
+
+		for _, resource := range resourceList.APIResources {
+			list, err := metadataClient.Resource(gv.WithResource(resource.Name)).Namespace(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				continue
+			}
+
+			for _, item := range list.Items {
+				item.APIVersion = resourceList.GroupVersion
+				item.Kind = resource.Kind
+				objects = append(objects, item)
+			}
+		}
+	}
+
+	return objects, nil
+}
+
+func init() {
+	workloadCmd.AddCommand(workloadTreeCmd)
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -29,6 +30,7 @@ import (
 	realizerclient "github.com/vmware-tanzu/cartographer/pkg/realizer/client"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/healthcheck"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/runnable/gc"
+	"github.com/vmware-tanzu/cartographer/pkg/redact"
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/selector"
 	"github.com/vmware-tanzu/cartographer/pkg/stamp"
@@ -39,6 +41,7 @@ import (
 //go:generate go run -modfile ../../hack/tools/go.mod github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 type ContextGenerator interface {
+	ResolveOwnerParams(ctx context.Context, getter ParamValueGetter) error
 	Generate(templateParams TemplateParams, resource OwnerResource, outputs OutputsGetter, labels templates.Labels) map[string]interface{}
 }
 
@@ -102,7 +105,7 @@ func (r *resourceRealizer) Do(ctx context.Context, resource OwnerResource, bluep
 
 	log.V(logger.DEBUG).Info("realizing template", "template", fmt.Sprintf("[%s/%s]", resource.TemplateRef.Kind, templateName))
 
-	apiTemplate, err = r.systemRepo.GetTemplate(ctx, templateName, resource.TemplateRef.Kind)
+	apiTemplate, err = r.systemRepo.GetTemplate(ctx, templateName, resource.TemplateRef.Kind, r.owner.GetNamespace())
 	if err != nil {
 		log.Error(err, "failed to get cluster template")
 		return nil, nil, nil, passThrough, templateName, errors.GetTemplateError{
@@ -114,17 +117,59 @@ func (r *resourceRealizer) Do(ctx context.Context, resource OwnerResource, bluep
 		}
 	}
 
+	var revision int64
+	apiTemplate, revision, err = r.templateRevision(ctx, apiTemplate, resource.TemplateRef.Kind, templateName, resource.TemplateRevision)
+	if err != nil {
+		log.Error(err, "failed to get template revision")
+		return nil, nil, nil, passThrough, templateName, errors.GetTemplateError{
+			Err:           err,
+			ResourceName:  resource.Name,
+			TemplateName:  templateName,
+			BlueprintName: blueprintName,
+			BlueprintType: errors.SupplyChain,
+		}
+	}
+
 	template, err = templates.NewReaderFromAPI(apiTemplate)
 	if err != nil {
 		log.Error(err, "failed to get cluster template")
 		return nil, nil, nil, passThrough, templateName, fmt.Errorf("failed to get cluster template [%+v]: %w", resource.TemplateRef, err)
 	}
+	template = templates.WithRevision(template, revision)
+
+	if err := r.templatingContext.ResolveOwnerParams(ctx, r.ownerRepo); err != nil {
+		log.Error(err, "failed to resolve params")
+		return template, nil, nil, passThrough, templateName, errors.ResolveParamsError{
+			Err:           err,
+			ResourceName:  resource.Name,
+			BlueprintName: blueprintName,
+			BlueprintType: errors.SupplyChain,
+		}
+	}
 
 	labels := r.resourceLabeler(resource, template)
 
-	stamper := templates.StamperBuilder(r.owner, r.templatingContext.Generate(template, resource, outputs, labels), labels)
+	templatingContext := r.templatingContext.Generate(template, resource, outputs, labels)
+
+	if params, ok := templatingContext["params"].(map[string]apiextensionsv1.JSON); ok {
+		if err := template.GetDefaultParams().ValidateValues(params); err != nil {
+			err = redact.FromContext(ctx).Error(err)
+			log.Error(err, "params do not conform to the template's param schemas")
+			return template, nil, nil, passThrough, templateName, errors.ParamValidationError{
+				Err:           err,
+				TemplateName:  templateName,
+				TemplateKind:  resource.TemplateRef.Kind,
+				ResourceName:  resource.Name,
+				BlueprintName: blueprintName,
+				BlueprintType: errors.SupplyChain,
+			}
+		}
+	}
+
+	stamper := templates.StamperBuilder(r.owner, templatingContext, labels)
 	stampedObject, err = stamper.Stamp(ctx, template.GetResourceTemplate())
 	if err != nil {
+		err = redact.FromContext(ctx).Error(err)
 		log.Error(err, "failed to stamp resource")
 		return template, nil, nil, passThrough, templateName, errors.StampError{
 			Err:           err,
@@ -150,6 +195,79 @@ func (r *resourceRealizer) Do(ctx context.Context, resource OwnerResource, bluep
 	}
 }
 
+// templateRevision returns apiTemplate as the owner stamps it, along with its revision
+// when known: with the spec of the revision the resource pins, or else with its current
+// spec once the owner adopted the latest revision, and with the spec of its stable
+// revision while the latest revision is being rolled out to other owners. A pinned
+// revision is stamped even when the template of the given kind and name was deleted.
+func (r *resourceRealizer) templateRevision(ctx context.Context, apiTemplate client.Object, kind, name string, pinned int64) (client.Object, int64, error) {
+	if apiTemplate == nil && pinned != 0 {
+		templateRevision, err := r.systemRepo.GetRevisionOfTemplate(ctx, name, kind, r.owner.GetNamespace(), pinned)
+		if err != nil {
+			return nil, 0, err
+		}
+		if templateRevision == nil {
+			return nil, 0, fmt.Errorf("pinned revision [%d] of template [%s/%s] not found", pinned, kind, name)
+		}
+
+		logr.FromContextOrDiscard(ctx).V(logger.DEBUG).Info("stamping revision of deleted template", "revision", templateRevision.Name)
+
+		restored, err := templateRevision.Template()
+		if err != nil {
+			return nil, 0, err
+		}
+		return restored, pinned, nil
+	}
+
+	template, ok := apiTemplate.(v1alpha1.TemplateObject)
+	if !ok {
+		return apiTemplate, 0, nil
+	}
+
+	status := template.GetTemplateStatus()
+	revision := pinned
+	if revision == 0 {
+		if v1alpha1.AdoptsLatestRevision(template, r.owner) {
+			if status.Rollout == nil || status.ObservedGeneration != template.GetGeneration() {
+				return apiTemplate, 0, nil
+			}
+			return apiTemplate, status.Rollout.LatestRevision, nil
+		}
+		revision = status.Rollout.StableRevision
+	}
+
+	gvk, err := utils.GetObjectGVK(template, r.systemRepo.GetScheme())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get object gvk for template [%s]: %w", template.GetName(), err)
+	}
+
+	ref := v1alpha1.TemplateRevisionReference{
+		Kind:      gvk.Kind,
+		Namespace: template.GetNamespace(),
+		Name:      template.GetName(),
+	}
+	revisionName := v1alpha1.TemplateRevisionName(ref, revision)
+
+	logr.FromContextOrDiscard(ctx).V(logger.DEBUG).Info("stamping revision of template", "revision", revisionName)
+
+	templateRevision, err := r.systemRepo.GetTemplateRevision(ctx, revisionName)
+	if err != nil {
+		return nil, 0, err
+	}
+	if templateRevision == nil {
+		if pinned != 0 {
+			return nil, 0, fmt.Errorf("pinned revision [%s] not found", revisionName)
+		}
+		return nil, 0, fmt.Errorf("stable revision [%s] not found", revisionName)
+	}
+
+	restored, err := templateRevision.Restore(template)
+	if err != nil {
+		return nil, 0, err
+	}
+	return restored, revision, nil
+}
+
 func GetTemplateNameFromResource(resource OwnerResource, blueprintName string, owner client.Object) (string, bool, v1alpha1.TemplateOption, error) {
 	var (
 		templateName   string
@@ -181,7 +299,8 @@ func (r *resourceRealizer) doImmutable(ctx context.Context, resource OwnerResour
 	err := r.ownerRepo.EnsureImmutableObjectExistsOnCluster(ctx, stampedObject, labels)
 
 	if err != nil {
-		log.Error(err, "failed to ensure object exists on cluster", "object", stampedObject)
+		err = redact.FromContext(ctx).Error(err)
+		log.Error(err, "failed to ensure object exists on cluster", "object", redact.FromContext(ctx).Object(stampedObject))
 		return template, nil, nil, passThrough, templateName, errors.ApplyStampedObjectError{
 			Err:           err,
 			StampedObject: stampedObject,
@@ -236,7 +355,7 @@ func (r *resourceRealizer) doImmutable(ctx context.Context, resource OwnerResour
 
 		qualifiedResource, rErr = utils.GetQualifiedResource(mapper, objectToReport)
 		if rErr != nil {
-			log.Error(err, "failed to retrieve qualified resource name", "object", objectToReport)
+			log.Error(err, "failed to retrieve qualified resource name", "object", redact.FromContext(ctx).Object(objectToReport))
 			qualifiedResource = "could not fetch - see the log line for 'failed to retrieve qualified resource name'"
 		}
 
@@ -261,7 +380,8 @@ func (r *resourceRealizer) doMutable(ctx context.Context, resource OwnerResource
 
 	err := r.ownerRepo.EnsureMutableObjectExistsOnCluster(ctx, stampedObject)
 	if err != nil {
-		log.Error(err, "failed to ensure object exists on cluster", "object", stampedObject)
+		err = redact.FromContext(ctx).Error(err)
+		log.Error(err, "failed to ensure object exists on cluster", "object", redact.FromContext(ctx).Object(stampedObject))
 		return template, nil, nil, passThrough, templateName, errors.ApplyStampedObjectError{
 			Err:           err,
 			StampedObject: stampedObject,
@@ -274,11 +394,11 @@ func (r *resourceRealizer) doMutable(ctx context.Context, resource OwnerResource
 	output, err := stampReader.Output(stampedObject)
 
 	if err != nil {
-		log.Error(err, "failed to retrieve output from object", "object", stampedObject)
+		log.Error(err, "failed to retrieve output from object", "object", redact.FromContext(ctx).Object(stampedObject))
 
 		qualifiedResource, rErr := utils.GetQualifiedResource(mapper, stampedObject)
 		if rErr != nil {
-			log.Error(err, "failed to retrieve qualified resource name", "object", stampedObject)
+			log.Error(err, "failed to retrieve qualified resource name", "object", redact.FromContext(ctx).Object(stampedObject))
 			qualifiedResource = "could not fetch - see the log line for 'failed to retrieve qualified resource name'"
 		}
 
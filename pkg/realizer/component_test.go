@@ -40,6 +40,7 @@ import (
 	cerrors "github.com/vmware-tanzu/cartographer/pkg/errors"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/realizerfakes"
+	"github.com/vmware-tanzu/cartographer/pkg/redact"
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/stamp"
@@ -249,6 +250,117 @@ var _ = Describe("Resource", func() {
 				})
 			})
 
+			When("the owner has not adopted the latest revision of the template", func() {
+				var revision *v1alpha1.ClusterTemplateRevision
+
+				BeforeEach(func() {
+					stable := templateAPI.DeepCopy()
+					stable.Spec.URLPath = "data.some_other_info"
+					var err error
+					revision, err = v1alpha1.NewTemplateRevision(v1alpha1.TemplateRevisionReference{
+						Kind:      "ClusterSourceTemplate",
+						Namespace: "some-namespace",
+						Name:      "source-template-1",
+					}, 1, stable)
+					Expect(err).NotTo(HaveOccurred())
+
+					templateAPI.Spec.Rollout = &v1alpha1.Rollout{}
+					templateAPI.Status.Rollout = &v1alpha1.RolloutStatus{LatestRevision: 2, StableRevision: 1}
+
+					scheme := runtime.NewScheme()
+					Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+					fakeSystemRepo.GetSchemeReturns(scheme)
+					fakeSystemRepo.GetTemplateReturns(templateAPI, nil)
+					fakeSystemRepo.GetTemplateRevisionReturns(revision, nil)
+				})
+
+				It("stamps the stable revision", func() {
+					template, _, out, _, _, err := r.Do(ctx, resource, blueprintName, outputs, fakeMapper)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(templates.RevisionOf(template)).To(Equal(int64(1)))
+
+					Expect(fakeSystemRepo.GetTemplateRevisionCallCount()).To(Equal(1))
+					_, name := fakeSystemRepo.GetTemplateRevisionArgsForCall(0)
+					Expect(name).To(Equal(v1alpha1.TemplateRevisionName(v1alpha1.TemplateRevisionReference{Kind: "ClusterSourceTemplate", Namespace: "some-namespace", Name: "source-template-1"}, 1)))
+
+					Expect(out.Source.URL).To(Equal("some-revision"))
+				})
+
+				When("the stable revision does not exist", func() {
+					BeforeEach(func() {
+						fakeSystemRepo.GetTemplateRevisionReturns(nil, nil)
+					})
+
+					It("returns GetTemplateError", func() {
+						_, _, _, _, _, err := r.Do(ctx, resource, blueprintName, outputs, fakeMapper)
+						Expect(err).To(BeAssignableToTypeOf(cerrors.GetTemplateError{}))
+						Expect(err.Error()).To(ContainSubstring("stable revision [clustersourcetemplate.some-namespace.source-template-1-"))
+					})
+				})
+
+				When("the resource pins a revision of the template", func() {
+					BeforeEach(func() {
+						resource.TemplateRevision = 1
+						templateAPI.Status.Rollout = &v1alpha1.RolloutStatus{LatestRevision: 2, StableRevision: 2}
+					})
+
+					It("stamps the pinned revision", func() {
+						template, _, out, _, _, err := r.Do(ctx, resource, blueprintName, outputs, fakeMapper)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(templates.RevisionOf(template)).To(Equal(int64(1)))
+
+						_, name := fakeSystemRepo.GetTemplateRevisionArgsForCall(0)
+						Expect(name).To(Equal(v1alpha1.TemplateRevisionName(v1alpha1.TemplateRevisionReference{Kind: "ClusterSourceTemplate", Namespace: "some-namespace", Name: "source-template-1"}, 1)))
+
+						Expect(out.Source.URL).To(Equal("some-revision"))
+					})
+
+					When("the pinned revision does not exist", func() {
+						BeforeEach(func() {
+							fakeSystemRepo.GetTemplateRevisionReturns(nil, nil)
+						})
+
+						It("returns GetTemplateError", func() {
+							_, _, _, _, _, err := r.Do(ctx, resource, blueprintName, outputs, fakeMapper)
+							Expect(err).To(BeAssignableToTypeOf(cerrors.GetTemplateError{}))
+							Expect(err.Error()).To(ContainSubstring("pinned revision [clustersourcetemplate.some-namespace.source-template-1-"))
+						})
+					})
+
+					When("the template was deleted", func() {
+						BeforeEach(func() {
+							fakeSystemRepo.GetTemplateReturns(nil, nil)
+							fakeSystemRepo.GetRevisionOfTemplateReturns(revision, nil)
+						})
+
+						It("stamps the pinned revision", func() {
+							template, _, out, _, _, err := r.Do(ctx, resource, blueprintName, outputs, fakeMapper)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(templates.RevisionOf(template)).To(Equal(int64(1)))
+
+							Expect(fakeSystemRepo.GetRevisionOfTemplateCallCount()).To(Equal(1))
+							_, name, kind, namespace, pinned := fakeSystemRepo.GetRevisionOfTemplateArgsForCall(0)
+							Expect([]string{name, kind, namespace}).To(Equal([]string{"image-template-1", "ClusterImageTemplate", ""}))
+							Expect(pinned).To(BeEquivalentTo(1))
+
+							Expect(out.Source.URL).To(Equal("some-revision"))
+						})
+
+						When("the pinned revision does not exist either", func() {
+							BeforeEach(func() {
+								fakeSystemRepo.GetRevisionOfTemplateReturns(nil, nil)
+							})
+
+							It("returns GetTemplateError", func() {
+								_, _, _, _, _, err := r.Do(ctx, resource, blueprintName, outputs, fakeMapper)
+								Expect(err).To(BeAssignableToTypeOf(cerrors.GetTemplateError{}))
+								Expect(err.Error()).To(ContainSubstring("pinned revision [1] of template [ClusterImageTemplate/image-template-1] not found"))
+							})
+						})
+					})
+				})
+			})
+
 			When("template is immutable", func() {
 				BeforeEach(func() {
 					templateAPI.Spec.TemplateSpec.Lifecycle = "immutable"
@@ -523,6 +635,46 @@ var _ = Describe("Resource", func() {
 			})
 		})
 
+		When("the params do not conform to the template's param schemas", func() {
+			BeforeEach(func() {
+				templateAPI := &v1alpha1.ClusterImageTemplate{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ClusterImageTemplate",
+						APIVersion: "carto.run/v1alpha1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "image-template-1",
+						Namespace: "some-namespace",
+					},
+					Spec: v1alpha1.ImageTemplateSpec{
+						TemplateSpec: v1alpha1.TemplateSpec{
+							Template: &runtime.RawExtension{},
+							Params: v1alpha1.TemplateParams{
+								{
+									Name:   "registry",
+									Schema: &v1alpha1.ParamSchema{Type: "string", Required: true},
+								},
+							},
+						},
+					},
+				}
+
+				fakeSystemRepo.GetTemplateReturns(templateAPI, nil)
+			})
+
+			It("returns ParamValidationError naming the param and does not stamp", func() {
+				template, stampedObject, _, isPassThrough, templateRefName, err := r.Do(ctx, resource, blueprintName, outputs, fakeMapper)
+				Expect(template).ToNot(BeNil())
+				Expect(stampedObject).To(BeNil())
+				Expect(isPassThrough).To(BeFalse())
+				Expect(templateRefName).To(Equal("image-template-1"))
+
+				Expect(err).To(MatchError("invalid params for resource [resource-1] for template [ClusterImageTemplate/image-template-1] in supply chain [supply-chain-name]: param [registry] is required"))
+				Expect(reflect.TypeOf(err).String()).To(Equal("errors.ParamValidationError"))
+				Expect(fakeOwnerRepo.EnsureMutableObjectExistsOnClusterCallCount()).To(Equal(0))
+			})
+		})
+
 		When("unable to retrieve the output from the stamped object", func() {
 			BeforeEach(func() {
 				configMap := &corev1.ConfigMap{
@@ -651,6 +803,21 @@ var _ = Describe("Resource", func() {
 				Expect(err.Error()).To(ContainSubstring("bad object"))
 				Expect(reflect.TypeOf(err).String()).To(Equal("errors.ApplyStampedObjectError"))
 			})
+
+			Context("and the error echoes a sensitive value", func() {
+				BeforeEach(func() {
+					redactor := redact.NewRedactor()
+					redactor.Add("s3cr3t")
+					ctx = redact.NewContext(ctx, redactor)
+					fakeOwnerRepo.EnsureMutableObjectExistsOnClusterReturns(errors.New("bad object: invalid value s3cr3t"))
+				})
+
+				It("redacts the value from the error", func() {
+					_, _, _, _, _, err := r.Do(ctx, resource, blueprintName, outputs, fakeMapper)
+					Expect(err).To(MatchError(ContainSubstring("bad object: invalid value [REDACTED]")))
+					Expect(err.Error()).NotTo(ContainSubstring("s3cr3t"))
+				})
+			})
 		})
 
 		When("resource template has namespace specified", func() {
@@ -902,7 +1069,7 @@ var _ = Describe("Resource", func() {
 						Expect(err).NotTo(HaveOccurred())
 						Expect(templateRefName).To(Equal("template-chosen"))
 
-						_, name, kind := fakeSystemRepo.GetTemplateArgsForCall(0)
+						_, name, kind, _ := fakeSystemRepo.GetTemplateArgsForCall(0)
 						Expect(name).To(Equal("template-chosen"))
 						Expect(kind).To(Equal("ClusterImageTemplate"))
 					})
@@ -947,7 +1114,7 @@ var _ = Describe("Resource", func() {
 						Expect(templateRefName).To(Equal("template-chosen"))
 
 						Expect(err).NotTo(HaveOccurred())
-						_, name, kind := fakeSystemRepo.GetTemplateArgsForCall(0)
+						_, name, kind, _ := fakeSystemRepo.GetTemplateArgsForCall(0)
 						Expect(name).To(Equal("template-chosen"))
 						Expect(kind).To(Equal("ClusterImageTemplate"))
 					})
@@ -983,7 +1150,7 @@ var _ = Describe("Resource", func() {
 						Expect(templateRefName).To(Equal("template-chosen"))
 
 						Expect(err).NotTo(HaveOccurred())
-						_, name, kind := fakeSystemRepo.GetTemplateArgsForCall(0)
+						_, name, kind, _ := fakeSystemRepo.GetTemplateArgsForCall(0)
 						Expect(name).To(Equal("template-chosen"))
 						Expect(kind).To(Equal("ClusterImageTemplate"))
 					})
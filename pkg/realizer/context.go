@@ -15,31 +15,52 @@
 package realizer
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/redact"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
 )
 
 // Todo: Pass an interface for owner and ownerParams that supports getParams and getObject
 func NewContextGenerator(owner client.Object, ownerParams []v1alpha1.OwnerParam, blueprintParams []v1alpha1.BlueprintParam) *contextGenerator {
 	return &contextGenerator{
-		blueprintParams: blueprintParams,
-		ownerParams:     ownerParams,
-		owner:           owner,
+		blueprintParams:     blueprintParams,
+		ownerParams:         ownerParams,
+		resolvedOwnerParams: inlineOwnerParams(ownerParams),
+		owner:               owner,
+		consumedOwnerParams: make(map[string]bool),
+		overriddenParams:    make(map[string]bool),
 	}
 }
 
 type contextGenerator struct {
-	blueprintParams []v1alpha1.BlueprintParam
-	ownerParams     []v1alpha1.OwnerParam
-	owner           client.Object
+	blueprintParams     []v1alpha1.BlueprintParam
+	ownerParams         []v1alpha1.OwnerParam
+	resolvedOwnerParams []v1alpha1.OwnerParam
+	resolved            bool
+	owner               client.Object
+	consumedOwnerParams map[string]bool
+	overriddenParams    map[string]bool
 }
 
 // Generate builds a context based on the template, owner and resource
-func (c contextGenerator) Generate(templateParams TemplateParams, resource OwnerResource, outputs OutputsGetter, labels templates.Labels) map[string]interface{} {
+func (c *contextGenerator) Generate(templateParams TemplateParams, resource OwnerResource, outputs OutputsGetter, labels templates.Labels) map[string]interface{} {
 	inputGenerator := NewInputGenerator(resource, outputs)
-	merger := NewParamMerger(resource.Params, c.blueprintParams, c.ownerParams)
+	merger := NewParamMerger(resource.Params, c.blueprintParams, c.resolvedOwnerParams)
+
+	consumed, overridden := NewParamMerger(resource.Params, c.blueprintParams, c.ownerParams).OwnerParamUsage(templateParams)
+	for _, name := range consumed {
+		c.consumedOwnerParams[name] = true
+	}
+	for _, name := range overridden {
+		c.overriddenParams[name] = true
+	}
 
 	configs := inputGenerator.GetConfigs()
 	sources := inputGenerator.GetSources()
@@ -75,3 +96,83 @@ func (c contextGenerator) Generate(templateParams TemplateParams, resource Owner
 
 	return result
 }
+
+// ResolveOwnerParams reads the values of the owner params sourced from Secrets and
+// ConfigMaps, registering those read from Secrets with the redactor in ctx. Until then,
+// contexts only hold the owner params with an inline value. Values are only read once.
+func (c *contextGenerator) ResolveOwnerParams(ctx context.Context, getter ParamValueGetter) error {
+	if c.resolved {
+		return nil
+	}
+
+	redactor := redact.FromContext(ctx)
+	var resolvedOwnerParams []v1alpha1.OwnerParam
+	for _, param := range c.ownerParams {
+		if param.ValueFrom == nil {
+			resolvedOwnerParams = append(resolvedOwnerParams, param)
+			continue
+		}
+
+		if len(param.Value.Raw) > 0 {
+			return fmt.Errorf("param [%s] must specify only one of value and valueFrom", param.Name)
+		}
+
+		value, found, sensitive, err := getParamValue(ctx, getter, c.owner.GetNamespace(), *param.ValueFrom)
+		if err != nil {
+			return fmt.Errorf("unable to read value of param [%s]: %w", param.Name, err)
+		}
+		if !found {
+			continue
+		}
+		if sensitive {
+			redactor.Add(value)
+		}
+
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("unable to marshal value of param [%s]: %w", param.Name, err)
+		}
+		resolvedOwnerParams = append(resolvedOwnerParams, v1alpha1.OwnerParam{
+			Name:  param.Name,
+			Value: apiextensionsv1.JSON{Raw: raw},
+		})
+	}
+
+	c.resolvedOwnerParams = resolvedOwnerParams
+	c.resolved = true
+	return nil
+}
+
+func inlineOwnerParams(ownerParams []v1alpha1.OwnerParam) []v1alpha1.OwnerParam {
+	var inline []v1alpha1.OwnerParam
+	for _, param := range ownerParams {
+		if param.ValueFrom == nil {
+			inline = append(inline, param)
+		}
+	}
+	return inline
+}
+
+// UnusedOwnerParams are the owner params that no context generated so far consumed,
+// and that no blueprint value overrode.
+func (c *contextGenerator) UnusedOwnerParams() []string {
+	var unused []string
+	for _, param := range c.ownerParams {
+		if !c.consumedOwnerParams[param.Name] && !c.overriddenParams[param.Name] {
+			unused = append(unused, param.Name)
+		}
+	}
+	return unused
+}
+
+// OverriddenOwnerParams are the owner params that every context generated so far ignored
+// because the blueprint sets them with a value.
+func (c *contextGenerator) OverriddenOwnerParams() []string {
+	var overridden []string
+	for _, param := range c.ownerParams {
+		if !c.consumedOwnerParams[param.Name] && c.overriddenParams[param.Name] {
+			overridden = append(overridden, param.Name)
+		}
+	}
+	return overridden
+}
@@ -31,7 +31,7 @@ import (
 func IsClusterTemplate(reference *corev1.ObjectReference) bool {
 	apiVersion := v1alpha1.SchemeGroupVersion.Group + "/" + v1alpha1.SchemeGroupVersion.Version
 	if reference != nil &&
-		reference.Kind == "ClusterTemplate" &&
+		v1alpha1.ClusterTemplateKind(reference.Kind) == "ClusterTemplate" &&
 		reference.APIVersion == apiVersion {
 		return true
 	}
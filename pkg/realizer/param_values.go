@@ -0,0 +1,93 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package realizer
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+// ParamValueGetter reads the Secrets and ConfigMaps owner params source their values from.
+type ParamValueGetter interface {
+	GetUnstructured(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error)
+}
+
+// getParamValue reads the key selected by source. found is false when an optional
+// key or the object holding it does not exist, and sensitive is true for keys of Secrets.
+func getParamValue(ctx context.Context, getter ParamValueGetter, namespace string, source v1alpha1.ParamValueSource) (value string, found bool, sensitive bool, err error) {
+	switch {
+	case source.SecretKeyRef != nil && source.ConfigMapKeyRef != nil:
+		return "", false, false, fmt.Errorf("valueFrom must specify only one of secretKeyRef and configMapKeyRef")
+	case source.SecretKeyRef != nil:
+		ref := source.SecretKeyRef
+		value, found, err = getKey(ctx, getter, "Secret", namespace, ref.Name, ref.Key, isOptional(ref.Optional))
+		return value, found, true, err
+	case source.ConfigMapKeyRef != nil:
+		ref := source.ConfigMapKeyRef
+		value, found, err = getKey(ctx, getter, "ConfigMap", namespace, ref.Name, ref.Key, isOptional(ref.Optional))
+		return value, found, false, err
+	default:
+		return "", false, false, fmt.Errorf("valueFrom must specify one of secretKeyRef and configMapKeyRef")
+	}
+}
+
+func getKey(ctx context.Context, getter ParamValueGetter, kind, namespace, name, key string, optional bool) (string, bool, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(kind))
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+
+	existing, err := getter.GetUnstructured(ctx, obj)
+	if err != nil {
+		return "", false, fmt.Errorf("unable to get %s [%s/%s]: %w", kind, namespace, name, err)
+	}
+	if existing == nil {
+		if optional {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("%s [%s/%s] not found", kind, namespace, name)
+	}
+
+	value, found, err := unstructured.NestedString(existing.Object, "data", key)
+	if err != nil {
+		return "", false, fmt.Errorf("unable to read key [%s] of %s [%s/%s]: %w", key, kind, namespace, name, err)
+	}
+	if !found {
+		if optional {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("key [%s] not found in %s [%s/%s]", key, kind, namespace, name)
+	}
+
+	if kind == "Secret" {
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return "", false, fmt.Errorf("unable to decode key [%s] of %s [%s/%s]: %w", key, kind, namespace, name, err)
+		}
+		value = string(decoded)
+	}
+
+	return value, true, nil
+}
+
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
+}
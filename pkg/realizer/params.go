@@ -82,6 +82,37 @@ func (p ParamMerger) Merge(templateParams TemplateParams) map[string]apiextensio
 
 }
 
+// OwnerParamUsage reports which owner params a template stamped with the merged params
+// consumes, and which it ignores because the blueprint protects them with a value. Owner
+// params that neither the template nor the blueprint declare are in neither list.
+func (p ParamMerger) OwnerParamUsage(templateParams TemplateParams) (consumed []string, overridden []string) {
+	declared := make(map[string]bool)
+	protected := make(map[string]bool)
+
+	if templateParams != nil {
+		for _, param := range templateParams.GetDefaultParams() {
+			declared[param.Name] = true
+		}
+	}
+
+	for _, blueprintParams := range [][]v1alpha1.BlueprintParam{p.blueprintParams, p.resourceParams} {
+		for _, param := range blueprintParams {
+			declared[param.Name] = true
+			protected[param.Name] = param.Value != nil
+		}
+	}
+
+	for _, ownerParam := range p.ownerParams {
+		if protected[ownerParam.Name] {
+			overridden = append(overridden, ownerParam.Name)
+		} else if declared[ownerParam.Name] {
+			consumed = append(consumed, ownerParam.Name)
+		}
+	}
+
+	return consumed, overridden
+}
+
 func ownerCanOverride(isProtected map[string]bool, key string) bool {
 	protected, written := isProtected[key]
 	return !written || !protected
@@ -15,13 +15,23 @@
 package realizer_test
 
 import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer"
+	"github.com/vmware-tanzu/cartographer/pkg/redact"
+	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
 )
 
 type Template struct {
@@ -184,4 +194,218 @@ var _ = Describe("Params", func() {
 			ownerParam,
 			"from the owner"),
 	)
+
+	Describe("OwnerParamUsage", func() {
+		It("consumes owner params declared by the template", func() {
+			consumed, overridden := realizer.NewParamMerger(nil, nil, []v1alpha1.OwnerParam{*ownerParam}).OwnerParamUsage(template)
+			Expect(consumed).To(ConsistOf("target-name"))
+			Expect(overridden).To(BeEmpty())
+		})
+
+		It("consumes owner params the blueprint delegates to the owner", func() {
+			consumed, overridden := realizer.NewParamMerger(nil, []v1alpha1.BlueprintParam{*delegatingBlueprintParam}, []v1alpha1.OwnerParam{*ownerParam}).OwnerParamUsage(nil)
+			Expect(consumed).To(ConsistOf("target-name"))
+			Expect(overridden).To(BeEmpty())
+		})
+
+		It("reports owner params the blueprint protects with a value as overridden", func() {
+			consumed, overridden := realizer.NewParamMerger(nil, []v1alpha1.BlueprintParam{*nonDelegatingBlueprintParam}, []v1alpha1.OwnerParam{*ownerParam}).OwnerParamUsage(template)
+			Expect(consumed).To(BeEmpty())
+			Expect(overridden).To(ConsistOf("target-name"))
+		})
+
+		It("considers the resource param over the blueprint param", func() {
+			consumed, overridden := realizer.NewParamMerger(
+				[]v1alpha1.BlueprintParam{*delegatingResourceParam},
+				[]v1alpha1.BlueprintParam{*nonDelegatingBlueprintParam},
+				[]v1alpha1.OwnerParam{*ownerParam},
+			).OwnerParamUsage(template)
+			Expect(consumed).To(ConsistOf("target-name"))
+			Expect(overridden).To(BeEmpty())
+		})
+
+		It("reports neither for owner params nothing declares", func() {
+			consumed, overridden := realizer.NewParamMerger(nil, nil, []v1alpha1.OwnerParam{
+				{Name: "gitops_branch", Value: apiextensionsv1.JSON{Raw: []byte(`"main"`)}},
+			}).OwnerParamUsage(template)
+			Expect(consumed).To(BeEmpty())
+			Expect(overridden).To(BeEmpty())
+		})
+	})
+
+	Describe("ContextGenerator", func() {
+		It("reports owner params that no generated context used", func() {
+			ownerParams := []v1alpha1.OwnerParam{
+				*ownerParam,
+				{Name: "gitops_branch", Value: apiextensionsv1.JSON{Raw: []byte(`"main"`)}},
+				{Name: "protected", Value: apiextensionsv1.JSON{Raw: []byte(`"from the owner"`)}},
+			}
+			blueprintParams := []v1alpha1.BlueprintParam{
+				{Name: "protected", Value: &apiextensionsv1.JSON{Raw: []byte(`"from the blueprint"`)}},
+			}
+			contextGenerator := realizer.NewContextGenerator(&v1alpha1.Workload{}, ownerParams, blueprintParams)
+
+			contextGenerator.Generate(template, realizer.OwnerResource{}, realizer.NewOutputs(), nil)
+
+			Expect(contextGenerator.UnusedOwnerParams()).To(Equal([]string{"gitops_branch"}))
+			Expect(contextGenerator.OverriddenOwnerParams()).To(Equal([]string{"protected"}))
+		})
+
+		Describe("ResolveOwnerParams", func() {
+			var (
+				ctx          context.Context
+				redactor     *redact.Redactor
+				repo         *repositoryfakes.FakeRepository
+				ownerParams  []v1alpha1.OwnerParam
+				optional     = true
+				workload     *v1alpha1.Workload
+				objectsByKey map[string]*unstructured.Unstructured
+			)
+
+			BeforeEach(func() {
+				redactor = redact.NewRedactor()
+				ctx = redact.NewContext(context.Background(), redactor)
+				workload = &v1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns"}}
+
+				objectsByKey = map[string]*unstructured.Unstructured{
+					"Secret/my-ns/registry-credentials": {Object: map[string]interface{}{
+						"data": map[string]interface{}{
+							"password": base64.StdEncoding.EncodeToString([]byte("s3cr3t")),
+						},
+					}},
+					"ConfigMap/my-ns/settings": {Object: map[string]interface{}{
+						"data": map[string]interface{}{
+							"registry": "registry.example.com",
+						},
+					}},
+				}
+				repo = &repositoryfakes.FakeRepository{}
+				repo.GetUnstructuredStub = func(_ context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+					return objectsByKey[fmt.Sprintf("%s/%s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())], nil
+				}
+
+				ownerParams = []v1alpha1.OwnerParam{
+					{Name: "inline", Value: apiextensionsv1.JSON{Raw: []byte(`"inline value"`)}},
+					{Name: "registry_password", ValueFrom: &v1alpha1.ParamValueSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "registry-credentials"},
+							Key:                  "password",
+						},
+					}},
+					{Name: "registry", ValueFrom: &v1alpha1.ParamValueSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+							Key:                  "registry",
+						},
+					}},
+				}
+			})
+
+			generatedParams := func(contextGenerator realizer.ContextGenerator) map[string]apiextensionsv1.JSON {
+				return contextGenerator.Generate(nil, realizer.OwnerResource{}, realizer.NewOutputs(), nil)["params"].(map[string]apiextensionsv1.JSON)
+			}
+
+			It("only passes inline values until resolved", func() {
+				contextGenerator := realizer.NewContextGenerator(workload, ownerParams, nil)
+
+				Expect(generatedParams(contextGenerator)).To(Equal(map[string]apiextensionsv1.JSON{
+					"inline": {Raw: []byte(`"inline value"`)},
+				}))
+			})
+
+			It("passes the values of Secret and ConfigMap keys in the owner's namespace as strings", func() {
+				contextGenerator := realizer.NewContextGenerator(workload, ownerParams, nil)
+
+				Expect(contextGenerator.ResolveOwnerParams(ctx, repo)).To(Succeed())
+
+				Expect(generatedParams(contextGenerator)).To(Equal(map[string]apiextensionsv1.JSON{
+					"inline":            {Raw: []byte(`"inline value"`)},
+					"registry_password": {Raw: []byte(`"s3cr3t"`)},
+					"registry":          {Raw: []byte(`"registry.example.com"`)},
+				}))
+			})
+
+			It("registers the values read from Secrets with the redactor", func() {
+				contextGenerator := realizer.NewContextGenerator(workload, ownerParams, nil)
+
+				Expect(contextGenerator.ResolveOwnerParams(ctx, repo)).To(Succeed())
+
+				Expect(redactor.String("s3cr3t at registry.example.com")).To(Equal("[REDACTED] at registry.example.com"))
+			})
+
+			It("only reads the values once", func() {
+				contextGenerator := realizer.NewContextGenerator(workload, ownerParams, nil)
+
+				Expect(contextGenerator.ResolveOwnerParams(ctx, repo)).To(Succeed())
+				Expect(contextGenerator.ResolveOwnerParams(ctx, repo)).To(Succeed())
+
+				Expect(repo.GetUnstructuredCallCount()).To(Equal(2))
+			})
+
+			Context("the Secret does not exist", func() {
+				BeforeEach(func() {
+					delete(objectsByKey, "Secret/my-ns/registry-credentials")
+				})
+
+				It("returns an error", func() {
+					contextGenerator := realizer.NewContextGenerator(workload, ownerParams, nil)
+
+					Expect(contextGenerator.ResolveOwnerParams(ctx, repo)).To(MatchError(
+						"unable to read value of param [registry_password]: Secret [my-ns/registry-credentials] not found",
+					))
+				})
+
+				It("leaves the param unset when the key is optional", func() {
+					ownerParams[1].ValueFrom.SecretKeyRef.Optional = &optional
+					contextGenerator := realizer.NewContextGenerator(workload, ownerParams, nil)
+
+					Expect(contextGenerator.ResolveOwnerParams(ctx, repo)).To(Succeed())
+					Expect(generatedParams(contextGenerator)).NotTo(HaveKey("registry_password"))
+				})
+			})
+
+			Context("the key does not exist", func() {
+				BeforeEach(func() {
+					ownerParams[2].ValueFrom.ConfigMapKeyRef.Key = "missing"
+				})
+
+				It("returns an error", func() {
+					contextGenerator := realizer.NewContextGenerator(workload, ownerParams, nil)
+
+					Expect(contextGenerator.ResolveOwnerParams(ctx, repo)).To(MatchError(
+						"unable to read value of param [registry]: key [missing] not found in ConfigMap [my-ns/settings]",
+					))
+				})
+			})
+
+			Context("reading the object fails", func() {
+				BeforeEach(func() {
+					repo.GetUnstructuredStub = nil
+					repo.GetUnstructuredReturns(nil, errors.New("forbidden"))
+				})
+
+				It("returns an error", func() {
+					contextGenerator := realizer.NewContextGenerator(workload, ownerParams, nil)
+
+					Expect(contextGenerator.ResolveOwnerParams(ctx, repo)).To(MatchError(
+						"unable to read value of param [registry_password]: unable to get Secret [my-ns/registry-credentials]: forbidden",
+					))
+				})
+			})
+
+			Context("a param has both a value and valueFrom", func() {
+				BeforeEach(func() {
+					ownerParams[1].Value = apiextensionsv1.JSON{Raw: []byte(`"inline"`)}
+				})
+
+				It("returns an error", func() {
+					contextGenerator := realizer.NewContextGenerator(workload, ownerParams, nil)
+
+					Expect(contextGenerator.ResolveOwnerParams(ctx, repo)).To(MatchError(
+						"param [registry_password] must specify only one of value and valueFrom",
+					))
+				})
+			})
+		})
+	})
 })
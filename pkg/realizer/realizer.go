@@ -39,43 +39,280 @@ import (
 	"github.com/vmware-tanzu/cartographer/pkg/logger"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/healthcheck"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/statuses"
+	"github.com/vmware-tanzu/cartographer/pkg/redact"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
 	"github.com/vmware-tanzu/cartographer/pkg/utils"
 )
 
-func MakeSupplychainOwnerResources(supplyChain *v1alpha1.ClusterSupplyChain) []OwnerResource {
+// FragmentGetter returns the ClusterSupplyChainFragment with the given name, or nil
+// when it does not exist.
+type FragmentGetter func(name string) (*v1alpha1.ClusterSupplyChainFragment, error)
+
+// MakeSupplychainOwnerResources returns the resources of the supply chain, with every
+// resource referencing a ClusterSupplyChainFragment replaced by the fragment's resources.
+func MakeSupplychainOwnerResources(supplyChain v1alpha1.SupplyChainObject, getFragment FragmentGetter) ([]OwnerResource, error) {
+	supplyChainResources, _, err := expandFragments(supplyChain.GetSupplyChainSpec().Resources, getFragment, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand fragments of supply chain [%s]: %w", supplyChain.GetName(), err)
+	}
+	if includesFragment(supplyChain.GetSupplyChainSpec().Resources) {
+		if err := validateExpandedResources(supplyChainResources); err != nil {
+			return nil, fmt.Errorf("failed to expand fragments of supply chain [%s]: %w", supplyChain.GetName(), err)
+		}
+	}
+
 	var resources []OwnerResource
-	for _, resource := range supplyChain.Spec.Resources {
+	for _, resource := range supplyChainResources {
 		resources = append(resources, OwnerResource{
 			Name: resource.Name,
 			TemplateRef: v1alpha1.TemplateReference{
 				Kind: resource.TemplateRef.Kind,
 				Name: resource.TemplateRef.Name,
 			},
-			TemplateOptions: resource.TemplateRef.Options,
-			Params:          resource.Params,
-			Sources:         resource.Sources,
-			Images:          resource.Images,
-			Configs:         resource.Configs,
+			TemplateRevision: resource.TemplateRef.Revision,
+			TemplateOptions:  resource.TemplateRef.Options,
+			Params:           resource.Params,
+			Sources:          resource.Sources,
+			Images:           resource.Images,
+			Configs:          resource.Configs,
 		})
 	}
-	return resources
+	return resources, nil
 }
 
-func MakeDeliveryOwnerResources(delivery *v1alpha1.ClusterDelivery) []OwnerResource {
+// expandFragments replaces each resource referencing a fragment with the fragment's
+// resources, named <resource>-<fragment resource>. Inputs of the fragment are mapped to
+// the including resource's inputs of the same name, and references to the including
+// resource are rewritten to the fragment's output resource. included holds the names
+// of the fragments being expanded, to detect cycles.
+// The returned map holds, for every resource that included a fragment, the name of the
+// resource now providing its output.
+func expandFragments(resources []v1alpha1.SupplyChainResource, getFragment FragmentGetter, included []string) ([]v1alpha1.SupplyChainResource, map[string]string, error) {
+	expansions := map[string][]v1alpha1.SupplyChainResource{}
+	outputs := map[string]string{}
+
+	for _, resource := range resources {
+		if resource.TemplateRef.Kind != v1alpha1.SupplyChainFragmentKind {
+			continue
+		}
+
+		fragmentName := resource.TemplateRef.Name
+		includedPath := append(append([]string{}, included...), fragmentName)
+		if slices.Contains(included, fragmentName) {
+			return nil, nil, fmt.Errorf("resource [%s] creates a cycle of fragments: %s", resource.Name, formatFragmentPath(includedPath))
+		}
+
+		if getFragment == nil {
+			return nil, nil, fmt.Errorf("resource [%s] references fragment [%s] but fragments cannot be retrieved", resource.Name, fragmentName)
+		}
+		fragment, err := getFragment(fragmentName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get fragment [%s] for resource [%s]: %w", fragmentName, resource.Name, err)
+		}
+		if fragment == nil {
+			return nil, nil, fmt.Errorf("fragment [%s] for resource [%s] not found", fragmentName, resource.Name)
+		}
+
+		fragmentResources, fragmentOutputs, err := expandFragments(fragment.Spec.Resources, getFragment, includedPath)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		fragmentResourceNames := map[string]bool{}
+		for _, fragmentResource := range fragmentResources {
+			fragmentResourceNames[fragmentResource.Name] = true
+		}
+
+		output := fragment.GetOutputResourceName()
+		if expandedOutput, ok := fragmentOutputs[output]; ok {
+			output = expandedOutput
+		}
+		if !fragmentResourceNames[output] {
+			return nil, nil, fmt.Errorf("output [%s] of fragment [%s] is not a resource of the fragment", output, fragmentName)
+		}
+		outputs[resource.Name] = prefixedName(resource.Name, output)
+
+		for _, fragmentResource := range fragmentResources {
+			expanded := fragmentResource
+			expanded.Name = prefixedName(resource.Name, fragmentResource.Name)
+			expanded.Params = mergeFragmentParams(fragmentResource.Params, resource.Params)
+
+			for _, refs := range []struct {
+				inner *[]v1alpha1.ResourceReference
+				outer []v1alpha1.ResourceReference
+				kind  string
+			}{
+				{&expanded.Sources, resource.Sources, "source"},
+				{&expanded.Images, resource.Images, "image"},
+				{&expanded.Configs, resource.Configs, "config"},
+			} {
+				mapped, err := mapFragmentRefs(*refs.inner, refs.outer, fragmentResourceNames, resource.Name)
+				if err != nil {
+					return nil, nil, fmt.Errorf("invalid %s input of resource [%s] of fragment [%s]: %w", refs.kind, fragmentResource.Name, fragmentName, err)
+				}
+				*refs.inner = mapped
+			}
+
+			expansions[resource.Name] = append(expansions[resource.Name], expanded)
+		}
+	}
+
+	var expandedResources []v1alpha1.SupplyChainResource
+	for _, resource := range resources {
+		if expansion, ok := expansions[resource.Name]; ok {
+			for _, expandedResource := range expansion {
+				expandedResources = append(expandedResources, withOutputRefs(expandedResource, outputs))
+			}
+			continue
+		}
+		expandedResources = append(expandedResources, withOutputRefs(resource, outputs))
+	}
+
+	return expandedResources, outputs, nil
+}
+
+func includesFragment(resources []v1alpha1.SupplyChainResource) bool {
+	for _, resource := range resources {
+		if resource.TemplateRef.Kind == v1alpha1.SupplyChainFragmentKind {
+			return true
+		}
+	}
+	return false
+}
+
+// validateExpandedResources checks what can only be checked once fragments are expanded:
+// that the names of the expanded resources do not collide, and that every input is provided
+// by a resource of the kind the input requires.
+func validateExpandedResources(resources []v1alpha1.SupplyChainResource) error {
+	byName := map[string]v1alpha1.SupplyChainResource{}
+	for _, resource := range resources {
+		if _, ok := byName[resource.Name]; ok {
+			return fmt.Errorf("more than one resource is named [%s] once fragments are expanded", resource.Name)
+		}
+		byName[resource.Name] = resource
+	}
+
+	for _, resource := range resources {
+		for _, refs := range []struct {
+			refs       []v1alpha1.ResourceReference
+			targetKind string
+		}{
+			{resource.Sources, "ClusterSourceTemplate"},
+			{resource.Images, "ClusterImageTemplate"},
+			{resource.Configs, "ClusterConfigTemplate"},
+		} {
+			for _, ref := range refs.refs {
+				provider, ok := byName[ref.Resource]
+				if !ok {
+					return fmt.Errorf("input [%s] of resource [%s] is provided by unknown resource [%s]", ref.Name, resource.Name, ref.Resource)
+				}
+				if v1alpha1.ClusterTemplateKind(provider.TemplateRef.Kind) != refs.targetKind {
+					return fmt.Errorf("resource [%s] providing [%s] to resource [%s] must reference a %s", provider.Name, ref.Name, resource.Name, refs.targetKind)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func formatFragmentPath(path []string) string {
+	formatted := path[0]
+	for _, name := range path[1:] {
+		formatted = fmt.Sprintf("%s -> %s", formatted, name)
+	}
+	return formatted
+}
+
+func prefixedName(prefix, name string) string {
+	return fmt.Sprintf("%s-%s", prefix, name)
+}
+
+// mapFragmentRefs prefixes references to resources of the fragment, and resolves every
+// other reference, an input of the fragment, through the including resource's references.
+func mapFragmentRefs(refs, includingRefs []v1alpha1.ResourceReference, fragmentResourceNames map[string]bool, prefix string) ([]v1alpha1.ResourceReference, error) {
+	var mapped []v1alpha1.ResourceReference
+	for _, ref := range refs {
+		if fragmentResourceNames[ref.Resource] {
+			mapped = append(mapped, v1alpha1.ResourceReference{Name: ref.Name, Resource: prefixedName(prefix, ref.Resource)})
+			continue
+		}
+
+		found := false
+		for _, includingRef := range includingRefs {
+			if includingRef.Name == ref.Resource {
+				mapped = append(mapped, v1alpha1.ResourceReference{Name: ref.Name, Resource: includingRef.Resource})
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("input [%s] is not provided by resource [%s]", ref.Resource, prefix)
+		}
+	}
+	return mapped, nil
+}
+
+// mergeFragmentParams overrides the params of a fragment resource with the params of the
+// resource including the fragment.
+func mergeFragmentParams(fragmentParams, includingParams []v1alpha1.BlueprintParam) []v1alpha1.BlueprintParam {
+	if len(includingParams) == 0 {
+		return fragmentParams
+	}
+
+	var merged []v1alpha1.BlueprintParam
+	for _, param := range fragmentParams {
+		overridden := false
+		for _, includingParam := range includingParams {
+			if includingParam.Name == param.Name {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, param)
+		}
+	}
+	return append(merged, includingParams...)
+}
+
+func withOutputRefs(resource v1alpha1.SupplyChainResource, outputs map[string]string) v1alpha1.SupplyChainResource {
+	if len(outputs) == 0 {
+		return resource
+	}
+
+	rewrite := func(refs []v1alpha1.ResourceReference) []v1alpha1.ResourceReference {
+		var rewritten []v1alpha1.ResourceReference
+		for _, ref := range refs {
+			if output, ok := outputs[ref.Resource]; ok {
+				ref.Resource = output
+			}
+			rewritten = append(rewritten, ref)
+		}
+		return rewritten
+	}
+
+	resource.Sources = rewrite(resource.Sources)
+	resource.Images = rewrite(resource.Images)
+	resource.Configs = rewrite(resource.Configs)
+	return resource
+}
+
+func MakeDeliveryOwnerResources(delivery v1alpha1.DeliveryObject) []OwnerResource {
 	var resources []OwnerResource
-	for _, resource := range delivery.Spec.Resources {
+	for _, resource := range delivery.GetDeliverySpec().Resources {
 		resources = append(resources, OwnerResource{
 			Name: resource.Name,
 			TemplateRef: v1alpha1.TemplateReference{
 				Kind: resource.TemplateRef.Kind,
 				Name: resource.TemplateRef.Name,
 			},
-			TemplateOptions: resource.TemplateRef.Options,
-			Params:          resource.Params,
-			Sources:         resource.Sources,
-			Configs:         resource.Configs,
-			Deployment:      resource.Deployment,
+			TemplateRevision: resource.TemplateRef.Revision,
+			TemplateOptions:  resource.TemplateRef.Options,
+			Params:           resource.Params,
+			Sources:          resource.Sources,
+			Configs:          resource.Configs,
+			Deployment:       resource.Deployment,
 		})
 	}
 	return resources
@@ -118,7 +355,7 @@ func (r *realizer) Realize(ctx context.Context, resourceRealizer ResourceRealize
 
 		if stampedObject != nil {
 			log.V(logger.DEBUG).Info("realized resource as object",
-				"object", stampedObject)
+				"object", redact.FromContext(ctx).Object(stampedObject))
 		}
 
 		outs.AddOutput(resource.Name, out)
@@ -217,6 +454,7 @@ func (r *realizer) generateRealizedResource(ctx context.Context, resource OwnerR
 	}
 
 	var templateRef *corev1.ObjectReference
+	var templateRevision int64
 	var outputs []v1alpha1.Output
 
 	if template != nil {
@@ -225,18 +463,19 @@ func (r *realizer) generateRealizedResource(ctx context.Context, resource OwnerR
 			Name:       templateName,
 			APIVersion: v1alpha1.SchemeGroupVersion.String(),
 		}
-		outputs = getOutputs(previousRealizedResource, output)
+		templateRevision = templates.RevisionOf(template)
+		outputs = getOutputs(previousRealizedResource, output, redact.FromContext(ctx))
 	}
 
 	if isPassThrough {
-		outputs = getOutputs(previousRealizedResource, output)
+		outputs = getOutputs(previousRealizedResource, output, redact.FromContext(ctx))
 	}
 
 	var stampedRef *v1alpha1.StampedRef
 	if stampedObject != nil {
 		qualifiedResource, err := utils.GetQualifiedResource(r.mapper, stampedObject)
 		if err != nil {
-			log.Error(err, "failed to retrieve qualified resource name", "object", stampedObject)
+			log.Error(err, "failed to retrieve qualified resource name", "object", redact.FromContext(ctx).Object(stampedObject))
 			qualifiedResource = "could not fetch - see logs for 'failed to retrieve qualified resource name'"
 		}
 
@@ -252,16 +491,17 @@ func (r *realizer) generateRealizedResource(ctx context.Context, resource OwnerR
 	}
 
 	return &v1alpha1.RealizedResource{
-		Name:        resource.Name,
-		StampedRef:  stampedRef,
-		TemplateRef: templateRef,
-		Inputs:      inputs,
-		Outputs:     outputs,
+		Name:             resource.Name,
+		StampedRef:       stampedRef,
+		TemplateRef:      templateRef,
+		TemplateRevision: templateRevision,
+		Inputs:           inputs,
+		Outputs:          outputs,
 	}
 }
 
-func getOutputs(previousRealizedResource *v1alpha1.RealizedResource, output *templates.Output) []v1alpha1.Output {
-	outputs, err := generateResourceOutput(output)
+func getOutputs(previousRealizedResource *v1alpha1.RealizedResource, output *templates.Output, redactor *redact.Redactor) []v1alpha1.Output {
+	outputs, err := generateResourceOutput(output, redactor)
 	if err != nil {
 		outputs = previousRealizedResource.Outputs
 	} else {
@@ -282,9 +522,14 @@ func getOutputs(previousRealizedResource *v1alpha1.RealizedResource, output *tem
 	return outputs
 }
 
+// GenerateResourceOutputs returns the outputs of a resource as reported on the status of its owner.
+func GenerateResourceOutputs(output *templates.Output) ([]v1alpha1.Output, error) {
+	return generateResourceOutput(output, redact.NewRedactor())
+}
+
 // TODO: This should be polymorphic
 
-func generateResourceOutput(output *templates.Output) ([]v1alpha1.Output, error) {
+func generateResourceOutput(output *templates.Output, redactor *redact.Redactor) ([]v1alpha1.Output, error) {
 	if output == nil {
 		return nil, nil
 	}
@@ -292,25 +537,25 @@ func generateResourceOutput(output *templates.Output) ([]v1alpha1.Output, error)
 	var result []v1alpha1.Output
 
 	if output.Source != nil {
-		urlOut, err := buildOneOutput("url", output.Source.URL)
+		urlOut, err := buildOneOutput("url", output.Source.URL, redactor)
 		if err != nil {
 			return nil, err
 		}
 		result = append(result, urlOut)
 
-		revisionOut, err := buildOneOutput("revision", output.Source.Revision)
+		revisionOut, err := buildOneOutput("revision", output.Source.Revision, redactor)
 		if err != nil {
 			return nil, err
 		}
 		result = append(result, revisionOut)
 	} else if output.Image != nil {
-		out, err := buildOneOutput("image", output.Image)
+		out, err := buildOneOutput("image", output.Image, redactor)
 		if err != nil {
 			return nil, err
 		}
 		result = append(result, out)
 	} else if output.Config != nil {
-		out, err := buildOneOutput("config", output.Config)
+		out, err := buildOneOutput("config", output.Config, redactor)
 		if err != nil {
 			return nil, err
 		}
@@ -324,7 +569,9 @@ func generateResourceOutput(output *templates.Output) ([]v1alpha1.Output, error)
 
 const PreviewCharacterLimit = 1024
 
-func buildOneOutput(name string, value any) (v1alpha1.Output, error) {
+// buildOneOutput previews the value of an output, redacting sensitive values. The digest
+// is computed from the value itself so that it still changes when they do.
+func buildOneOutput(name string, value any, redactor *redact.Redactor) (v1alpha1.Output, error) {
 	bytes, err := yaml.Marshal(value)
 	if err != nil {
 		return v1alpha1.Output{}, err
@@ -334,7 +581,7 @@ func buildOneOutput(name string, value any) (v1alpha1.Output, error) {
 
 	return v1alpha1.Output{
 		Name:    name,
-		Preview: strings.ShortenString(string(bytes), PreviewCharacterLimit),
+		Preview: strings.ShortenString(redactor.String(string(bytes)), PreviewCharacterLimit),
 		Digest:  fmt.Sprintf("sha256:%x", sha),
 	}, nil
 
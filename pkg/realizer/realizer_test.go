@@ -25,6 +25,7 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -39,6 +40,7 @@ import (
 	"github.com/vmware-tanzu/cartographer/pkg/realizer"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/realizerfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/statuses"
+	"github.com/vmware-tanzu/cartographer/pkg/redact"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
 )
 
@@ -104,6 +106,7 @@ var _ = Describe("Realize", func() {
 			resource2             v1alpha1.SupplyChainResource
 		)
 		BeforeEach(func() {
+			executedResourceOrder = nil
 			template1 = &v1alpha1.ClusterImageTemplate{
 				TypeMeta: metav1.TypeMeta{},
 				ObjectMeta: metav1.ObjectMeta{
@@ -197,9 +200,27 @@ var _ = Describe("Realize", func() {
 
 		})
 
+		It("redacts sensitive values from the output previews", func() {
+			redactor := redact.NewRedactor()
+			redactor.Add("whatever")
+			ctx = redact.NewContext(ctx, redactor)
+
+			resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
+			err := rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeOwnerResources(supplyChain), resourceStatuses)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(resourceStatuses.GetCurrent()[0].Outputs).To(ConsistOf(MatchFields(IgnoreExtras,
+				Fields{
+					"Name":    Equal("image"),
+					"Preview": Equal("[REDACTED]\n"),
+					"Digest":  HavePrefix("sha256"),
+				},
+			)))
+		})
+
 		It("realizes each resource in supply chain order, accumulating output for each subsequent resource", func() {
 			resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
-			err := rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, realizer.MakeSupplychainOwnerResources(supplyChain), resourceStatuses)
+			err := rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeOwnerResources(supplyChain), resourceStatuses)
 			Expect(err).ToNot(HaveOccurred())
 
 			currentResourceStatuses := resourceStatuses.GetCurrent()
@@ -266,7 +287,7 @@ var _ = Describe("Realize", func() {
 
 		It("records an event for resource output changes and health status", func() {
 			resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
-			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, realizer.MakeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
 
 			Expect(recordedEvents).To(ConsistOf(
 				event{"Normal", events.ResourceOutputChangedReason, "[%s] found a new output in [%Q]", "obj1", []interface{}{"resource1"}},
@@ -293,7 +314,7 @@ var _ = Describe("Realize", func() {
 			}
 
 			resourceStatuses := statuses.NewResourceStatuses(previousResources, conditions.AddConditionForResourceSubmittedWorkload)
-			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, realizer.MakeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
 
 			Expect(recordedEvents).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{"Reason": Equal(events.ResourceOutputChangedReason)})))
 		})
@@ -312,7 +333,7 @@ var _ = Describe("Realize", func() {
 
 			It("returns the first error encountered and continues to realize", func() {
 				resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
-				err = rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, realizer.MakeSupplychainOwnerResources(supplyChain), resourceStatuses)
+				err = rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeOwnerResources(supplyChain), resourceStatuses)
 
 				Expect(err).To(MatchError("realizing is hard"))
 				rs := *resourceStatuses
@@ -413,7 +434,7 @@ var _ = Describe("Realize", func() {
 
 		It("records an event for resource output changes", func() {
 			resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
-			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, realizer.MakeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
 
 			Expect(recordedEvents).To(ConsistOf(
 				event{"Normal", events.ResourceOutputChangedReason, "[%s] found a new output in [%Q]", "obj1", []interface{}{"resource1"}},
@@ -440,14 +461,14 @@ var _ = Describe("Realize", func() {
 			}
 
 			resourceStatuses := statuses.NewResourceStatuses(previousResources, conditions.AddConditionForResourceSubmittedWorkload)
-			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, realizer.MakeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
 
 			Expect(recordedEvents).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{"Message": ContainSubstring("passed through")})))
 		})
 
 		It("generates the correct realized resource", func() {
 			resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
-			err := rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, realizer.MakeSupplychainOwnerResources(supplyChain), resourceStatuses)
+			err := rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeOwnerResources(supplyChain), resourceStatuses)
 			Expect(err).ToNot(HaveOccurred())
 
 			currentResourceStatuses := resourceStatuses.GetCurrent()
@@ -683,7 +704,7 @@ var _ = Describe("Realize", func() {
 			resourceRealizer.DoReturnsOnCall(2, reader3, obj, oldOutput2, false, "", nil)
 
 			resourceStatuses := statuses.NewResourceStatuses(previousResources, conditions.AddConditionForResourceSubmittedWorkload)
-			err := rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, realizer.MakeSupplychainOwnerResources(supplyChain), resourceStatuses)
+			err := rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeOwnerResources(supplyChain), resourceStatuses)
 			Expect(err).ToNot(HaveOccurred())
 
 			currentStatuses := resourceStatuses.GetCurrent()
@@ -757,7 +778,7 @@ var _ = Describe("Realize", func() {
 			It("the status uses the previous resource for resource 2", func() {
 				resourceStatuses := statuses.NewResourceStatuses(previousResources, conditions.AddConditionForResourceSubmittedWorkload)
 
-				err := rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, realizer.MakeSupplychainOwnerResources(supplyChain), resourceStatuses)
+				err := rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeOwnerResources(supplyChain), resourceStatuses)
 				Expect(err).To(MatchError("im in a bad state"))
 
 				Expect(evaluatedRealizedResourceNames).To(Equal([]string{"resource3"}))
@@ -834,3 +855,202 @@ var _ = Describe("Realize", func() {
 		})
 	})
 })
+
+func makeOwnerResources(supplyChain *v1alpha1.ClusterSupplyChain) []realizer.OwnerResource {
+	resources, err := realizer.MakeSupplychainOwnerResources(supplyChain, nil)
+	Expect(err).NotTo(HaveOccurred())
+	return resources
+}
+
+var _ = Describe("MakeSupplychainOwnerResources", func() {
+	var (
+		supplyChain *v1alpha1.ClusterSupplyChain
+		fragments   map[string]*v1alpha1.ClusterSupplyChainFragment
+		getFragment realizer.FragmentGetter
+	)
+
+	BeforeEach(func() {
+		fragments = map[string]*v1alpha1.ClusterSupplyChainFragment{
+			"build": {
+				ObjectMeta: metav1.ObjectMeta{Name: "build"},
+				Spec: v1alpha1.SupplyChainFragmentSpec{
+					Resources: []v1alpha1.SupplyChainResource{
+						{
+							Name:        "test",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterSourceTemplate", Name: "tester"},
+							Sources:     []v1alpha1.ResourceReference{{Name: "source", Resource: "source"}},
+							Params:      []v1alpha1.BlueprintParam{{Name: "verbose", Value: &apiextensionsv1.JSON{Raw: []byte(`false`)}}},
+						},
+						{
+							Name:        "image",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterImageTemplate", Name: "kpack"},
+							Sources:     []v1alpha1.ResourceReference{{Name: "source", Resource: "test"}},
+						},
+					},
+				},
+			},
+		}
+
+		getFragment = func(name string) (*v1alpha1.ClusterSupplyChainFragment, error) {
+			return fragments[name], nil
+		}
+
+		supplyChain = &v1alpha1.ClusterSupplyChain{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-supply-chain"},
+			Spec: v1alpha1.SupplyChainSpec{
+				Resources: []v1alpha1.SupplyChainResource{
+					{
+						Name:        "source-provider",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterSourceTemplate", Name: "git"},
+					},
+					{
+						Name:        "build",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterSupplyChainFragment", Name: "build"},
+						Sources:     []v1alpha1.ResourceReference{{Name: "source", Resource: "source-provider"}},
+						Params:      []v1alpha1.BlueprintParam{{Name: "verbose", Value: &apiextensionsv1.JSON{Raw: []byte(`true`)}}},
+					},
+					{
+						Name:        "config",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterConfigTemplate", Name: "convention"},
+						Images:      []v1alpha1.ResourceReference{{Name: "image", Resource: "build"}},
+					},
+				},
+			},
+		}
+	})
+
+	It("expands the fragment in place, prefixing its resource names", func() {
+		resources, err := realizer.MakeSupplychainOwnerResources(supplyChain, getFragment)
+		Expect(err).NotTo(HaveOccurred())
+
+		var names []string
+		for _, resource := range resources {
+			names = append(names, resource.Name)
+		}
+		Expect(names).To(Equal([]string{"source-provider", "build-test", "build-image", "config"}))
+	})
+
+	It("maps the inputs and output of the fragment", func() {
+		resources, err := realizer.MakeSupplychainOwnerResources(supplyChain, getFragment)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(resources[1].Sources).To(Equal([]v1alpha1.ResourceReference{{Name: "source", Resource: "source-provider"}}))
+		Expect(resources[2].Sources).To(Equal([]v1alpha1.ResourceReference{{Name: "source", Resource: "build-test"}}))
+		Expect(resources[3].Images).To(Equal([]v1alpha1.ResourceReference{{Name: "image", Resource: "build-image"}}))
+	})
+
+	It("overrides the fragment's params with those of the including resource", func() {
+		resources, err := realizer.MakeSupplychainOwnerResources(supplyChain, getFragment)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(resources[1].Params).To(Equal([]v1alpha1.BlueprintParam{{Name: "verbose", Value: &apiextensionsv1.JSON{Raw: []byte(`true`)}}}))
+	})
+
+	Context("the fragment declares its output", func() {
+		BeforeEach(func() {
+			fragments["build"].Spec.Output = "test"
+			supplyChain.Spec.Resources[2].Images = nil
+			supplyChain.Spec.Resources[2].Sources = []v1alpha1.ResourceReference{{Name: "tested", Resource: "build"}}
+		})
+
+		It("maps references to the including resource onto the declared output", func() {
+			resources, err := realizer.MakeSupplychainOwnerResources(supplyChain, getFragment)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources[3].Sources).To(Equal([]v1alpha1.ResourceReference{{Name: "tested", Resource: "build-test"}}))
+		})
+	})
+
+	Context("a fragment includes another fragment", func() {
+		BeforeEach(func() {
+			fragments["scan"] = &v1alpha1.ClusterSupplyChainFragment{
+				ObjectMeta: metav1.ObjectMeta{Name: "scan"},
+				Spec: v1alpha1.SupplyChainFragmentSpec{
+					Resources: []v1alpha1.SupplyChainResource{
+						{
+							Name:        "scanner",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterImageTemplate", Name: "grype"},
+							Images:      []v1alpha1.ResourceReference{{Name: "image", Resource: "image"}},
+						},
+					},
+				},
+			}
+			fragments["build"].Spec.Resources = append(fragments["build"].Spec.Resources, v1alpha1.SupplyChainResource{
+				Name:        "scan",
+				TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterSupplyChainFragment", Name: "scan"},
+				Images:      []v1alpha1.ResourceReference{{Name: "image", Resource: "image"}},
+			})
+		})
+
+		It("expands the nested fragment", func() {
+			resources, err := realizer.MakeSupplychainOwnerResources(supplyChain, getFragment)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resources).To(HaveLen(5))
+			Expect(resources[3].Name).To(Equal("build-scan-scanner"))
+			Expect(resources[3].Images).To(Equal([]v1alpha1.ResourceReference{{Name: "image", Resource: "build-image"}}))
+			Expect(resources[4].Images).To(Equal([]v1alpha1.ResourceReference{{Name: "image", Resource: "build-scan-scanner"}}))
+		})
+
+		Context("the nested fragment includes the first", func() {
+			BeforeEach(func() {
+				fragments["scan"].Spec.Resources = append(fragments["scan"].Spec.Resources, v1alpha1.SupplyChainResource{
+					Name:        "rebuild",
+					TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterSupplyChainFragment", Name: "build"},
+					Sources:     []v1alpha1.ResourceReference{{Name: "source", Resource: "source"}},
+				})
+			})
+
+			It("returns an error naming the cycle", func() {
+				_, err := realizer.MakeSupplychainOwnerResources(supplyChain, getFragment)
+				Expect(err).To(MatchError("failed to expand fragments of supply chain [my-supply-chain]: resource [rebuild] creates a cycle of fragments: build -> scan -> build"))
+			})
+		})
+	})
+
+	Context("an input of the fragment is not provided", func() {
+		BeforeEach(func() {
+			supplyChain.Spec.Resources[1].Sources = nil
+		})
+
+		It("returns an error", func() {
+			_, err := realizer.MakeSupplychainOwnerResources(supplyChain, getFragment)
+			Expect(err).To(MatchError("failed to expand fragments of supply chain [my-supply-chain]: invalid source input of resource [test] of fragment [build]: input [source] is not provided by resource [build]"))
+		})
+	})
+
+	Context("an expanded resource is named as a resource of the supply chain", func() {
+		BeforeEach(func() {
+			supplyChain.Spec.Resources = append(supplyChain.Spec.Resources, v1alpha1.SupplyChainResource{
+				Name:        "build-image",
+				TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterImageTemplate", Name: "prebuilt"},
+			})
+		})
+
+		It("returns an error", func() {
+			_, err := realizer.MakeSupplychainOwnerResources(supplyChain, getFragment)
+			Expect(err).To(MatchError("failed to expand fragments of supply chain [my-supply-chain]: more than one resource is named [build-image] once fragments are expanded"))
+		})
+	})
+
+	Context("the output of the fragment is not of the kind an input requires", func() {
+		BeforeEach(func() {
+			fragments["build"].Spec.Output = "test"
+		})
+
+		It("returns an error", func() {
+			_, err := realizer.MakeSupplychainOwnerResources(supplyChain, getFragment)
+			Expect(err).To(MatchError("failed to expand fragments of supply chain [my-supply-chain]: resource [build-test] providing [image] to resource [config] must reference a ClusterImageTemplate"))
+		})
+	})
+
+	Context("the fragment does not exist", func() {
+		BeforeEach(func() {
+			delete(fragments, "build")
+		})
+
+		It("returns an error", func() {
+			_, err := realizer.MakeSupplychainOwnerResources(supplyChain, getFragment)
+			Expect(err).To(MatchError("failed to expand fragments of supply chain [my-supply-chain]: fragment [build] for resource [build] not found"))
+		})
+	})
+})
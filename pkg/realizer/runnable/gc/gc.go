@@ -35,7 +35,9 @@ func (a ByCreationTimestamp) Less(i, j int) bool {
 }
 func (a ByCreationTimestamp) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
 
-func CleanupRunnableStampedObjects(ctx context.Context, examinedObjects []*stamp.ExaminedObject, retentionPolicy v1alpha1.RetentionPolicy, repo repository.Repository) {
+// CleanupRunnableStampedObjects deletes the stamped objects that fall outside the retention policy
+// and returns the objects that are retained, most recent first.
+func CleanupRunnableStampedObjects(ctx context.Context, examinedObjects []*stamp.ExaminedObject, retentionPolicy v1alpha1.RetentionPolicy, repo repository.Repository) []*stamp.ExaminedObject {
 	log := logr.FromContextOrDiscard(ctx).WithName("runnable-stamped-object-cleanup")
 	ctx = logr.NewContext(ctx, log)
 
@@ -43,6 +45,7 @@ func CleanupRunnableStampedObjects(ctx context.Context, examinedObjects []*stamp
 
 	var successfulFound int64
 	var failedFound int64
+	var retained []*stamp.ExaminedObject
 	for _, examinedObject := range examinedObjects {
 		runnableStampedObject := examinedObject.StampedObject
 		runnableHealth := examinedObject.Health
@@ -64,6 +67,10 @@ func CleanupRunnableStampedObjects(ctx context.Context, examinedObjects []*stamp
 			if err != nil {
 				log.Error(err, "failed to delete runnable stamped object", "stampedObject", runnableStampedObject)
 			}
+		} else {
+			retained = append(retained, examinedObject)
 		}
 	}
+
+	return retained
 }
@@ -199,6 +199,19 @@ var _ = Describe("CleanupRunnableStampedObjects", func() {
 			))
 		})
 
+		It("returns the retained runnable stamped objects, most recent first", func() {
+			retained := gc.CleanupRunnableStampedObjects(ctx, allExaminedObjects, retentionPolicy, repo)
+
+			var retainedNames []string
+			for _, examinedObject := range retained {
+				retainedNames = append(retainedNames, examinedObject.StampedObject.GetName())
+			}
+			Expect(retainedNames).To(HaveLen(5))
+			Expect(retainedNames[:2]).To(ConsistOf("MostRecentSuccess", "MostRecentFailure"))
+			Expect(retainedNames[2:4]).To(ConsistOf("RecentSuccessRetainedByPolicy1", "RecentFailureRetainedByPolicy2"))
+			Expect(retainedNames[4]).To(Equal("RecentSuccessRetainedByPolicy2"))
+		})
+
 		It("ignores runnable stamped objects that have not succeeded or failed", func() {
 			failedRunnableStampedObjectToBeIgnored1 := MakeRunnableStampedObject("Unknown", "RecentFailureToBeDeleted1", "2022-01-10T17:00:07Z")
 			failedRunnableStampedObjectToBeIgnored2 := MakeRunnableStampedObject("Unknown", "RecentFailureToBeDeleted2", "2022-01-09T17:00:07Z")
@@ -22,8 +22,10 @@ import (
 	"reflect"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
@@ -42,7 +44,7 @@ import (
 
 //counterfeiter:generate . Realizer
 type Realizer interface {
-	Realize(ctx context.Context, runnable *v1alpha1.Runnable, systemRepo repository.Repository, runnableRepo repository.Repository, discoveryClient discovery.DiscoveryInterface) (*unstructured.Unstructured, templates.Outputs, error)
+	Realize(ctx context.Context, runnable *v1alpha1.Runnable, systemRepo repository.Repository, runnableRepo repository.Repository, discoveryClient discovery.DiscoveryInterface) (*unstructured.Unstructured, templates.Outputs, []v1alpha1.RunnableRun, error)
 }
 
 func NewRealizer(mapper meta.RESTMapper) Realizer {
@@ -61,7 +63,7 @@ type TemplatingContext struct {
 }
 
 //counterfeiter:generate k8s.io/client-go/discovery.DiscoveryInterface
-func (r *runnableRealizer) Realize(ctx context.Context, runnable *v1alpha1.Runnable, systemRepo repository.Repository, runnableRepo repository.Repository, discoveryClient discovery.DiscoveryInterface) (*unstructured.Unstructured, templates.Outputs, error) {
+func (r *runnableRealizer) Realize(ctx context.Context, runnable *v1alpha1.Runnable, systemRepo repository.Repository, runnableRepo repository.Repository, discoveryClient discovery.DiscoveryInterface) (*unstructured.Unstructured, templates.Outputs, []v1alpha1.RunnableRun, error) {
 	log := logr.FromContextOrDiscard(ctx).WithValues("template", runnable.Spec.RunTemplateRef)
 	ctx = logr.NewContext(ctx, log)
 
@@ -70,7 +72,7 @@ func (r *runnableRealizer) Realize(ctx context.Context, runnable *v1alpha1.Runna
 
 	if err != nil {
 		log.Error(err, "failed to get runnable cluster template")
-		return nil, nil, errors.RunnableGetRunTemplateError{
+		return nil, nil, nil, errors.RunnableGetRunTemplateError{
 			Err:         err,
 			TemplateRef: &runnable.Spec.RunTemplateRef,
 		}
@@ -86,7 +88,7 @@ func (r *runnableRealizer) Realize(ctx context.Context, runnable *v1alpha1.Runna
 	selected, err := r.resolveSelector(ctx, runnable.Spec.Selector, runnableRepo, discoveryClient, runnable.GetNamespace())
 	if err != nil {
 		log.Error(err, "failed to resolve selector", "selector", runnable.Spec.Selector)
-		return nil, nil, errors.RunnableResolveSelectorError{
+		return nil, nil, nil, errors.RunnableResolveSelectorError{
 			Err:      err,
 			Selector: runnable.Spec.Selector,
 		}
@@ -104,7 +106,7 @@ func (r *runnableRealizer) Realize(ctx context.Context, runnable *v1alpha1.Runna
 	stampedObject, err := stampContext.Stamp(ctx, template.GetResourceTemplate())
 	if err != nil {
 		log.Error(err, "failed to stamp resource")
-		return nil, nil, errors.RunnableStampError{
+		return nil, nil, nil, errors.RunnableStampError{
 			Err:         err,
 			TemplateRef: &runnable.Spec.RunTemplateRef,
 		}
@@ -113,7 +115,7 @@ func (r *runnableRealizer) Realize(ctx context.Context, runnable *v1alpha1.Runna
 	err = runnableRepo.EnsureImmutableObjectExistsOnCluster(ctx, stampedObject, map[string]string{"carto.run/runnable-name": runnable.Name})
 	if err != nil {
 		log.Error(err, "failed to ensure object exists on cluster", "object", stampedObject)
-		return nil, nil, errors.RunnableApplyStampedObjectError{
+		return nil, nil, nil, errors.RunnableApplyStampedObjectError{
 			Err:           err,
 			StampedObject: stampedObject,
 			TemplateRef:   &runnable.Spec.RunTemplateRef,
@@ -123,7 +125,7 @@ func (r *runnableRealizer) Realize(ctx context.Context, runnable *v1alpha1.Runna
 	allRunnableStampedObjects, err := runnableRepo.ListUnstructured(ctx, stampedObject.GroupVersionKind(), stampedObject.GetNamespace(), labels)
 	if err != nil {
 		log.Error(err, "failed to list objects")
-		return stampedObject, nil, errors.ListCreatedObjectsError{
+		return stampedObject, nil, nil, errors.ListCreatedObjectsError{
 			Err:       err,
 			Namespace: stampedObject.GetNamespace(),
 			Labels:    labels,
@@ -143,9 +145,18 @@ func (r *runnableRealizer) Realize(ctx context.Context, runnable *v1alpha1.Runna
 		})
 	}
 
-	gc.CleanupRunnableStampedObjects(ctx, examinedObjects, runnable.Spec.RetentionPolicy, runnableRepo)
+	var tektonReader *stamp.TektonOutputReader
+	if apiRunTemplate.Spec.TektonResults {
+		tektonReader = stamp.NewTektonOutputReader()
+	}
+
+	retainedObjects := gc.CleanupRunnableStampedObjects(ctx, examinedObjects, runnable.Spec.RetentionPolicy, runnableRepo)
+	runs := r.runHistory(ctx, template, tektonReader, healthRule, retainedObjects, runnable.Status.Runs, runnableRepo)
 
 	outputs, outputSource, err := template.GetLatestSuccessfulOutput(allRunnableStampedObjects)
+	if err == nil && outputSource != nil {
+		outputs = WithTektonResults(ctx, tektonReader, outputSource, outputs)
+	}
 	if err != nil {
 		for _, obj := range allRunnableStampedObjects {
 			log.V(logger.DEBUG).Info("failed to retrieve output from any object", "considered", obj)
@@ -157,7 +168,7 @@ func (r *runnableRealizer) Realize(ctx context.Context, runnable *v1alpha1.Runna
 			qualifiedResource = "could not fetch - see logs for 'failed to retrieve qualified resource name'"
 		}
 
-		return stampedObject, nil, errors.RunnableRetrieveOutputError{
+		return stampedObject, nil, runs, errors.RunnableRetrieveOutputError{
 			Err:               err,
 			StampedObject:     stampedObject,
 			TemplateRef:       &runnable.Spec.RunTemplateRef,
@@ -178,7 +189,117 @@ func (r *runnableRealizer) Realize(ctx context.Context, runnable *v1alpha1.Runna
 		outputs = runnable.Status.Outputs
 	}
 
-	return stampedObject, outputs, nil
+	return stampedObject, outputs, runs, nil
+}
+
+// runHistory returns a run for each of the objects the retention policy retained, most recent first
+func (r *runnableRealizer) runHistory(ctx context.Context, template templates.ClusterRunTemplate, tektonReader *stamp.TektonOutputReader, healthRule *v1alpha1.HealthRule, retainedObjects []*stamp.ExaminedObject, previousRuns []v1alpha1.RunnableRun, runnableRepo repository.Repository) []v1alpha1.RunnableRun {
+	log := logr.FromContextOrDiscard(ctx)
+
+	previousFailures := map[string]string{}
+	for _, previousRun := range previousRuns {
+		if previousRun.Health == metav1.ConditionFalse && previousRun.StampedRef != nil && previousRun.StampedRef.ObjectReference != nil {
+			previousFailures[previousRun.StampedRef.Name] = previousRun.Message
+		}
+	}
+
+	var runs []v1alpha1.RunnableRun
+	for _, examinedObject := range retainedObjects {
+		obj := examinedObject.StampedObject
+
+		qualifiedResource, err := utils.GetQualifiedResource(r.mapper, obj)
+		if err != nil {
+			log.V(logger.DEBUG).Info("failed to retrieve qualified resource name for run", "object", obj, "error", err.Error())
+		}
+
+		healthCondition := healthcheck.DetermineHealthCondition(healthRule, nil, obj)
+
+		run := v1alpha1.RunnableRun{
+			StampedRef: &v1alpha1.StampedRef{
+				ObjectReference: &corev1.ObjectReference{
+					Kind:       obj.GetKind(),
+					Namespace:  obj.GetNamespace(),
+					Name:       obj.GetName(),
+					APIVersion: obj.GetAPIVersion(),
+				},
+				Resource: qualifiedResource,
+			},
+			StartTime: obj.GetCreationTimestamp(),
+			Health:    examinedObject.Health,
+			Message:   healthCondition.Message,
+		}
+
+		if examinedObject.Health != metav1.ConditionUnknown {
+			succeededCondition := utils.ExtractConditions(obj).ConditionWithType(healthRule.SingleConditionType)
+			if succeededCondition != nil && !succeededCondition.LastTransitionTime.IsZero() {
+				completionTime := succeededCondition.LastTransitionTime
+				run.CompletionTime = &completionTime
+			}
+		}
+
+		if examinedObject.Health == metav1.ConditionTrue {
+			outputs, err := template.GetOutputs(obj)
+			if err != nil {
+				log.V(logger.DEBUG).Info("failed to retrieve outputs for run", "object", obj, "error", err.Error())
+			}
+			outputs = WithTektonResults(ctx, tektonReader, obj, outputs)
+			if len(outputs) > 0 {
+				run.Outputs = outputs
+			}
+		}
+
+		// a failed Tekton run whose taskruns are no longer found keeps the message read when it failed
+		if examinedObject.Health == metav1.ConditionFalse && tektonReader != nil && stamp.IsTektonRun(obj) {
+			taskRuns := listTaskRuns(ctx, obj, runnableRepo)
+			if message := previousFailures[obj.GetName()]; len(taskRuns) == 0 && message != "" {
+				run.Message = message
+			} else if message := tektonReader.FailureMessage(obj, taskRuns); message != "" {
+				run.Message = message
+			}
+		}
+
+		runs = append(runs, run)
+	}
+
+	return runs
+}
+
+// WithTektonResults adds the results of a Tekton run, when read by the tektonReader, to the outputs read
+// from the output paths of the run template. Outputs read from the paths take precedence.
+func WithTektonResults(ctx context.Context, tektonReader *stamp.TektonOutputReader, stampedObject *unstructured.Unstructured, outputs templates.Outputs) templates.Outputs {
+	if tektonReader == nil || !stamp.IsTektonRun(stampedObject) {
+		return outputs
+	}
+
+	log := logr.FromContextOrDiscard(ctx)
+
+	results, err := tektonReader.Outputs(stampedObject)
+	if err != nil {
+		log.V(logger.DEBUG).Info("failed to read tekton results", "object", stampedObject, "error", err.Error())
+		return outputs
+	}
+
+	for key, value := range outputs {
+		results[key] = value
+	}
+	return results
+}
+
+func listTaskRuns(ctx context.Context, stampedObject *unstructured.Unstructured, runnableRepo repository.Repository) []*unstructured.Unstructured {
+	if stampedObject.GetKind() != stamp.TektonPipelineRunKind {
+		return nil
+	}
+
+	log := logr.FromContextOrDiscard(ctx)
+
+	taskRuns, err := runnableRepo.ListUnstructured(ctx, stamp.TaskRunGVK(stampedObject), stampedObject.GetNamespace(), map[string]string{
+		stamp.TektonPipelineRunLabel: stampedObject.GetName(),
+	})
+	if err != nil {
+		log.V(logger.DEBUG).Info("failed to list taskruns of pipelinerun", "object", stampedObject, "error", err.Error())
+		return nil
+	}
+	return taskRuns
 }
 
 func (r *runnableRealizer) resolveSelector(ctx context.Context, selector *v1alpha1.ResourceSelector, repository repository.Repository, discoveryClient discovery.DiscoveryInterface, namespace string) (map[string]interface{}, error) {
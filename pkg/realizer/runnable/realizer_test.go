@@ -26,6 +26,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -68,6 +69,13 @@ var _ = Describe("Realizer", func() {
 		runnableRepo = &repositoryfakes.FakeRepository{}
 		discoveryClient = &runnablefakes.FakeDiscoveryInterface{}
 		fakeMapper = &realizerfakes.FakeRESTMapper{}
+		fakeMapper.RESTMappingReturns(&meta.RESTMapping{
+			Resource: schema.GroupVersionResource{
+				Group:    "test.run",
+				Version:  "v1alpha1",
+				Resource: "testobjs",
+			},
+		}, nil)
 		rlzr = realizer.NewRealizer(fakeMapper)
 
 		runnable = &v1alpha1.Runnable{
@@ -144,7 +152,7 @@ var _ = Describe("Realizer", func() {
 		})
 
 		It("stamps out the resource from the template", func() {
-			_, _, _ = rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+			_, _, _, _ = rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
 
 			Expect(systemRepo.GetRunTemplateCallCount()).To(Equal(1))
 			_, actualTemplate := systemRepo.GetRunTemplateArgsForCall(0)
@@ -175,12 +183,12 @@ var _ = Describe("Realizer", func() {
 		})
 
 		It("does not return an error", func() {
-			_, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+			_, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
 		It("emits a ResourceOutputChangedReason event when the output changes", func() {
-			stampedObject, _, _ := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+			stampedObject, _, _, _ := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
 			Expect(rec.ResourceEventfCallCount()).To(Equal(1))
 			evType, reason, messageFmt, resourceObj, fmtArgs := rec.ResourceEventfArgsForCall(0)
 			Expect(evType).To(Equal("Normal"))
@@ -192,18 +200,18 @@ var _ = Describe("Realizer", func() {
 
 		It("does not emit any event when the output has not changed", func() {
 			runnable.Status.Outputs = templates.Outputs{"myout": apiextensionsv1.JSON{Raw: []byte(`"is a string"`)}}
-			_, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+			_, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(rec.Invocations()).To(BeEmpty())
 		})
 
 		It("returns the outputs", func() {
-			_, outputs, _ := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+			_, outputs, _, _ := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
 			Expect(outputs["myout"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`"is a string"`)}))
 		})
 
 		It("returns the stampedObject", func() {
-			stampedObject, _, _ := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+			stampedObject, _, _, _ := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
 			Expect(stampedObject.Object["spec"]).To(Equal(map[string]interface{}{
 				"foo":   "is a string",
 				"value": nil,
@@ -284,7 +292,7 @@ var _ = Describe("Realizer", func() {
 				return nil
 			}
 
-			_, _, err = rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+			_, _, _, err = rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(runnableRepo.DeleteCallCount()).To(Equal(2))
@@ -295,13 +303,136 @@ var _ = Describe("Realizer", func() {
 			Expect(allDeletedObjects).To(ConsistOf(success2, failed2))
 		})
 
+		It("returns the runs retained by the retention policy, most recent first", func() {
+			runnable.Spec.RetentionPolicy.MaxFailedRuns = 1
+			runnable.Spec.RetentionPolicy.MaxSuccessfulRuns = 1
+
+			t0 := time.Now().Truncate(time.Second)
+
+			dec := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
+			failed1 := &unstructured.Unstructured{}
+			_, _, err := dec.Decode([]byte(utils.HereYaml(`
+				apiVersion: test.run/v1alpha1
+				kind: TestObj
+				metadata:
+				  name: failed1
+				  namespace: my-important-ns
+				  creationTimestamp: `+t0.Add(-1*time.Hour).Format(time.RFC3339)+`
+				status:
+				  conditions:
+					- type: Succeeded
+					  status: "False"
+					  message: step [unit-tests] failed
+					  lastTransitionTime: `+t0.Add(-30*time.Minute).Format(time.RFC3339)+`
+			`)), nil, failed1)
+			Expect(err).NotTo(HaveOccurred())
+			failed2 := &unstructured.Unstructured{}
+			_, _, err = dec.Decode([]byte(utils.HereYaml(`
+				apiVersion: test.run/v1alpha1
+				kind: TestObj
+				metadata:
+				  name: failed2
+				  namespace: my-important-ns
+				  creationTimestamp: `+t0.Add(-2*time.Hour).Format(time.RFC3339)+`
+				status:
+				  conditions:
+					- type: Succeeded
+					  status: "False"
+			`)), nil, failed2)
+			Expect(err).NotTo(HaveOccurred())
+
+			runnableRepo.EnsureImmutableObjectExistsOnClusterStub = func(ctx context.Context, obj *unstructured.Unstructured, labels map[string]string) error {
+				createdUnstructured.Object = obj.Object
+				createdUnstructured.SetName("success1")
+				createdUnstructured.SetCreationTimestamp(metav1.Time{Time: t0})
+				runnableRepo.ListUnstructuredReturns([]*unstructured.Unstructured{failed2, createdUnstructured, failed1}, nil)
+				return nil
+			}
+
+			_, _, runs, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(runnableRepo.DeleteCallCount()).To(Equal(1))
+			_, deleted := runnableRepo.DeleteArgsForCall(0)
+			Expect(deleted).To(Equal(failed2))
+
+			Expect(runs).To(HaveLen(2))
+
+			Expect(runs[0].StampedRef.Name).To(Equal("success1"))
+			Expect(runs[0].StampedRef.Kind).To(Equal("TestObj"))
+			Expect(runs[0].StampedRef.Resource).To(Equal("testobjs.test.run"))
+			Expect(runs[0].StartTime.Time).To(Equal(t0))
+			Expect(runs[0].Health).To(Equal(metav1.ConditionTrue))
+			Expect(runs[0].Outputs).To(Equal(map[string]apiextensionsv1.JSON{
+				"myout": {Raw: []byte(`"is a string"`)},
+			}))
+
+			Expect(runs[1].StampedRef.Name).To(Equal("failed1"))
+			Expect(runs[1].Health).To(Equal(metav1.ConditionFalse))
+			Expect(runs[1].CompletionTime.Time.Equal(t0.Add(-30 * time.Minute))).To(BeTrue())
+			Expect(runs[1].Message).To(Equal("step [unit-tests] failed"))
+			Expect(runs[1].Outputs).To(BeEmpty())
+		})
+
+		It("keeps in progress runs in addition to those the retention policy caps", func() {
+			runnable.Spec.RetentionPolicy.MaxFailedRuns = 1
+			runnable.Spec.RetentionPolicy.MaxSuccessfulRuns = 1
+
+			t0 := time.Now().Truncate(time.Second)
+
+			dec := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
+			running := &unstructured.Unstructured{}
+			_, _, err := dec.Decode([]byte(utils.HereYaml(`
+				apiVersion: test.run/v1alpha1
+				kind: TestObj
+				metadata:
+				  name: running
+				  namespace: my-important-ns
+				  creationTimestamp: `+t0.Add(time.Minute).Format(time.RFC3339)+`
+			`)), nil, running)
+			Expect(err).NotTo(HaveOccurred())
+			failed1 := &unstructured.Unstructured{}
+			_, _, err = dec.Decode([]byte(utils.HereYaml(`
+				apiVersion: test.run/v1alpha1
+				kind: TestObj
+				metadata:
+				  name: failed1
+				  namespace: my-important-ns
+				  creationTimestamp: `+t0.Add(-1*time.Hour).Format(time.RFC3339)+`
+				status:
+				  conditions:
+					- type: Succeeded
+					  status: "False"
+			`)), nil, failed1)
+			Expect(err).NotTo(HaveOccurred())
+
+			runnableRepo.EnsureImmutableObjectExistsOnClusterStub = func(ctx context.Context, obj *unstructured.Unstructured, labels map[string]string) error {
+				createdUnstructured.Object = obj.Object
+				createdUnstructured.SetName("success1")
+				createdUnstructured.SetCreationTimestamp(metav1.Time{Time: t0})
+				runnableRepo.ListUnstructuredReturns([]*unstructured.Unstructured{running, createdUnstructured, failed1}, nil)
+				return nil
+			}
+
+			_, _, runs, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(runnableRepo.DeleteCallCount()).To(Equal(0))
+
+			Expect(runs).To(HaveLen(3))
+			Expect(runs[0].StampedRef.Name).To(Equal("running"))
+			Expect(runs[0].Health).To(Equal(metav1.ConditionUnknown))
+			Expect(runs[1].StampedRef.Name).To(Equal("success1"))
+			Expect(runs[2].StampedRef.Name).To(Equal("failed1"))
+		})
+
 		Context("error on EnsureImmutableObjectExistsOnCluster", func() {
 			BeforeEach(func() {
 				runnableRepo.EnsureImmutableObjectExistsOnClusterReturns(errors.New("some bad error"))
 			})
 
 			It("returns ApplyStampedObjectError", func() {
-				_, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+				_, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("some bad error"))
 				Expect(reflect.TypeOf(err).String()).To(Equal("errors.RunnableApplyStampedObjectError"))
@@ -314,7 +445,7 @@ var _ = Describe("Realizer", func() {
 			})
 
 			It("returns ListCreatedObjectsError", func() {
-				_, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+				_, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("some list error"))
 				Expect(reflect.TypeOf(err).String()).To(Equal("errors.ListCreatedObjectsError"))
@@ -346,7 +477,7 @@ var _ = Describe("Realizer", func() {
 				})
 
 				It("makes the selected object available in the templating context", func() {
-					_, _, _ = rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+					_, _, _, _ = rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
 
 					Expect(runnableRepo.ListUnstructuredCallCount()).To(Equal(2))
 					_, gvk, namespace, labels := runnableRepo.ListUnstructuredArgsForCall(0)
@@ -391,7 +522,7 @@ var _ = Describe("Realizer", func() {
 				})
 
 				It("makes the selected object available in the templating context", func() {
-					_, _, _ = rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+					_, _, _, _ = rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
 
 					Expect(runnableRepo.ListUnstructuredCallCount()).To(Equal(2))
 					_, gvk, namespace, labels := runnableRepo.ListUnstructuredArgsForCall(0)
@@ -438,7 +569,7 @@ var _ = Describe("Realizer", func() {
 			})
 
 			It("returns ResolveSelectorError", func() {
-				_, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+				_, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring(`unable to resolve selector [map[expected-label:expected-value]], apiVersion [apiversion-to-be-selected], kind [kind-to-be-selected]: selector matched multiple objects`))
 				Expect(reflect.TypeOf(err).String()).To(Equal("errors.RunnableResolveSelectorError"))
@@ -458,7 +589,7 @@ var _ = Describe("Realizer", func() {
 			})
 
 			It("returns ResolveSelectorError", func() {
-				_, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+				_, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring(`unable to resolve selector [map[expected-label:expected-value]], apiVersion [apiversion-to-be-selected], kind [kind-to-be-selected]: selector did not match any objects`))
 				Expect(reflect.TypeOf(err).String()).To(Equal("errors.RunnableResolveSelectorError"))
@@ -478,7 +609,7 @@ var _ = Describe("Realizer", func() {
 			})
 
 			It("returns ResolveSelectorError", func() {
-				_, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+				_, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring(`unable to resolve selector [map[expected-label:expected-value]], apiVersion [apiversion-to-be-selected], kind [kind-to-be-selected]: failed to list objects in namespace matching selector [map[expected-label:expected-value]]: listing unstructured is hard`))
 				Expect(reflect.TypeOf(err).String()).To(Equal("errors.RunnableResolveSelectorError"))
@@ -486,6 +617,226 @@ var _ = Describe("Realizer", func() {
 		})
 	})
 
+	Context("with a ClusterRunTemplate that reads tekton results", func() {
+		var templateAPI *v1alpha1.ClusterRunTemplate
+
+		BeforeEach(func() {
+			templateAPI = &v1alpha1.ClusterRunTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-template",
+				},
+				Spec: v1alpha1.RunTemplateSpec{
+					Template: runtime.RawExtension{
+						Raw: []byte(`{"apiVersion": "tekton.dev/v1beta1", "kind": "PipelineRun", "metadata": {"generateName": "my-run-"}}`),
+					},
+					TektonResults: true,
+				},
+			}
+			systemRepo.GetRunTemplateReturns(templateAPI, nil)
+
+			createdUnstructured = &unstructured.Unstructured{}
+			runnable.Spec.RetentionPolicy = v1alpha1.RetentionPolicy{MaxFailedRuns: 10, MaxSuccessfulRuns: 10}
+		})
+
+		Context("the pipelinerun succeeded", func() {
+			BeforeEach(func() {
+				runnableRepo.EnsureImmutableObjectExistsOnClusterStub = func(ctx context.Context, obj *unstructured.Unstructured, labels map[string]string) error {
+					createdUnstructured.Object = obj.Object
+					createdUnstructured.SetName("my-run-abcde")
+					createdUnstructured.SetCreationTimestamp(metav1.Now())
+					createdUnstructured.Object["status"] = map[string]interface{}{
+						"conditions": []interface{}{
+							map[string]interface{}{"type": "Succeeded", "status": "True"},
+						},
+						"pipelineResults": []interface{}{
+							map[string]interface{}{"name": "digest", "value": "sha256:abc"},
+							map[string]interface{}{"name": "commit", "value": "from-results"},
+						},
+					}
+					runnableRepo.ListUnstructuredReturns([]*unstructured.Unstructured{createdUnstructured}, nil)
+					return nil
+				}
+			})
+
+			It("returns the pipeline results as outputs", func() {
+				_, outputs, runs, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(outputs).To(Equal(templates.Outputs{
+					"digest": apiextensionsv1.JSON{Raw: []byte(`"sha256:abc"`)},
+					"commit": apiextensionsv1.JSON{Raw: []byte(`"from-results"`)},
+				}))
+				Expect(runs).To(HaveLen(1))
+				Expect(runs[0].Outputs).To(Equal(map[string]apiextensionsv1.JSON(outputs)))
+			})
+
+			It("prefers declared outputs over results with the same name", func() {
+				templateAPI.Spec.Outputs = map[string]string{"commit": "metadata.name"}
+
+				_, outputs, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(outputs["commit"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`"my-run-abcde"`)}))
+				Expect(outputs["digest"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`"sha256:abc"`)}))
+			})
+		})
+
+		Context("the pipelinerun failed", func() {
+			var failedTaskRun *unstructured.Unstructured
+
+			BeforeEach(func() {
+				failedTaskRun = &unstructured.Unstructured{}
+				dec := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
+				_, _, err := dec.Decode([]byte(utils.HereYaml(`
+					apiVersion: tekton.dev/v1beta1
+					kind: TaskRun
+					metadata:
+					  name: my-run-abcde-test
+					status:
+					  conditions:
+						- type: Succeeded
+						  status: "False"
+						  reason: Failed
+						  message: oops
+					  steps:
+						- name: unit-tests
+						  terminated:
+							exitCode: 1
+				`)), nil, failedTaskRun)
+				Expect(err).NotTo(HaveOccurred())
+
+				runnableRepo.EnsureImmutableObjectExistsOnClusterStub = func(ctx context.Context, obj *unstructured.Unstructured, labels map[string]string) error {
+					createdUnstructured.Object = obj.Object
+					createdUnstructured.SetName("my-run-abcde")
+					createdUnstructured.SetNamespace("my-important-ns")
+					createdUnstructured.SetCreationTimestamp(metav1.Now())
+					createdUnstructured.Object["status"] = map[string]interface{}{
+						"conditions": []interface{}{
+							map[string]interface{}{"type": "Succeeded", "status": "False", "reason": "Failed", "message": "Tasks Completed: 1 (Failed: 1)"},
+						},
+					}
+					return nil
+				}
+
+				runnableRepo.ListUnstructuredStub = func(ctx context.Context, gvk schema.GroupVersionKind, namespace string, labels map[string]string) ([]*unstructured.Unstructured, error) {
+					if gvk.Kind == "TaskRun" {
+						return []*unstructured.Unstructured{failedTaskRun}, nil
+					}
+					return []*unstructured.Unstructured{createdUnstructured}, nil
+				}
+			})
+
+			It("reports the failing taskrun and step in the run's message", func() {
+				_, _, runs, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(runs).To(HaveLen(1))
+				Expect(runs[0].Health).To(Equal(metav1.ConditionFalse))
+				Expect(runs[0].Message).To(Equal("pipelinerun [my-run-abcde] failed: taskrun [my-run-abcde-test] failed at step [unit-tests] with reason [Failed]: oops"))
+
+				Expect(runnableRepo.ListUnstructuredCallCount()).To(Equal(2))
+				_, gvk, namespace, labels := runnableRepo.ListUnstructuredArgsForCall(1)
+				Expect(gvk).To(Equal(schema.GroupVersionKind{Group: "tekton.dev", Version: "v1beta1", Kind: "TaskRun"}))
+				Expect(namespace).To(Equal("my-important-ns"))
+				Expect(labels).To(Equal(map[string]string{"tekton.dev/pipelineRun": "my-run-abcde"}))
+			})
+
+			Context("after an earlier failed pipelinerun", func() {
+				var earlierRun, earlierTaskRun *unstructured.Unstructured
+
+				BeforeEach(func() {
+					earlierRun = &unstructured.Unstructured{}
+					earlierRun.SetAPIVersion("tekton.dev/v1beta1")
+					earlierRun.SetKind("PipelineRun")
+					earlierRun.SetName("my-run-vwxyz")
+					earlierRun.SetNamespace("my-important-ns")
+					earlierRun.SetCreationTimestamp(metav1.NewTime(time.Now().Add(-time.Hour)))
+					earlierRun.Object["status"] = map[string]interface{}{
+						"conditions": []interface{}{
+							map[string]interface{}{"type": "Succeeded", "status": "False", "reason": "Failed", "message": "Tasks Completed: 1 (Failed: 1)"},
+						},
+					}
+
+					earlierTaskRun = failedTaskRun.DeepCopy()
+					earlierTaskRun.SetName("my-run-vwxyz-lint")
+
+					runnable.Status.Runs = []v1alpha1.RunnableRun{{
+						StampedRef: &v1alpha1.StampedRef{ObjectReference: &corev1.ObjectReference{Name: "my-run-vwxyz"}},
+						Health:     metav1.ConditionFalse,
+						Message:    "pipelinerun [my-run-vwxyz] failed: taskrun [my-run-vwxyz-lint] failed",
+					}}
+
+					runnableRepo.ListUnstructuredStub = func(ctx context.Context, gvk schema.GroupVersionKind, namespace string, labels map[string]string) ([]*unstructured.Unstructured, error) {
+						if gvk.Kind == "TaskRun" {
+							if labels["tekton.dev/pipelineRun"] == "my-run-vwxyz" {
+								return []*unstructured.Unstructured{earlierTaskRun}, nil
+							}
+							return []*unstructured.Unstructured{failedTaskRun}, nil
+						}
+						return []*unstructured.Unstructured{earlierRun, createdUnstructured}, nil
+					}
+				})
+
+				It("reports the failing taskrun of each run", func() {
+					_, _, runs, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(runs).To(HaveLen(2))
+					Expect(runs[0].Message).To(HavePrefix("pipelinerun [my-run-abcde] failed: taskrun [my-run-abcde-test]"))
+					Expect(runs[1].Message).To(HavePrefix("pipelinerun [my-run-vwxyz] failed: taskrun [my-run-vwxyz-lint] failed at step [unit-tests]"))
+
+					Expect(runnableRepo.ListUnstructuredCallCount()).To(Equal(3))
+				})
+
+				Context("whose taskruns are no longer found", func() {
+					BeforeEach(func() {
+						earlierTaskRun = nil
+						runnableRepo.ListUnstructuredStub = func(ctx context.Context, gvk schema.GroupVersionKind, namespace string, labels map[string]string) ([]*unstructured.Unstructured, error) {
+							if gvk.Kind == "TaskRun" {
+								if labels["tekton.dev/pipelineRun"] == "my-run-vwxyz" {
+									return nil, nil
+								}
+								return []*unstructured.Unstructured{failedTaskRun}, nil
+							}
+							return []*unstructured.Unstructured{earlierRun, createdUnstructured}, nil
+						}
+					})
+
+					It("keeps the message read when it failed", func() {
+						_, _, runs, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(runs).To(HaveLen(2))
+						Expect(runs[1].Message).To(Equal("pipelinerun [my-run-vwxyz] failed: taskrun [my-run-vwxyz-lint] failed"))
+					})
+				})
+			})
+
+			Context("followed by a pipelinerun in progress", func() {
+				var laterRun *unstructured.Unstructured
+
+				BeforeEach(func() {
+					laterRun = &unstructured.Unstructured{}
+					laterRun.SetAPIVersion("tekton.dev/v1beta1")
+					laterRun.SetKind("PipelineRun")
+					laterRun.SetName("my-run-fghij")
+					laterRun.SetNamespace("my-important-ns")
+					laterRun.SetCreationTimestamp(metav1.NewTime(time.Now().Add(time.Hour)))
+
+					runnableRepo.ListUnstructuredStub = func(ctx context.Context, gvk schema.GroupVersionKind, namespace string, labels map[string]string) ([]*unstructured.Unstructured, error) {
+						if gvk.Kind == "TaskRun" {
+							return []*unstructured.Unstructured{failedTaskRun}, nil
+						}
+						return []*unstructured.Unstructured{createdUnstructured, laterRun}, nil
+					}
+				})
+
+				It("reports the failing taskrun of the failed run", func() {
+					_, _, runs, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(runs).To(HaveLen(2))
+					Expect(runs[0].Health).To(Equal(metav1.ConditionUnknown))
+					Expect(runs[1].Message).To(Equal("pipelinerun [my-run-abcde] failed: taskrun [my-run-abcde-test] failed at step [unit-tests] with reason [Failed]: oops"))
+				})
+			})
+		})
+	})
+
 	Context("with unsatisfied output paths", func() {
 		BeforeEach(func() {
 			templateAPI := &v1alpha1.ClusterRunTemplate{
@@ -533,7 +884,7 @@ var _ = Describe("Realizer", func() {
 		})
 
 		It("returns RetrieveOutputError", func() {
-			_, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+			_, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring(`unable to retrieve outputs from stamped object [my-important-ns/my-stamped-resource-] of type [athing.EXAMPLE.COM] for run template [my-template]: failed to evaluate path [data.hasnot]: jsonpath returned empty list: data.hasnot`))
 			Expect(reflect.TypeOf(err).String()).To(Equal("errors.RunnableRetrieveOutputError"))
@@ -551,7 +902,7 @@ var _ = Describe("Realizer", func() {
 		})
 
 		It("returns StampError", func() {
-			_, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+			_, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring(`unable to stamp object for run template [my-template]: failed to unmarshal json resource template: unexpected end of JSON input`))
 			Expect(reflect.TypeOf(err).String()).To(Equal("errors.RunnableStampError"))
@@ -571,7 +922,7 @@ var _ = Describe("Realizer", func() {
 		})
 
 		It("returns GetRunTemplateError", func() {
-			_, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
+			_, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo, discoveryClient)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring(`unable to get run template [my-template]: Errol mcErrorFace`))
 			Expect(reflect.TypeOf(err).String()).To(Equal("errors.RunnableGetRunTemplateError"))
@@ -14,7 +14,7 @@ import (
 )
 
 type FakeRealizer struct {
-	RealizeStub        func(context.Context, *v1alpha1.Runnable, repository.Repository, repository.Repository, discovery.DiscoveryInterface) (*unstructured.Unstructured, templates.Outputs, error)
+	RealizeStub        func(context.Context, *v1alpha1.Runnable, repository.Repository, repository.Repository, discovery.DiscoveryInterface) (*unstructured.Unstructured, templates.Outputs, []v1alpha1.RunnableRun, error)
 	realizeMutex       sync.RWMutex
 	realizeArgsForCall []struct {
 		arg1 context.Context
@@ -26,18 +26,20 @@ type FakeRealizer struct {
 	realizeReturns struct {
 		result1 *unstructured.Unstructured
 		result2 templates.Outputs
-		result3 error
+		result3 []v1alpha1.RunnableRun
+		result4 error
 	}
 	realizeReturnsOnCall map[int]struct {
 		result1 *unstructured.Unstructured
 		result2 templates.Outputs
-		result3 error
+		result3 []v1alpha1.RunnableRun
+		result4 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeRealizer) Realize(arg1 context.Context, arg2 *v1alpha1.Runnable, arg3 repository.Repository, arg4 repository.Repository, arg5 discovery.DiscoveryInterface) (*unstructured.Unstructured, templates.Outputs, error) {
+func (fake *FakeRealizer) Realize(arg1 context.Context, arg2 *v1alpha1.Runnable, arg3 repository.Repository, arg4 repository.Repository, arg5 discovery.DiscoveryInterface) (*unstructured.Unstructured, templates.Outputs, []v1alpha1.RunnableRun, error) {
 	fake.realizeMutex.Lock()
 	ret, specificReturn := fake.realizeReturnsOnCall[len(fake.realizeArgsForCall)]
 	fake.realizeArgsForCall = append(fake.realizeArgsForCall, struct {
@@ -55,9 +57,9 @@ func (fake *FakeRealizer) Realize(arg1 context.Context, arg2 *v1alpha1.Runnable,
 		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2, ret.result3
+		return ret.result1, ret.result2, ret.result3, ret.result4
 	}
-	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3, fakeReturns.result4
 }
 
 func (fake *FakeRealizer) RealizeCallCount() int {
@@ -66,7 +68,7 @@ func (fake *FakeRealizer) RealizeCallCount() int {
 	return len(fake.realizeArgsForCall)
 }
 
-func (fake *FakeRealizer) RealizeCalls(stub func(context.Context, *v1alpha1.Runnable, repository.Repository, repository.Repository, discovery.DiscoveryInterface) (*unstructured.Unstructured, templates.Outputs, error)) {
+func (fake *FakeRealizer) RealizeCalls(stub func(context.Context, *v1alpha1.Runnable, repository.Repository, repository.Repository, discovery.DiscoveryInterface) (*unstructured.Unstructured, templates.Outputs, []v1alpha1.RunnableRun, error)) {
 	fake.realizeMutex.Lock()
 	defer fake.realizeMutex.Unlock()
 	fake.RealizeStub = stub
@@ -79,18 +81,19 @@ func (fake *FakeRealizer) RealizeArgsForCall(i int) (context.Context, *v1alpha1.
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
-func (fake *FakeRealizer) RealizeReturns(result1 *unstructured.Unstructured, result2 templates.Outputs, result3 error) {
+func (fake *FakeRealizer) RealizeReturns(result1 *unstructured.Unstructured, result2 templates.Outputs, result3 []v1alpha1.RunnableRun, result4 error) {
 	fake.realizeMutex.Lock()
 	defer fake.realizeMutex.Unlock()
 	fake.RealizeStub = nil
 	fake.realizeReturns = struct {
 		result1 *unstructured.Unstructured
 		result2 templates.Outputs
-		result3 error
-	}{result1, result2, result3}
+		result3 []v1alpha1.RunnableRun
+		result4 error
+	}{result1, result2, result3, result4}
 }
 
-func (fake *FakeRealizer) RealizeReturnsOnCall(i int, result1 *unstructured.Unstructured, result2 templates.Outputs, result3 error) {
+func (fake *FakeRealizer) RealizeReturnsOnCall(i int, result1 *unstructured.Unstructured, result2 templates.Outputs, result3 []v1alpha1.RunnableRun, result4 error) {
 	fake.realizeMutex.Lock()
 	defer fake.realizeMutex.Unlock()
 	fake.RealizeStub = nil
@@ -98,14 +101,16 @@ func (fake *FakeRealizer) RealizeReturnsOnCall(i int, result1 *unstructured.Unst
 		fake.realizeReturnsOnCall = make(map[int]struct {
 			result1 *unstructured.Unstructured
 			result2 templates.Outputs
-			result3 error
+			result3 []v1alpha1.RunnableRun
+			result4 error
 		})
 	}
 	fake.realizeReturnsOnCall[i] = struct {
 		result1 *unstructured.Unstructured
 		result2 templates.Outputs
-		result3 error
-	}{result1, result2, result3}
+		result3 []v1alpha1.RunnableRun
+		result4 error
+	}{result1, result2, result3, result4}
 }
 
 func (fake *FakeRealizer) Invocations() map[string][][]interface{} {
@@ -0,0 +1,162 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redact
+
+import (
+	"context"
+	"encoding/base64"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/strings/slices"
+)
+
+const Placeholder = "[REDACTED]"
+
+// Redactor replaces sensitive values, such as params read from Secrets, in what leaves
+// the controller: statuses, logs and events. Values are also redacted in their base64
+// encoding, as stamped Secrets carry them. A nil Redactor redacts nothing.
+type Redactor struct {
+	mu     sync.RWMutex
+	values []string
+}
+
+func NewRedactor() *Redactor {
+	return &Redactor{}
+}
+
+// Add registers a value to redact. Empty values are ignored.
+func (r *Redactor) Add(value string) {
+	if r == nil || value == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, candidate := range []string{value, base64.StdEncoding.EncodeToString([]byte(value))} {
+		if !slices.Contains(r.values, candidate) {
+			r.values = append(r.values, candidate)
+		}
+	}
+
+	// Longer values first, so that a value containing another is redacted whole
+	sort.SliceStable(r.values, func(i, j int) bool {
+		return len(r.values[i]) > len(r.values[j])
+	})
+}
+
+func (r *Redactor) String(s string) string {
+	if r == nil {
+		return s
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, value := range r.values {
+		s = strings.ReplaceAll(s, value, Placeholder)
+	}
+	return s
+}
+
+// Error returns err, or an error with the same message redacted if it holds a
+// sensitive value. The redacted error still wraps err, so that its type can be
+// inspected with errors.As.
+func (r *Redactor) Error(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	message := r.String(err.Error())
+	if message == err.Error() {
+		return err
+	}
+	return redactedError{message: message, err: err}
+}
+
+// Object returns obj, or a copy of it with every string holding a sensitive value
+// redacted.
+func (r *Redactor) Object(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	if r == nil || obj == nil {
+		return obj
+	}
+
+	redacted, changed := r.value(obj.Object)
+	if !changed {
+		return obj
+	}
+	return &unstructured.Unstructured{Object: redacted.(map[string]interface{})}
+}
+
+func (r *Redactor) value(v interface{}) (interface{}, bool) {
+	switch typed := v.(type) {
+	case string:
+		redacted := r.String(typed)
+		return redacted, redacted != typed
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(typed))
+		changed := false
+		for key, item := range typed {
+			var itemChanged bool
+			result[key], itemChanged = r.value(item)
+			changed = changed || itemChanged
+		}
+		return result, changed
+	case []interface{}:
+		result := make([]interface{}, len(typed))
+		changed := false
+		for i, item := range typed {
+			var itemChanged bool
+			result[i], itemChanged = r.value(item)
+			changed = changed || itemChanged
+		}
+		return result, changed
+	default:
+		return v, false
+	}
+}
+
+type redactedError struct {
+	message string
+	err     error
+}
+
+func (e redactedError) Error() string {
+	return e.message
+}
+
+func (e redactedError) Unwrap() error {
+	return e.err
+}
+
+// contextKey is how we find the Redactor in a context.Context.
+type contextKey struct{}
+
+// NewContext returns a new Context, derived from ctx, which carries the
+// provided Redactor.
+func NewContext(ctx context.Context, r *Redactor) context.Context {
+	return context.WithValue(ctx, contextKey{}, r)
+}
+
+// FromContext returns the Redactor carried by ctx, or nil, which redacts nothing.
+func FromContext(ctx context.Context) *Redactor {
+	if r, ok := ctx.Value(contextKey{}).(*Redactor); ok {
+		return r
+	}
+	return nil
+}
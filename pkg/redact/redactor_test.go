@@ -0,0 +1,120 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redact_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/cartographer/pkg/redact"
+)
+
+var _ = Describe("Redactor", func() {
+	var redactor *redact.Redactor
+
+	BeforeEach(func() {
+		redactor = redact.NewRedactor()
+		redactor.Add("s3cr3t")
+	})
+
+	Describe("String", func() {
+		It("redacts the values", func() {
+			Expect(redactor.String("token: s3cr3t")).To(Equal("token: [REDACTED]"))
+		})
+
+		It("redacts the base64 encoding of the values", func() {
+			Expect(redactor.String("token: czNjcjN0")).To(Equal("token: [REDACTED]"))
+		})
+
+		It("redacts a value containing another whole", func() {
+			redactor.Add("s3cr3t-and-more")
+			Expect(redactor.String("token: s3cr3t-and-more")).To(Equal("token: [REDACTED]"))
+		})
+
+		It("ignores empty values", func() {
+			redactor.Add("")
+			Expect(redactor.String("token")).To(Equal("token"))
+		})
+
+		It("redacts nothing when nil", func() {
+			var nilRedactor *redact.Redactor
+			nilRedactor.Add("token")
+			Expect(nilRedactor.String("token")).To(Equal("token"))
+		})
+	})
+
+	Describe("Error", func() {
+		It("returns errors without sensitive values as they are", func() {
+			err := errors.New("some error")
+			Expect(redactor.Error(err)).To(BeIdenticalTo(err))
+		})
+
+		It("redacts the message of errors with sensitive values", func() {
+			Expect(redactor.Error(errors.New("bad value s3cr3t"))).To(MatchError("bad value [REDACTED]"))
+		})
+
+		It("keeps the redacted error wrapping the original", func() {
+			err := errors.New("bad value s3cr3t")
+			Expect(errors.Is(redactor.Error(err), err)).To(BeTrue())
+		})
+
+		It("returns nil for nil", func() {
+			Expect(redactor.Error(nil)).To(BeNil())
+		})
+	})
+
+	Describe("Object", func() {
+		It("redacts a copy of the object", func() {
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{
+				"kind": "Secret",
+				"data": map[string]interface{}{
+					"token": "czNjcjN0",
+				},
+				"args": []interface{}{"--token=s3cr3t", int64(1)},
+			}}
+
+			redacted := redactor.Object(obj)
+
+			Expect(redacted.Object).To(Equal(map[string]interface{}{
+				"kind": "Secret",
+				"data": map[string]interface{}{
+					"token": "[REDACTED]",
+				},
+				"args": []interface{}{"--token=[REDACTED]", int64(1)},
+			}))
+			Expect(obj.Object["args"]).To(Equal([]interface{}{"--token=s3cr3t", int64(1)}))
+		})
+
+		It("returns objects without sensitive values as they are", func() {
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{"kind": "ConfigMap"}}
+			Expect(redactor.Object(obj)).To(BeIdenticalTo(obj))
+		})
+	})
+
+	Describe("context", func() {
+		It("carries the redactor", func() {
+			ctx := redact.NewContext(context.Background(), redactor)
+			Expect(redact.FromContext(ctx)).To(BeIdenticalTo(redactor))
+		})
+
+		It("returns nil when the context carries no redactor", func() {
+			Expect(redact.FromContext(context.Background())).To(BeNil())
+		})
+	})
+})
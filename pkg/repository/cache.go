@@ -15,6 +15,7 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"sync"
@@ -34,6 +35,40 @@ type RepoCache interface {
 	UnchangedSinceCachedFromList(local *unstructured.Unstructured, remote []*unstructured.Unstructured, ownerDiscriminant string) *unstructured.Unstructured
 }
 
+type bypassCacheKey struct{}
+
+type cacheBypass int
+
+const (
+	bypassNone cacheBypass = iota
+	bypassMutable
+	bypassAll
+)
+
+// BypassCache returns a context in which the repository does not consult its cache, so that
+// every object is submitted to the api server and immutable objects are created anew.
+func BypassCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, bypassAll)
+}
+
+// BypassMutableCache returns a context in which the repository does not consult its cache for
+// mutable objects, so that they are submitted to the api server. Immutable objects are not created anew.
+func BypassMutableCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, bypassMutable)
+}
+
+// IsCacheBypassed reports whether the context was returned by BypassCache
+func IsCacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(bypassCacheKey{}).(cacheBypass)
+	return bypass == bypassAll
+}
+
+// IsMutableCacheBypassed reports whether the context was returned by BypassCache or BypassMutableCache
+func IsMutableCacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(bypassCacheKey{}).(cacheBypass)
+	return bypass != bypassNone
+}
+
 func NewCache(l Logger) RepoCache {
 	return &cache{
 		mtx:            &sync.RWMutex{},
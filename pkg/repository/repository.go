@@ -32,6 +32,7 @@ import (
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/events"
 	"github.com/vmware-tanzu/cartographer/pkg/logger"
+	"github.com/vmware-tanzu/cartographer/pkg/redact"
 )
 
 //go:generate go run -modfile ../../hack/tools/go.mod github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -42,18 +43,24 @@ import (
 type Repository interface {
 	EnsureImmutableObjectExistsOnCluster(ctx context.Context, obj *unstructured.Unstructured, labels map[string]string) error
 	EnsureMutableObjectExistsOnCluster(ctx context.Context, obj *unstructured.Unstructured) error
-	GetTemplate(ctx context.Context, name, kind string) (client.Object, error)
+	GetTemplate(ctx context.Context, name, kind, namespace string) (client.Object, error)
 	GetRunTemplate(ctx context.Context, ref v1alpha1.TemplateReference) (*v1alpha1.ClusterRunTemplate, error)
-	GetSupplyChainsForWorkload(ctx context.Context, workload *v1alpha1.Workload) ([]*v1alpha1.ClusterSupplyChain, error)
-	GetDeliveriesForDeliverable(ctx context.Context, deliverable *v1alpha1.Deliverable) ([]*v1alpha1.ClusterDelivery, error)
+	GetTemplateRevision(ctx context.Context, name string) (*v1alpha1.ClusterTemplateRevision, error)
+	GetRevisionOfTemplate(ctx context.Context, name, kind, namespace string, revision int64) (*v1alpha1.ClusterTemplateRevision, error)
+	ListTemplateRevisions(ctx context.Context, ref v1alpha1.TemplateRevisionReference) ([]v1alpha1.ClusterTemplateRevision, error)
+	GetSupplyChainRevision(ctx context.Context, name string) (*v1alpha1.ClusterSupplyChainRevision, error)
+	ListSupplyChainRevisions(ctx context.Context, ref v1alpha1.SupplyChainRevisionReference) ([]v1alpha1.ClusterSupplyChainRevision, error)
+	GetSupplyChainsForWorkload(ctx context.Context, workload *v1alpha1.Workload) ([]v1alpha1.SupplyChainObject, error)
+	GetDeliveriesForDeliverable(ctx context.Context, deliverable *v1alpha1.Deliverable) ([]v1alpha1.DeliveryObject, error)
 	GetWorkload(ctx context.Context, name string, namespace string) (*v1alpha1.Workload, error)
 	GetDeliverable(ctx context.Context, name string, namespace string) (*v1alpha1.Deliverable, error)
-	GetSupplyChain(ctx context.Context, name string) (*v1alpha1.ClusterSupplyChain, error)
+	GetSupplyChain(ctx context.Context, name, namespace string) (v1alpha1.SupplyChainObject, error)
+	GetSupplyChainFragment(ctx context.Context, name string) (*v1alpha1.ClusterSupplyChainFragment, error)
 	StatusUpdate(ctx context.Context, object client.Object) error
 	GetRunnable(ctx context.Context, name string, namespace string) (*v1alpha1.Runnable, error)
 	GetUnstructured(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error)
 	ListUnstructured(ctx context.Context, gvk schema.GroupVersionKind, namespace string, labels map[string]string) ([]*unstructured.Unstructured, error)
-	GetDelivery(ctx context.Context, name string) (*v1alpha1.ClusterDelivery, error)
+	GetDelivery(ctx context.Context, name, namespace string) (v1alpha1.DeliveryObject, error)
 	GetScheme() *runtime.Scheme
 	GetServiceAccount(ctx context.Context, serviceAccountName, ns string) (*corev1.ServiceAccount, error)
 	Delete(ctx context.Context, objToDelete *unstructured.Unstructured) error
@@ -106,14 +113,20 @@ func (r *repository) GetServiceAccount(ctx context.Context, name, namespace stri
 	return serviceAccount, nil
 }
 
-func (r *repository) GetDelivery(ctx context.Context, name string) (*v1alpha1.ClusterDelivery, error) {
+// GetDelivery returns the ClusterDelivery with the given name when namespace is empty,
+// and the namespaced Delivery otherwise.
+func (r *repository) GetDelivery(ctx context.Context, name, namespace string) (v1alpha1.DeliveryObject, error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("GetDelivery")
 
-	delivery := &v1alpha1.ClusterDelivery{}
+	var delivery v1alpha1.DeliveryObject = &v1alpha1.ClusterDelivery{}
+	if namespace != "" {
+		delivery = &v1alpha1.Delivery{}
+	}
 
 	key := client.ObjectKey{
-		Name: name,
+		Namespace: namespace,
+		Name:      name,
 	}
 
 	err := r.cl.Get(ctx, key, delivery)
@@ -123,7 +136,7 @@ func (r *repository) GetDelivery(ctx context.Context, name string) (*v1alpha1.Cl
 	}
 	if err != nil {
 		log.Error(err, "failed to get delivery object from api server")
-		return nil, fmt.Errorf("failed to get delivery object from api server [%s]: %w", name, err)
+		return nil, fmt.Errorf("failed to get delivery object from api server [%s]: %w", getNamespacedName(name, namespace), err)
 	}
 
 	return delivery, nil
@@ -135,22 +148,23 @@ func (r *repository) EnsureMutableObjectExistsOnCluster(ctx context.Context, obj
 
 	existingObj, err := r.GetUnstructured(ctx, obj)
 	log.V(logger.DEBUG).Info("considering object from api server",
-		"considered", obj)
+		"considered", redact.FromContext(ctx).Object(obj))
 
 	if err != nil {
 		return err
 	}
 
 	if existingObj != nil {
-		cacheHit := r.rc.UnchangedSinceCached(obj, existingObj)
-		if cacheHit != nil {
+		if IsMutableCacheBypassed(ctx) {
+			log.V(logger.DEBUG).Info("cache bypassed")
+		} else if cacheHit := r.rc.UnchangedSinceCached(obj, existingObj); cacheHit != nil {
 			*obj = *cacheHit
 			return nil
 		}
-		log.Info("patching object", "object", obj)
+		log.Info("patching object", "object", redact.FromContext(ctx).Object(obj))
 		return r.patchUnstructured(ctx, existingObj, obj)
 	} else {
-		log.Info("creating object", "object", obj)
+		log.Info("creating object", "object", redact.FromContext(ctx).Object(obj))
 		return r.createUnstructured(ctx, obj, "")
 	}
 }
@@ -163,7 +177,7 @@ func (r *repository) EnsureImmutableObjectExistsOnCluster(ctx context.Context, o
 
 	for _, considered := range unstructuredList {
 		log.V(logger.DEBUG).Info("considering objects from api server",
-			"considered", considered)
+			"considered", redact.FromContext(ctx).Object(considered))
 	}
 
 	if err != nil {
@@ -172,13 +186,14 @@ func (r *repository) EnsureImmutableObjectExistsOnCluster(ctx context.Context, o
 
 	ownerDiscriminant := buildOwnerDiscriminant(labels)
 
-	cacheHit := r.rc.UnchangedSinceCachedFromList(obj, unstructuredList, ownerDiscriminant)
-	if cacheHit != nil {
+	if IsCacheBypassed(ctx) {
+		log.V(logger.DEBUG).Info("cache bypassed")
+	} else if cacheHit := r.rc.UnchangedSinceCachedFromList(obj, unstructuredList, ownerDiscriminant); cacheHit != nil {
 		*obj = *cacheHit
 		return nil
 	}
 
-	log.Info("creating object", "object", obj)
+	log.Info("creating object", "object", redact.FromContext(ctx).Object(obj))
 	return r.createUnstructured(ctx, obj, ownerDiscriminant)
 }
 
@@ -234,25 +249,45 @@ func (r *repository) ListUnstructured(ctx context.Context, gvk schema.GroupVersi
 	pointersToUnstructureds := make([]*unstructured.Unstructured, len(unstructuredList.Items))
 
 	//FIXME: why are we taking a deep copy?
-	for i, item := range unstructuredList.Items {
+	for i := range unstructuredList.Items {
+		item := &unstructuredList.Items[i]
 		log.V(logger.DEBUG).Info("unstructured that matched",
-			"namespace", namespace, "labels", labels, "unstructured", item)
+			"namespace", namespace, "labels", labels, "unstructured", redact.FromContext(ctx).Object(item))
 		pointersToUnstructureds[i] = item.DeepCopy()
 	}
 	return pointersToUnstructureds, nil
 }
 
-func (r *repository) GetTemplate(ctx context.Context, name string, kind string) (client.Object, error) {
+// GetTemplate returns the template of the given kind and name. A namespaced template kind
+// is looked up in namespace first, falling back to its cluster scoped kind when there is
+// no such template in the namespace.
+func (r *repository) GetTemplate(ctx context.Context, name, kind, namespace string) (client.Object, error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("GetTemplate")
 
+	if v1alpha1.IsNamespacedTemplateKind(kind) {
+		if namespace != "" {
+			template, err := r.getTemplate(ctx, name, kind, namespace)
+			if err != nil || template != nil {
+				return template, err
+			}
+		}
+		kind = v1alpha1.ClusterTemplateKind(kind)
+	}
+
+	return r.getTemplate(ctx, name, kind, "")
+}
+
+func (r *repository) getTemplate(ctx context.Context, name, kind, namespace string) (client.Object, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
 	apiTemplate, err := v1alpha1.GetAPITemplate(kind)
 	if err != nil {
 		log.Error(err, "unable to get api template")
 		return nil, fmt.Errorf("unable to get api template [%s/%s]: %w", kind, name, err)
 	}
 
-	err = r.getObject(ctx, name, "", apiTemplate)
+	err = r.getObject(ctx, name, namespace, apiTemplate)
 	//TODO: Remove IsNotFound check, this should just be an error, breaks kuttl test
 	if kerrors.IsNotFound(err) {
 		log.V(logger.DEBUG).Info("template is not found on api server")
@@ -260,7 +295,7 @@ func (r *repository) GetTemplate(ctx context.Context, name string, kind string)
 	}
 	if err != nil {
 		log.Error(err, "failed to get template object from api server")
-		return nil, fmt.Errorf("failed to get template object from api server [%s/%s]: %w", kind, name, err)
+		return nil, fmt.Errorf("failed to get template object from api server [%s/%s]: %w", kind, getNamespacedName(name, namespace), err)
 	}
 
 	return apiTemplate, nil
@@ -283,6 +318,111 @@ func (r *repository) GetRunTemplate(ctx context.Context, ref v1alpha1.TemplateRe
 	return runTemplate, nil
 }
 
+func (r *repository) GetTemplateRevision(ctx context.Context, name string) (*v1alpha1.ClusterTemplateRevision, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	log.V(logger.DEBUG).Info("GetTemplateRevision")
+
+	revision := &v1alpha1.ClusterTemplateRevision{}
+	err := r.getObject(ctx, name, "", revision)
+	if kerrors.IsNotFound(err) {
+		log.V(logger.DEBUG).Info("template revision is not found on api server")
+		return nil, nil
+	}
+	if err != nil {
+		log.Error(err, "failed to get template revision object from api server")
+		return nil, fmt.Errorf("failed to get template revision object from api server [%s]: %w", name, err)
+	}
+
+	return revision, nil
+}
+
+// GetRevisionOfTemplate returns the given revision of the template of the given kind and
+// name, whether or not the template still exists. Like GetTemplate, a namespaced kind
+// falls back to the revisions of the cluster scoped template of the same name.
+func (r *repository) GetRevisionOfTemplate(ctx context.Context, name, kind, namespace string, revision int64) (*v1alpha1.ClusterTemplateRevision, error) {
+	if v1alpha1.IsNamespacedTemplateKind(kind) {
+		if namespace != "" {
+			ref := v1alpha1.TemplateRevisionReference{Kind: kind, Namespace: namespace, Name: name}
+			templateRevision, err := r.GetTemplateRevision(ctx, v1alpha1.TemplateRevisionName(ref, revision))
+			if err != nil || templateRevision != nil {
+				return templateRevision, err
+			}
+		}
+		kind = v1alpha1.ClusterTemplateKind(kind)
+	}
+
+	ref := v1alpha1.TemplateRevisionReference{Kind: kind, Name: name}
+	return r.GetTemplateRevision(ctx, v1alpha1.TemplateRevisionName(ref, revision))
+}
+
+// ListTemplateRevisions returns the revisions of the referenced template, oldest first.
+func (r *repository) ListTemplateRevisions(ctx context.Context, ref v1alpha1.TemplateRevisionReference) ([]v1alpha1.ClusterTemplateRevision, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	log.V(logger.DEBUG).Info("ListTemplateRevisions")
+
+	list := &v1alpha1.ClusterTemplateRevisionList{}
+	if err := r.cl.List(ctx, list, client.MatchingLabels(v1alpha1.TemplateRevisionSelector(ref))); err != nil {
+		log.Error(err, "unable to list template revisions from api server")
+		return nil, fmt.Errorf("unable to list template revisions from api server: %w", err)
+	}
+
+	// the selector holds a hash of the reference, which may collide
+	var revisions []v1alpha1.ClusterTemplateRevision
+	for _, revision := range list.Items {
+		if revision.Spec.TemplateRef == ref {
+			revisions = append(revisions, revision)
+		}
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Spec.Revision < revisions[j].Spec.Revision
+	})
+
+	return revisions, nil
+}
+
+func (r *repository) GetSupplyChainRevision(ctx context.Context, name string) (*v1alpha1.ClusterSupplyChainRevision, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	log.V(logger.DEBUG).Info("GetSupplyChainRevision")
+
+	revision := &v1alpha1.ClusterSupplyChainRevision{}
+	err := r.getObject(ctx, name, "", revision)
+	if kerrors.IsNotFound(err) {
+		log.V(logger.DEBUG).Info("supply chain revision is not found on api server")
+		return nil, nil
+	}
+	if err != nil {
+		log.Error(err, "failed to get supply chain revision object from api server")
+		return nil, fmt.Errorf("failed to get supply chain revision object from api server [%s]: %w", name, err)
+	}
+
+	return revision, nil
+}
+
+// ListSupplyChainRevisions returns the revisions of the referenced supply chain, oldest first.
+func (r *repository) ListSupplyChainRevisions(ctx context.Context, ref v1alpha1.SupplyChainRevisionReference) ([]v1alpha1.ClusterSupplyChainRevision, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	log.V(logger.DEBUG).Info("ListSupplyChainRevisions")
+
+	list := &v1alpha1.ClusterSupplyChainRevisionList{}
+	if err := r.cl.List(ctx, list, client.MatchingLabels(v1alpha1.SupplyChainRevisionSelector(ref))); err != nil {
+		log.Error(err, "unable to list supply chain revisions from api server")
+		return nil, fmt.Errorf("unable to list supply chain revisions from api server: %w", err)
+	}
+
+	// the selector holds a hash of the reference, which may collide
+	var revisions []v1alpha1.ClusterSupplyChainRevision
+	for _, revision := range list.Items {
+		if revision.Spec.SupplyChainRef == ref {
+			revisions = append(revisions, revision)
+		}
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Spec.Revision < revisions[j].Spec.Revision
+	})
+
+	return revisions, nil
+}
+
 func (r *repository) createUnstructured(ctx context.Context, obj *unstructured.Unstructured, ownerDiscriminant string) error {
 	submitted := obj.DeepCopy()
 	if err := r.cl.Create(ctx, obj); err != nil {
@@ -311,17 +451,37 @@ func (r *repository) patchUnstructured(ctx context.Context, existingObj *unstruc
 	return nil
 }
 
-func (r *repository) GetSupplyChainsForWorkload(ctx context.Context, workload *v1alpha1.Workload) ([]*v1alpha1.ClusterSupplyChain, error) {
+// GetSupplyChainsForWorkload selects from the SupplyChains in the namespace of the
+// workload, and only when none of those match, from the ClusterSupplyChains.
+func (r *repository) GetSupplyChainsForWorkload(ctx context.Context, workload *v1alpha1.Workload) ([]v1alpha1.SupplyChainObject, error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("GetSupplyChainsForWorkload")
 
+	namespacedList := &v1alpha1.SupplyChainList{}
+	if err := r.cl.List(ctx, namespacedList, client.InNamespace(workload.Namespace)); err != nil {
+		log.Error(err, "unable to list namespaced supply chains from api server")
+		return nil, fmt.Errorf("unable to list supply chains in namespace [%s] from api server: %w", workload.Namespace, err)
+	}
+
+	var namespacedSupplyChains []v1alpha1.SupplyChainObject
+
+	for _, item := range namespacedList.Items {
+		itemValue := item
+		namespacedSupplyChains = append(namespacedSupplyChains, &itemValue)
+	}
+
+	selected, err := GetSelectedSupplyChain(namespacedSupplyChains, workload, log)
+	if err != nil || len(selected) > 0 {
+		return selected, err
+	}
+
 	list := &v1alpha1.ClusterSupplyChainList{}
 	if err := r.cl.List(ctx, list); err != nil {
 		log.Error(err, "unable to list supply chains from api server")
 		return nil, fmt.Errorf("unable to list supply chains from api server: %w", err)
 	}
 
-	var supplyChains []*v1alpha1.ClusterSupplyChain
+	var supplyChains []v1alpha1.SupplyChainObject
 
 	for _, item := range list.Items {
 		itemValue := item
@@ -331,14 +491,14 @@ func (r *repository) GetSupplyChainsForWorkload(ctx context.Context, workload *v
 	return GetSelectedSupplyChain(supplyChains, workload, log)
 }
 
-func GetSelectedSupplyChain(allSupplyChains []*v1alpha1.ClusterSupplyChain, workload *v1alpha1.Workload, log logr.Logger) ([]*v1alpha1.ClusterSupplyChain, error) {
+func GetSelectedSupplyChain(allSupplyChains []v1alpha1.SupplyChainObject, workload *v1alpha1.Workload, log logr.Logger) ([]v1alpha1.SupplyChainObject, error) {
 	var selectorGetters []SelectingObject
 	for _, item := range allSupplyChains {
 		itemValue := item
 		selectorGetters = append(selectorGetters, itemValue)
 	}
 
-	var supplyChains []*v1alpha1.ClusterSupplyChain
+	var supplyChains []v1alpha1.SupplyChainObject
 	matches, err := BestSelectorMatch(workload, selectorGetters)
 	if err != nil {
 		return nil, fmt.Errorf("evaluating supply chain selectors against workload [%s/%s] failed: %w", workload.Namespace, workload.Name, err)
@@ -346,29 +506,86 @@ func GetSelectedSupplyChain(allSupplyChains []*v1alpha1.ClusterSupplyChain, work
 	for _, matchingObject := range matches {
 		log.V(logger.DEBUG).Info("supply chain matched workload",
 			"supply chain", matchingObject)
-		supplyChains = append(supplyChains, matchingObject.(*v1alpha1.ClusterSupplyChain))
+		supplyChains = append(supplyChains, matchingObject.(v1alpha1.SupplyChainObject))
 	}
 
-	return supplyChains, nil
+	return highestPrioritySupplyChains(supplyChains), nil
 }
 
-func (r *repository) GetDeliveriesForDeliverable(ctx context.Context, deliverable *v1alpha1.Deliverable) ([]*v1alpha1.ClusterDelivery, error) {
+// highestPrioritySupplyChains breaks ties between equally specific supply chains
+// by keeping only those with the highest spec.priority.
+func highestPrioritySupplyChains(supplyChains []v1alpha1.SupplyChainObject) []v1alpha1.SupplyChainObject {
+	if len(supplyChains) < 2 {
+		return supplyChains
+	}
+
+	highest := supplyChains[0].GetSupplyChainSpec().Priority
+	for _, supplyChain := range supplyChains {
+		if supplyChain.GetSupplyChainSpec().Priority > highest {
+			highest = supplyChain.GetSupplyChainSpec().Priority
+		}
+	}
+
+	var prioritized []v1alpha1.SupplyChainObject
+	for _, supplyChain := range supplyChains {
+		if supplyChain.GetSupplyChainSpec().Priority == highest {
+			prioritized = append(prioritized, supplyChain)
+		}
+	}
+	return prioritized
+}
+
+// GetDeliveriesForDeliverable selects from the Deliveries in the namespace of the
+// deliverable, and only when none of those match, from the ClusterDeliveries.
+func (r *repository) GetDeliveriesForDeliverable(ctx context.Context, deliverable *v1alpha1.Deliverable) ([]v1alpha1.DeliveryObject, error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("GetDeliveriesForDeliverable")
 
+	namespacedList := &v1alpha1.DeliveryList{}
+	if err := r.cl.List(ctx, namespacedList, client.InNamespace(deliverable.Namespace)); err != nil {
+		log.Error(err, "unable to list namespaced deliveries from api server")
+		return nil, fmt.Errorf("unable to list deliveries in namespace [%s] from api server: %w", deliverable.Namespace, err)
+	}
+
+	var selectorGetters []SelectingObject
+	for _, item := range namespacedList.Items {
+		itemValue := item
+		selectorGetters = append(selectorGetters, &itemValue)
+	}
+
+	deliveries, err := selectDeliveries(deliverable, selectorGetters, log)
+	if err != nil || len(deliveries) > 0 {
+		return deliveries, err
+	}
+
 	list := &v1alpha1.ClusterDeliveryList{}
 	if err := r.cl.List(ctx, list); err != nil {
 		log.Error(err, "unable to list deliveries from api server")
 		return nil, fmt.Errorf("unable to list deliveries from api server: %w", err)
 	}
 
-	var selectorGetters []SelectingObject
+	selectorGetters = nil
 	for _, item := range list.Items {
 		itemValue := item
 		selectorGetters = append(selectorGetters, &itemValue)
 	}
 
-	var deliveries []*v1alpha1.ClusterDelivery
+	return selectDeliveries(deliverable, selectorGetters, log)
+}
+
+// GetSelectedDelivery selects from the given deliveries those whose selectors best match the deliverable.
+func GetSelectedDelivery(allDeliveries []v1alpha1.DeliveryObject, deliverable *v1alpha1.Deliverable, log logr.Logger) ([]v1alpha1.DeliveryObject, error) {
+	var selectorGetters []SelectingObject
+	for _, item := range allDeliveries {
+		itemValue := item
+		selectorGetters = append(selectorGetters, itemValue)
+	}
+
+	return selectDeliveries(deliverable, selectorGetters, log)
+}
+
+func selectDeliveries(deliverable *v1alpha1.Deliverable, selectorGetters []SelectingObject, log logr.Logger) ([]v1alpha1.DeliveryObject, error) {
+	var deliveries []v1alpha1.DeliveryObject
 	matches, err := BestSelectorMatch(deliverable, selectorGetters)
 	if err != nil {
 		return nil, fmt.Errorf("evaluating supply chain selectors against deliverable [%s/%s] failed: %w", deliverable.Namespace, deliverable.Name, err)
@@ -376,7 +593,7 @@ func (r *repository) GetDeliveriesForDeliverable(ctx context.Context, deliverabl
 	for _, matchingObject := range matches {
 		log.V(logger.DEBUG).Info("delivery matched deliverable",
 			"delivery", matchingObject)
-		deliveries = append(deliveries, matchingObject.(*v1alpha1.ClusterDelivery))
+		deliveries = append(deliveries, matchingObject.(v1alpha1.DeliveryObject))
 	}
 
 	log.V(logger.DEBUG).Info("deliveries matched deliverable",
@@ -473,23 +690,47 @@ func (r *repository) GetRunnable(ctx context.Context, name string, namespace str
 	return runnable, nil
 }
 
-func (r *repository) GetSupplyChain(ctx context.Context, name string) (*v1alpha1.ClusterSupplyChain, error) {
+// GetSupplyChain returns the ClusterSupplyChain with the given name when namespace is
+// empty, and the namespaced SupplyChain otherwise.
+func (r *repository) GetSupplyChain(ctx context.Context, name, namespace string) (v1alpha1.SupplyChainObject, error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("GetSupplyChain")
 
-	supplyChain := v1alpha1.ClusterSupplyChain{}
+	var supplyChain v1alpha1.SupplyChainObject = &v1alpha1.ClusterSupplyChain{}
+	if namespace != "" {
+		supplyChain = &v1alpha1.SupplyChain{}
+	}
 
-	err := r.getObject(ctx, name, "", &supplyChain)
+	err := r.getObject(ctx, name, namespace, supplyChain)
 	if kerrors.IsNotFound(err) {
 		log.V(logger.DEBUG).Info("supply chain is not found on api server")
 		return nil, nil
 	}
 	if err != nil {
 		log.Error(err, "failed to get supply chain object from api server")
-		return nil, fmt.Errorf("failed to get supply chain object from api server [%s]: %w", name, err)
+		return nil, fmt.Errorf("failed to get supply chain object from api server [%s]: %w", getNamespacedName(name, namespace), err)
+	}
+
+	return supplyChain, nil
+}
+
+func (r *repository) GetSupplyChainFragment(ctx context.Context, name string) (*v1alpha1.ClusterSupplyChainFragment, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	log.V(logger.DEBUG).Info("GetSupplyChainFragment")
+
+	fragment := v1alpha1.ClusterSupplyChainFragment{}
+
+	err := r.getObject(ctx, name, "", &fragment)
+	if kerrors.IsNotFound(err) {
+		log.V(logger.DEBUG).Info("supply chain fragment is not found on api server")
+		return nil, nil
+	}
+	if err != nil {
+		log.Error(err, "failed to get supply chain fragment object from api server")
+		return nil, fmt.Errorf("failed to get supply chain fragment object from api server [%s]: %w", name, err)
 	}
 
-	return &supplyChain, nil
+	return &fragment, nil
 }
 
 func (r *repository) StatusUpdate(ctx context.Context, object client.Object) error {
@@ -22,6 +22,7 @@ import (
 	"reflect"
 
 	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gbytes"
@@ -40,6 +41,7 @@ import (
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/events"
 	"github.com/vmware-tanzu/cartographer/pkg/events/eventsfakes"
+	"github.com/vmware-tanzu/cartographer/pkg/redact"
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/utils"
@@ -111,6 +113,26 @@ spec:
 				Expect(obj.GetObjectKind().GroupVersionKind()).To(Equal(stampedObj.GroupVersionKind()))
 			})
 
+			Context("when the object holds a sensitive value", func() {
+				BeforeEach(func() {
+					ctx = logr.NewContext(ctx, funcr.New(func(prefix, args string) {
+						_, _ = fmt.Fprintln(out, prefix, args)
+					}, funcr.Options{Verbosity: 1}))
+					redactor := redact.NewRedactor()
+					redactor.Add("s3cr3t")
+					ctx = redact.NewContext(ctx, redactor)
+
+					stampedObj.SetAnnotations(map[string]string{"token": "s3cr3t"})
+				})
+
+				It("redacts it from the debug logs", func() {
+					Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)).To(Succeed())
+
+					Expect(string(out.Contents())).To(ContainSubstring("considering object from api server"))
+					Expect(string(out.Contents())).NotTo(ContainSubstring("s3cr3t"))
+				})
+			})
+
 			Context("when the apiServer errors when trying to get the object", func() {
 				BeforeEach(func() {
 					cl.GetReturns(errors.New("some-error"))
@@ -274,6 +296,32 @@ spec:
 					})
 				})
 
+				Context("and the context bypasses the cache", func() {
+					BeforeEach(func() {
+						cache.UnchangedSinceCachedReturns(existingObj)
+						ctx = repository.BypassCache(ctx)
+					})
+
+					It("does not consult the cache and patches the object", func() {
+						Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)).To(Succeed())
+						Expect(cache.UnchangedSinceCachedCallCount()).To(Equal(0))
+						Expect(cl.PatchCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("and the context bypasses the cache for mutable objects", func() {
+					BeforeEach(func() {
+						cache.UnchangedSinceCachedReturns(existingObj)
+						ctx = repository.BypassMutableCache(ctx)
+					})
+
+					It("does not consult the cache and patches the object", func() {
+						Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)).To(Succeed())
+						Expect(cache.UnchangedSinceCachedCallCount()).To(Equal(0))
+						Expect(cl.PatchCallCount()).To(Equal(1))
+					})
+				})
+
 				Context("and the cache determines there has been a change since the last update", func() {
 					BeforeEach(func() {
 						cache.UnchangedSinceCachedReturns(nil)
@@ -409,6 +457,27 @@ spec:
 					}
 				})
 
+				Context("and the objects hold a sensitive value", func() {
+					BeforeEach(func() {
+						ctx = logr.NewContext(ctx, funcr.New(func(prefix, args string) {
+							_, _ = fmt.Fprintln(out, prefix, args)
+						}, funcr.Options{Verbosity: 1}))
+						redactor := redact.NewRedactor()
+						redactor.Add("s3cr3t")
+						ctx = redact.NewContext(ctx, redactor)
+
+						existingObjList.Items[0].SetAnnotations(map[string]string{"token": "s3cr3t"})
+					})
+
+					It("redacts it from the debug logs", func() {
+						Expect(repo.EnsureImmutableObjectExistsOnCluster(ctx, stampedObj, labels)).To(Succeed())
+
+						Expect(string(out.Contents())).To(ContainSubstring("considering objects from api server"))
+						Expect(string(out.Contents())).To(ContainSubstring("unstructured that matched"))
+						Expect(string(out.Contents())).NotTo(ContainSubstring("s3cr3t"))
+					})
+				})
+
 				It("the cache is consulted (with an ownerDiscriminant of the labels) to see if there was a change since the last time the cache was updated", func() {
 					Expect(repo.EnsureImmutableObjectExistsOnCluster(ctx, stampedObj, labels)).To(Succeed())
 					Expect(cache.UnchangedSinceCachedFromListCallCount()).To(Equal(1))
@@ -449,6 +518,32 @@ spec:
 					})
 				})
 
+				Context("and the context bypasses the cache", func() {
+					BeforeEach(func() {
+						cache.UnchangedSinceCachedFromListReturns(existingObj)
+						ctx = repository.BypassCache(ctx)
+					})
+
+					It("does not consult the cache and creates a new object", func() {
+						Expect(repo.EnsureImmutableObjectExistsOnCluster(ctx, stampedObj, labels)).To(Succeed())
+						Expect(cache.UnchangedSinceCachedFromListCallCount()).To(Equal(0))
+						Expect(cl.CreateCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("and the context bypasses the cache for mutable objects only", func() {
+					BeforeEach(func() {
+						cache.UnchangedSinceCachedFromListReturns(existingObj)
+						ctx = repository.BypassMutableCache(ctx)
+					})
+
+					It("consults the cache and does not create a new object", func() {
+						Expect(repo.EnsureImmutableObjectExistsOnCluster(ctx, stampedObj, labels)).To(Succeed())
+						Expect(cache.UnchangedSinceCachedFromListCallCount()).To(Equal(1))
+						Expect(cl.CreateCallCount()).To(Equal(0))
+					})
+				})
+
 				Context("and the cache determines there has been a change since the last update", func() {
 					BeforeEach(func() {
 						cache.UnchangedSinceCachedReturns(nil)
@@ -557,7 +652,7 @@ spec:
 			It("attempts to list the object from the apiServer", func() {
 				_, err := repo.GetSupplyChainsForWorkload(ctx, &v1alpha1.Workload{})
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("unable to list supply chains from api server: some list error"))
+				Expect(err.Error()).To(ContainSubstring("unable to list supply chains in namespace [] from api server: some list error"))
 			})
 		})
 
@@ -567,7 +662,7 @@ spec:
 			})
 
 			It("attempts to get the object from the apiServer", func() {
-				_, err := repo.GetSupplyChain(ctx, "sc-name")
+				_, err := repo.GetSupplyChain(ctx, "sc-name", "")
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("failed to get supply chain object from api server [sc-name]: failed to get object [sc-name] from api server: some get error"))
 			})
@@ -659,7 +754,7 @@ spec:
 						Kind: "some-unsupported-kind",
 						Name: "my-template",
 					}
-					_, err := repo.GetTemplate(ctx, reference.Name, reference.Kind)
+					_, err := repo.GetTemplate(ctx, reference.Name, reference.Kind, "")
 					Expect(err).To(HaveOccurred())
 					Expect(err.Error()).To(ContainSubstring("unable to get api template [some-unsupported-kind/my-template]: resource does not have valid kind: some-unsupported-kind"))
 				})
@@ -674,7 +769,7 @@ spec:
 						Kind: "ClusterImageTemplate",
 						Name: "image-template",
 					}
-					_, err := repo.GetTemplate(ctx, reference.Name, reference.Kind)
+					_, err := repo.GetTemplate(ctx, reference.Name, reference.Kind, "")
 					Expect(err).To(HaveOccurred())
 					Expect(err.Error()).To(ContainSubstring("failed to get template object from api server [ClusterImageTemplate/image-template]: failed to get object [image-template] from api server: some bad get error"))
 				})
@@ -689,7 +784,7 @@ spec:
 					cl.GetReturns(apiError)
 				})
 				It("returns the error", func() {
-					_, err := repo.GetDelivery(ctx, "my-delivery")
+					_, err := repo.GetDelivery(ctx, "my-delivery", "")
 					Expect(err).To(HaveOccurred())
 					Expect(err.Error()).To(ContainSubstring(apiError.Error()))
 				})
@@ -708,7 +803,7 @@ spec:
 					cl.GetReturns(apiError)
 				})
 				It("returns a nil result without error and without logging an error", func() {
-					delivery, err := repo.GetDelivery(ctx, "my-delivery")
+					delivery, err := repo.GetDelivery(ctx, "my-delivery", "")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(delivery).To(BeNil())
 
@@ -729,7 +824,7 @@ spec:
 				})
 
 				It("asks for the delivery by name", func() {
-					_, err := repo.GetDelivery(ctx, "my-delivery")
+					_, err := repo.GetDelivery(ctx, "my-delivery", "")
 					Expect(err).NotTo(HaveOccurred())
 
 					Expect(cl.GetCallCount()).To(Equal(1))
@@ -741,7 +836,7 @@ spec:
 				})
 
 				It("returns the delivery without error", func() {
-					delivery, err := repo.GetDelivery(ctx, "my-delivery")
+					delivery, err := repo.GetDelivery(ctx, "my-delivery", "")
 					Expect(err).NotTo(HaveOccurred())
 
 					Expect(delivery).To(Equal(apiDelivery))
@@ -848,7 +943,7 @@ spec:
 					Kind: "ClusterSourceTemplate",
 					Name: "some-name",
 				}
-				template, err := repo.GetTemplate(ctx, templateRef.Name, templateRef.Kind)
+				template, err := repo.GetTemplate(ctx, templateRef.Name, templateRef.Kind, "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(template.GetName()).To(Equal("some-name"))
 			})
@@ -869,12 +964,110 @@ spec:
 					Kind: "ClusterSourceTemplate",
 					Name: "some-name",
 				}
-				template, err := repo.GetTemplate(ctx, templateRef.Name, templateRef.Kind)
+				template, err := repo.GetTemplate(ctx, templateRef.Name, templateRef.Kind, "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(template.GetName()).To(Equal("some-name"))
 			})
 		})
 
+		Context("GetTemplate of a namespaced kind", func() {
+			BeforeEach(func() {
+				clientObjects = []client.Object{
+					&v1alpha1.ClusterSourceTemplate{
+						ObjectMeta: metav1.ObjectMeta{Name: "git"},
+					},
+					&v1alpha1.SourceTemplate{
+						ObjectMeta: metav1.ObjectMeta{Name: "git", Namespace: "team-a"},
+					},
+				}
+			})
+
+			It("gets the template from the namespace", func() {
+				template, err := repo.GetTemplate(ctx, "git", "SourceTemplate", "team-a")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(template).To(BeAssignableToTypeOf(&v1alpha1.SourceTemplate{}))
+				Expect(template.GetNamespace()).To(Equal("team-a"))
+			})
+
+			It("falls back to the cluster template of the same name", func() {
+				template, err := repo.GetTemplate(ctx, "git", "SourceTemplate", "team-b")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(template).To(BeAssignableToTypeOf(&v1alpha1.ClusterSourceTemplate{}))
+			})
+
+			It("does not look in the namespace for cluster kinds", func() {
+				template, err := repo.GetTemplate(ctx, "git", "ClusterSourceTemplate", "team-a")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(template).To(BeAssignableToTypeOf(&v1alpha1.ClusterSourceTemplate{}))
+			})
+		})
+
+		Context("ListTemplateRevisions", func() {
+			var ref v1alpha1.TemplateRevisionReference
+
+			BeforeEach(func() {
+				ref = v1alpha1.TemplateRevisionReference{Kind: "ClusterSourceTemplate", Name: "some-name"}
+				template := &v1alpha1.ClusterSourceTemplate{ObjectMeta: metav1.ObjectMeta{Name: "some-name"}}
+
+				clientObjects = nil
+				for _, revisionRef := range []v1alpha1.TemplateRevisionReference{
+					ref,
+					{Kind: "ClusterSourceTemplate", Name: "other-name"},
+					{Kind: "SourceTemplate", Namespace: "some-namespace", Name: "some-name"},
+				} {
+					for _, number := range []int64{2, 1} {
+						revision, err := v1alpha1.NewTemplateRevision(revisionRef, number, template)
+						Expect(err).NotTo(HaveOccurred())
+						clientObjects = append(clientObjects, revision)
+					}
+				}
+			})
+
+			It("returns the revisions of the template, oldest first", func() {
+				revisions, err := repo.ListTemplateRevisions(ctx, ref)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(revisions).To(HaveLen(2))
+				Expect(revisions[0].Spec.TemplateRef).To(Equal(ref))
+				Expect(revisions[0].Spec.Revision).To(Equal(int64(1)))
+				Expect(revisions[1].Spec.TemplateRef).To(Equal(ref))
+				Expect(revisions[1].Spec.Revision).To(Equal(int64(2)))
+			})
+		})
+
+		Context("GetRevisionOfTemplate", func() {
+			BeforeEach(func() {
+				template := &v1alpha1.ClusterSourceTemplate{ObjectMeta: metav1.ObjectMeta{Name: "some-name"}}
+
+				clientObjects = nil
+				for _, revisionRef := range []v1alpha1.TemplateRevisionReference{
+					{Kind: "ClusterSourceTemplate", Name: "some-name"},
+					{Kind: "SourceTemplate", Namespace: "some-namespace", Name: "some-name"},
+				} {
+					revision, err := v1alpha1.NewTemplateRevision(revisionRef, 1, template)
+					Expect(err).NotTo(HaveOccurred())
+					clientObjects = append(clientObjects, revision)
+				}
+			})
+
+			It("gets the revision of the namespaced template", func() {
+				revision, err := repo.GetRevisionOfTemplate(ctx, "some-name", "SourceTemplate", "some-namespace", 1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(revision.Spec.TemplateRef.Namespace).To(Equal("some-namespace"))
+			})
+
+			It("falls back to the revision of the cluster scoped template", func() {
+				revision, err := repo.GetRevisionOfTemplate(ctx, "some-name", "SourceTemplate", "other-namespace", 1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(revision.Spec.TemplateRef.Kind).To(Equal("ClusterSourceTemplate"))
+			})
+
+			It("returns nothing when the revision does not exist", func() {
+				revision, err := repo.GetRevisionOfTemplate(ctx, "some-name", "ClusterSourceTemplate", "", 2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(revision).To(BeNil())
+			})
+		})
+
 		Context("GetRunTemplate", func() {
 			BeforeEach(func() {
 				clientObjects = []client.Object{
@@ -996,14 +1189,14 @@ spec:
 			})
 
 			It("gets the supply chain successfully", func() {
-				sc, err := repo.GetSupplyChain(ctx, "sc-name")
+				sc, err := repo.GetSupplyChain(ctx, "sc-name", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(sc.GetName()).To(Equal("sc-name"))
 			})
 
 			Context("supply chain doesnt exist", func() {
 				It("returns no error without logging an error", func() {
-					sc, err := repo.GetSupplyChain(ctx, "sc-that-does-not-exist-name")
+					sc, err := repo.GetSupplyChain(ctx, "sc-that-does-not-exist-name", "")
 					Expect(err).ToNot(HaveOccurred())
 					Expect(sc).To(BeNil())
 
@@ -1012,6 +1205,33 @@ spec:
 			})
 		})
 
+		Context("GetSupplyChainFragment", func() {
+			BeforeEach(func() {
+				fragment := &v1alpha1.ClusterSupplyChainFragment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "fragment-name",
+					},
+				}
+				clientObjects = []client.Object{fragment}
+			})
+
+			It("gets the fragment successfully", func() {
+				fragment, err := repo.GetSupplyChainFragment(ctx, "fragment-name")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fragment.GetName()).To(Equal("fragment-name"))
+			})
+
+			Context("fragment doesnt exist", func() {
+				It("returns no error without logging an error", func() {
+					fragment, err := repo.GetSupplyChainFragment(ctx, "fragment-that-does-not-exist-name")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(fragment).To(BeNil())
+
+					Expect(out.Contents()).To(BeEmpty())
+				})
+			})
+		})
+
 		Context("GetSupplyChainsForWorkload", func() {
 			Context("When a matchFields key is invalid", func() {
 				BeforeEach(func() {
@@ -1084,7 +1304,7 @@ spec:
 					supplyChains, err := repo.GetSupplyChainsForWorkload(ctx, workload)
 					Expect(err).ToNot(HaveOccurred())
 					Expect(len(supplyChains)).To(Equal(1))
-					Expect(supplyChains[0].Name).To(Equal("supplychain-name"))
+					Expect(supplyChains[0].GetName()).To(Equal("supplychain-name"))
 				})
 			})
 
@@ -1128,7 +1348,7 @@ spec:
 					supplyChains, err := repo.GetSupplyChainsForWorkload(ctx, workload)
 					Expect(err).ToNot(HaveOccurred())
 					Expect(len(supplyChains)).To(Equal(1))
-					Expect(supplyChains[0].Name).To(Equal("supplychain-name"))
+					Expect(supplyChains[0].GetName()).To(Equal("supplychain-name"))
 				})
 
 				It("returns no supply chains for workload", func() {
@@ -1146,6 +1366,141 @@ spec:
 					Expect(len(supplyChains)).To(Equal(0))
 				})
 			})
+
+			Context("More than one equally specific supply chain", func() {
+				var workload *v1alpha1.Workload
+
+				makeSupplyChain := func(name string, priority int32) *v1alpha1.ClusterSupplyChain {
+					return &v1alpha1.ClusterSupplyChain{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: name,
+						},
+						Spec: v1alpha1.SupplyChainSpec{
+							LegacySelector: v1alpha1.LegacySelector{
+								Selector: map[string]string{"foo": "bar"},
+							},
+							Priority: priority,
+						},
+					}
+				}
+
+				BeforeEach(func() {
+					workload = &v1alpha1.Workload{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:   "workload-name",
+							Labels: map[string]string{"foo": "bar"},
+						},
+					}
+				})
+
+				Context("with different priorities", func() {
+					BeforeEach(func() {
+						clientObjects = []client.Object{
+							makeSupplyChain("low", -1),
+							makeSupplyChain("high", 10),
+							makeSupplyChain("default", 0),
+						}
+					})
+
+					It("returns the supply chain with the highest priority", func() {
+						supplyChains, err := repo.GetSupplyChainsForWorkload(ctx, workload)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(len(supplyChains)).To(Equal(1))
+						Expect(supplyChains[0].GetName()).To(Equal("high"))
+					})
+				})
+
+				Context("sharing the highest priority", func() {
+					BeforeEach(func() {
+						clientObjects = []client.Object{
+							makeSupplyChain("low", 0),
+							makeSupplyChain("high-1", 5),
+							makeSupplyChain("high-2", 5),
+						}
+					})
+
+					It("returns all supply chains sharing the highest priority", func() {
+						supplyChains, err := repo.GetSupplyChainsForWorkload(ctx, workload)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(len(supplyChains)).To(Equal(2))
+						Expect([]string{supplyChains[0].GetName(), supplyChains[1].GetName()}).To(ConsistOf("high-1", "high-2"))
+					})
+				})
+
+				Context("where a less specific supply chain has a higher priority", func() {
+					BeforeEach(func() {
+						specific := makeSupplyChain("specific", 0)
+						specific.Spec.Selector["env"] = "prod"
+						workload.Labels["env"] = "prod"
+						clientObjects = []client.Object{
+							makeSupplyChain("generic", 100),
+							specific,
+						}
+					})
+
+					It("returns the more specific supply chain", func() {
+						supplyChains, err := repo.GetSupplyChainsForWorkload(ctx, workload)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(len(supplyChains)).To(Equal(1))
+						Expect(supplyChains[0].GetName()).To(Equal("specific"))
+					})
+				})
+			})
+
+			Context("namespaced and cluster supply chains", func() {
+				var workload *v1alpha1.Workload
+
+				BeforeEach(func() {
+					workload = &v1alpha1.Workload{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "workload-name",
+							Namespace: "team-a",
+							Labels:    map[string]string{"foo": "bar"},
+						},
+					}
+					clientObjects = []client.Object{
+						&v1alpha1.ClusterSupplyChain{
+							ObjectMeta: metav1.ObjectMeta{Name: "cluster-chain"},
+							Spec: v1alpha1.SupplyChainSpec{
+								LegacySelector: v1alpha1.LegacySelector{Selector: map[string]string{"foo": "bar"}},
+							},
+						},
+						&v1alpha1.SupplyChain{
+							ObjectMeta: metav1.ObjectMeta{Name: "team-chain", Namespace: "team-a"},
+							Spec: v1alpha1.SupplyChainSpec{
+								LegacySelector: v1alpha1.LegacySelector{Selector: map[string]string{"foo": "bar"}},
+							},
+						},
+						&v1alpha1.SupplyChain{
+							ObjectMeta: metav1.ObjectMeta{Name: "other-team-chain", Namespace: "team-b"},
+							Spec: v1alpha1.SupplyChainSpec{
+								LegacySelector: v1alpha1.LegacySelector{Selector: map[string]string{"foo": "bar"}},
+							},
+						},
+					}
+				})
+
+				It("prefers the supply chain in the namespace of the workload", func() {
+					supplyChains, err := repo.GetSupplyChainsForWorkload(ctx, workload)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(supplyChains)).To(Equal(1))
+					Expect(supplyChains[0].GetName()).To(Equal("team-chain"))
+					Expect(supplyChains[0].GetNamespace()).To(Equal("team-a"))
+				})
+
+				Context("when no namespaced supply chain matches", func() {
+					BeforeEach(func() {
+						workload.Namespace = "team-c"
+					})
+
+					It("falls back to the cluster supply chains", func() {
+						supplyChains, err := repo.GetSupplyChainsForWorkload(ctx, workload)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(len(supplyChains)).To(Equal(1))
+						Expect(supplyChains[0].GetName()).To(Equal("cluster-chain"))
+					})
+				})
+			})
 		})
 
 		Context("Delete", func() {
@@ -67,32 +67,50 @@ type FakeRepository struct {
 		result1 *v1alpha1.Deliverable
 		result2 error
 	}
-	GetDeliveriesForDeliverableStub        func(context.Context, *v1alpha1.Deliverable) ([]*v1alpha1.ClusterDelivery, error)
+	GetDeliveriesForDeliverableStub        func(context.Context, *v1alpha1.Deliverable) ([]v1alpha1.DeliveryObject, error)
 	getDeliveriesForDeliverableMutex       sync.RWMutex
 	getDeliveriesForDeliverableArgsForCall []struct {
 		arg1 context.Context
 		arg2 *v1alpha1.Deliverable
 	}
 	getDeliveriesForDeliverableReturns struct {
-		result1 []*v1alpha1.ClusterDelivery
+		result1 []v1alpha1.DeliveryObject
 		result2 error
 	}
 	getDeliveriesForDeliverableReturnsOnCall map[int]struct {
-		result1 []*v1alpha1.ClusterDelivery
+		result1 []v1alpha1.DeliveryObject
 		result2 error
 	}
-	GetDeliveryStub        func(context.Context, string) (*v1alpha1.ClusterDelivery, error)
+	GetDeliveryStub        func(context.Context, string, string) (v1alpha1.DeliveryObject, error)
 	getDeliveryMutex       sync.RWMutex
 	getDeliveryArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	getDeliveryReturns struct {
-		result1 *v1alpha1.ClusterDelivery
+		result1 v1alpha1.DeliveryObject
 		result2 error
 	}
 	getDeliveryReturnsOnCall map[int]struct {
-		result1 *v1alpha1.ClusterDelivery
+		result1 v1alpha1.DeliveryObject
+		result2 error
+	}
+	GetRevisionOfTemplateStub        func(context.Context, string, string, string, int64) (*v1alpha1.ClusterTemplateRevision, error)
+	getRevisionOfTemplateMutex       sync.RWMutex
+	getRevisionOfTemplateArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 int64
+	}
+	getRevisionOfTemplateReturns struct {
+		result1 *v1alpha1.ClusterTemplateRevision
+		result2 error
+	}
+	getRevisionOfTemplateReturnsOnCall map[int]struct {
+		result1 *v1alpha1.ClusterTemplateRevision
 		result2 error
 	}
 	GetRunTemplateStub        func(context.Context, v1alpha1.TemplateReference) (*v1alpha1.ClusterRunTemplate, error)
@@ -149,40 +167,70 @@ type FakeRepository struct {
 		result1 *v1.ServiceAccount
 		result2 error
 	}
-	GetSupplyChainStub        func(context.Context, string) (*v1alpha1.ClusterSupplyChain, error)
+	GetSupplyChainStub        func(context.Context, string, string) (v1alpha1.SupplyChainObject, error)
 	getSupplyChainMutex       sync.RWMutex
 	getSupplyChainArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	getSupplyChainReturns struct {
-		result1 *v1alpha1.ClusterSupplyChain
+		result1 v1alpha1.SupplyChainObject
 		result2 error
 	}
 	getSupplyChainReturnsOnCall map[int]struct {
-		result1 *v1alpha1.ClusterSupplyChain
+		result1 v1alpha1.SupplyChainObject
+		result2 error
+	}
+	GetSupplyChainFragmentStub        func(context.Context, string) (*v1alpha1.ClusterSupplyChainFragment, error)
+	getSupplyChainFragmentMutex       sync.RWMutex
+	getSupplyChainFragmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getSupplyChainFragmentReturns struct {
+		result1 *v1alpha1.ClusterSupplyChainFragment
 		result2 error
 	}
-	GetSupplyChainsForWorkloadStub        func(context.Context, *v1alpha1.Workload) ([]*v1alpha1.ClusterSupplyChain, error)
+	getSupplyChainFragmentReturnsOnCall map[int]struct {
+		result1 *v1alpha1.ClusterSupplyChainFragment
+		result2 error
+	}
+	GetSupplyChainRevisionStub        func(context.Context, string) (*v1alpha1.ClusterSupplyChainRevision, error)
+	getSupplyChainRevisionMutex       sync.RWMutex
+	getSupplyChainRevisionArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getSupplyChainRevisionReturns struct {
+		result1 *v1alpha1.ClusterSupplyChainRevision
+		result2 error
+	}
+	getSupplyChainRevisionReturnsOnCall map[int]struct {
+		result1 *v1alpha1.ClusterSupplyChainRevision
+		result2 error
+	}
+	GetSupplyChainsForWorkloadStub        func(context.Context, *v1alpha1.Workload) ([]v1alpha1.SupplyChainObject, error)
 	getSupplyChainsForWorkloadMutex       sync.RWMutex
 	getSupplyChainsForWorkloadArgsForCall []struct {
 		arg1 context.Context
 		arg2 *v1alpha1.Workload
 	}
 	getSupplyChainsForWorkloadReturns struct {
-		result1 []*v1alpha1.ClusterSupplyChain
+		result1 []v1alpha1.SupplyChainObject
 		result2 error
 	}
 	getSupplyChainsForWorkloadReturnsOnCall map[int]struct {
-		result1 []*v1alpha1.ClusterSupplyChain
+		result1 []v1alpha1.SupplyChainObject
 		result2 error
 	}
-	GetTemplateStub        func(context.Context, string, string) (client.Object, error)
+	GetTemplateStub        func(context.Context, string, string, string) (client.Object, error)
 	getTemplateMutex       sync.RWMutex
 	getTemplateArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
 		arg3 string
+		arg4 string
 	}
 	getTemplateReturns struct {
 		result1 client.Object
@@ -192,6 +240,20 @@ type FakeRepository struct {
 		result1 client.Object
 		result2 error
 	}
+	GetTemplateRevisionStub        func(context.Context, string) (*v1alpha1.ClusterTemplateRevision, error)
+	getTemplateRevisionMutex       sync.RWMutex
+	getTemplateRevisionArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getTemplateRevisionReturns struct {
+		result1 *v1alpha1.ClusterTemplateRevision
+		result2 error
+	}
+	getTemplateRevisionReturnsOnCall map[int]struct {
+		result1 *v1alpha1.ClusterTemplateRevision
+		result2 error
+	}
 	GetUnstructuredStub        func(context.Context, *unstructured.Unstructured) (*unstructured.Unstructured, error)
 	getUnstructuredMutex       sync.RWMutex
 	getUnstructuredArgsForCall []struct {
@@ -221,6 +283,34 @@ type FakeRepository struct {
 		result1 *v1alpha1.Workload
 		result2 error
 	}
+	ListSupplyChainRevisionsStub        func(context.Context, v1alpha1.SupplyChainRevisionReference) ([]v1alpha1.ClusterSupplyChainRevision, error)
+	listSupplyChainRevisionsMutex       sync.RWMutex
+	listSupplyChainRevisionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 v1alpha1.SupplyChainRevisionReference
+	}
+	listSupplyChainRevisionsReturns struct {
+		result1 []v1alpha1.ClusterSupplyChainRevision
+		result2 error
+	}
+	listSupplyChainRevisionsReturnsOnCall map[int]struct {
+		result1 []v1alpha1.ClusterSupplyChainRevision
+		result2 error
+	}
+	ListTemplateRevisionsStub        func(context.Context, v1alpha1.TemplateRevisionReference) ([]v1alpha1.ClusterTemplateRevision, error)
+	listTemplateRevisionsMutex       sync.RWMutex
+	listTemplateRevisionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 v1alpha1.TemplateRevisionReference
+	}
+	listTemplateRevisionsReturns struct {
+		result1 []v1alpha1.ClusterTemplateRevision
+		result2 error
+	}
+	listTemplateRevisionsReturnsOnCall map[int]struct {
+		result1 []v1alpha1.ClusterTemplateRevision
+		result2 error
+	}
 	ListUnstructuredStub        func(context.Context, schema.GroupVersionKind, string, map[string]string) ([]*unstructured.Unstructured, error)
 	listUnstructuredMutex       sync.RWMutex
 	listUnstructuredArgsForCall []struct {
@@ -506,7 +596,7 @@ func (fake *FakeRepository) GetDeliverableReturnsOnCall(i int, result1 *v1alpha1
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) GetDeliveriesForDeliverable(arg1 context.Context, arg2 *v1alpha1.Deliverable) ([]*v1alpha1.ClusterDelivery, error) {
+func (fake *FakeRepository) GetDeliveriesForDeliverable(arg1 context.Context, arg2 *v1alpha1.Deliverable) ([]v1alpha1.DeliveryObject, error) {
 	fake.getDeliveriesForDeliverableMutex.Lock()
 	ret, specificReturn := fake.getDeliveriesForDeliverableReturnsOnCall[len(fake.getDeliveriesForDeliverableArgsForCall)]
 	fake.getDeliveriesForDeliverableArgsForCall = append(fake.getDeliveriesForDeliverableArgsForCall, struct {
@@ -532,7 +622,7 @@ func (fake *FakeRepository) GetDeliveriesForDeliverableCallCount() int {
 	return len(fake.getDeliveriesForDeliverableArgsForCall)
 }
 
-func (fake *FakeRepository) GetDeliveriesForDeliverableCalls(stub func(context.Context, *v1alpha1.Deliverable) ([]*v1alpha1.ClusterDelivery, error)) {
+func (fake *FakeRepository) GetDeliveriesForDeliverableCalls(stub func(context.Context, *v1alpha1.Deliverable) ([]v1alpha1.DeliveryObject, error)) {
 	fake.getDeliveriesForDeliverableMutex.Lock()
 	defer fake.getDeliveriesForDeliverableMutex.Unlock()
 	fake.GetDeliveriesForDeliverableStub = stub
@@ -545,45 +635,46 @@ func (fake *FakeRepository) GetDeliveriesForDeliverableArgsForCall(i int) (conte
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeRepository) GetDeliveriesForDeliverableReturns(result1 []*v1alpha1.ClusterDelivery, result2 error) {
+func (fake *FakeRepository) GetDeliveriesForDeliverableReturns(result1 []v1alpha1.DeliveryObject, result2 error) {
 	fake.getDeliveriesForDeliverableMutex.Lock()
 	defer fake.getDeliveriesForDeliverableMutex.Unlock()
 	fake.GetDeliveriesForDeliverableStub = nil
 	fake.getDeliveriesForDeliverableReturns = struct {
-		result1 []*v1alpha1.ClusterDelivery
+		result1 []v1alpha1.DeliveryObject
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) GetDeliveriesForDeliverableReturnsOnCall(i int, result1 []*v1alpha1.ClusterDelivery, result2 error) {
+func (fake *FakeRepository) GetDeliveriesForDeliverableReturnsOnCall(i int, result1 []v1alpha1.DeliveryObject, result2 error) {
 	fake.getDeliveriesForDeliverableMutex.Lock()
 	defer fake.getDeliveriesForDeliverableMutex.Unlock()
 	fake.GetDeliveriesForDeliverableStub = nil
 	if fake.getDeliveriesForDeliverableReturnsOnCall == nil {
 		fake.getDeliveriesForDeliverableReturnsOnCall = make(map[int]struct {
-			result1 []*v1alpha1.ClusterDelivery
+			result1 []v1alpha1.DeliveryObject
 			result2 error
 		})
 	}
 	fake.getDeliveriesForDeliverableReturnsOnCall[i] = struct {
-		result1 []*v1alpha1.ClusterDelivery
+		result1 []v1alpha1.DeliveryObject
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) GetDelivery(arg1 context.Context, arg2 string) (*v1alpha1.ClusterDelivery, error) {
+func (fake *FakeRepository) GetDelivery(arg1 context.Context, arg2 string, arg3 string) (v1alpha1.DeliveryObject, error) {
 	fake.getDeliveryMutex.Lock()
 	ret, specificReturn := fake.getDeliveryReturnsOnCall[len(fake.getDeliveryArgsForCall)]
 	fake.getDeliveryArgsForCall = append(fake.getDeliveryArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.GetDeliveryStub
 	fakeReturns := fake.getDeliveryReturns
-	fake.recordInvocation("GetDelivery", []interface{}{arg1, arg2})
+	fake.recordInvocation("GetDelivery", []interface{}{arg1, arg2, arg3})
 	fake.getDeliveryMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -597,41 +688,109 @@ func (fake *FakeRepository) GetDeliveryCallCount() int {
 	return len(fake.getDeliveryArgsForCall)
 }
 
-func (fake *FakeRepository) GetDeliveryCalls(stub func(context.Context, string) (*v1alpha1.ClusterDelivery, error)) {
+func (fake *FakeRepository) GetDeliveryCalls(stub func(context.Context, string, string) (v1alpha1.DeliveryObject, error)) {
 	fake.getDeliveryMutex.Lock()
 	defer fake.getDeliveryMutex.Unlock()
 	fake.GetDeliveryStub = stub
 }
 
-func (fake *FakeRepository) GetDeliveryArgsForCall(i int) (context.Context, string) {
+func (fake *FakeRepository) GetDeliveryArgsForCall(i int) (context.Context, string, string) {
 	fake.getDeliveryMutex.RLock()
 	defer fake.getDeliveryMutex.RUnlock()
 	argsForCall := fake.getDeliveryArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeRepository) GetDeliveryReturns(result1 *v1alpha1.ClusterDelivery, result2 error) {
+func (fake *FakeRepository) GetDeliveryReturns(result1 v1alpha1.DeliveryObject, result2 error) {
 	fake.getDeliveryMutex.Lock()
 	defer fake.getDeliveryMutex.Unlock()
 	fake.GetDeliveryStub = nil
 	fake.getDeliveryReturns = struct {
-		result1 *v1alpha1.ClusterDelivery
+		result1 v1alpha1.DeliveryObject
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) GetDeliveryReturnsOnCall(i int, result1 *v1alpha1.ClusterDelivery, result2 error) {
+func (fake *FakeRepository) GetDeliveryReturnsOnCall(i int, result1 v1alpha1.DeliveryObject, result2 error) {
 	fake.getDeliveryMutex.Lock()
 	defer fake.getDeliveryMutex.Unlock()
 	fake.GetDeliveryStub = nil
 	if fake.getDeliveryReturnsOnCall == nil {
 		fake.getDeliveryReturnsOnCall = make(map[int]struct {
-			result1 *v1alpha1.ClusterDelivery
+			result1 v1alpha1.DeliveryObject
 			result2 error
 		})
 	}
 	fake.getDeliveryReturnsOnCall[i] = struct {
-		result1 *v1alpha1.ClusterDelivery
+		result1 v1alpha1.DeliveryObject
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetRevisionOfTemplate(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 int64) (*v1alpha1.ClusterTemplateRevision, error) {
+	fake.getRevisionOfTemplateMutex.Lock()
+	ret, specificReturn := fake.getRevisionOfTemplateReturnsOnCall[len(fake.getRevisionOfTemplateArgsForCall)]
+	fake.getRevisionOfTemplateArgsForCall = append(fake.getRevisionOfTemplateArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 int64
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.GetRevisionOfTemplateStub
+	fakeReturns := fake.getRevisionOfTemplateReturns
+	fake.recordInvocation("GetRevisionOfTemplate", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.getRevisionOfTemplateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) GetRevisionOfTemplateCallCount() int {
+	fake.getRevisionOfTemplateMutex.RLock()
+	defer fake.getRevisionOfTemplateMutex.RUnlock()
+	return len(fake.getRevisionOfTemplateArgsForCall)
+}
+
+func (fake *FakeRepository) GetRevisionOfTemplateCalls(stub func(context.Context, string, string, string, int64) (*v1alpha1.ClusterTemplateRevision, error)) {
+	fake.getRevisionOfTemplateMutex.Lock()
+	defer fake.getRevisionOfTemplateMutex.Unlock()
+	fake.GetRevisionOfTemplateStub = stub
+}
+
+func (fake *FakeRepository) GetRevisionOfTemplateArgsForCall(i int) (context.Context, string, string, string, int64) {
+	fake.getRevisionOfTemplateMutex.RLock()
+	defer fake.getRevisionOfTemplateMutex.RUnlock()
+	argsForCall := fake.getRevisionOfTemplateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeRepository) GetRevisionOfTemplateReturns(result1 *v1alpha1.ClusterTemplateRevision, result2 error) {
+	fake.getRevisionOfTemplateMutex.Lock()
+	defer fake.getRevisionOfTemplateMutex.Unlock()
+	fake.GetRevisionOfTemplateStub = nil
+	fake.getRevisionOfTemplateReturns = struct {
+		result1 *v1alpha1.ClusterTemplateRevision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetRevisionOfTemplateReturnsOnCall(i int, result1 *v1alpha1.ClusterTemplateRevision, result2 error) {
+	fake.getRevisionOfTemplateMutex.Lock()
+	defer fake.getRevisionOfTemplateMutex.Unlock()
+	fake.GetRevisionOfTemplateStub = nil
+	if fake.getRevisionOfTemplateReturnsOnCall == nil {
+		fake.getRevisionOfTemplateReturnsOnCall = make(map[int]struct {
+			result1 *v1alpha1.ClusterTemplateRevision
+			result2 error
+		})
+	}
+	fake.getRevisionOfTemplateReturnsOnCall[i] = struct {
+		result1 *v1alpha1.ClusterTemplateRevision
 		result2 error
 	}{result1, result2}
 }
@@ -886,19 +1045,20 @@ func (fake *FakeRepository) GetServiceAccountReturnsOnCall(i int, result1 *v1.Se
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) GetSupplyChain(arg1 context.Context, arg2 string) (*v1alpha1.ClusterSupplyChain, error) {
+func (fake *FakeRepository) GetSupplyChain(arg1 context.Context, arg2 string, arg3 string) (v1alpha1.SupplyChainObject, error) {
 	fake.getSupplyChainMutex.Lock()
 	ret, specificReturn := fake.getSupplyChainReturnsOnCall[len(fake.getSupplyChainArgsForCall)]
 	fake.getSupplyChainArgsForCall = append(fake.getSupplyChainArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.GetSupplyChainStub
 	fakeReturns := fake.getSupplyChainReturns
-	fake.recordInvocation("GetSupplyChain", []interface{}{arg1, arg2})
+	fake.recordInvocation("GetSupplyChain", []interface{}{arg1, arg2, arg3})
 	fake.getSupplyChainMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -912,46 +1072,176 @@ func (fake *FakeRepository) GetSupplyChainCallCount() int {
 	return len(fake.getSupplyChainArgsForCall)
 }
 
-func (fake *FakeRepository) GetSupplyChainCalls(stub func(context.Context, string) (*v1alpha1.ClusterSupplyChain, error)) {
+func (fake *FakeRepository) GetSupplyChainCalls(stub func(context.Context, string, string) (v1alpha1.SupplyChainObject, error)) {
 	fake.getSupplyChainMutex.Lock()
 	defer fake.getSupplyChainMutex.Unlock()
 	fake.GetSupplyChainStub = stub
 }
 
-func (fake *FakeRepository) GetSupplyChainArgsForCall(i int) (context.Context, string) {
+func (fake *FakeRepository) GetSupplyChainArgsForCall(i int) (context.Context, string, string) {
 	fake.getSupplyChainMutex.RLock()
 	defer fake.getSupplyChainMutex.RUnlock()
 	argsForCall := fake.getSupplyChainArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeRepository) GetSupplyChainReturns(result1 *v1alpha1.ClusterSupplyChain, result2 error) {
+func (fake *FakeRepository) GetSupplyChainReturns(result1 v1alpha1.SupplyChainObject, result2 error) {
 	fake.getSupplyChainMutex.Lock()
 	defer fake.getSupplyChainMutex.Unlock()
 	fake.GetSupplyChainStub = nil
 	fake.getSupplyChainReturns = struct {
-		result1 *v1alpha1.ClusterSupplyChain
+		result1 v1alpha1.SupplyChainObject
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) GetSupplyChainReturnsOnCall(i int, result1 *v1alpha1.ClusterSupplyChain, result2 error) {
+func (fake *FakeRepository) GetSupplyChainReturnsOnCall(i int, result1 v1alpha1.SupplyChainObject, result2 error) {
 	fake.getSupplyChainMutex.Lock()
 	defer fake.getSupplyChainMutex.Unlock()
 	fake.GetSupplyChainStub = nil
 	if fake.getSupplyChainReturnsOnCall == nil {
 		fake.getSupplyChainReturnsOnCall = make(map[int]struct {
-			result1 *v1alpha1.ClusterSupplyChain
+			result1 v1alpha1.SupplyChainObject
 			result2 error
 		})
 	}
 	fake.getSupplyChainReturnsOnCall[i] = struct {
-		result1 *v1alpha1.ClusterSupplyChain
+		result1 v1alpha1.SupplyChainObject
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetSupplyChainFragment(arg1 context.Context, arg2 string) (*v1alpha1.ClusterSupplyChainFragment, error) {
+	fake.getSupplyChainFragmentMutex.Lock()
+	ret, specificReturn := fake.getSupplyChainFragmentReturnsOnCall[len(fake.getSupplyChainFragmentArgsForCall)]
+	fake.getSupplyChainFragmentArgsForCall = append(fake.getSupplyChainFragmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetSupplyChainFragmentStub
+	fakeReturns := fake.getSupplyChainFragmentReturns
+	fake.recordInvocation("GetSupplyChainFragment", []interface{}{arg1, arg2})
+	fake.getSupplyChainFragmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) GetSupplyChainFragmentCallCount() int {
+	fake.getSupplyChainFragmentMutex.RLock()
+	defer fake.getSupplyChainFragmentMutex.RUnlock()
+	return len(fake.getSupplyChainFragmentArgsForCall)
+}
+
+func (fake *FakeRepository) GetSupplyChainFragmentCalls(stub func(context.Context, string) (*v1alpha1.ClusterSupplyChainFragment, error)) {
+	fake.getSupplyChainFragmentMutex.Lock()
+	defer fake.getSupplyChainFragmentMutex.Unlock()
+	fake.GetSupplyChainFragmentStub = stub
+}
+
+func (fake *FakeRepository) GetSupplyChainFragmentArgsForCall(i int) (context.Context, string) {
+	fake.getSupplyChainFragmentMutex.RLock()
+	defer fake.getSupplyChainFragmentMutex.RUnlock()
+	argsForCall := fake.getSupplyChainFragmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) GetSupplyChainFragmentReturns(result1 *v1alpha1.ClusterSupplyChainFragment, result2 error) {
+	fake.getSupplyChainFragmentMutex.Lock()
+	defer fake.getSupplyChainFragmentMutex.Unlock()
+	fake.GetSupplyChainFragmentStub = nil
+	fake.getSupplyChainFragmentReturns = struct {
+		result1 *v1alpha1.ClusterSupplyChainFragment
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) GetSupplyChainsForWorkload(arg1 context.Context, arg2 *v1alpha1.Workload) ([]*v1alpha1.ClusterSupplyChain, error) {
+func (fake *FakeRepository) GetSupplyChainFragmentReturnsOnCall(i int, result1 *v1alpha1.ClusterSupplyChainFragment, result2 error) {
+	fake.getSupplyChainFragmentMutex.Lock()
+	defer fake.getSupplyChainFragmentMutex.Unlock()
+	fake.GetSupplyChainFragmentStub = nil
+	if fake.getSupplyChainFragmentReturnsOnCall == nil {
+		fake.getSupplyChainFragmentReturnsOnCall = make(map[int]struct {
+			result1 *v1alpha1.ClusterSupplyChainFragment
+			result2 error
+		})
+	}
+	fake.getSupplyChainFragmentReturnsOnCall[i] = struct {
+		result1 *v1alpha1.ClusterSupplyChainFragment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetSupplyChainRevision(arg1 context.Context, arg2 string) (*v1alpha1.ClusterSupplyChainRevision, error) {
+	fake.getSupplyChainRevisionMutex.Lock()
+	ret, specificReturn := fake.getSupplyChainRevisionReturnsOnCall[len(fake.getSupplyChainRevisionArgsForCall)]
+	fake.getSupplyChainRevisionArgsForCall = append(fake.getSupplyChainRevisionArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetSupplyChainRevisionStub
+	fakeReturns := fake.getSupplyChainRevisionReturns
+	fake.recordInvocation("GetSupplyChainRevision", []interface{}{arg1, arg2})
+	fake.getSupplyChainRevisionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) GetSupplyChainRevisionCallCount() int {
+	fake.getSupplyChainRevisionMutex.RLock()
+	defer fake.getSupplyChainRevisionMutex.RUnlock()
+	return len(fake.getSupplyChainRevisionArgsForCall)
+}
+
+func (fake *FakeRepository) GetSupplyChainRevisionCalls(stub func(context.Context, string) (*v1alpha1.ClusterSupplyChainRevision, error)) {
+	fake.getSupplyChainRevisionMutex.Lock()
+	defer fake.getSupplyChainRevisionMutex.Unlock()
+	fake.GetSupplyChainRevisionStub = stub
+}
+
+func (fake *FakeRepository) GetSupplyChainRevisionArgsForCall(i int) (context.Context, string) {
+	fake.getSupplyChainRevisionMutex.RLock()
+	defer fake.getSupplyChainRevisionMutex.RUnlock()
+	argsForCall := fake.getSupplyChainRevisionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) GetSupplyChainRevisionReturns(result1 *v1alpha1.ClusterSupplyChainRevision, result2 error) {
+	fake.getSupplyChainRevisionMutex.Lock()
+	defer fake.getSupplyChainRevisionMutex.Unlock()
+	fake.GetSupplyChainRevisionStub = nil
+	fake.getSupplyChainRevisionReturns = struct {
+		result1 *v1alpha1.ClusterSupplyChainRevision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetSupplyChainRevisionReturnsOnCall(i int, result1 *v1alpha1.ClusterSupplyChainRevision, result2 error) {
+	fake.getSupplyChainRevisionMutex.Lock()
+	defer fake.getSupplyChainRevisionMutex.Unlock()
+	fake.GetSupplyChainRevisionStub = nil
+	if fake.getSupplyChainRevisionReturnsOnCall == nil {
+		fake.getSupplyChainRevisionReturnsOnCall = make(map[int]struct {
+			result1 *v1alpha1.ClusterSupplyChainRevision
+			result2 error
+		})
+	}
+	fake.getSupplyChainRevisionReturnsOnCall[i] = struct {
+		result1 *v1alpha1.ClusterSupplyChainRevision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetSupplyChainsForWorkload(arg1 context.Context, arg2 *v1alpha1.Workload) ([]v1alpha1.SupplyChainObject, error) {
 	fake.getSupplyChainsForWorkloadMutex.Lock()
 	ret, specificReturn := fake.getSupplyChainsForWorkloadReturnsOnCall[len(fake.getSupplyChainsForWorkloadArgsForCall)]
 	fake.getSupplyChainsForWorkloadArgsForCall = append(fake.getSupplyChainsForWorkloadArgsForCall, struct {
@@ -977,7 +1267,7 @@ func (fake *FakeRepository) GetSupplyChainsForWorkloadCallCount() int {
 	return len(fake.getSupplyChainsForWorkloadArgsForCall)
 }
 
-func (fake *FakeRepository) GetSupplyChainsForWorkloadCalls(stub func(context.Context, *v1alpha1.Workload) ([]*v1alpha1.ClusterSupplyChain, error)) {
+func (fake *FakeRepository) GetSupplyChainsForWorkloadCalls(stub func(context.Context, *v1alpha1.Workload) ([]v1alpha1.SupplyChainObject, error)) {
 	fake.getSupplyChainsForWorkloadMutex.Lock()
 	defer fake.getSupplyChainsForWorkloadMutex.Unlock()
 	fake.GetSupplyChainsForWorkloadStub = stub
@@ -990,46 +1280,47 @@ func (fake *FakeRepository) GetSupplyChainsForWorkloadArgsForCall(i int) (contex
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeRepository) GetSupplyChainsForWorkloadReturns(result1 []*v1alpha1.ClusterSupplyChain, result2 error) {
+func (fake *FakeRepository) GetSupplyChainsForWorkloadReturns(result1 []v1alpha1.SupplyChainObject, result2 error) {
 	fake.getSupplyChainsForWorkloadMutex.Lock()
 	defer fake.getSupplyChainsForWorkloadMutex.Unlock()
 	fake.GetSupplyChainsForWorkloadStub = nil
 	fake.getSupplyChainsForWorkloadReturns = struct {
-		result1 []*v1alpha1.ClusterSupplyChain
+		result1 []v1alpha1.SupplyChainObject
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) GetSupplyChainsForWorkloadReturnsOnCall(i int, result1 []*v1alpha1.ClusterSupplyChain, result2 error) {
+func (fake *FakeRepository) GetSupplyChainsForWorkloadReturnsOnCall(i int, result1 []v1alpha1.SupplyChainObject, result2 error) {
 	fake.getSupplyChainsForWorkloadMutex.Lock()
 	defer fake.getSupplyChainsForWorkloadMutex.Unlock()
 	fake.GetSupplyChainsForWorkloadStub = nil
 	if fake.getSupplyChainsForWorkloadReturnsOnCall == nil {
 		fake.getSupplyChainsForWorkloadReturnsOnCall = make(map[int]struct {
-			result1 []*v1alpha1.ClusterSupplyChain
+			result1 []v1alpha1.SupplyChainObject
 			result2 error
 		})
 	}
 	fake.getSupplyChainsForWorkloadReturnsOnCall[i] = struct {
-		result1 []*v1alpha1.ClusterSupplyChain
+		result1 []v1alpha1.SupplyChainObject
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) GetTemplate(arg1 context.Context, arg2 string, arg3 string) (client.Object, error) {
+func (fake *FakeRepository) GetTemplate(arg1 context.Context, arg2 string, arg3 string, arg4 string) (client.Object, error) {
 	fake.getTemplateMutex.Lock()
 	ret, specificReturn := fake.getTemplateReturnsOnCall[len(fake.getTemplateArgsForCall)]
 	fake.getTemplateArgsForCall = append(fake.getTemplateArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
 		arg3 string
-	}{arg1, arg2, arg3})
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.GetTemplateStub
 	fakeReturns := fake.getTemplateReturns
-	fake.recordInvocation("GetTemplate", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("GetTemplate", []interface{}{arg1, arg2, arg3, arg4})
 	fake.getTemplateMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1043,17 +1334,17 @@ func (fake *FakeRepository) GetTemplateCallCount() int {
 	return len(fake.getTemplateArgsForCall)
 }
 
-func (fake *FakeRepository) GetTemplateCalls(stub func(context.Context, string, string) (client.Object, error)) {
+func (fake *FakeRepository) GetTemplateCalls(stub func(context.Context, string, string, string) (client.Object, error)) {
 	fake.getTemplateMutex.Lock()
 	defer fake.getTemplateMutex.Unlock()
 	fake.GetTemplateStub = stub
 }
 
-func (fake *FakeRepository) GetTemplateArgsForCall(i int) (context.Context, string, string) {
+func (fake *FakeRepository) GetTemplateArgsForCall(i int) (context.Context, string, string, string) {
 	fake.getTemplateMutex.RLock()
 	defer fake.getTemplateMutex.RUnlock()
 	argsForCall := fake.getTemplateArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeRepository) GetTemplateReturns(result1 client.Object, result2 error) {
@@ -1082,6 +1373,71 @@ func (fake *FakeRepository) GetTemplateReturnsOnCall(i int, result1 client.Objec
 	}{result1, result2}
 }
 
+func (fake *FakeRepository) GetTemplateRevision(arg1 context.Context, arg2 string) (*v1alpha1.ClusterTemplateRevision, error) {
+	fake.getTemplateRevisionMutex.Lock()
+	ret, specificReturn := fake.getTemplateRevisionReturnsOnCall[len(fake.getTemplateRevisionArgsForCall)]
+	fake.getTemplateRevisionArgsForCall = append(fake.getTemplateRevisionArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetTemplateRevisionStub
+	fakeReturns := fake.getTemplateRevisionReturns
+	fake.recordInvocation("GetTemplateRevision", []interface{}{arg1, arg2})
+	fake.getTemplateRevisionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) GetTemplateRevisionCallCount() int {
+	fake.getTemplateRevisionMutex.RLock()
+	defer fake.getTemplateRevisionMutex.RUnlock()
+	return len(fake.getTemplateRevisionArgsForCall)
+}
+
+func (fake *FakeRepository) GetTemplateRevisionCalls(stub func(context.Context, string) (*v1alpha1.ClusterTemplateRevision, error)) {
+	fake.getTemplateRevisionMutex.Lock()
+	defer fake.getTemplateRevisionMutex.Unlock()
+	fake.GetTemplateRevisionStub = stub
+}
+
+func (fake *FakeRepository) GetTemplateRevisionArgsForCall(i int) (context.Context, string) {
+	fake.getTemplateRevisionMutex.RLock()
+	defer fake.getTemplateRevisionMutex.RUnlock()
+	argsForCall := fake.getTemplateRevisionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) GetTemplateRevisionReturns(result1 *v1alpha1.ClusterTemplateRevision, result2 error) {
+	fake.getTemplateRevisionMutex.Lock()
+	defer fake.getTemplateRevisionMutex.Unlock()
+	fake.GetTemplateRevisionStub = nil
+	fake.getTemplateRevisionReturns = struct {
+		result1 *v1alpha1.ClusterTemplateRevision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetTemplateRevisionReturnsOnCall(i int, result1 *v1alpha1.ClusterTemplateRevision, result2 error) {
+	fake.getTemplateRevisionMutex.Lock()
+	defer fake.getTemplateRevisionMutex.Unlock()
+	fake.GetTemplateRevisionStub = nil
+	if fake.getTemplateRevisionReturnsOnCall == nil {
+		fake.getTemplateRevisionReturnsOnCall = make(map[int]struct {
+			result1 *v1alpha1.ClusterTemplateRevision
+			result2 error
+		})
+	}
+	fake.getTemplateRevisionReturnsOnCall[i] = struct {
+		result1 *v1alpha1.ClusterTemplateRevision
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeRepository) GetUnstructured(arg1 context.Context, arg2 *unstructured.Unstructured) (*unstructured.Unstructured, error) {
 	fake.getUnstructuredMutex.Lock()
 	ret, specificReturn := fake.getUnstructuredReturnsOnCall[len(fake.getUnstructuredArgsForCall)]
@@ -1213,6 +1569,136 @@ func (fake *FakeRepository) GetWorkloadReturnsOnCall(i int, result1 *v1alpha1.Wo
 	}{result1, result2}
 }
 
+func (fake *FakeRepository) ListSupplyChainRevisions(arg1 context.Context, arg2 v1alpha1.SupplyChainRevisionReference) ([]v1alpha1.ClusterSupplyChainRevision, error) {
+	fake.listSupplyChainRevisionsMutex.Lock()
+	ret, specificReturn := fake.listSupplyChainRevisionsReturnsOnCall[len(fake.listSupplyChainRevisionsArgsForCall)]
+	fake.listSupplyChainRevisionsArgsForCall = append(fake.listSupplyChainRevisionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 v1alpha1.SupplyChainRevisionReference
+	}{arg1, arg2})
+	stub := fake.ListSupplyChainRevisionsStub
+	fakeReturns := fake.listSupplyChainRevisionsReturns
+	fake.recordInvocation("ListSupplyChainRevisions", []interface{}{arg1, arg2})
+	fake.listSupplyChainRevisionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) ListSupplyChainRevisionsCallCount() int {
+	fake.listSupplyChainRevisionsMutex.RLock()
+	defer fake.listSupplyChainRevisionsMutex.RUnlock()
+	return len(fake.listSupplyChainRevisionsArgsForCall)
+}
+
+func (fake *FakeRepository) ListSupplyChainRevisionsCalls(stub func(context.Context, v1alpha1.SupplyChainRevisionReference) ([]v1alpha1.ClusterSupplyChainRevision, error)) {
+	fake.listSupplyChainRevisionsMutex.Lock()
+	defer fake.listSupplyChainRevisionsMutex.Unlock()
+	fake.ListSupplyChainRevisionsStub = stub
+}
+
+func (fake *FakeRepository) ListSupplyChainRevisionsArgsForCall(i int) (context.Context, v1alpha1.SupplyChainRevisionReference) {
+	fake.listSupplyChainRevisionsMutex.RLock()
+	defer fake.listSupplyChainRevisionsMutex.RUnlock()
+	argsForCall := fake.listSupplyChainRevisionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) ListSupplyChainRevisionsReturns(result1 []v1alpha1.ClusterSupplyChainRevision, result2 error) {
+	fake.listSupplyChainRevisionsMutex.Lock()
+	defer fake.listSupplyChainRevisionsMutex.Unlock()
+	fake.ListSupplyChainRevisionsStub = nil
+	fake.listSupplyChainRevisionsReturns = struct {
+		result1 []v1alpha1.ClusterSupplyChainRevision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) ListSupplyChainRevisionsReturnsOnCall(i int, result1 []v1alpha1.ClusterSupplyChainRevision, result2 error) {
+	fake.listSupplyChainRevisionsMutex.Lock()
+	defer fake.listSupplyChainRevisionsMutex.Unlock()
+	fake.ListSupplyChainRevisionsStub = nil
+	if fake.listSupplyChainRevisionsReturnsOnCall == nil {
+		fake.listSupplyChainRevisionsReturnsOnCall = make(map[int]struct {
+			result1 []v1alpha1.ClusterSupplyChainRevision
+			result2 error
+		})
+	}
+	fake.listSupplyChainRevisionsReturnsOnCall[i] = struct {
+		result1 []v1alpha1.ClusterSupplyChainRevision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) ListTemplateRevisions(arg1 context.Context, arg2 v1alpha1.TemplateRevisionReference) ([]v1alpha1.ClusterTemplateRevision, error) {
+	fake.listTemplateRevisionsMutex.Lock()
+	ret, specificReturn := fake.listTemplateRevisionsReturnsOnCall[len(fake.listTemplateRevisionsArgsForCall)]
+	fake.listTemplateRevisionsArgsForCall = append(fake.listTemplateRevisionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 v1alpha1.TemplateRevisionReference
+	}{arg1, arg2})
+	stub := fake.ListTemplateRevisionsStub
+	fakeReturns := fake.listTemplateRevisionsReturns
+	fake.recordInvocation("ListTemplateRevisions", []interface{}{arg1, arg2})
+	fake.listTemplateRevisionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) ListTemplateRevisionsCallCount() int {
+	fake.listTemplateRevisionsMutex.RLock()
+	defer fake.listTemplateRevisionsMutex.RUnlock()
+	return len(fake.listTemplateRevisionsArgsForCall)
+}
+
+func (fake *FakeRepository) ListTemplateRevisionsCalls(stub func(context.Context, v1alpha1.TemplateRevisionReference) ([]v1alpha1.ClusterTemplateRevision, error)) {
+	fake.listTemplateRevisionsMutex.Lock()
+	defer fake.listTemplateRevisionsMutex.Unlock()
+	fake.ListTemplateRevisionsStub = stub
+}
+
+func (fake *FakeRepository) ListTemplateRevisionsArgsForCall(i int) (context.Context, v1alpha1.TemplateRevisionReference) {
+	fake.listTemplateRevisionsMutex.RLock()
+	defer fake.listTemplateRevisionsMutex.RUnlock()
+	argsForCall := fake.listTemplateRevisionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) ListTemplateRevisionsReturns(result1 []v1alpha1.ClusterTemplateRevision, result2 error) {
+	fake.listTemplateRevisionsMutex.Lock()
+	defer fake.listTemplateRevisionsMutex.Unlock()
+	fake.ListTemplateRevisionsStub = nil
+	fake.listTemplateRevisionsReturns = struct {
+		result1 []v1alpha1.ClusterTemplateRevision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) ListTemplateRevisionsReturnsOnCall(i int, result1 []v1alpha1.ClusterTemplateRevision, result2 error) {
+	fake.listTemplateRevisionsMutex.Lock()
+	defer fake.listTemplateRevisionsMutex.Unlock()
+	fake.ListTemplateRevisionsStub = nil
+	if fake.listTemplateRevisionsReturnsOnCall == nil {
+		fake.listTemplateRevisionsReturnsOnCall = make(map[int]struct {
+			result1 []v1alpha1.ClusterTemplateRevision
+			result2 error
+		})
+	}
+	fake.listTemplateRevisionsReturnsOnCall[i] = struct {
+		result1 []v1alpha1.ClusterTemplateRevision
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeRepository) ListUnstructured(arg1 context.Context, arg2 schema.GroupVersionKind, arg3 string, arg4 map[string]string) ([]*unstructured.Unstructured, error) {
 	fake.listUnstructuredMutex.Lock()
 	ret, specificReturn := fake.listUnstructuredReturnsOnCall[len(fake.listUnstructuredArgsForCall)]
@@ -1357,6 +1843,8 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.getDeliveriesForDeliverableMutex.RUnlock()
 	fake.getDeliveryMutex.RLock()
 	defer fake.getDeliveryMutex.RUnlock()
+	fake.getRevisionOfTemplateMutex.RLock()
+	defer fake.getRevisionOfTemplateMutex.RUnlock()
 	fake.getRunTemplateMutex.RLock()
 	defer fake.getRunTemplateMutex.RUnlock()
 	fake.getRunnableMutex.RLock()
@@ -1367,14 +1855,24 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.getServiceAccountMutex.RUnlock()
 	fake.getSupplyChainMutex.RLock()
 	defer fake.getSupplyChainMutex.RUnlock()
+	fake.getSupplyChainFragmentMutex.RLock()
+	defer fake.getSupplyChainFragmentMutex.RUnlock()
+	fake.getSupplyChainRevisionMutex.RLock()
+	defer fake.getSupplyChainRevisionMutex.RUnlock()
 	fake.getSupplyChainsForWorkloadMutex.RLock()
 	defer fake.getSupplyChainsForWorkloadMutex.RUnlock()
 	fake.getTemplateMutex.RLock()
 	defer fake.getTemplateMutex.RUnlock()
+	fake.getTemplateRevisionMutex.RLock()
+	defer fake.getTemplateRevisionMutex.RUnlock()
 	fake.getUnstructuredMutex.RLock()
 	defer fake.getUnstructuredMutex.RUnlock()
 	fake.getWorkloadMutex.RLock()
 	defer fake.getWorkloadMutex.RUnlock()
+	fake.listSupplyChainRevisionsMutex.RLock()
+	defer fake.listSupplyChainRevisionsMutex.RUnlock()
+	fake.listTemplateRevisionsMutex.RLock()
+	defer fake.listTemplateRevisionsMutex.RUnlock()
 	fake.listUnstructuredMutex.RLock()
 	defer fake.listUnstructuredMutex.RUnlock()
 	fake.statusUpdateMutex.RLock()
@@ -30,7 +30,8 @@ type SelectingObject interface {
 	GetName() string
 }
 
-func legacySelectorToSelector(legacySelector v1alpha1.LegacySelector) v1alpha1.Selector {
+// LegacySelectorToSelector returns the selector of the selector fields of a blueprint
+func LegacySelectorToSelector(legacySelector v1alpha1.LegacySelector) v1alpha1.Selector {
 	return v1alpha1.Selector{
 		LabelSelector: metav1.LabelSelector{
 			MatchLabels:      legacySelector.Selector,
@@ -43,7 +44,7 @@ func legacySelectorToSelector(legacySelector v1alpha1.LegacySelector) v1alpha1.S
 func selectingObjectsSelectors(selectingObjects []SelectingObject) []v1alpha1.Selector {
 	selectors := make([]v1alpha1.Selector, len(selectingObjects))
 	for idx, selectingObject := range selectingObjects {
-		selectors[idx] = legacySelectorToSelector(selectingObject.GetSelectors())
+		selectors[idx] = LegacySelectorToSelector(selectingObject.GetSelectors())
 	}
 	return selectors
 }
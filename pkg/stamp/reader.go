@@ -43,6 +43,8 @@ type Outputter interface {
 }
 
 func NewPassThroughReader(kind string, name string, inputReader PassThroughInput) (Outputter, error) {
+	kind = v1alpha1.ClusterTemplateKind(kind)
+
 	switch {
 
 	case kind == "ClusterSourceTemplate":
@@ -73,6 +75,16 @@ func NewReader(template client.Object, inputReader DeploymentInput) (Outputter,
 		return NewDeploymentPassThroughReader(inputReader, v), nil
 	case *v1alpha1.ClusterTemplate:
 		return NewNoOutputReader(), nil
+	case *v1alpha1.SourceTemplate:
+		return NewSourceOutputReader(&v1alpha1.ClusterSourceTemplate{ObjectMeta: v.ObjectMeta, Spec: v.Spec}), nil
+	case *v1alpha1.ImageTemplate:
+		return NewImageOutputReader(&v1alpha1.ClusterImageTemplate{ObjectMeta: v.ObjectMeta, Spec: v.Spec}), nil
+	case *v1alpha1.ConfigTemplate:
+		return NewConfigOutputReader(&v1alpha1.ClusterConfigTemplate{ObjectMeta: v.ObjectMeta, Spec: v.Spec}), nil
+	case *v1alpha1.DeploymentTemplate:
+		return NewDeploymentPassThroughReader(inputReader, &v1alpha1.ClusterDeploymentTemplate{ObjectMeta: v.ObjectMeta, Spec: v.Spec}), nil
+	case *v1alpha1.Template:
+		return NewNoOutputReader(), nil
 	}
 	return nil, fmt.Errorf("template does not match a known template")
 }
@@ -0,0 +1,206 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stamp
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+	"github.com/vmware-tanzu/cartographer/pkg/utils"
+)
+
+const (
+	TektonGroup           = "tekton.dev"
+	TektonPipelineRunKind = "PipelineRun"
+	TektonTaskRunKind     = "TaskRun"
+
+	// TektonPipelineRunLabel is set by Tekton on every TaskRun created for a PipelineRun
+	TektonPipelineRunLabel = "tekton.dev/pipelineRun"
+)
+
+// IsTektonRun returns true when the object is a Tekton PipelineRun or TaskRun
+func IsTektonRun(obj *unstructured.Unstructured) bool {
+	gvk := obj.GroupVersionKind()
+	return gvk.Group == TektonGroup && (gvk.Kind == TektonPipelineRunKind || gvk.Kind == TektonTaskRunKind)
+}
+
+// TaskRunGVK returns the GroupVersionKind of the TaskRuns belonging to a PipelineRun
+func TaskRunGVK(pipelineRun *unstructured.Unstructured) schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   TektonGroup,
+		Version: pipelineRun.GroupVersionKind().Version,
+		Kind:    TektonTaskRunKind,
+	}
+}
+
+// TektonOutputReader reads outputs and failure details from Tekton PipelineRuns and TaskRuns
+type TektonOutputReader struct{}
+
+func NewTektonOutputReader() *TektonOutputReader {
+	return &TektonOutputReader{}
+}
+
+// Outputs maps the results of a PipelineRun (status.pipelineResults or status.results) or
+// a TaskRun (status.taskResults or status.results) to outputs keyed by result name.
+func (r *TektonOutputReader) Outputs(stampedObject *unstructured.Unstructured) (templates.Outputs, error) {
+	if stampedObject == nil || !IsTektonRun(stampedObject) {
+		return nil, fmt.Errorf("object is not a tekton PipelineRun or TaskRun")
+	}
+
+	resultsField := "taskResults"
+	if stampedObject.GetKind() == TektonPipelineRunKind {
+		resultsField = "pipelineResults"
+	}
+
+	results, found, err := unstructured.NestedSlice(stampedObject.UnstructuredContent(), "status", resultsField)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read status.%s: %w", resultsField, err)
+	}
+	if !found {
+		results, _, err = unstructured.NestedSlice(stampedObject.UnstructuredContent(), "status", "results")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read status.results: %w", err)
+		}
+	}
+
+	outputs := templates.Outputs{}
+	for _, result := range results {
+		resultMap, ok := result.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, ok := resultMap["name"].(string)
+		if !ok || name == "" {
+			continue
+		}
+
+		value, err := json.Marshal(resultMap["value"])
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result [%s]: %w", name, err)
+		}
+		outputs[name] = apiextensionsv1.JSON{Raw: value}
+	}
+
+	return outputs, nil
+}
+
+// FailureMessage describes why a failed PipelineRun or TaskRun failed, naming the earliest TaskRun
+// to fail, its failing step and the reason. For a PipelineRun, taskRuns are the TaskRuns it created; TaskRun
+// statuses embedded in the PipelineRun's status.taskRuns are also considered.
+// An empty string is returned when the run has not failed.
+func (r *TektonOutputReader) FailureMessage(stampedObject *unstructured.Unstructured, taskRuns []*unstructured.Unstructured) string {
+	if stampedObject == nil || !IsTektonRun(stampedObject) {
+		return ""
+	}
+
+	succeeded := utils.ExtractConditions(stampedObject).ConditionWithType("Succeeded")
+	if succeeded == nil || succeeded.Status != metav1.ConditionFalse {
+		return ""
+	}
+
+	if stampedObject.GetKind() == TektonTaskRunKind {
+		return taskRunFailureMessage(stampedObject.GetName(), stampedObject.UnstructuredContent())
+	}
+
+	candidates := map[string]map[string]interface{}{}
+
+	embeddedTaskRuns, _, _ := unstructured.NestedMap(stampedObject.UnstructuredContent(), "status", "taskRuns")
+	for name, embedded := range embeddedTaskRuns {
+		if embeddedMap, ok := embedded.(map[string]interface{}); ok {
+			candidates[name] = embeddedMap
+		}
+	}
+	for _, taskRun := range taskRuns {
+		candidates[taskRun.GetName()] = taskRun.UnstructuredContent()
+	}
+
+	type failure struct {
+		message     string
+		name        string
+		completedAt time.Time
+	}
+
+	var failures []failure
+	for name, taskRun := range candidates {
+		if message := taskRunFailureMessage(name, taskRun); message != "" {
+			failures = append(failures, failure{message: message, name: name, completedAt: taskRunCompletionTime(taskRun)})
+		}
+	}
+
+	// the earliest TaskRun to fail is reported, TaskRuns of unknown completion time last
+	sort.Slice(failures, func(i, j int) bool {
+		a, b := failures[i], failures[j]
+		if !a.completedAt.Equal(b.completedAt) {
+			if a.completedAt.IsZero() || b.completedAt.IsZero() {
+				return b.completedAt.IsZero()
+			}
+			return a.completedAt.Before(b.completedAt)
+		}
+		return a.name < b.name
+	})
+
+	if len(failures) > 0 {
+		return fmt.Sprintf("pipelinerun [%s] failed: %s", stampedObject.GetName(), failures[0].message)
+	}
+
+	return fmt.Sprintf("pipelinerun [%s] failed with reason [%s]: %s", stampedObject.GetName(), succeeded.Reason, succeeded.Message)
+}
+
+// taskRunCompletionTime is the status.completionTime of a TaskRun, falling back to the time its
+// Succeeded condition last transitioned. The zero time is returned when neither is known.
+func taskRunCompletionTime(taskRun map[string]interface{}) time.Time {
+	if completionTime, found, _ := unstructured.NestedString(taskRun, "status", "completionTime"); found {
+		if parsed, err := time.Parse(time.RFC3339, completionTime); err == nil {
+			return parsed
+		}
+	}
+
+	succeeded := utils.ExtractConditions(&unstructured.Unstructured{Object: taskRun}).ConditionWithType("Succeeded")
+	if succeeded != nil {
+		return succeeded.LastTransitionTime.Time
+	}
+	return time.Time{}
+}
+
+func taskRunFailureMessage(name string, taskRun map[string]interface{}) string {
+	succeeded := utils.ExtractConditions(&unstructured.Unstructured{Object: taskRun}).ConditionWithType("Succeeded")
+	if succeeded == nil || succeeded.Status != metav1.ConditionFalse {
+		return ""
+	}
+
+	steps, _, _ := unstructured.NestedSlice(taskRun, "status", "steps")
+	for _, step := range steps {
+		stepMap, ok := step.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		exitCode, found, _ := unstructured.NestedInt64(stepMap, "terminated", "exitCode")
+		if !found || exitCode == 0 {
+			continue
+		}
+		stepName, _, _ := unstructured.NestedString(stepMap, "name")
+		return fmt.Sprintf("taskrun [%s] failed at step [%s] with reason [%s]: %s", name, stepName, succeeded.Reason, succeeded.Message)
+	}
+
+	return fmt.Sprintf("taskrun [%s] failed with reason [%s]: %s", name, succeeded.Reason, succeeded.Message)
+}
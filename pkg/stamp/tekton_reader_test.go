@@ -0,0 +1,288 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stamp_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/cartographer/pkg/stamp"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+	"github.com/vmware-tanzu/cartographer/pkg/utils"
+)
+
+func makeTektonObject(manifest string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	err := yaml.Unmarshal([]byte(utils.HereYaml(manifest)), obj)
+	Expect(err).NotTo(HaveOccurred())
+	return obj
+}
+
+var _ = Describe("TektonOutputReader", func() {
+	var reader *stamp.TektonOutputReader
+
+	BeforeEach(func() {
+		reader = stamp.NewTektonOutputReader()
+	})
+
+	Describe("IsTektonRun", func() {
+		It("is true for PipelineRuns and TaskRuns", func() {
+			Expect(stamp.IsTektonRun(makeTektonObject(`
+				apiVersion: tekton.dev/v1beta1
+				kind: PipelineRun
+			`))).To(BeTrue())
+			Expect(stamp.IsTektonRun(makeTektonObject(`
+				apiVersion: tekton.dev/v1
+				kind: TaskRun
+			`))).To(BeTrue())
+		})
+
+		It("is false for other objects", func() {
+			Expect(stamp.IsTektonRun(makeTektonObject(`
+				apiVersion: tekton.dev/v1beta1
+				kind: Pipeline
+			`))).To(BeFalse())
+			Expect(stamp.IsTektonRun(makeTektonObject(`
+				apiVersion: batch/v1
+				kind: Job
+			`))).To(BeFalse())
+		})
+	})
+
+	Describe("Outputs", func() {
+		It("reads status.pipelineResults of a v1beta1 PipelineRun", func() {
+			outputs, err := reader.Outputs(makeTektonObject(`
+				apiVersion: tekton.dev/v1beta1
+				kind: PipelineRun
+				status:
+				  pipelineResults:
+					- name: digest
+					  value: sha256:abc
+					- name: tags
+					  value: [latest, v1]
+			`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputs).To(Equal(templates.Outputs{
+				"digest": apiextensionsv1.JSON{Raw: []byte(`"sha256:abc"`)},
+				"tags":   apiextensionsv1.JSON{Raw: []byte(`["latest","v1"]`)},
+			}))
+		})
+
+		It("reads status.taskResults of a v1beta1 TaskRun", func() {
+			outputs, err := reader.Outputs(makeTektonObject(`
+				apiVersion: tekton.dev/v1beta1
+				kind: TaskRun
+				status:
+				  taskResults:
+					- name: commit
+					  value: abc123
+			`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputs).To(Equal(templates.Outputs{
+				"commit": apiextensionsv1.JSON{Raw: []byte(`"abc123"`)},
+			}))
+		})
+
+		It("reads status.results of a v1 PipelineRun", func() {
+			outputs, err := reader.Outputs(makeTektonObject(`
+				apiVersion: tekton.dev/v1
+				kind: PipelineRun
+				status:
+				  results:
+					- name: digest
+					  value: sha256:def
+			`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputs).To(Equal(templates.Outputs{
+				"digest": apiextensionsv1.JSON{Raw: []byte(`"sha256:def"`)},
+			}))
+		})
+
+		It("returns empty outputs when the run has no results", func() {
+			outputs, err := reader.Outputs(makeTektonObject(`
+				apiVersion: tekton.dev/v1
+				kind: TaskRun
+			`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputs).To(BeEmpty())
+		})
+
+		It("returns an error for objects that are not tekton runs", func() {
+			_, err := reader.Outputs(makeTektonObject(`
+				apiVersion: batch/v1
+				kind: Job
+			`))
+			Expect(err).To(MatchError("object is not a tekton PipelineRun or TaskRun"))
+		})
+	})
+
+	Describe("FailureMessage", func() {
+		It("is empty when the run has not failed", func() {
+			Expect(reader.FailureMessage(makeTektonObject(`
+				apiVersion: tekton.dev/v1beta1
+				kind: TaskRun
+				metadata:
+				  name: my-taskrun
+				status:
+				  conditions:
+					- type: Succeeded
+					  status: "True"
+			`), nil)).To(BeEmpty())
+		})
+
+		It("names the failing step of a TaskRun", func() {
+			Expect(reader.FailureMessage(makeTektonObject(`
+				apiVersion: tekton.dev/v1beta1
+				kind: TaskRun
+				metadata:
+				  name: my-taskrun
+				status:
+				  conditions:
+					- type: Succeeded
+					  status: "False"
+					  reason: Failed
+					  message: '"step-unit-tests" exited with code 1'
+				  steps:
+					- name: checkout
+					  terminated:
+						exitCode: 0
+					- name: unit-tests
+					  terminated:
+						exitCode: 1
+			`), nil)).To(Equal(`taskrun [my-taskrun] failed at step [unit-tests] with reason [Failed]: "step-unit-tests" exited with code 1`))
+		})
+
+		Context("a failed PipelineRun", func() {
+			var pipelineRun *unstructured.Unstructured
+
+			BeforeEach(func() {
+				pipelineRun = makeTektonObject(`
+					apiVersion: tekton.dev/v1beta1
+					kind: PipelineRun
+					metadata:
+					  name: my-pipelinerun
+					status:
+					  conditions:
+						- type: Succeeded
+						  status: "False"
+						  reason: Failed
+						  message: "Tasks Completed: 2 (Failed: 1, Cancelled 0), Skipped: 0"
+				`)
+			})
+
+			It("names the failing TaskRun and step", func() {
+				taskRuns := []*unstructured.Unstructured{
+					makeTektonObject(`
+						apiVersion: tekton.dev/v1beta1
+						kind: TaskRun
+						metadata:
+						  name: my-pipelinerun-fetch
+						status:
+						  conditions:
+							- type: Succeeded
+							  status: "True"
+					`),
+					makeTektonObject(`
+						apiVersion: tekton.dev/v1beta1
+						kind: TaskRun
+						metadata:
+						  name: my-pipelinerun-test
+						status:
+						  conditions:
+							- type: Succeeded
+							  status: "False"
+							  reason: Failed
+							  message: '"step-go-test" exited with code 2'
+						  steps:
+							- name: go-test
+							  terminated:
+								exitCode: 2
+					`),
+				}
+
+				Expect(reader.FailureMessage(pipelineRun, taskRuns)).To(Equal(
+					`pipelinerun [my-pipelinerun] failed: taskrun [my-pipelinerun-test] failed at step [go-test] with reason [Failed]: "step-go-test" exited with code 2`,
+				))
+			})
+
+			It("names the TaskRun that failed first when several failed", func() {
+				taskRuns := []*unstructured.Unstructured{
+					makeTektonObject(`
+						apiVersion: tekton.dev/v1beta1
+						kind: TaskRun
+						metadata:
+						  name: my-pipelinerun-a-lint
+						status:
+						  completionTime: "2026-10-19T12:05:00Z"
+						  conditions:
+							- type: Succeeded
+							  status: "False"
+							  reason: Failed
+							  message: lint failed
+					`),
+					makeTektonObject(`
+						apiVersion: tekton.dev/v1beta1
+						kind: TaskRun
+						metadata:
+						  name: my-pipelinerun-b-test
+						status:
+						  completionTime: "2026-10-19T12:01:00Z"
+						  conditions:
+							- type: Succeeded
+							  status: "False"
+							  reason: Failed
+							  message: test failed
+					`),
+				}
+
+				Expect(reader.FailureMessage(pipelineRun, taskRuns)).To(Equal(
+					`pipelinerun [my-pipelinerun] failed: taskrun [my-pipelinerun-b-test] failed with reason [Failed]: test failed`,
+				))
+			})
+
+			It("considers TaskRun statuses embedded in the PipelineRun", func() {
+				err := unstructured.SetNestedMap(pipelineRun.Object, map[string]interface{}{
+					"my-pipelinerun-test": map[string]interface{}{
+						"pipelineTaskName": "test",
+						"status": map[string]interface{}{
+							"conditions": []interface{}{
+								map[string]interface{}{
+									"type":    "Succeeded",
+									"status":  "False",
+									"reason":  "TaskRunTimeout",
+									"message": "timed out",
+								},
+							},
+						},
+					},
+				}, "status", "taskRuns")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(reader.FailureMessage(pipelineRun, nil)).To(Equal(
+					`pipelinerun [my-pipelinerun] failed: taskrun [my-pipelinerun-test] failed with reason [TaskRunTimeout]: timed out`,
+				))
+			})
+
+			It("falls back to the PipelineRun's condition when no failing TaskRun is found", func() {
+				Expect(reader.FailureMessage(pipelineRun, nil)).To(Equal(
+					`pipelinerun [my-pipelinerun] failed with reason [Failed]: Tasks Completed: 2 (Failed: 1, Cancelled 0), Skipped: 0`,
+				))
+			})
+		})
+	})
+})
@@ -39,6 +39,7 @@ type ClusterRunTemplate interface {
 	GetName() string
 	GetResourceTemplate() v1alpha1.TemplateSpec
 	GetLatestSuccessfulOutput(stampedObjects []*unstructured.Unstructured) (Outputs, *unstructured.Unstructured, error)
+	GetOutputs(stampedObject *unstructured.Unstructured) (Outputs, error)
 }
 
 type runTemplate struct {
@@ -65,6 +66,13 @@ func (t *runTemplate) GetLatestSuccessfulOutput(stampedObjects []*unstructured.U
 	return outputs, latestMatchingObject, outputError
 }
 
+// GetOutputs evaluates the template's output paths against a single stamped object,
+// regardless of the object's Succeeded condition.
+func (t *runTemplate) GetOutputs(stampedObject *unstructured.Unstructured) (Outputs, error) {
+	outputError, outputs := t.getOutputsOfSingleObject(t.evaluator, *stampedObject)
+	return outputs, outputError
+}
+
 func (t *runTemplate) getLatestSuccessfulObject(stampedObjects []*unstructured.Unstructured) *unstructured.Unstructured {
 	var (
 		latestTime           time.Time // zero value is used for comparison
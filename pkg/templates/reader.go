@@ -74,6 +74,34 @@ func NewReaderFromAPI(template client.Object) (Reader, error) {
 		return NewClusterDeploymentTemplateReader(v), nil
 	case *v1alpha1.ClusterTemplate:
 		return NewClusterTemplateReader(v), nil
+	case *v1alpha1.SourceTemplate:
+		return NewClusterSourceTemplateReader(&v1alpha1.ClusterSourceTemplate{ObjectMeta: v.ObjectMeta, Spec: v.Spec}), nil
+	case *v1alpha1.ImageTemplate:
+		return NewClusterImageTemplateReader(&v1alpha1.ClusterImageTemplate{ObjectMeta: v.ObjectMeta, Spec: v.Spec}), nil
+	case *v1alpha1.ConfigTemplate:
+		return NewClusterConfigTemplateReader(&v1alpha1.ClusterConfigTemplate{ObjectMeta: v.ObjectMeta, Spec: v.Spec}), nil
+	case *v1alpha1.DeploymentTemplate:
+		return NewClusterDeploymentTemplateReader(&v1alpha1.ClusterDeploymentTemplate{ObjectMeta: v.ObjectMeta, Spec: v.Spec}), nil
+	case *v1alpha1.Template:
+		return NewClusterTemplateReader(&v1alpha1.ClusterTemplate{ObjectMeta: v.ObjectMeta, Spec: v.Spec}), nil
 	}
 	return nil, fmt.Errorf("resource does not match a known template")
 }
+
+type revisionReader struct {
+	Reader
+	revision int64
+}
+
+// WithRevision records on reader the revision of the template it reads.
+func WithRevision(reader Reader, revision int64) Reader {
+	return &revisionReader{Reader: reader, revision: revision}
+}
+
+// RevisionOf returns the revision of the template reader reads, or 0 when unknown.
+func RevisionOf(reader Reader) int64 {
+	if r, ok := reader.(*revisionReader); ok {
+		return r.revision
+	}
+	return 0
+}
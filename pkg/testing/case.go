@@ -16,72 +16,339 @@ package testing
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-multierror"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
 )
 
 // Test is an individual template test.
 // Given and Expect values must be provided.
+// ExpectOutputs and ExpectHealth assert the outputs and health the realizer would read from the
+// stamped object with the fixture of Given merged over it. They may be provided alongside or instead of Expect.
+// When Given has a Runnable, ExpectRunOutputs asserts the outputs read from the run instead of ExpectOutputs.
+// When Given realizes every resource of a supply chain or delivery, ExpectResources is provided instead of Expect,
+// holding the expected result of resources keyed by resource name.
 // Fields in the expected object's metadata may be ignored
 // When run as part of a Suite, an individual case(s) may be focused.
 // This will exercise the individual test(s).
 // Note that the overall suite will fail (preventing focused tests from passing CI).
+// Name is optional, and describes the test to select it when running a Suite.
 type Test struct {
-	Given          Given
-	Expect         Expectation
-	CompareOptions *CompareOptions
-	Focus          bool
+	Name             string
+	Given            Given
+	Expect           Expectation
+	ExpectOutputs    *templates.Output
+	ExpectHealth     *HealthExpectation
+	ExpectRunOutputs templates.Outputs
+	ExpectResources  map[string]*ResourceExpectation
+	CompareOptions   *CompareOptions
+	Focus            bool
 }
 
-// Given must specify a Template and a Workload.
-// SupplyChain is optional
+// Given must specify a Template and one of a Workload, a Deliverable or a Runnable.
+// SupplyChain is optional, and only used with a Workload.
+// Delivery is optional, and only used with a Deliverable.
+// A Runnable requires a ClusterRunTemplate as its Template. Selected is optional, and simulates
+// the object the selector of the Runnable resolves to.
+// Fixture is optional, and simulates the stamped object as observed on the cluster.
+// Templates may be specified instead of a Template, along with a SupplyChainFileSet or a DeliveryFileSet,
+// to realize every resource of the supply chain or delivery in order. Each resource stamps the template
+// it selects among them, and its outputs are fed to the resources that consume them.
+// Fixtures simulate the objects stamped for resources, keyed by resource name.
+// The outputs of a resource are read from its stamped object with its fixture merged over it.
 type Given struct {
 	Template    Template
 	Workload    Workload
 	SupplyChain SupplyChain
+	Deliverable Deliverable
+	Delivery    Delivery
+	Runnable    Runnable
+	Selected    Fixture
+	Fixture     Fixture
+	Templates   []Template
+	Fixtures    map[string]Fixture
+}
+
+// ResourceExpectation is the expected result of realizing a resource of a supply chain or delivery
+// Object, Outputs and Health may be left nil to not assert on them
+type ResourceExpectation struct {
+	Object  Expectation
+	Outputs *templates.Output
+	Health  *HealthExpectation
 }
 
 func (c *Test) Run() error {
-	expectedObject, err := c.Expect.getExpected()
-	if err != nil {
-		return fmt.Errorf("failed to get expected object: %w", err)
+	return c.RunContext(context.Background())
+}
+
+// RunContext runs the test, stamping with ctx: once ctx is done, ytt is stopped and the test fails.
+func (c *Test) RunContext(ctx context.Context) error {
+	_, err := c.run(ctx)
+	return err
+}
+
+// stamping is what stamping the templates of a test produced: the resource realized from its
+// template, or the resources realized from its supply chain or delivery, and the error stamping failed with
+type stamping struct {
+	actual   *RealizedResource
+	realized map[string]*RealizedResource
+	err      error
+}
+
+// run stamps the templates of the test and asserts its expectations of what was stamped, which is
+// returned so that it can be reused without stamping again. It is nil when the test fails before stamping.
+func (c *Test) run(ctx context.Context) (*stamping, error) {
+	if err := c.validateExpectations(); err != nil {
+		return nil, err
+	}
+
+	stamped := c.stamp(ctx)
+	return stamped, c.assert(stamped)
+}
+
+func (c *Test) validateExpectations() error {
+	if len(c.Given.Templates) > 0 {
+		if c.Expect != nil {
+			return fmt.Errorf("expected object is not used when every resource of the blueprint is realized, expect resources instead")
+		}
+
+		if len(c.ExpectResources) == 0 {
+			return fmt.Errorf("no expectations of the realized resources")
+		}
+
+		return nil
+	}
+
+	if c.Expect == nil && c.ExpectOutputs == nil && c.ExpectHealth == nil && c.ExpectRunOutputs == nil {
+		return fmt.Errorf("no expectations of the stamped object")
+	}
+
+	return nil
+}
+
+func (c *Test) stamp(ctx context.Context) *stamping {
+	if len(c.Given.Templates) > 0 {
+		realized, err := c.Given.realize(ctx)
+		return &stamping{realized: realized, err: err}
+	}
+
+	actual, err := c.Given.getActual(ctx)
+	return &stamping{actual: actual, err: err}
+}
+
+func (c *Test) assert(stamped *stamping) error {
+	if len(c.Given.Templates) > 0 {
+		return c.assertResources(stamped)
+	}
+
+	var expectedObject *unstructured.Unstructured
+	if c.Expect != nil {
+		var err error
+		expectedObject, err = c.Expect.getExpected()
+		if err != nil {
+			return fmt.Errorf("failed to get expected object: %w", err)
+		}
 	}
 
-	actualObject, err := c.Given.getActualObject()
-	if errors.Is(err, yttNotFound) {
+	if errors.Is(stamped.err, yttNotFound) {
 		return fmt.Errorf("test requires ytt, but ytt was not found in path")
-	} else if err != nil {
-		return fmt.Errorf("failed to get actual object: %w", err)
+	} else if stamped.err != nil {
+		return fmt.Errorf("failed to get actual object: %w", stamped.err)
 	}
+	actual := stamped.actual
 
-	c.stripIgnoredFields(expectedObject, actualObject)
+	var errs []error
+
+	if expectedObject != nil {
+		if err := c.compare(expectedObject, actual.StampedObject.DeepCopy()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	errs = append(errs, c.assertObserved(actual, c.ExpectOutputs, c.ExpectHealth)...)
+
+	if c.ExpectRunOutputs != nil {
+		if err := c.assertRunOutputs(actual); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return joinErrors(errs)
+}
+
+// assertRunOutputs compares the decoded values of run outputs, as their encodings may differ
+func (c *Test) assertRunOutputs(actual *RealizedResource) error {
+	if actual.outputErr != nil {
+		return fmt.Errorf("failed to read run outputs: %w", actual.outputErr)
+	}
+
+	expectedOutputs, err := decodeRunOutputs(c.ExpectRunOutputs)
+	if err != nil {
+		return fmt.Errorf("decode expected run outputs: %w", err)
+	}
+
+	actualOutputs, err := decodeRunOutputs(actual.RunOutputs)
+	if err != nil {
+		return fmt.Errorf("decode actual run outputs: %w", err)
+	}
+
+	opts, err := c.cmpOptions()
+	if err != nil {
+		return err
+	}
+
+	if diff := cmp.Diff(expectedOutputs, actualOutputs, opts); diff != "" {
+		return DiffError{Message: "expected run outputs do not equal actual", Diff: diff}
+	}
+
+	return nil
+}
 
-	var opts cmp.Options
-	if c.CompareOptions != nil && c.CompareOptions.CMPOption != nil {
-		opts, err = c.CompareOptions.CMPOption()
+func decodeRunOutputs(outputs templates.Outputs) (map[string]interface{}, error) {
+	decoded := make(map[string]interface{}, len(outputs))
+	for key, output := range outputs {
+		var value interface{}
+		if err := json.Unmarshal(output.Raw, &value); err != nil {
+			return nil, fmt.Errorf("output [%s]: %w", key, err)
+		}
+		decoded[key] = value
+	}
+
+	return decoded, nil
+}
+
+// assertObserved asserts the outputs and health read from a stamped object
+func (c *Test) assertObserved(actual *RealizedResource, expectedOutputs *templates.Output, expectedHealth *HealthExpectation) []error {
+	var errs []error
+
+	if expectedOutputs != nil {
+		opts, err := c.cmpOptions()
 		if err != nil {
-			return fmt.Errorf("get compare options: %w", err)
+			return []error{err}
+		}
+
+		if actual.outputErr != nil {
+			errs = append(errs, fmt.Errorf("failed to read outputs: %w", actual.outputErr))
+		} else if diff := cmp.Diff(expectedOutputs, actual.Output, opts); diff != "" {
+			errs = append(errs, DiffError{Message: "expected outputs do not equal actual", Diff: diff})
+		}
+	}
+
+	if expectedHealth != nil {
+		if err := expectedHealth.assert(actual.Health); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+func joinErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	return multierror.Append(nil, errs...)
+}
+
+func (c *Test) assertResources(stamped *stamping) error {
+	if errors.Is(stamped.err, yttNotFound) {
+		return fmt.Errorf("test requires ytt, but ytt was not found in path")
+	} else if stamped.err != nil {
+		return fmt.Errorf("failed to realize blueprint: %w", stamped.err)
+	}
+	realized := stamped.realized
+
+	var resourceNames []string
+	for name := range c.ExpectResources {
+		resourceNames = append(resourceNames, name)
+	}
+	sort.Strings(resourceNames)
+
+	var errs []error
+	for _, name := range resourceNames {
+		expectation := c.ExpectResources[name]
+
+		resource, ok := realized[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("resource [%s] not found in blueprint", name))
+			continue
+		}
+
+		if expectation.Object != nil {
+			if err := c.compareResourceObject(expectation.Object, resource); err != nil {
+				errs = append(errs, fmt.Errorf("resource [%s]: %w", name, err))
+			}
+		}
+
+		if expectation.Health != nil && resource.StampedObject == nil {
+			errs = append(errs, fmt.Errorf("resource [%s]: resource passes its input through and has no health", name))
+			continue
 		}
+
+		for _, err := range c.assertObserved(resource, expectation.Outputs, expectation.Health) {
+			errs = append(errs, fmt.Errorf("resource [%s]: %w", name, err))
+		}
+	}
+
+	return joinErrors(errs)
+}
+
+func (c *Test) compareResourceObject(expectation Expectation, resource *RealizedResource) error {
+	if resource.StampedObject == nil {
+		return fmt.Errorf("resource passes its input through and does not stamp an object")
+	}
+
+	expectedObject, err := expectation.getExpected()
+	if err != nil {
+		return fmt.Errorf("failed to get expected object: %w", err)
+	}
+
+	return c.compare(expectedObject.DeepCopy(), resource.StampedObject.DeepCopy())
+}
+
+func (c *Test) compare(expectedObject, actualObject *unstructured.Unstructured) error {
+	c.stripIgnoredFields(expectedObject, actualObject)
+
+	opts, err := c.cmpOptions()
+	if err != nil {
+		return err
 	}
 
 	if diff := cmp.Diff(expectedObject.Object, actualObject.Object, opts); diff != "" {
-		return fmt.Errorf("expected does not equal actual: (-expected +actual):\n%s", diff)
+		return DiffError{Message: "expected does not equal actual", Diff: diff}
 	}
 
 	return nil
 }
 
-func (i *Given) getActualObject() (*unstructured.Unstructured, error) {
-	ctx := context.Background()
+func (c *Test) cmpOptions() (cmp.Options, error) {
+	if c.CompareOptions == nil || c.CompareOptions.CMPOption == nil {
+		return nil, nil
+	}
 
-	workload, err := i.Workload.GetWorkload()
+	opts, err := c.CompareOptions.CMPOption()
 	if err != nil {
-		return nil, fmt.Errorf("get workload failed: %w", err)
+		return nil, fmt.Errorf("get compare options: %w", err)
+	}
+
+	return opts, nil
+}
+
+// getActual stamps the template and observes the stamped object with the fixture merged over it
+func (i *Given) getActual(ctx context.Context) (*RealizedResource, error) {
+	if err := i.validateOwner(); err != nil {
+		return nil, err
 	}
 
 	apiTemplate, err := i.Template.GetTemplate()
@@ -93,6 +360,10 @@ func (i *Given) getActualObject() (*unstructured.Unstructured, error) {
 		return nil, fmt.Errorf("template validation failed: %w", err)
 	}
 
+	if i.Runnable != nil {
+		return i.getActualRun(ctx, *apiTemplate)
+	}
+
 	template, err := templates.NewReaderFromAPI(*apiTemplate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cluster template")
@@ -105,9 +376,139 @@ func (i *Given) getActualObject() (*unstructured.Unstructured, error) {
 		}
 	}
 
-	if i.SupplyChain == nil {
-		i.SupplyChain = &MockSupplyChain{}
+	var stamped *stampedResource
+
+	if i.Deliverable != nil {
+		deliverable, err := i.Deliverable.GetDeliverable()
+		if err != nil {
+			return nil, fmt.Errorf("get deliverable failed: %w", err)
+		}
+
+		if i.Delivery == nil {
+			i.Delivery = &MockDelivery{}
+		}
+
+		stamped, err = i.Delivery.stamp(ctx, deliverable, *apiTemplate, template)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		workload, err := i.Workload.GetWorkload()
+		if err != nil {
+			return nil, fmt.Errorf("get workload failed: %w", err)
+		}
+
+		if i.SupplyChain == nil {
+			i.SupplyChain = &MockSupplyChain{}
+		}
+
+		stamped, err = i.SupplyChain.stamp(ctx, workload, *apiTemplate, template)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return observe(*apiTemplate, template, stamped, i.Fixture)
+}
+
+// getActualRun stamps the run template for the runnable and observes the stamped object
+// with the fixture merged over it
+func (i *Given) getActualRun(ctx context.Context, apiTemplate ValidatableTemplate) (*RealizedResource, error) {
+	runTemplate, ok := apiTemplate.(*v1alpha1.ClusterRunTemplate)
+	if !ok {
+		return nil, fmt.Errorf("a runnable requires a ClusterRunTemplate, but template [%s] is a %s", apiTemplate.GetName(), apiTemplate.GetObjectKind().GroupVersionKind().Kind)
+	}
+
+	runnable, err := i.Runnable.GetRunnable()
+	if err != nil {
+		return nil, fmt.Errorf("get runnable failed: %w", err)
+	}
+
+	stamped, err := stampRun(ctx, runnable, runTemplate, i.Selected)
+	if err != nil {
+		return nil, err
+	}
+
+	return observeRun(ctx, runTemplate, stamped, i.Fixture)
+}
+
+// validateOwner ensures exactly one of a workload, a deliverable and a runnable is given,
+// along with a blueprint of the matching kind
+func (i *Given) validateOwner() error {
+	owners := 0
+	for _, given := range []bool{i.Workload != nil, i.Deliverable != nil, i.Runnable != nil} {
+		if given {
+			owners++
+		}
+	}
+
+	switch {
+	case owners > 1:
+		return fmt.Errorf("only one of workload, deliverable and runnable may be given")
+	case owners == 0:
+		return fmt.Errorf("a workload, a deliverable or a runnable must be given")
+	case i.Workload == nil && i.SupplyChain != nil && !isMockSupplyChain(i.SupplyChain):
+		return fmt.Errorf("a supply chain may only be given for a workload")
+	case i.Deliverable == nil && i.Delivery != nil:
+		return fmt.Errorf("a delivery may only be given for a deliverable")
+	case i.Runnable == nil && i.Selected != nil:
+		return fmt.Errorf("a selected object may only be given for a runnable")
+	}
+
+	return nil
+}
+
+func isMockSupplyChain(supplyChain SupplyChain) bool {
+	mock, ok := supplyChain.(*MockSupplyChain)
+	return ok && mock.Params == nil && mock.Inputs == nil
+}
+
+func (i *Given) realize(ctx context.Context) (map[string]*RealizedResource, error) {
+	if err := i.validateOwner(); err != nil {
+		return nil, err
+	}
+
+	if i.Runnable != nil {
+		return nil, fmt.Errorf("realizing every resource requires a supply chain or delivery rather than a runnable")
+	}
+
+	var apiTemplates []ValidatableTemplate
+	for _, template := range i.Templates {
+		apiTemplate, err := template.GetTemplate()
+		if err != nil {
+			return nil, fmt.Errorf("get populated template failed: %w", err)
+		}
+
+		if err = (*apiTemplate).ValidateCreate(); err != nil {
+			return nil, fmt.Errorf("template [%s] validation failed: %w", (*apiTemplate).GetName(), err)
+		}
+
+		apiTemplates = append(apiTemplates, *apiTemplate)
+	}
+
+	if i.Deliverable != nil {
+		deliverable, err := i.Deliverable.GetDeliverable()
+		if err != nil {
+			return nil, fmt.Errorf("get deliverable failed: %w", err)
+		}
+
+		delivery, ok := i.Delivery.(*DeliveryFileSet)
+		if !ok {
+			return nil, fmt.Errorf("realizing every resource requires a delivery rather than a mock delivery")
+		}
+
+		return delivery.realize(ctx, deliverable, apiTemplates, i.Fixtures)
+	}
+
+	workload, err := i.Workload.GetWorkload()
+	if err != nil {
+		return nil, fmt.Errorf("get workload failed: %w", err)
+	}
+
+	supplyChain, ok := i.SupplyChain.(*SupplyChainFileSet)
+	if !ok {
+		return nil, fmt.Errorf("realizing every resource requires a supply chain rather than a mock supply chain")
 	}
 
-	return i.SupplyChain.stamp(ctx, workload, *apiTemplate, template)
+	return supplyChain.realize(ctx, workload, apiTemplates, i.Fixtures)
 }
@@ -24,14 +24,25 @@ import (
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
 )
 
 type testInfo struct {
-	Metadata       testInfoMetadata       `yaml:"metadata"`
-	Given          testInfoGiven          `yaml:"given"`
-	Expected       *string                `yaml:"expected"`
-	Focus          *bool                  `yaml:"focus"`
-	CompareOptions testInfoCompareOptions `yaml:"compareOptions"`
+	Metadata           testInfoMetadata                    `yaml:"metadata"`
+	Given              testInfoGiven                       `yaml:"given"`
+	Expected           *string                             `yaml:"expected"`
+	ExpectedOutputs    *templates.Output                   `yaml:"expectedOutputs"`
+	ExpectedHealth     *HealthExpectation                  `yaml:"expectedHealth"`
+	ExpectedRunOutputs templates.Outputs                   `yaml:"expectedRunOutputs"`
+	ExpectedResources  map[string]testInfoExpectedResource `yaml:"expectedResources"`
+	Focus              *bool                               `yaml:"focus"`
+	CompareOptions     testInfoCompareOptions              `yaml:"compareOptions"`
+}
+
+type testInfoExpectedResource struct {
+	Object  *string            `yaml:"object"`
+	Outputs *templates.Output  `yaml:"outputs"`
+	Health  *HealthExpectation `yaml:"health"`
 }
 
 type testInfoMetadata struct {
@@ -49,9 +60,17 @@ type testInfoCompareOptions struct {
 
 type testInfoGiven struct {
 	Template        testInfoTemplate    `yaml:"template"`
+	Fixture         *string             `yaml:"fixture"`
+	Templates       []string            `yaml:"templates"`
+	Fixtures        map[string]string   `yaml:"fixtures"`
 	Workload        *string             `yaml:"workload"`
 	MockSupplyChain testInfoMockSC      `yaml:"mockSupplyChain"`
 	SupplyChain     testInfoSupplyChain `yaml:"supplyChain"`
+	Deliverable     *string             `yaml:"deliverable"`
+	MockDelivery    testInfoMockSC      `yaml:"mockDelivery"`
+	Delivery        testInfoSupplyChain `yaml:"delivery"`
+	Runnable        *string             `yaml:"runnable"`
+	Selected        *string             `yaml:"selected"`
 }
 
 type testInfoTemplate struct {
@@ -74,9 +93,14 @@ type testInfoSupplyChain struct {
 const (
 	templateDefaultFilename             = "template.yaml"
 	workloadDefaultFilename             = "workload.yaml"
+	deliverableDefaultFilename          = "deliverable.yaml"
+	runnableDefaultFilename             = "runnable.yaml"
+	selectedDefaultFilename             = "selected.yaml"
 	expectedDefaultFilename             = "expected.yaml"
+	fixtureDefaultFilename              = "fixture.yaml"
 	templateYttValuesDefaultFilename    = "template-ytt-values.yaml"
 	supplyChainYttValuesDefaultFilename = "supply-chain-ytt-values.yaml"
+	deliveryYttValuesDefaultFilename    = "delivery-ytt-values.yaml"
 )
 
 func populateTestCase(testCase *Test, directory string) (*Test, error) {
@@ -95,6 +119,16 @@ func populateTestCase(testCase *Test, directory string) (*Test, error) {
 		return nil, fmt.Errorf("populate testCase workload: %w", err)
 	}
 
+	testCase, err = populateTestCaseDeliverable(testCase, directory, info)
+	if err != nil {
+		return nil, fmt.Errorf("populate testCase deliverable: %w", err)
+	}
+
+	testCase, err = populateTestCaseRunnable(testCase, directory, info)
+	if err != nil {
+		return nil, fmt.Errorf("populate testCase runnable: %w", err)
+	}
+
 	newExpectedFilePath, err := getLocallySpecifiedPath(directory, expectedDefaultFilename, info.Expected)
 	if err != nil {
 		return nil, fmt.Errorf("get expected file specified in directory %s: %w", directory, err)
@@ -103,6 +137,34 @@ func populateTestCase(testCase *Test, directory string) (*Test, error) {
 		testCase.Expect = &ExpectedFile{Path: newExpectedFilePath}
 	}
 
+	if info.ExpectedOutputs != nil {
+		testCase.ExpectOutputs = info.ExpectedOutputs
+	}
+
+	if info.ExpectedHealth != nil {
+		testCase.ExpectHealth = info.ExpectedHealth
+	}
+
+	if info.ExpectedRunOutputs != nil {
+		testCase.ExpectRunOutputs = info.ExpectedRunOutputs
+	}
+
+	newFixtureFilePath, err := getLocallySpecifiedPath(directory, fixtureDefaultFilename, info.Given.Fixture)
+	if err != nil {
+		return nil, fmt.Errorf("get fixture file specified in directory %s: %w", directory, err)
+	}
+	if newFixtureFilePath != "" {
+		testCase.Given.Fixture = &FixtureFile{Path: newFixtureFilePath}
+	}
+
+	testCase = populateTestCaseResources(testCase, directory, info)
+
+	// names describe a single directory, so they are not inherited
+	testCase.Name = ""
+	if info.Metadata.Name != nil {
+		testCase.Name = *info.Metadata.Name
+	}
+
 	if info.Focus != nil {
 		testCase.Focus = *info.Focus
 	}
@@ -124,9 +186,55 @@ func populateTestCase(testCase *Test, directory string) (*Test, error) {
 		return nil, fmt.Errorf("only one of mock supply chain and real supply chain may be specified")
 	}
 
+	var (
+		mockDeliverySpecified bool
+		deliverySpecified     bool
+	)
+
+	testCase, mockDeliverySpecified = populateTestCaseMockDelivery(testCase, info)
+	testCase, deliverySpecified, err = populateTestCaseDelivery(testCase, directory, info)
+	if err != nil {
+		return nil, fmt.Errorf("populate testCase delivery: %w", err)
+	}
+
+	if mockDeliverySpecified && deliverySpecified {
+		return nil, fmt.Errorf("only one of mock delivery and real delivery may be specified")
+	}
+
 	return testCase, nil
 }
 
+// populateTestCaseResources populates the templates and fixtures of the resources of a supply chain
+// realized in order, along with their expected results. Paths are relative to the directory.
+func populateTestCaseResources(testCase *Test, directory string, info *testInfo) *Test {
+	if len(info.Given.Templates) > 0 {
+		testCase.Given.Templates = nil
+		for _, path := range info.Given.Templates {
+			testCase.Given.Templates = append(testCase.Given.Templates, &TemplateFile{Path: filepath.Join(directory, path)})
+		}
+	}
+
+	if len(info.Given.Fixtures) > 0 {
+		testCase.Given.Fixtures = make(map[string]Fixture, len(info.Given.Fixtures))
+		for resourceName, path := range info.Given.Fixtures {
+			testCase.Given.Fixtures[resourceName] = &FixtureFile{Path: filepath.Join(directory, path)}
+		}
+	}
+
+	if len(info.ExpectedResources) > 0 {
+		testCase.ExpectResources = make(map[string]*ResourceExpectation, len(info.ExpectedResources))
+		for resourceName, expected := range info.ExpectedResources {
+			expectation := &ResourceExpectation{Outputs: expected.Outputs, Health: expected.Health}
+			if expected.Object != nil {
+				expectation.Object = &ExpectedFile{Path: filepath.Join(directory, *expected.Object)}
+			}
+			testCase.ExpectResources[resourceName] = expectation
+		}
+	}
+
+	return testCase
+}
+
 func populateCompareOptions(testCase *Test, info *testInfo) *Test {
 	if info.CompareOptions.IgnoreMetadata != nil {
 		if testCase.CompareOptions == nil {
@@ -172,6 +280,44 @@ func populateTestCaseWorkload(testCase *Test, directory string, info *testInfo)
 	}
 	if newWorkloadValue != "" {
 		testCase.Given.Workload = &WorkloadFile{Path: newWorkloadValue}
+		testCase.Given.Deliverable = nil
+		testCase.Given.Runnable = nil
+	}
+	return testCase, nil
+}
+
+// populateTestCaseDeliverable replaces an inherited workload or runnable, as a test has only one of them
+func populateTestCaseDeliverable(testCase *Test, directory string, info *testInfo) (*Test, error) {
+	newDeliverableValue, err := getLocallySpecifiedPath(directory, deliverableDefaultFilename, info.Given.Deliverable)
+	if err != nil {
+		return nil, fmt.Errorf("get deliverable file specified in directory %s: %w", directory, err)
+	}
+	if newDeliverableValue != "" {
+		testCase.Given.Deliverable = &DeliverableFile{Path: newDeliverableValue}
+		testCase.Given.Workload = nil
+		testCase.Given.Runnable = nil
+	}
+	return testCase, nil
+}
+
+// populateTestCaseRunnable replaces an inherited workload or deliverable, as a test has only one of them
+func populateTestCaseRunnable(testCase *Test, directory string, info *testInfo) (*Test, error) {
+	newRunnableValue, err := getLocallySpecifiedPath(directory, runnableDefaultFilename, info.Given.Runnable)
+	if err != nil {
+		return nil, fmt.Errorf("get runnable file specified in directory %s: %w", directory, err)
+	}
+	if newRunnableValue != "" {
+		testCase.Given.Runnable = &RunnableFile{Path: newRunnableValue}
+		testCase.Given.Workload = nil
+		testCase.Given.Deliverable = nil
+	}
+
+	newSelectedValue, err := getLocallySpecifiedPath(directory, selectedDefaultFilename, info.Given.Selected)
+	if err != nil {
+		return nil, fmt.Errorf("get selected file specified in directory %s: %w", directory, err)
+	}
+	if newSelectedValue != "" {
+		testCase.Given.Selected = &FixtureFile{Path: newSelectedValue}
 	}
 	return testCase, nil
 }
@@ -223,6 +369,73 @@ func populateTestCaseMockSupplyChain(testCase *Test, info *testInfo) (*Test, boo
 	return testCase, mockSupplyChainSpecified
 }
 
+func populateTestCaseMockDelivery(testCase *Test, info *testInfo) (*Test, bool) {
+	var mockDeliverySpecified bool
+	mockDelivery := MockDelivery{}
+
+	if info.Given.MockDelivery.BlueprintInputs != nil {
+		mockDelivery.Inputs = &SupplyChainInputsObject{Inputs: info.Given.MockDelivery.BlueprintInputs}
+		mockDeliverySpecified = true
+	}
+
+	if info.Given.MockDelivery.BlueprintParams != nil {
+		mockDelivery.Params = &SupplyChainParamsObject{Params: info.Given.MockDelivery.BlueprintParams}
+		mockDeliverySpecified = true
+	}
+
+	if mockDeliverySpecified {
+		testCase.Given.Delivery = &mockDelivery
+	}
+
+	return testCase, mockDeliverySpecified
+}
+
+func populateTestCaseDelivery(testCase *Test, directory string, info *testInfo) (*Test, bool, error) {
+	var deliverySpecified bool
+
+	newDelivery := DeliveryFileSet{}
+
+	if previousDeliverySet, prevDeliverySetExisted := testCase.Given.Delivery.(*DeliveryFileSet); prevDeliverySetExisted {
+		newDelivery = *previousDeliverySet
+	}
+
+	if info.Given.Delivery.TargetResourceName != nil {
+		newDelivery.TargetResourceName = *info.Given.Delivery.TargetResourceName
+	}
+
+	if info.Given.Delivery.PreviousOutputs != nil {
+		newDelivery.PreviousOutputs = info.Given.Delivery.PreviousOutputs
+	}
+
+	yttFile, err := getLocallySpecifiedPath(directory, deliveryYttValuesDefaultFilename, info.Given.Delivery.YttPath)
+	if err != nil {
+		return nil, false, fmt.Errorf("get delivery ytt file specified in directory %s: %w", directory, err)
+	}
+	if yttFile != "" {
+		newDelivery.YttFiles = []string{yttFile}
+	}
+
+	if len(info.Given.Delivery.Paths) > 0 {
+		newDelivery.Paths = info.Given.Delivery.Paths
+		deliverySpecified = true
+	} else {
+		filesPrefixedDeliveryInDir, err := getFilesPrefixedInDir(directory, "delivery")
+		if err != nil {
+			return nil, false, fmt.Errorf("get files prefixed with delivery in dir: %w", err)
+		}
+		if len(filesPrefixedDeliveryInDir) > 0 {
+			newDelivery.Paths = filesPrefixedDeliveryInDir
+			deliverySpecified = true
+		}
+	}
+
+	if deliveryFileSetNotEmpty(&newDelivery) {
+		testCase.Given.Delivery = &newDelivery
+	}
+
+	return testCase, deliverySpecified, nil
+}
+
 func populateTestCaseSupplyChain(testCase *Test, directory string, info *testInfo) (*Test, bool, error) {
 	var supplyChainSpecified bool
 
@@ -252,7 +465,7 @@ func populateTestCaseSupplyChain(testCase *Test, directory string, info *testInf
 		newSupplyChain.Paths = info.Given.SupplyChain.Paths
 		supplyChainSpecified = true
 	} else {
-		filesPrefixedSupplyChainInDir, err := getFilesPrefixedSupplyChainInDir(directory)
+		filesPrefixedSupplyChainInDir, err := getFilesPrefixedInDir(directory, "supply-chain")
 		if err != nil {
 			return nil, false, fmt.Errorf("get files prefixed with supply-chain in dir: %w", err)
 		}
@@ -285,21 +498,21 @@ func getLocallySpecifiedPath(directory, filename string, priorityPath *string) (
 	return candidatePath, nil
 }
 
-func getFilesPrefixedSupplyChainInDir(directory string) ([]string, error) {
+func getFilesPrefixedInDir(directory, prefix string) ([]string, error) {
 	files, err := os.ReadDir(directory)
 	if err != nil {
 		return nil, fmt.Errorf("os read directory: %w", err)
 	}
 
-	var supplyChainYamlFilesFound []string
+	var yamlFilesFound []string
 
 	for _, file := range files {
-		if strings.HasPrefix(file.Name(), "supply-chain") && strings.HasSuffix(file.Name(), ".yaml") {
-			supplyChainYamlFilesFound = append(supplyChainYamlFilesFound, filepath.Join(directory, file.Name()))
+		if strings.HasPrefix(file.Name(), prefix) && strings.HasSuffix(file.Name(), ".yaml") {
+			yamlFilesFound = append(yamlFilesFound, filepath.Join(directory, file.Name()))
 		}
 	}
 
-	return supplyChainYamlFilesFound, nil
+	return yamlFilesFound, nil
 }
 
 func fileSetNotEmpty(supplyChainfileSet *SupplyChainFileSet) bool {
@@ -308,3 +521,10 @@ func fileSetNotEmpty(supplyChainfileSet *SupplyChainFileSet) bool {
 		supplyChainfileSet.TargetResourceName != "" ||
 		supplyChainfileSet.PreviousOutputs != nil
 }
+
+func deliveryFileSetNotEmpty(deliveryFileSet *DeliveryFileSet) bool {
+	return len(deliveryFileSet.YttFiles) > 0 ||
+		len(deliveryFileSet.Paths) > 0 ||
+		deliveryFileSet.TargetResourceName != "" ||
+		deliveryFileSet.PreviousOutputs != nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+var evalCmd = &cobra.Command{
+	Use:   "eval <directory> <expression>",
+	Short: "eval evaluates an expression in the templating context of each test",
+	Long: `the eval command evaluates an expression against the templating context each test's template is stamped with.
+The expression is either a JSONPath, e.g. 'workload.spec.params', or holds $(...)$ tags as a template would,
+e.g. 'image: $(images.image.image)$'.
+Read more at cartographer.sh`,
+	Example: "cartotest eval ./tests/templates 'workload.metadata.name'",
+	Args:    cobra.ExactArgs(2),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return rootCmd.PreRunE(cmd, args[:1])
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		return CliEval(args[0], args[1])
+	},
+}
+
+// CliEval evaluates expression in the templating contexts of the tests in directory,
+// printing a line per context and reporting the contexts it cannot be evaluated in on stderr
+func CliEval(directory string, expression string) error {
+	baseTestCase := Test{}
+	testSuite, err := buildTestSuite(&baseTestCase, directory)
+	if err != nil {
+		return fmt.Errorf("build test cases: %w", err)
+	}
+
+	testsToEval := testSuite.selectTests(runOptions)
+
+	names := make([]string, 0, len(testsToEval))
+	for name := range testsToEval {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var failed bool
+	for _, name := range names {
+		contexts, err := testsToEval[name].TemplatingContexts()
+		if err != nil {
+			failed = true
+			_, _ = fmt.Fprintf(os.Stderr, "%s: build templating context: %s\n", name, err.Error())
+			continue
+		}
+
+		resources := make([]string, 0, len(contexts))
+		for resource := range contexts {
+			resources = append(resources, resource)
+		}
+		sort.Strings(resources)
+
+		for _, resource := range resources {
+			label := name
+			if resource != "" {
+				label = fmt.Sprintf("%s [%s]", name, resource)
+			}
+
+			value, err := EvaluateInContext(contexts[resource], expression)
+			if err == nil {
+				var formatted string
+				formatted, err = formatEvaluated(value)
+				if err == nil {
+					fmt.Printf("%s: %s\n", label, formatted)
+					continue
+				}
+			}
+
+			failed = true
+			_, _ = fmt.Fprintf(os.Stderr, "%s: %s\n", label, err.Error())
+		}
+	}
+
+	if failed {
+		return TestFailError{}
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(evalCmd)
+}
@@ -0,0 +1,206 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/graph"
+	"github.com/vmware-tanzu/cartographer/pkg/lint"
+)
+
+const (
+	dotGraphFormat     = "dot"
+	mermaidGraphFormat = "mermaid"
+)
+
+var (
+	graphFormat     string
+	graphFiles      []string
+	graphNamespace  string
+	graphKubeconfig string
+)
+
+type graphKind struct {
+	kind          string
+	clusterScoped bool
+	newObject     func() client.Object
+}
+
+// graphKinds are the kinds that can be graphed, by the lower case name given on the command line
+var graphKinds = map[string]graphKind{
+	"clustersupplychain": {"ClusterSupplyChain", true, func() client.Object { return &v1alpha1.ClusterSupplyChain{} }},
+	"supplychain":        {"SupplyChain", false, func() client.Object { return &v1alpha1.SupplyChain{} }},
+	"clusterdelivery":    {"ClusterDelivery", true, func() client.Object { return &v1alpha1.ClusterDelivery{} }},
+	"delivery":           {"Delivery", false, func() client.Object { return &v1alpha1.Delivery{} }},
+	"workload":           {"Workload", false, func() client.Object { return &v1alpha1.Workload{} }},
+	"deliverable":        {"Deliverable", false, func() client.Object { return &v1alpha1.Deliverable{} }},
+}
+
+var graphCmd = &cobra.Command{
+	Use:   "graph <kind> <name>",
+	Short: "graph renders supply chains, deliveries and the resources realized for their owners",
+	Long: `the graph command renders the resources of a ClusterSupplyChain, SupplyChain, ClusterDelivery or Delivery as a graph,
+with the inputs they read from each other and their template options. For a Workload or Deliverable, it renders the
+resources realized for it, with their health, template, stamped object and output previews.
+Objects are read from the files given with --file, or from the cluster of the kubeconfig otherwise.
+Read more at cartographer.sh`,
+	Example: `cartotest graph clustersupplychain source-to-url --file ./config --format mermaid
+cartotest graph workload my-app --namespace dev`,
+	Args: cobra.ExactArgs(2),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if graphFormat != dotGraphFormat && graphFormat != mermaidGraphFormat {
+			return fmt.Errorf("format must be one of %s, %s", dotGraphFormat, mermaidGraphFormat)
+		}
+
+		if _, ok := graphKinds[strings.ToLower(args[0])]; !ok {
+			kinds := make([]string, 0, len(graphKinds))
+			for kind := range graphKinds {
+				kinds = append(kinds, kind)
+			}
+			sort.Strings(kinds)
+			return fmt.Errorf("kind must be one of %s", strings.Join(kinds, ", "))
+		}
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		return CliGraph(args[0], args[1])
+	},
+}
+
+// CliGraph prints the graph of the object of the given kind and name, read from the files
+// of the graph command or from the cluster
+func CliGraph(kind string, name string) error {
+	var (
+		object client.Object
+		err    error
+	)
+	if len(graphFiles) > 0 {
+		object, err = getGraphedObjectFromFiles(graphKinds[strings.ToLower(kind)], name)
+	} else {
+		object, err = getGraphedObjectFromCluster(graphKinds[strings.ToLower(kind)], name)
+	}
+	if err != nil {
+		return err
+	}
+
+	var g *graph.Graph
+	switch typed := object.(type) {
+	case v1alpha1.SupplyChainObject:
+		g = graph.ForSupplyChain(typed)
+	case v1alpha1.DeliveryObject:
+		g = graph.ForDelivery(typed)
+	case *v1alpha1.Workload:
+		g = graph.ForOwner(typed.Name, typed.Status.Resources)
+	case *v1alpha1.Deliverable:
+		g = graph.ForOwner(typed.Name, typed.Status.Resources)
+	}
+
+	if graphFormat == mermaidGraphFormat {
+		fmt.Print(g.Mermaid())
+	} else {
+		fmt.Print(g.DOT())
+	}
+
+	return nil
+}
+
+func getGraphedObjectFromFiles(kind graphKind, name string) (client.Object, error) {
+	objects, findings, err := lint.Load(graphFiles...)
+	if err != nil {
+		return nil, fmt.Errorf("load objects: %w", err)
+	}
+
+	for _, finding := range findings {
+		if finding.Severity == lint.SeverityError {
+			return nil, fmt.Errorf("load objects: %s: %s", finding.File, finding.Message)
+		}
+	}
+
+	for _, object := range objects {
+		if object.Kind() != kind.kind || object.GetName() != name {
+			continue
+		}
+		if !kind.clusterScoped && graphNamespace != "" && object.GetNamespace() != graphNamespace {
+			continue
+		}
+
+		return object.Object, nil
+	}
+
+	return nil, fmt.Errorf("%s [%s] not found in %s", kind.kind, name, strings.Join(graphFiles, ", "))
+}
+
+func getGraphedObjectFromCluster(kind graphKind, name string) (client.Object, error) {
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("add to scheme: %w", err)
+	}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = graphKubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{})
+
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("load kubeconfig: %w", err)
+	}
+
+	namespace := graphNamespace
+	if namespace == "" {
+		namespace, _, err = clientConfig.Namespace()
+		if err != nil {
+			return nil, fmt.Errorf("get namespace of kubeconfig context: %w", err)
+		}
+	}
+
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("create client: %w", err)
+	}
+
+	key := client.ObjectKey{Name: name}
+	if !kind.clusterScoped {
+		key.Namespace = namespace
+	}
+
+	object := kind.newObject()
+	if err = c.Get(context.TODO(), key, object); err != nil {
+		return nil, fmt.Errorf("get %s [%s]: %w", kind.kind, name, err)
+	}
+
+	return object, nil
+}
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+	graphCmd.Flags().StringVar(&graphFormat, "format", dotGraphFormat, fmt.Sprintf("format of the graph, one of %s, %s", dotGraphFormat, mermaidGraphFormat))
+	graphCmd.Flags().StringSliceVarP(&graphFiles, "file", "f", nil, "yaml file or directory to read the object from, rather than the cluster")
+	graphCmd.Flags().StringVarP(&graphNamespace, "namespace", "n", "", "namespace of a namespaced object, defaults to the namespace of the kubeconfig context")
+	graphCmd.Flags().StringVar(&graphKubeconfig, "kubeconfig", "", "path to the kubeconfig, defaults to KUBECONFIG or ~/.kube/config")
+}
@@ -0,0 +1,145 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/cartographer/pkg/lint"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint <path>...",
+	Short: "lint checks supply chains, deliveries and templates without a cluster",
+	Long: `the lint command reads the Cartographer objects in the yaml files at the given paths, descending into directories.
+It runs the validations of the admission webhooks against them, checks that the templates referenced by supply chains
+and deliveries exist, that the params they provide are read and the params their templates read are provided,
+and that every template option may be selected. The command fails when any error is found.
+Read more at cartographer.sh`,
+	Example: "cartotest lint ./config --output json",
+	Args:    cobra.MinimumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if outputFormat == junitOutputFormat {
+			return fmt.Errorf("output of lint must be one of %s, %s", textOutputFormat, jsonOutputFormat)
+		}
+
+		for _, path := range args {
+			if _, err := os.Stat(path); err != nil {
+				return fmt.Errorf("argument must be a valid path")
+			}
+		}
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		return CliLint(args...)
+	},
+}
+
+// CliLint lints the Cartographer objects at paths and reports the findings in the output format
+func CliLint(paths ...string) error {
+	objects, findings, err := lint.Load(paths...)
+	if err != nil {
+		return fmt.Errorf("load objects: %w", err)
+	}
+
+	lintFindings, err := lint.Lint(context.TODO(), objects)
+	if err != nil {
+		return fmt.Errorf("lint objects: %w", err)
+	}
+	findings = append(findings, lintFindings...)
+
+	return reportLintFindings(findings)
+}
+
+type lintReport struct {
+	Errors   int            `json:"errors"`
+	Warnings int            `json:"warnings"`
+	Findings []lint.Finding `json:"findings"`
+}
+
+func reportLintFindings(findings []lint.Finding) error {
+	report := lintReport{Findings: findings}
+	if report.Findings == nil {
+		report.Findings = []lint.Finding{}
+	}
+
+	var textReport strings.Builder
+	for _, finding := range findings {
+		if finding.Severity == lint.SeverityError {
+			report.Errors++
+		} else {
+			report.Warnings++
+		}
+
+		object := finding.File
+		if finding.Kind != "" {
+			object = fmt.Sprintf("%s %s/%s", object, finding.Kind, finding.Name)
+			if finding.Namespace != "" {
+				object = fmt.Sprintf("%s %s/%s/%s", finding.File, finding.Kind, finding.Namespace, finding.Name)
+			}
+		}
+		textReport.WriteString(fmt.Sprintf("%s: %s: [%s] %s\n", strings.ToUpper(string(finding.Severity)), object, finding.Rule, finding.Message))
+	}
+	textReport.WriteString(fmt.Sprintf("%d errors, %d warnings\n", report.Errors, report.Warnings))
+
+	output := []byte(textReport.String())
+	if outputFormat == jsonOutputFormat {
+		jsonReport, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to create json report: %w", err)
+		}
+		output = append(jsonReport, '\n')
+	}
+
+	switch {
+	case outputFile != "":
+		if err := os.WriteFile(outputFile, output, 0644); err != nil {
+			return fmt.Errorf("failed to write report to %s: %w", outputFile, err)
+		}
+		if outputFormat != textOutputFormat {
+			if _, err := fmt.Fprint(os.Stderr, textReport.String()); err != nil {
+				return fmt.Errorf("write to stdErr failed")
+			}
+		}
+	case outputFormat == textOutputFormat:
+		if _, err := os.Stderr.Write(output); err != nil {
+			return fmt.Errorf("write to stdErr failed")
+		}
+	default:
+		if _, err := os.Stdout.Write(output); err != nil {
+			return fmt.Errorf("write to stdOut failed")
+		}
+	}
+
+	if report.Errors > 0 {
+		return TestFailError{}
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+}
@@ -0,0 +1,206 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string          `xml:"name,attr"`
+	Classname  string          `xml:"classname,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Failure    *junitFailure   `xml:"failure,omitempty"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Contents string `xml:",chardata"`
+}
+
+// reportJUnit reports the tests as a JUnit XML document, with a failure holding the
+// reason a test failed along with any diff between the expected and actual values
+func reportJUnit(tests []testCaseReporter, hasFocusedTests bool) ([]byte, error) {
+	suite := junitTestSuite{Name: "cartotest"}
+
+	var totalSeconds float64
+	for _, test := range tests {
+		totalSeconds += test.duration.Seconds()
+
+		testCase := junitTestCase{
+			Name:      test.name(),
+			Classname: test.path,
+			Time:      fmt.Sprintf("%.3f", test.duration.Seconds()),
+		}
+
+		if description := test.description(); description != "" {
+			testCase.Properties = []junitProperty{{Name: "description", Value: description}}
+		}
+
+		if test.err != nil {
+			reason, diff := failureDetails(test.err)
+			testCase.Failure = &junitFailure{Message: reason, Contents: diff}
+			suite.Failures++
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	if hasFocusedTests {
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      "focus",
+			Classname: "cartotest",
+			Time:      "0.000",
+			Failure:   &junitFailure{Message: focusedTestsFailure},
+		})
+		suite.Failures++
+	}
+
+	suite.Tests = len(suite.TestCases)
+	suite.Time = fmt.Sprintf("%.3f", totalSeconds)
+
+	report, err := xml.MarshalIndent(junitTestSuites{
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal junit report: %w", err)
+	}
+
+	return append([]byte(xml.Header), append(report, '\n')...), nil
+}
+
+type jsonReport struct {
+	Passed  bool             `json:"passed"`
+	Focused bool             `json:"focused"`
+	Tests   []jsonTestResult `json:"tests"`
+}
+
+type jsonTestResult struct {
+	Name        string  `json:"name"`
+	Path        string  `json:"path"`
+	Description string  `json:"description,omitempty"`
+	Status      string  `json:"status"`
+	Duration    float64 `json:"durationSeconds"`
+	Failure     string  `json:"failure,omitempty"`
+	Diff        string  `json:"diff,omitempty"`
+}
+
+func reportJSON(tests []testCaseReporter, hasFocusedTests bool, errorOccurred bool) ([]byte, error) {
+	report := jsonReport{
+		Passed:  !errorOccurred,
+		Focused: hasFocusedTests,
+		Tests:   []jsonTestResult{},
+	}
+
+	for _, test := range tests {
+		result := jsonTestResult{
+			Name:        test.name(),
+			Path:        test.path,
+			Description: test.description(),
+			Status:      "passed",
+			Duration:    test.duration.Seconds(),
+		}
+
+		if test.err != nil {
+			result.Status = "failed"
+			result.Failure, result.Diff = failureDetails(test.err)
+		}
+
+		report.Tests = append(report.Tests, result)
+	}
+
+	// diffs quote go values, which read better without html escaping
+	var output bytes.Buffer
+	encoder := json.NewEncoder(&output)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return nil, fmt.Errorf("marshal json report: %w", err)
+	}
+
+	return output.Bytes(), nil
+}
+
+func (t *testCaseReporter) name() string {
+	if t.info.Metadata.Name != nil {
+		return *t.info.Metadata.Name
+	}
+	return t.path
+}
+
+func (t *testCaseReporter) description() string {
+	if t.info.Metadata.Description != nil {
+		return *t.info.Metadata.Description
+	}
+	return ""
+}
+
+// failureDetails splits the error of a failed test into the reasons it failed and the
+// diffs between expected and actual values, so that reports can show them apart
+func failureDetails(err error) (string, string) {
+	errs := []error{err}
+
+	var merr *multierror.Error
+	if errors.As(err, &merr) {
+		errs = merr.Errors
+	}
+
+	var reasons, diffs []string
+	for _, e := range errs {
+		reason := e.Error()
+
+		var diffErr DiffError
+		if errors.As(e, &diffErr) {
+			reason = strings.Replace(reason, diffErr.Error(), diffErr.Message, 1)
+			diffs = append(diffs, fmt.Sprintf("%s: (-expected +actual):\n%s", reason, diffErr.Diff))
+		}
+
+		reasons = append(reasons, reason)
+	}
+
+	return strings.Join(reasons, "; "), strings.Join(diffs, "\n")
+}
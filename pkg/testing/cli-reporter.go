@@ -20,48 +20,139 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"sigs.k8s.io/yaml"
 )
 
 type testCaseReporter struct {
-	err  error
-	path string
-	info *testInfo
+	err      error
+	path     string
+	duration time.Duration
+	info     *testInfo
 }
 
-func reportTestResults(passedTests []string, failedTests []*FailedTest, hasFocusedTests bool) error {
+const (
+	textOutputFormat  = "text"
+	junitOutputFormat = "junit"
+	jsonOutputFormat  = "json"
+)
+
+var outputFormats = []string{textOutputFormat, junitOutputFormat, jsonOutputFormat}
+
+const focusedTestsFailure = "test suite failed due to focused test, check individual test case status"
+
+func reportTestResults(results []*TestResult, hasFocusedTests bool) error {
 	var (
-		tests         []testCaseReporter
+		passedTests   []testCaseReporter
+		failedTests   []testCaseReporter
 		errorOccurred bool
-		reportString  string
 	)
 
-	for _, passedTest := range passedTests {
-		testCase, err := newTestCaseReporter(passedTest, nil)
+	for _, result := range results {
+		testCase, err := newTestCaseReporter(result.Name, result.Err)
 		if err != nil {
-			return fmt.Errorf("failed to create new test case reporter for test %s: %w", passedTest, err)
+			return fmt.Errorf("failed to create new test case reporter for test %s: %w", result.Name, err)
 		}
-		tests = append(tests, *testCase)
+		testCase.duration = result.Duration
+
+		if result.Err != nil {
+			failedTests = append(failedTests, *testCase)
+			errorOccurred = true
+		} else {
+			passedTests = append(passedTests, *testCase)
+		}
+	}
+
+	tests := append(passedTests, failedTests...)
+
+	if hasFocusedTests {
+		errorOccurred = true
 	}
 
-	for _, failedTest := range failedTests {
-		testCase, err := newTestCaseReporter(failedTest.name, failedTest.err)
+	textReport := reportText(tests, hasFocusedTests, errorOccurred)
+
+	var report []byte
+	switch outputFormat {
+	case junitOutputFormat:
+		junit, err := reportJUnit(tests, hasFocusedTests)
 		if err != nil {
-			return fmt.Errorf("failed to create new test case reporter for test %s: %w", failedTest.name, err)
+			return fmt.Errorf("failed to create junit report: %w", err)
+		}
+		report = junit
+	case jsonOutputFormat:
+		jsonReport, err := reportJSON(tests, hasFocusedTests, errorOccurred)
+		if err != nil {
+			return fmt.Errorf("failed to create json report: %w", err)
+		}
+		report = jsonReport
+	default:
+		report = []byte(textReport)
+	}
+
+	switch {
+	case outputFile != "":
+		if err := os.WriteFile(outputFile, report, 0644); err != nil {
+			return fmt.Errorf("failed to write report to %s: %w", outputFile, err)
+		}
+		if outputFormat != textOutputFormat {
+			if _, err := fmt.Fprint(os.Stderr, textReport); err != nil {
+				return fmt.Errorf("write to stdErr failed")
+			}
+		}
+	case outputFormat == textOutputFormat:
+		if _, err := os.Stderr.Write(report); err != nil {
+			return fmt.Errorf("write to stdErr failed")
+		}
+	default:
+		if _, err := os.Stdout.Write(report); err != nil {
+			return fmt.Errorf("write to stdOut failed")
 		}
-		tests = append(tests, *testCase)
-		errorOccurred = true
 	}
 
+	if errorOccurred {
+		return TestFailError{}
+	}
+
+	return nil
+}
+
+func reportUpdatedFiles(updatedFiles []string) error {
+	reportString := fmt.Sprintf("updated %d expected files", len(updatedFiles))
+	for _, file := range updatedFiles {
+		reportString = fmt.Sprintf("%s\n  %s", reportString, file)
+	}
+
+	if _, err := fmt.Fprintf(os.Stderr, "%s\n", reportString); err != nil {
+		return fmt.Errorf("write to stdErr failed")
+	}
+
+	return nil
+}
+
+func reportDumpedFiles(dumpedFiles []string) error {
+	reportString := fmt.Sprintf("dumped %d templating contexts", len(dumpedFiles))
+	for _, file := range dumpedFiles {
+		reportString = fmt.Sprintf("%s\n  %s", reportString, file)
+	}
+
+	if _, err := fmt.Fprintf(os.Stderr, "%s\n", reportString); err != nil {
+		return fmt.Errorf("write to stdErr failed")
+	}
+
+	return nil
+}
+
+func reportText(tests []testCaseReporter, hasFocusedTests bool, errorOccurred bool) string {
+	var reportString string
+
 	for _, test := range tests {
 		reportString = fmt.Sprintf("%s\n%s", reportString, test.report(verbose))
 	}
 
 	if hasFocusedTests {
-		reportString = fmt.Sprintf("%s\n\ntest suite failed due to focused test, check individual test case status", reportString)
-		errorOccurred = true
+		reportString = fmt.Sprintf("%s\n\n%s", reportString, focusedTestsFailure)
 	}
 
 	if errorOccurred {
@@ -70,16 +161,7 @@ func reportTestResults(passedTests []string, failedTests []*FailedTest, hasFocus
 		reportString = fmt.Sprintf("%s\nPASS", reportString)
 	}
 
-	_, err := fmt.Fprintf(os.Stderr, "%s\n", reportString)
-	if err != nil {
-		return fmt.Errorf("write to stdErr failed")
-	}
-
-	if errorOccurred {
-		return TestFailError{}
-	}
-
-	return nil
+	return reportString + "\n"
 }
 
 func newTestCaseReporter(path string, err error) (*testCaseReporter, error) {
@@ -15,11 +15,16 @@
 package testing
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"regexp"
+	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"k8s.io/utils/strings/slices"
 )
 
 type TestFailError struct {
@@ -48,9 +53,18 @@ func Execute() {
 }
 
 var (
-	version   = "development"
-	directory string
-	verbose   bool
+	version      = "development"
+	directory    string
+	verbose      bool
+	outputFormat string
+	outputFile   string
+	update       bool
+	dumpContext  string
+	parallel     int
+	runPattern   string
+	skipPattern  string
+	timeout      time.Duration
+	runOptions   RunOptions
 )
 
 var rootCmd = &cobra.Command{
@@ -62,11 +76,39 @@ var rootCmd = &cobra.Command{
 Read more at cartographer.sh`,
 	Args:    cobra.ExactArgs(1),
 	Example: "cartotest ./tests/templates",
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		log.SetFormatter(&log.TextFormatter{})
 		if verbose {
 			log.SetLevel(log.DebugLevel)
 		}
+
+		if !slices.Contains(outputFormats, outputFormat) {
+			return fmt.Errorf("output must be one of %s", strings.Join(outputFormats, ", "))
+		}
+
+		if parallel < 1 {
+			return fmt.Errorf("parallel must be at least 1")
+		}
+
+		runOptions = RunOptions{Parallel: parallel, Timeout: timeout}
+
+		if runPattern != "" {
+			filter, err := regexp.Compile(runPattern)
+			if err != nil {
+				return fmt.Errorf("invalid run pattern: %w", err)
+			}
+			runOptions.Run = filter
+		}
+
+		if skipPattern != "" {
+			filter, err := regexp.Compile(skipPattern)
+			if err != nil {
+				return fmt.Errorf("invalid skip pattern: %w", err)
+			}
+			runOptions.Skip = filter
+		}
+
+		return nil
 	},
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		directory := args[0]
@@ -86,7 +128,7 @@ Read more at cartographer.sh`,
 		cmd.SilenceErrors = true
 
 		directory := args[0]
-		return CliTest(directory)
+		return cliTest(cmd.Context(), directory)
 	},
 }
 
@@ -104,24 +146,60 @@ Read more at cartographer.sh`,
 		cmd.SilenceUsage = true
 		cmd.SilenceErrors = true
 
-		return CliTest(directory)
+		return cliTest(cmd.Context(), directory)
 	},
 }
 
 func CliTest(directory string) error {
+	return cliTest(context.Background(), directory)
+}
+
+// cliTest runs the tests of directory once, then updates expected files and dumps templating
+// contexts from what the tests stamped, as the flags require
+func cliTest(ctx context.Context, directory string) error {
 	baseTestCase := Test{}
 	testSuite, err := buildTestSuite(&baseTestCase, directory)
 	if err != nil {
 		return fmt.Errorf("build test cases: %w", err)
 	}
 
-	passedTests, failedTests := testSuite.Assert()
+	results := testSuite.Results(ctx, runOptions)
+
+	if update {
+		updatedFiles, err := UpdateExpectedFiles(results)
+		if err != nil {
+			return fmt.Errorf("update expected files: %w", err)
+		}
+
+		if err = reportUpdatedFiles(updatedFiles); err != nil {
+			return err
+		}
+	}
+
+	if dumpContext != "" {
+		dumpedFiles, err := DumpTemplatingContexts(results, dumpContext)
+		if err != nil {
+			return fmt.Errorf("dump templating contexts: %w", err)
+		}
+
+		if err = reportDumpedFiles(dumpedFiles); err != nil {
+			return err
+		}
+	}
 
-	return reportTestResults(passedTests, failedTests, testSuite.HasFocusedTests())
+	return reportTestResults(results, testSuite.HasFocusedTests())
 }
 
 func init() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "output logs and increase test failure verbosity")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", textOutputFormat, fmt.Sprintf("format of the test report, one of %s", strings.Join(outputFormats, ", ")))
+	rootCmd.PersistentFlags().IntVarP(&parallel, "parallel", "p", 1, "number of tests to run at once")
+	rootCmd.PersistentFlags().StringVar(&runPattern, "run", "", "only run the tests whose path or name match this regular expression")
+	rootCmd.PersistentFlags().StringVar(&skipPattern, "skip", "", "skip the tests whose path or name match this regular expression")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "fail a test that runs longer than this duration, e.g. 30s")
+	rootCmd.PersistentFlags().BoolVar(&update, "update", false, "rewrite the expected file of each failing test with the actual stamped object")
+	rootCmd.PersistentFlags().StringVar(&dumpContext, "dump-context", "", "directory to write the templating context of each test to, one yaml file per test")
+	rootCmd.PersistentFlags().StringVar(&outputFile, "output-file", "", "file to write the test report to, rather than stdout (or stderr for the text report)")
 
 	rootCmd.AddCommand(templateCmd)
 	templateCmd.Flags().StringVarP(&directory, "directory", "d", "", "directory to test")
@@ -15,6 +15,7 @@
 package testing
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/google/go-cmp/cmp"
@@ -31,6 +32,16 @@ type CompareOptions struct {
 
 type CMPOption func() (cmp.Options, error)
 
+// DiffError is returned when an actual value does not equal the expected one
+type DiffError struct {
+	Message string
+	Diff    string
+}
+
+func (e DiffError) Error() string {
+	return fmt.Sprintf("%s: (-expected +actual):\n%s", e.Message, e.Diff)
+}
+
 func (c *Test) stripIgnoredFields(expected *unstructured.Unstructured, actual *unstructured.Unstructured) {
 	delete(expected.Object, "status")
 	delete(actual.Object, "status")
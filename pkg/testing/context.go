@@ -0,0 +1,154 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/valyala/fasttemplate"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/cartographer/pkg/eval"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+)
+
+// StampError is returned when a template cannot be stamped. It holds the templating context
+// the template was stamped with, and the name of the resource stamped when realizing every
+// resource of a supply chain or delivery.
+type StampError struct {
+	Resource          string
+	Err               error
+	TemplatingContext templates.JsonPathContext
+}
+
+func (e StampError) Error() string {
+	return fmt.Sprintf("could not stamp: %s", e.Err.Error())
+}
+
+func (e StampError) Unwrap() error {
+	return e.Err
+}
+
+// TemplatingContexts returns the templating contexts the templates of the test are stamped with,
+// whether or not they could be stamped. When every resource of a supply chain or delivery is
+// realized, they are keyed by resource name, leaving out the resources that pass an input through.
+// Otherwise, the only context is keyed by the empty string. When a template cannot be stamped, only
+// the context it was stamped with is returned.
+func (c *Test) TemplatingContexts() (map[string]templates.JsonPathContext, error) {
+	return c.stamp(context.Background()).templatingContexts()
+}
+
+func (s *stamping) templatingContexts() (map[string]templates.JsonPathContext, error) {
+	var stampErr StampError
+	if errors.As(s.err, &stampErr) {
+		return map[string]templates.JsonPathContext{stampErr.Resource: stampErr.TemplatingContext}, nil
+	} else if s.err != nil {
+		return nil, s.err
+	}
+
+	if s.realized == nil {
+		return map[string]templates.JsonPathContext{"": s.actual.TemplatingContext}, nil
+	}
+
+	contexts := make(map[string]templates.JsonPathContext, len(s.realized))
+	for name, resource := range s.realized {
+		if resource.TemplatingContext != nil {
+			contexts[name] = resource.TemplatingContext
+		}
+	}
+	return contexts, nil
+}
+
+// DumpTemplatingContexts writes the templating contexts the tests of results were stamped with to a yaml
+// file per test in directory, named after the test, and returns the paths of the written files.
+// The contexts of a test realizing every resource of a supply chain or delivery are keyed by resource name.
+// Tests whose contexts could not be built are left as reported.
+func DumpTemplatingContexts(results []*TestResult, directory string) ([]string, error) {
+	var paths []string
+	for _, result := range results {
+		if result.stamped == nil {
+			continue
+		}
+
+		contexts, err := result.stamped.templatingContexts()
+		if err != nil {
+			continue
+		}
+		name := result.Name
+
+		var dump interface{} = contexts
+		if context, ok := contexts[""]; ok && len(contexts) == 1 {
+			dump = context
+		}
+
+		data, err := yaml.Marshal(dump)
+		if err != nil {
+			return nil, fmt.Errorf("marshal templating context of test %s: %w", name, err)
+		}
+
+		// test names are paths when read from directories, which are kept within the dump directory
+		path := filepath.Join(directory, filepath.Clean(string(filepath.Separator)+name)+".yaml")
+		if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, fmt.Errorf("create directory for templating context of test %s: %w", name, err)
+		}
+
+		if err = os.WriteFile(path, data, 0644); err != nil {
+			return nil, fmt.Errorf("write templating context of test %s: %w", name, err)
+		}
+
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+// EvaluateInContext evaluates an expression against a templating context as the stamper would.
+// Expressions holding a $(...)$ tag are interpolated, any other is evaluated as a JSONPath.
+func EvaluateInContext(context templates.JsonPathContext, expression string) (interface{}, error) {
+	evaluator := eval.EvaluatorBuilder()
+
+	if !strings.Contains(expression, "$(") {
+		return evaluator.EvaluateJsonPath(expression, context)
+	}
+
+	tagInterpolator := templates.StandardTagInterpolator{
+		Context:   context,
+		Evaluator: evaluator,
+	}
+
+	return templates.InterpolateLeafNode(fasttemplate.ExecuteFuncStringWithErr, []byte(expression), tagInterpolator)
+}
+
+// formatEvaluated formats a value evaluated in a templating context, leaving strings as they are
+func formatEvaluated(value interface{}) (string, error) {
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("marshal evaluated value: %w", err)
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/controllers"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+)
+
+type Delivery interface {
+	stamp(ctx context.Context, deliverable *v1alpha1.Deliverable, apiTemplate ValidatableTemplate, template templates.Reader) (*stampedResource, error)
+}
+
+// DeliveryFileSet is a set of one or more deliveries
+// Paths is a list of either paths to a delivery
+// or a directory containing delivery files
+// YttValues and YttFiles are values to use in preprocessing the deliveries
+// TargetResourceName is the name of the resource that will be stamped
+// PreviousOutputs are mocked outputs from earlier resources in the delivery,
+// including the deployment consumed by a ClusterDeploymentTemplate
+type DeliveryFileSet struct {
+	Paths              []string
+	YttValues          Values
+	YttFiles           []string
+	TargetResourceName string
+	PreviousOutputs    *realizer.Outputs
+}
+
+func (d *DeliveryFileSet) stamp(ctx context.Context, deliverable *v1alpha1.Deliverable, templateObject ValidatableTemplate, template templates.Reader) (*stampedResource, error) {
+	blueprint, err := d.getBlueprint(ctx, deliverable)
+	if err != nil {
+		return nil, err
+	}
+
+	return blueprint.stampTarget(ctx, d.TargetResourceName, d.PreviousOutputs, templateObject, template)
+}
+
+// realize realizes every resource of the selected delivery in order, as the realizer would.
+func (d *DeliveryFileSet) realize(ctx context.Context, deliverable *v1alpha1.Deliverable, apiTemplates []ValidatableTemplate, fixtures map[string]Fixture) (map[string]*RealizedResource, error) {
+	blueprint, err := d.getBlueprint(ctx, deliverable)
+	if err != nil {
+		return nil, err
+	}
+
+	return blueprint.realize(ctx, apiTemplates, fixtures)
+}
+
+func (d *DeliveryFileSet) getBlueprint(ctx context.Context, deliverable *v1alpha1.Deliverable) (*blueprint, error) {
+	delivery, err := d.getDelivery(ctx, deliverable)
+	if err != nil {
+		return nil, fmt.Errorf("get delivery: %w", err)
+	}
+
+	return &blueprint{
+		kind:        "delivery",
+		owner:       deliverable,
+		ownerParams: deliverable.Spec.Params,
+		object:      delivery,
+		params:      delivery.GetDeliverySpec().Params,
+		resources:   realizer.MakeDeliveryOwnerResources(delivery),
+		labeler:     controllers.BuildDeliverableResourceLabeler(deliverable, delivery),
+	}, nil
+}
+
+func (d *DeliveryFileSet) getDelivery(ctx context.Context, deliverable *v1alpha1.Deliverable) (v1alpha1.DeliveryObject, error) {
+	var noLog *NoLog
+
+	allDeliveries, err := d.readAllPaths(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("read all paths, %w", err)
+	}
+
+	selectedDeliveries, err := repository.GetSelectedDelivery(allDeliveries, deliverable, logr.New(noLog))
+	if err != nil {
+		return nil, fmt.Errorf("get selected delivery, %w", err)
+	}
+
+	if len(selectedDeliveries) == 0 {
+		return nil, fmt.Errorf("no delivery [%s/%s] found where full selector is satisfied by labels: %v",
+			deliverable.Namespace, deliverable.Name, deliverable.Labels)
+	}
+
+	if len(selectedDeliveries) > 1 {
+		return nil, fmt.Errorf("more than one delivery selected for deliverable [%s/%s]: %+v",
+			deliverable.Namespace, deliverable.Name, controllers.GetDeliveryNames(selectedDeliveries))
+	}
+
+	return selectedDeliveries[0], nil
+}
+
+func (d *DeliveryFileSet) readAllPaths(ctx context.Context) ([]v1alpha1.DeliveryObject, error) {
+	var deliveries []v1alpha1.DeliveryObject
+
+	for _, path := range d.Paths {
+		file, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not get fileinfo for path: %w", err)
+		}
+
+		if file.IsDir() {
+			additionalDeliveries, err := d.readDeliveryDir(ctx, path)
+			if err != nil {
+				return nil, fmt.Errorf("read delivery directory: %w", err)
+			}
+
+			deliveries = append(deliveries, additionalDeliveries...)
+		} else {
+			delivery, err := d.readDeliveryFile(ctx, path)
+			if err != nil {
+				return nil, fmt.Errorf("could not read delivery file: %w", err)
+			}
+
+			deliveries = append(deliveries, delivery)
+		}
+	}
+
+	return deliveries, nil
+}
+
+// readDeliveryDir is not recursive and will not walk a nested directory
+func (d *DeliveryFileSet) readDeliveryDir(ctx context.Context, path string) ([]v1alpha1.DeliveryObject, error) {
+	files, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("os read directory: %w", err)
+	}
+
+	var deliveries []v1alpha1.DeliveryObject
+
+	for _, file := range files {
+		fullPath := filepath.Join(path, file.Name())
+		delivery, err := d.readDeliveryFile(ctx, fullPath)
+		if err != nil {
+			return nil, fmt.Errorf("read delivery file: %s, %w", fullPath, err)
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+func (d *DeliveryFileSet) readDeliveryFile(ctx context.Context, path string) (*v1alpha1.ClusterDelivery, error) {
+	var (
+		deliveryFilepath string
+		err              error
+	)
+
+	if len(d.YttValues) != 0 || len(d.YttFiles) != 0 {
+		err := ensureYTTAvailable(ctx)
+
+		if err != nil {
+			return nil, fmt.Errorf("ensure ytt available: %w", err)
+		}
+
+		deliveryFilepath, err = d.preprocessYtt(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to preprocess ytt: %w", err)
+		}
+		defer os.RemoveAll(deliveryFilepath)
+	} else {
+		deliveryFilepath = path
+	}
+
+	delivery := &v1alpha1.ClusterDelivery{}
+
+	deliveryData, err := os.ReadFile(deliveryFilepath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read delivery file: %w", err)
+	}
+
+	if err = yaml.Unmarshal(deliveryData, delivery); err != nil {
+		return nil, fmt.Errorf("unmarshall delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+func (d *DeliveryFileSet) preprocessYtt(ctx context.Context, deliveryFilepath string) (string, error) {
+	yt := YTT()
+	yt.Values(d.YttValues)
+	yt.F(deliveryFilepath)
+	for _, yttfile := range d.YttFiles {
+		yt.F(yttfile)
+	}
+	f, err := yt.ToTempFile(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file by ytt: %w", err)
+	}
+
+	return f.Name(), nil
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -72,3 +73,22 @@ func (e *ExpectedFile) getExpected() (*unstructured.Unstructured, error) {
 
 	return &expectedStampedObject, nil
 }
+
+// HealthExpectation is the expected ResourcesHealthy condition of a stamped object
+// Reason and Message are only compared when set
+type HealthExpectation struct {
+	Status  metav1.ConditionStatus
+	Reason  string
+	Message string
+}
+
+func (h *HealthExpectation) assert(condition metav1.Condition) error {
+	if condition.Status != h.Status ||
+		(h.Reason != "" && condition.Reason != h.Reason) ||
+		(h.Message != "" && condition.Message != h.Message) {
+		return fmt.Errorf("expected health [status: %s, reason: %s, message: %s] but got [status: %s, reason: %s, message: %s]",
+			h.Status, h.Reason, h.Message, condition.Status, condition.Reason, condition.Message)
+	}
+
+	return nil
+}
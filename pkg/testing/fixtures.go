@@ -0,0 +1,91 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"fmt"
+	"os"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// Fixture simulates an object as it is observed on the cluster,
+// such as a stamped object along with the status its controller reports
+type Fixture interface {
+	GetFixture() (*unstructured.Unstructured, error)
+}
+
+// FixtureObject implements Fixture
+type FixtureObject struct {
+	Object *unstructured.Unstructured
+}
+
+func (f *FixtureObject) GetFixture() (*unstructured.Unstructured, error) {
+	return f.Object, nil
+}
+
+// FixtureFile implements Fixture
+// Path is the filepath to the yaml definition of the object
+type FixtureFile struct {
+	Path string
+}
+
+func (f *FixtureFile) GetFixture() (*unstructured.Unstructured, error) {
+	fixtureData, err := os.ReadFile(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read fixture file: %w", err)
+	}
+
+	// fixtures may only hold the fields of interest, so kind and apiVersion are not required
+	fixture := map[string]interface{}{}
+
+	if err = yaml.Unmarshal(fixtureData, &fixture); err != nil {
+		return nil, fmt.Errorf("unmarshall fixture: %w", err)
+	}
+
+	return &unstructured.Unstructured{Object: fixture}, nil
+}
+
+// observedObject returns a copy of object with fixture merged over it, as the object would be
+// observed once its controller reconciled it. Lists in the fixture replace those of the object.
+func observedObject(object *unstructured.Unstructured, fixture Fixture) (*unstructured.Unstructured, error) {
+	observed := object.DeepCopy()
+	if fixture == nil {
+		return observed, nil
+	}
+
+	fixtureObject, err := fixture.GetFixture()
+	if err != nil {
+		return nil, fmt.Errorf("get fixture: %w", err)
+	}
+
+	mergeFields(observed.Object, runtime.DeepCopyJSON(fixtureObject.Object))
+
+	return observed, nil
+}
+
+func mergeFields(dst, src map[string]interface{}) {
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeFields(dstMap, srcMap)
+			continue
+		}
+		dst[key] = value
+	}
+}
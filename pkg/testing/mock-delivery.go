@@ -0,0 +1,48 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"context"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+)
+
+// MockDelivery implements Delivery
+// Inputs simulate expected inputs that are the outputs from earlier resources in the delivery,
+// including the deployment consumed by a ClusterDeploymentTemplate
+// Params supplies params as if defined in the delivery
+type MockDelivery struct {
+	Params SupplyChainParams
+	Inputs SupplyChainInputs
+}
+
+func (i *MockDelivery) stamp(ctx context.Context, deliverable *v1alpha1.Deliverable, apiTemplate ValidatableTemplate, template templates.Reader) (*stampedResource, error) {
+	labels := completeDeliverableLabels(*deliverable, apiTemplate.GetName(), apiTemplate.GetObjectKind().GroupVersionKind().Kind)
+
+	return stampMock(ctx, deliverable, deliverable.Spec.Params, i.Params, i.Inputs, labels, template)
+}
+
+func completeDeliverableLabels(deliverable v1alpha1.Deliverable, name string, kind string) map[string]string {
+	labels := make(map[string]string)
+
+	labels["carto.run/deliverable-name"] = deliverable.GetName()
+	labels["carto.run/deliverable-namespace"] = deliverable.GetNamespace()
+	labels["carto.run/template-kind"] = kind
+	labels["carto.run/cluster-template-name"] = name
+
+	return labels
+}
@@ -20,7 +20,7 @@ import (
 	"os"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
@@ -36,37 +36,10 @@ type MockSupplyChain struct {
 	Inputs SupplyChainInputs
 }
 
-func (i *MockSupplyChain) stamp(ctx context.Context, workload *v1alpha1.Workload, apiTemplate ValidatableTemplate, template templates.Reader) (*unstructured.Unstructured, error) {
+func (i *MockSupplyChain) stamp(ctx context.Context, workload *v1alpha1.Workload, apiTemplate ValidatableTemplate, template templates.Reader) (*stampedResource, error) {
 	labels := completeLabels(*workload, apiTemplate.GetName(), apiTemplate.GetObjectKind().GroupVersionKind().Kind)
 
-	var (
-		err error
-	)
-
-	blueprintParams := make([]v1alpha1.BlueprintParam, 0)
-
-	if i.Params != nil {
-		blueprintParams, err = i.Params.GetParams()
-		if err != nil {
-			return nil, fmt.Errorf("get blueprint params failed: %w", err)
-		}
-	}
-
-	paramMerger := realizer.NewParamMerger([]v1alpha1.BlueprintParam{}, blueprintParams, workload.Spec.Params)
-	params := paramMerger.Merge(template)
-
-	templatingContext, err := i.createTemplatingContext(*workload, params)
-	if err != nil {
-		return nil, fmt.Errorf("create templating context: %w", err)
-	}
-
-	stampContext := templates.StamperBuilder(workload, templatingContext, labels)
-	actualStampedObject, err := stampContext.Stamp(ctx, template.GetResourceTemplate())
-	if err != nil {
-		return nil, fmt.Errorf("could not stamp: %w", err)
-	}
-
-	return actualStampedObject, nil
+	return stampMock(ctx, workload, workload.Spec.Params, i.Params, i.Inputs, labels, template)
 }
 
 func completeLabels(workload v1alpha1.Workload, name string, kind string) map[string]string {
@@ -80,28 +53,54 @@ func completeLabels(workload v1alpha1.Workload, name string, kind string) map[st
 	return labels
 }
 
-func (i *MockSupplyChain) createTemplatingContext(workload v1alpha1.Workload, params map[string]apiextensionsv1.JSON) (map[string]interface{}, error) {
+// stampMock stamps a template for an owner, a workload or deliverable, as a blueprint declaring
+// the given params would. Inputs simulate the outputs of earlier resources in the blueprint.
+func stampMock(ctx context.Context, owner client.Object, ownerParams []v1alpha1.OwnerParam, blueprintParamsGetter SupplyChainParams, inputsGetter SupplyChainInputs, labels map[string]string, template templates.Reader) (*stampedResource, error) {
 	var (
-		inputs *Inputs
-		err    error
+		err error
 	)
 
-	inputs = &Inputs{}
+	blueprintParams := make([]v1alpha1.BlueprintParam, 0)
+
+	if blueprintParamsGetter != nil {
+		blueprintParams, err = blueprintParamsGetter.GetParams()
+		if err != nil {
+			return nil, fmt.Errorf("get blueprint params failed: %w", err)
+		}
+	}
+
+	paramMerger := realizer.NewParamMerger([]v1alpha1.BlueprintParam{}, blueprintParams, ownerParams)
+	params := paramMerger.Merge(template)
 
-	if i.Inputs != nil {
-		inputs, err = i.Inputs.GetInputs()
+	inputs := &Inputs{}
+	if inputsGetter != nil {
+		inputs, err = inputsGetter.GetInputs()
 		if err != nil {
-			return nil, fmt.Errorf("get supply chain inputs: %w", err)
+			return nil, fmt.Errorf("get blueprint inputs: %w", err)
 		}
 	}
 
+	templatingContext := createTemplatingContext(owner, params, inputs)
+
+	stampContext := templates.StamperBuilder(owner, templatingContext, labels)
+	actualStampedObject, err := stampContext.Stamp(ctx, template.GetResourceTemplate())
+	if err != nil {
+		return nil, StampError{Err: err, TemplatingContext: templatingContext}
+	}
+
+	return &stampedResource{object: actualStampedObject, templatingContext: templatingContext, inputs: inputs}, nil
+}
+
+// createTemplatingContext holds the owner under both the workload and deliverable keys, as the realizer does
+func createTemplatingContext(owner client.Object, params map[string]apiextensionsv1.JSON, inputs *Inputs) map[string]interface{} {
 	templatingContext := map[string]interface{}{
-		"workload": workload,
-		"params":   params,
-		"sources":  inputs.Sources,
-		"images":   inputs.Images,
-		"configs":  inputs.Configs,
-		//"deployment": // not implemented yet,
+		"workload":    owner,
+		"deliverable": owner,
+		"params":      params,
+		"sources":     inputs.Sources,
+		"images":      inputs.Images,
+		"configs":     inputs.Configs,
+		"deployment":  inputs.Deployment,
 	}
 
 	if len(inputs.Sources) == 1 {
@@ -121,7 +120,7 @@ func (i *MockSupplyChain) createTemplatingContext(workload v1alpha1.Workload, pa
 			templatingContext["config"] = config.Config
 		}
 	}
-	return templatingContext, nil
+	return templatingContext
 }
 
 type SupplyChainParams interface {
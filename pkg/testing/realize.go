@@ -0,0 +1,201 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer/healthcheck"
+	"github.com/vmware-tanzu/cartographer/pkg/stamp"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+)
+
+// RealizedResource is the result of realizing a resource of a supply chain or delivery, or a runnable
+// StampedObject is nil for resources that pass an input through rather than stamp a template
+// Output and Health are read from the stamped object with its fixture merged over it
+// RunOutputs are read instead of Output from the object stamped for a runnable
+// TemplatingContext is the context the template was stamped with
+type RealizedResource struct {
+	StampedObject     *unstructured.Unstructured
+	Output            *templates.Output
+	RunOutputs        templates.Outputs
+	Health            metav1.Condition
+	TemplatingContext templates.JsonPathContext
+
+	outputErr error
+}
+
+// realize realizes every resource of the selected supply chain in order, as the realizer would.
+func (s *SupplyChainFileSet) realize(ctx context.Context, workload *v1alpha1.Workload, apiTemplates []ValidatableTemplate, fixtures map[string]Fixture) (map[string]*RealizedResource, error) {
+	blueprint, err := s.getBlueprint(ctx, workload)
+	if err != nil {
+		return nil, err
+	}
+
+	return blueprint.realize(ctx, apiTemplates, fixtures)
+}
+
+// realize realizes every resource of the blueprint in order. Each resource stamps the template
+// it selects among apiTemplates. Its outputs are read from the stamped object with its fixture,
+// if any, merged over it, and fed to the resources that consume them.
+func (b *blueprint) realize(ctx context.Context, apiTemplates []ValidatableTemplate, fixtures map[string]Fixture) (map[string]*RealizedResource, error) {
+	for name := range fixtures {
+		if _, err := getTargetResource(b.resources, name); err != nil {
+			return nil, fmt.Errorf("fixture given for unknown resource: %w", err)
+		}
+	}
+
+	outputs := realizer.NewOutputs()
+	realized := make(map[string]*RealizedResource, len(b.resources))
+
+	for i := range b.resources {
+		resource := &b.resources[i]
+
+		realizedResource, err := b.realizeResource(ctx, resource, apiTemplates, fixtures[resource.Name], outputs)
+		if err != nil {
+			return nil, fmt.Errorf("realize resource [%s]: %w", resource.Name, err)
+		}
+
+		outputs.AddOutput(resource.Name, realizedResource.Output)
+		realized[resource.Name] = realizedResource
+	}
+
+	return realized, nil
+}
+
+func (b *blueprint) realizeResource(ctx context.Context, resource *realizer.OwnerResource, apiTemplates []ValidatableTemplate, fixture Fixture, outputs realizer.Outputs) (*RealizedResource, error) {
+	inputGenerator := realizer.NewInputGenerator(*resource, outputs)
+
+	templateName, passThrough, templateOption, err := realizer.GetTemplateNameFromResource(*resource, b.object.GetName(), b.owner)
+	if err != nil {
+		return nil, fmt.Errorf("get template name from resource: %w", err)
+	}
+
+	if passThrough {
+		reader, err := stamp.NewPassThroughReader(resource.TemplateRef.Kind, templateOption.PassThrough, inputGenerator)
+		if err != nil {
+			return nil, fmt.Errorf("create pass through reader: %w", err)
+		}
+
+		output, err := reader.Output(nil)
+		if err != nil {
+			return nil, fmt.Errorf("read pass through output: %w", err)
+		}
+
+		return &RealizedResource{Output: output}, nil
+	}
+
+	apiTemplate := findTemplate(apiTemplates, resource.TemplateRef.Kind, templateName)
+	if apiTemplate == nil {
+		return nil, fmt.Errorf("template [%s/%s] was not given", resource.TemplateRef.Kind, templateName)
+	}
+
+	template, err := templates.NewReaderFromAPI(apiTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster template: %w", err)
+	}
+
+	if template.IsYTTTemplate() {
+		err = ensureYTTAvailable(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("ensure YTT available: %w", err)
+		}
+	}
+
+	stamped, err := b.stampResource(ctx, resource, template, outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	realized, err := observe(apiTemplate, template, stamped, fixture)
+	if err != nil {
+		return nil, err
+	}
+
+	if realized.outputErr != nil {
+		return nil, fmt.Errorf("read outputs: %w", realized.outputErr)
+	}
+
+	return realized, nil
+}
+
+// observe reads the outputs and health of a stamped object as the realizer would, once the
+// fixture is merged over it. Failing to read the outputs is recorded rather than returned, as the
+// realizer still reports the health of the object.
+func observe(apiTemplate ValidatableTemplate, template templates.Reader, stamped *stampedResource, fixture Fixture) (*RealizedResource, error) {
+	stampedObject := stamped.object
+	observed, err := observedObject(stampedObject, fixture)
+	if err != nil {
+		return nil, fmt.Errorf("apply fixture: %w", err)
+	}
+
+	reader, err := stamp.NewReader(apiTemplate, stamped.inputs)
+	if err != nil {
+		return nil, fmt.Errorf("create stamp reader: %w", err)
+	}
+
+	realized := &RealizedResource{StampedObject: stampedObject, TemplatingContext: stamped.templatingContext}
+	realized.Output, realized.outputErr = reader.Output(observed)
+
+	outputs, err := realizer.GenerateResourceOutputs(realized.Output)
+	if err != nil {
+		return nil, fmt.Errorf("generate resource outputs: %w", err)
+	}
+
+	realizedResource := &v1alpha1.RealizedResource{
+		TemplateRef: &corev1.ObjectReference{
+			Kind:       apiTemplate.GetObjectKind().GroupVersionKind().Kind,
+			Name:       apiTemplate.GetName(),
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		},
+		StampedRef: &v1alpha1.StampedRef{
+			ObjectReference: &corev1.ObjectReference{
+				Kind:       stampedObject.GetKind(),
+				Namespace:  stampedObject.GetNamespace(),
+				Name:       stampedObject.GetName(),
+				APIVersion: stampedObject.GetAPIVersion(),
+			},
+		},
+		Outputs: outputs,
+	}
+	realized.Health = healthcheck.DetermineHealthCondition(template.GetHealthRule(), realizedResource, observed)
+
+	return realized, nil
+}
+
+// stampedResource is an object stamped from a template, along with the templating context it was
+// stamped with and the inputs its outputs are read with
+type stampedResource struct {
+	object            *unstructured.Unstructured
+	templatingContext templates.JsonPathContext
+	inputs            stamp.DeploymentInput
+}
+
+func findTemplate(apiTemplates []ValidatableTemplate, kind, name string) ValidatableTemplate {
+	for _, apiTemplate := range apiTemplates {
+		if apiTemplate.GetName() == name && apiTemplate.GetObjectKind().GroupVersionKind().Kind == kind {
+			return apiTemplate
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer/healthcheck"
+	runnablerealizer "github.com/vmware-tanzu/cartographer/pkg/realizer/runnable"
+	"github.com/vmware-tanzu/cartographer/pkg/stamp"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+)
+
+type Runnable interface {
+	GetRunnable() (*v1alpha1.Runnable, error)
+}
+
+type RunnableObject struct {
+	Runnable *v1alpha1.Runnable
+}
+
+func (r *RunnableObject) GetRunnable() (*v1alpha1.Runnable, error) {
+	return r.Runnable, nil
+}
+
+type RunnableFile struct {
+	Path string
+}
+
+func (r *RunnableFile) GetRunnable() (*v1alpha1.Runnable, error) {
+	runnable := &v1alpha1.Runnable{}
+
+	runnableData, err := os.ReadFile(r.Path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read runnable file: %w", err)
+	}
+
+	if err = yaml.Unmarshal(runnableData, runnable); err != nil {
+		return nil, fmt.Errorf("unmarshall runnable: %w", err)
+	}
+
+	return runnable, nil
+}
+
+// stampRun stamps the run template for the runnable as the runnable realizer would,
+// with the selected object in place of the one its selector resolves to
+func stampRun(ctx context.Context, runnable *v1alpha1.Runnable, runTemplate *v1alpha1.ClusterRunTemplate, selected Fixture) (*stampedResource, error) {
+	template := templates.NewRunTemplateModel(runTemplate)
+
+	labels := map[string]string{
+		"carto.run/runnable-name":     runnable.Name,
+		"carto.run/run-template-name": template.GetName(),
+	}
+
+	var selectedObject map[string]interface{}
+	if selected != nil {
+		object, err := selected.GetFixture()
+		if err != nil {
+			return nil, fmt.Errorf("get selected object: %w", err)
+		}
+		selectedObject = object.Object
+	}
+
+	templatingContext := runnablerealizer.TemplatingContext{
+		Runnable: runnable,
+		Selected: selectedObject,
+	}
+
+	stampContext := templates.StamperBuilder(runnable, templatingContext, labels)
+
+	stampedObject, err := stampContext.Stamp(ctx, template.GetResourceTemplate())
+	if err != nil {
+		return nil, StampError{Err: err, TemplatingContext: templatingContext}
+	}
+
+	return &stampedResource{object: stampedObject, templatingContext: templatingContext}, nil
+}
+
+// observeRun reads the outputs and health of a run as the runnable realizer would, once the
+// fixture is merged over the stamped object. Outputs are only read from a successful run.
+func observeRun(ctx context.Context, runTemplate *v1alpha1.ClusterRunTemplate, stamped *stampedResource, fixture Fixture) (*RealizedResource, error) {
+	observed, err := observedObject(stamped.object, fixture)
+	if err != nil {
+		return nil, fmt.Errorf("apply fixture: %w", err)
+	}
+
+	// the api server sets the creation timestamp that the latest successful run is found by
+	if observed.GetCreationTimestamp().Time.IsZero() {
+		observed.SetCreationTimestamp(metav1.Now())
+	}
+
+	template := templates.NewRunTemplateModel(runTemplate)
+
+	realized := &RealizedResource{StampedObject: stamped.object, TemplatingContext: stamped.templatingContext}
+
+	var outputSource *unstructured.Unstructured
+	realized.RunOutputs, outputSource, realized.outputErr = template.GetLatestSuccessfulOutput([]*unstructured.Unstructured{observed})
+	if realized.outputErr == nil && outputSource != nil && runTemplate.Spec.TektonResults {
+		realized.RunOutputs = runnablerealizer.WithTektonResults(ctx, stamp.NewTektonOutputReader(), outputSource, realized.RunOutputs)
+	}
+
+	healthRule := &v1alpha1.HealthRule{SingleConditionType: "Succeeded"}
+	realized.Health = healthcheck.DetermineHealthCondition(healthRule, nil, observed)
+
+	return realized, nil
+}
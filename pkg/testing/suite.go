@@ -14,7 +14,16 @@
 
 package testing
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
 
 // Suite is a collection of named template tests which may be run together
 type Suite map[string]*Test
@@ -24,6 +33,28 @@ type FailedTest struct {
 	err  error
 }
 
+// TestResult is the outcome of running a Test of a Suite
+// Err is nil when the test passed
+type TestResult struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+
+	test    *Test
+	stamped *stamping
+}
+
+// RunOptions control which tests of a Suite are run, and how
+// Run and Skip select the tests whose name in the suite, or Test.Name, they match or do not match.
+// Parallel is the number of tests run at once, one when unset.
+// Timeout fails a test that runs longer, no timeout applies when unset.
+type RunOptions struct {
+	Run      *regexp.Regexp
+	Skip     *regexp.Regexp
+	Parallel int
+	Timeout  time.Duration
+}
+
 // Assert allows testing a Suite when a *testing.T is not available,
 // e.g. when tests are not run from 'go test'
 // It returns a list of the named tests that passed and a list of the named tests that failed with their errors
@@ -33,18 +64,93 @@ func (s *Suite) Assert() ([]string, []*FailedTest) {
 		failedTests []*FailedTest
 	)
 
+	for _, result := range s.Results(context.Background(), RunOptions{}) {
+		if result.Err != nil {
+			failedTests = append(failedTests, &FailedTest{name: result.Name, err: result.Err})
+		} else {
+			passedTests = append(passedTests, result.Name)
+		}
+	}
+
+	return passedTests, failedTests
+}
+
+// Results runs the tests of the suite selected by opts, stamping with ctx, and returns their results, ordered by name
+func (s *Suite) Results(ctx context.Context, opts RunOptions) []*TestResult {
+	testsToRun := s.selectTests(opts)
+
+	var names []string
+	for name := range testsToRun {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parallel := opts.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	results := make([]*TestResult, len(names))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+
+	for i, name := range names {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, name string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			start := time.Now()
+			stamped, err := runWithTimeout(ctx, testsToRun[name], opts.Timeout)
+			results[i] = &TestResult{Name: name, Err: err, Duration: time.Since(start), test: testsToRun[name], stamped: stamped}
+		}(i, name)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// runWithTimeout runs the test, failing it once the timeout elapses. Stamping is stopped
+// when it does, so that the test no longer runs once it returns.
+func runWithTimeout(ctx context.Context, testCase *Test, timeout time.Duration) (*stamping, error) {
+	if timeout <= 0 {
+		return testCase.run(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	stamped, err := testCase.run(ctx)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return stamped, fmt.Errorf("test timed out after %s", timeout)
+	}
+	return stamped, err
+}
+
+// selectTests returns the tests to run that the Run and Skip filters of opts select
+func (s *Suite) selectTests(opts RunOptions) Suite {
 	testsToRun, _ := s.getTestsToRun()
 
+	matches := func(filter *regexp.Regexp, name string, testCase *Test) bool {
+		return filter.MatchString(name) || (testCase.Name != "" && filter.MatchString(testCase.Name))
+	}
+
+	selected := make(Suite, len(testsToRun))
 	for name, testCase := range testsToRun {
-		err := testCase.Run()
-		if err != nil {
-			failedTests = append(failedTests, &FailedTest{name: name, err: err})
-		} else {
-			passedTests = append(passedTests, name)
+		if opts.Run != nil && !matches(opts.Run, name, testCase) {
+			continue
 		}
+		if opts.Skip != nil && matches(opts.Skip, name, testCase) {
+			continue
+		}
+		selected[name] = testCase
 	}
 
-	return passedTests, failedTests
+	return selected
 }
 
 func (s *Suite) HasFocusedTests() bool {
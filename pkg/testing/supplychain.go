@@ -21,7 +21,6 @@ import (
 	"path/filepath"
 
 	"github.com/go-logr/logr"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
@@ -33,7 +32,7 @@ import (
 )
 
 type SupplyChain interface {
-	stamp(ctx context.Context, workload *v1alpha1.Workload, apiTemplate ValidatableTemplate, template templates.Reader) (*unstructured.Unstructured, error)
+	stamp(ctx context.Context, workload *v1alpha1.Workload, apiTemplate ValidatableTemplate, template templates.Reader) (*stampedResource, error)
 }
 
 // SupplyChainFileSet is a set of one or more supply chains
@@ -50,10 +49,10 @@ type SupplyChainFileSet struct {
 	PreviousOutputs    *realizer.Outputs
 }
 
-func (s *SupplyChainFileSet) getSupplyChain(workload *v1alpha1.Workload) (*v1alpha1.ClusterSupplyChain, error) {
+func (s *SupplyChainFileSet) getSupplyChain(ctx context.Context, workload *v1alpha1.Workload) (v1alpha1.SupplyChainObject, error) {
 	var noLog *NoLog
 
-	allSupplyChains, err := s.readAllPaths()
+	allSupplyChains, err := s.readAllPaths(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("read all paths, %w", err)
 	}
@@ -76,8 +75,8 @@ func (s *SupplyChainFileSet) getSupplyChain(workload *v1alpha1.Workload) (*v1alp
 	return selectedSupplyChains[0], nil
 }
 
-func (s *SupplyChainFileSet) readAllPaths() ([]*v1alpha1.ClusterSupplyChain, error) {
-	var supplyChains []*v1alpha1.ClusterSupplyChain
+func (s *SupplyChainFileSet) readAllPaths(ctx context.Context) ([]v1alpha1.SupplyChainObject, error) {
+	var supplyChains []v1alpha1.SupplyChainObject
 
 	for _, path := range s.Paths {
 		file, err := os.Stat(path)
@@ -86,14 +85,14 @@ func (s *SupplyChainFileSet) readAllPaths() ([]*v1alpha1.ClusterSupplyChain, err
 		}
 
 		if file.IsDir() {
-			additionalSupplyChains, err := s.readSupplyChainDir(path)
+			additionalSupplyChains, err := s.readSupplyChainDir(ctx, path)
 			if err != nil {
 				return nil, fmt.Errorf("read supply chain directory: %w", err)
 			}
 
 			supplyChains = append(supplyChains, additionalSupplyChains...)
 		} else {
-			supplyChain, err := s.readSupplyChainFile(path)
+			supplyChain, err := s.readSupplyChainFile(ctx, path)
 			if err != nil {
 				return nil, fmt.Errorf("could not read supply chain file: %w", err)
 			}
@@ -106,17 +105,17 @@ func (s *SupplyChainFileSet) readAllPaths() ([]*v1alpha1.ClusterSupplyChain, err
 }
 
 // readSupplyChainDir is not recursive and will not walk a nested directory
-func (s *SupplyChainFileSet) readSupplyChainDir(path string) ([]*v1alpha1.ClusterSupplyChain, error) {
+func (s *SupplyChainFileSet) readSupplyChainDir(ctx context.Context, path string) ([]v1alpha1.SupplyChainObject, error) {
 	files, err := os.ReadDir(path)
 	if err != nil {
 		return nil, fmt.Errorf("os read directory: %w", err)
 	}
 
-	var supplyChains []*v1alpha1.ClusterSupplyChain
+	var supplyChains []v1alpha1.SupplyChainObject
 
 	for _, file := range files {
 		fullPath := filepath.Join(path, file.Name())
-		supplyChain, err := s.readSupplyChainFile(fullPath)
+		supplyChain, err := s.readSupplyChainFile(ctx, fullPath)
 		if err != nil {
 			return nil, fmt.Errorf("read supply chain file: %s, %w", fullPath, err)
 		}
@@ -127,20 +126,20 @@ func (s *SupplyChainFileSet) readSupplyChainDir(path string) ([]*v1alpha1.Cluste
 	return supplyChains, nil
 }
 
-func (s *SupplyChainFileSet) readSupplyChainFile(path string) (*v1alpha1.ClusterSupplyChain, error) {
+func (s *SupplyChainFileSet) readSupplyChainFile(ctx context.Context, path string) (*v1alpha1.ClusterSupplyChain, error) {
 	var (
 		supplyChainFilepath string
 		err                 error
 	)
 
 	if len(s.YttValues) != 0 || len(s.YttFiles) != 0 {
-		err := ensureYTTAvailable(context.TODO())
+		err := ensureYTTAvailable(ctx)
 
 		if err != nil {
 			return nil, fmt.Errorf("ensure ytt available: %w", err)
 		}
 
-		supplyChainFilepath, err = s.preprocessYtt(context.TODO(), path)
+		supplyChainFilepath, err = s.preprocessYtt(ctx, path)
 		if err != nil {
 			return nil, fmt.Errorf("failed to preprocess ytt: %w", err)
 		}
@@ -187,46 +186,96 @@ func (n *NoLog) Error(_ error, _ string, _ ...interface{}) {}
 func (n *NoLog) WithValues(_ ...interface{}) logr.LogSink  { return n }
 func (n *NoLog) WithName(name string) logr.LogSink         { return n }
 
-func (s *SupplyChainFileSet) stamp(ctx context.Context, workload *v1alpha1.Workload, templateObject ValidatableTemplate, template templates.Reader) (*unstructured.Unstructured, error) {
-	supplyChain, err := s.getSupplyChain(workload)
+func (s *SupplyChainFileSet) stamp(ctx context.Context, workload *v1alpha1.Workload, templateObject ValidatableTemplate, template templates.Reader) (*stampedResource, error) {
+	blueprint, err := s.getBlueprint(ctx, workload)
+	if err != nil {
+		return nil, err
+	}
+
+	return blueprint.stampTarget(ctx, s.TargetResourceName, s.PreviousOutputs, templateObject, template)
+}
+
+func (s *SupplyChainFileSet) getBlueprint(ctx context.Context, workload *v1alpha1.Workload) (*blueprint, error) {
+	supplyChain, err := s.getSupplyChain(ctx, workload)
 	if err != nil {
 		return nil, fmt.Errorf("get supplychain: %w", err)
 	}
 
-	resource, err := getTargetResource(realizer.MakeSupplychainOwnerResources(supplyChain), s.TargetResourceName)
+	ownerResources, err := realizer.MakeSupplychainOwnerResources(supplyChain, nil)
+	if err != nil {
+		return nil, fmt.Errorf("make supply chain owner resources: %w", err)
+	}
+
+	return &blueprint{
+		kind:        "supply chain",
+		owner:       workload,
+		ownerParams: workload.Spec.Params,
+		object:      supplyChain,
+		params:      supplyChain.GetSupplyChainSpec().Params,
+		resources:   ownerResources,
+		labeler:     controllers.BuildWorkloadResourceLabeler(workload, supplyChain),
+	}, nil
+}
+
+// blueprint is a supply chain selected for a workload, or a delivery selected for a deliverable,
+// holding what the realizer needs to stamp the templates of its resources
+type blueprint struct {
+	kind        string
+	owner       client.Object
+	ownerParams []v1alpha1.OwnerParam
+	object      client.Object
+	params      []v1alpha1.BlueprintParam
+	resources   []realizer.OwnerResource
+	labeler     realizer.ResourceLabeler
+}
+
+// stampTarget stamps the template of the target resource of the blueprint,
+// given mocked outputs of the resources it consumes
+func (b *blueprint) stampTarget(ctx context.Context, targetResourceName string, previousOutputs *realizer.Outputs, templateObject ValidatableTemplate, template templates.Reader) (*stampedResource, error) {
+	resource, err := getTargetResource(b.resources, targetResourceName)
 	if err != nil {
 		return nil, fmt.Errorf("get target resource: %w", err)
 	}
 
-	properTemplateProvided, err := templateMatchesResource(templateObject, resource, workload)
+	properTemplateProvided, err := templateMatchesResource(templateObject, resource, b.owner)
 	if err != nil {
 		return nil, fmt.Errorf("template matches resource: %w", err)
 	}
 
 	if !properTemplateProvided {
-		return nil, fmt.Errorf("template '%s' is not selected by resource/stage '%s' in supply chain '%s'", templateObject.GetName(), resource.Name, supplyChain.Name)
+		return nil, fmt.Errorf("template '%s' is not selected by resource/stage '%s' in %s '%s'", templateObject.GetName(), resource.Name, b.kind, b.object.GetName())
 	}
 
-	templatingContext := realizer.NewContextGenerator(workload, workload.Spec.Params, supplyChain.Spec.Params)
-
-	resourceLabeler := controllers.BuildWorkloadResourceLabeler(workload, supplyChain)
-	labels := resourceLabeler(*resource, template)
-
 	var outputs realizer.OutputsGetter
 
-	if s.PreviousOutputs != nil {
-		outputs = s.PreviousOutputs
+	if previousOutputs != nil {
+		outputs = previousOutputs
 	} else {
 		outputs = realizer.NewOutputs()
 	}
 
-	stamper := templates.StamperBuilder(workload, templatingContext.Generate(template, *resource, outputs, labels), labels)
+	return b.stampResource(ctx, resource, template, outputs)
+}
+
+// stampResource stamps the template of a resource of the blueprint as the realizer would,
+// given the outputs of the resources it consumes
+func (b *blueprint) stampResource(ctx context.Context, resource *realizer.OwnerResource, template templates.Reader, outputs realizer.OutputsGetter) (*stampedResource, error) {
+	contextGenerator := realizer.NewContextGenerator(b.owner, b.ownerParams, b.params)
+
+	labels := b.labeler(*resource, template)
+	templatingContext := contextGenerator.Generate(template, *resource, outputs, labels)
+
+	stamper := templates.StamperBuilder(b.owner, templatingContext, labels)
 	actualStampedObject, err := stamper.Stamp(ctx, template.GetResourceTemplate())
 	if err != nil {
-		return nil, fmt.Errorf("could not stamp: %w", err)
+		return nil, StampError{Resource: resource.Name, Err: err, TemplatingContext: templatingContext}
 	}
 
-	return actualStampedObject, nil
+	return &stampedResource{
+		object:            actualStampedObject,
+		templatingContext: templatingContext,
+		inputs:            realizer.NewInputGenerator(*resource, outputs),
+	}, nil
 }
 
 func templateMatchesResource(template ValidatableTemplate, resource *realizer.OwnerResource, owner client.Object) (bool, error) {
@@ -244,5 +293,5 @@ func getTargetResource(resources []realizer.OwnerResource, targetResourceName st
 		}
 	}
 
-	return nil, fmt.Errorf("did not find a supply chain resource with target name: %s", targetResourceName)
+	return nil, fmt.Errorf("did not find a resource with target name: %s", targetResourceName)
 }
@@ -36,6 +36,10 @@ type Inputs struct {
 	Deployment *templates.SourceInput
 }
 
+func (i *Inputs) GetDeployment() *templates.SourceInput {
+	return i.Deployment
+}
+
 type ValidatableTemplate interface {
 	ValidateCreate() error
 	client.Object
@@ -113,8 +117,12 @@ func (i *TemplateFile) GetTemplate() (*ValidatableTemplate, error) {
 		apiTemplate = &v1alpha1.ClusterImageTemplate{}
 	case "ClusterConfigTemplate":
 		apiTemplate = &v1alpha1.ClusterConfigTemplate{}
+	case "ClusterDeploymentTemplate":
+		apiTemplate = &v1alpha1.ClusterDeploymentTemplate{}
 	case "ClusterTemplate":
 		apiTemplate = &v1alpha1.ClusterTemplate{}
+	case "ClusterRunTemplate":
+		apiTemplate = &v1alpha1.ClusterRunTemplate{}
 	default:
 		return nil, fmt.Errorf("template kind not found")
 	}
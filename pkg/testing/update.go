@@ -0,0 +1,154 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// UpdateExpectedFiles rewrites the expected files of the tests of results whose stamped objects do not equal them,
+// and returns the paths of the rewritten files. Tests are not stamped again: the results of the tests
+// whose files are rewritten are asserted again against the rewritten files.
+// Only expectations read from files are rewritten. Tests whose objects could not be stamped are left as reported.
+func UpdateExpectedFiles(results []*TestResult) ([]string, error) {
+	updated := map[string]bool{}
+	for _, result := range results {
+		if result.stamped == nil || result.stamped.err != nil {
+			continue
+		}
+
+		paths, err := result.test.updateExpected(result.stamped)
+		if err != nil {
+			return nil, fmt.Errorf("update expected files of test %s: %w", result.Name, err)
+		}
+		if len(paths) > 0 {
+			result.Err = result.test.assert(result.stamped)
+		}
+		for _, path := range paths {
+			updated[path] = true
+		}
+	}
+
+	var paths []string
+	for path := range updated {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+// updateExpected rewrites each expected file of the test that what it stamped does not equal
+// with the stamped object, leaving out the fields ignored by CompareOptions, and returns the paths
+// of the rewritten files.
+func (c *Test) updateExpected(stamped *stamping) ([]string, error) {
+	if len(c.Given.Templates) > 0 {
+		return c.updateExpectedResources(stamped.realized)
+	}
+
+	expectedFile, ok := c.Expect.(*ExpectedFile)
+	if !ok {
+		return nil, nil
+	}
+
+	updated, err := c.updateExpectedFile(expectedFile, stamped.actual.StampedObject)
+	if err != nil || !updated {
+		return nil, err
+	}
+
+	return []string{expectedFile.Path}, nil
+}
+
+func (c *Test) updateExpectedResources(realized map[string]*RealizedResource) ([]string, error) {
+	var paths []string
+	for name, expectation := range c.ExpectResources {
+		expectedFile, ok := expectation.Object.(*ExpectedFile)
+		resource, found := realized[name]
+		if !ok || !found || resource.StampedObject == nil {
+			continue
+		}
+
+		updated, err := c.updateExpectedFile(expectedFile, resource.StampedObject)
+		if err != nil {
+			return nil, fmt.Errorf("resource [%s]: %w", name, err)
+		}
+		if updated {
+			paths = append(paths, expectedFile.Path)
+		}
+	}
+
+	return paths, nil
+}
+
+func (c *Test) updateExpectedFile(expectedFile *ExpectedFile, stampedObject *unstructured.Unstructured) (bool, error) {
+	expectedObject, err := expectedFile.getExpected()
+	if err != nil {
+		// an expected file that cannot be read is replaced
+		expectedObject = &unstructured.Unstructured{Object: map[string]interface{}{}}
+	}
+
+	actualObject := stampedObject.DeepCopy()
+	err = c.compare(expectedObject, actualObject)
+	if err == nil {
+		return false, nil
+	}
+
+	var diffErr DiffError
+	if !errors.As(err, &diffErr) {
+		return false, err
+	}
+
+	golden, err := yaml.Marshal(actualObject.Object)
+	if err != nil {
+		return false, fmt.Errorf("marshal stamped object: %w", err)
+	}
+
+	if err = os.WriteFile(expectedFile.Path, append(leadingComments(expectedFile.Path), golden...), 0644); err != nil {
+		return false, fmt.Errorf("write expected file: %w", err)
+	}
+
+	return true, nil
+}
+
+// leadingComments returns the comments, such as a license header, and document separator
+// that precede the object in the file at path, so that rewriting the object keeps them
+func leadingComments(path string) []byte {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var header bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") && trimmed != "---" {
+			break
+		}
+		header.WriteString(line + "\n")
+	}
+
+	return header.Bytes()
+}
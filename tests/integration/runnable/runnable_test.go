@@ -1081,4 +1081,125 @@ var _ = Describe("Stamping a resource on Runnable Creation", func() {
 			})
 		})
 	})
+
+	Describe("A ClusterRunTemplate that stamps a Tekton PipelineRun and reads its results", func() {
+		var pipelineRun *unstructured.Unstructured
+
+		BeforeEach(func() {
+			runTemplateYaml := HereYamlF(`
+				---
+				apiVersion: carto.run/v1alpha1
+				kind: ClusterRunTemplate
+				metadata:
+				  name: my-tekton-run-template
+				spec:
+				  tektonResults: true
+				  template:
+					apiVersion: tekton.dev/v1beta1
+					kind: PipelineRun
+					metadata:
+					  generateName: my-pipeline-run-
+					spec:
+					  pipelineRef:
+					    name: my-pipeline
+				`)
+
+			runTemplateDefinition = CreateObjectOnClusterFromYamlDefinition(ctx, c, runTemplateYaml)
+
+			runnableYaml := HereYamlF(`---
+				apiVersion: carto.run/v1alpha1
+				kind: Runnable
+				metadata:
+				  namespace: %s
+				  name: my-runnable
+				spec:
+				  serviceAccountName: %s
+				  runTemplateRef:
+				    name: my-tekton-run-template
+				    kind: ClusterRunTemplate
+				`,
+				testNS, serviceAccountName)
+
+			runnableDefinition = CreateObjectOnClusterFromYamlDefinition(ctx, c, runnableYaml)
+
+			pipelineRuns := &unstructured.UnstructuredList{}
+			pipelineRuns.SetAPIVersion("tekton.dev/v1beta1")
+			pipelineRuns.SetKind("PipelineRunList")
+			Eventually(func() ([]unstructured.Unstructured, error) {
+				err := c.List(ctx, pipelineRuns, client.InNamespace(testNS), client.MatchingLabels{"carto.run/runnable-name": "my-runnable"})
+				return pipelineRuns.Items, err
+			}).Should(HaveLen(1))
+			pipelineRun = &pipelineRuns.Items[0]
+		})
+
+		AfterEach(func() {
+			err := c.Delete(ctx, runnableDefinition)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = c.Delete(ctx, runTemplateDefinition)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		getRunnable := func() (*v1alpha1.Runnable, error) {
+			runnable := &v1alpha1.Runnable{}
+			err := c.Get(ctx, client.ObjectKey{Namespace: testNS, Name: "my-runnable"}, runnable)
+			return runnable, err
+		}
+
+		It("populates the runnable's outputs with the results of the succeeded pipelinerun", func() {
+			pipelineRun.Object["status"] = map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Succeeded", "status": "True", "reason": "Succeeded", "message": "Tasks Completed: 1"},
+				},
+				"pipelineResults": []interface{}{
+					map[string]interface{}{"name": "digest", "value": "sha256:abc123"},
+				},
+			}
+			Expect(c.Status().Update(ctx, pipelineRun)).To(Succeed())
+
+			Eventually(func() (map[string]apiextensionsv1.JSON, error) {
+				runnable, err := getRunnable()
+				return runnable.Status.Outputs, err
+			}).Should(HaveKeyWithValue("digest", apiextensionsv1.JSON{Raw: []byte(`"sha256:abc123"`)}))
+		})
+
+		It("reports the failing taskrun and step of the failed pipelinerun", func() {
+			taskRun := &unstructured.Unstructured{}
+			taskRun.SetAPIVersion("tekton.dev/v1beta1")
+			taskRun.SetKind("TaskRun")
+			taskRun.SetNamespace(testNS)
+			taskRun.SetName(pipelineRun.GetName() + "-unit-tests")
+			taskRun.SetLabels(map[string]string{"tekton.dev/pipelineRun": pipelineRun.GetName()})
+			Expect(c.Create(ctx, taskRun)).To(Succeed())
+
+			taskRun.Object["status"] = map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Succeeded", "status": "False", "reason": "Failed", "message": "step exited with 1"},
+				},
+				"steps": []interface{}{
+					map[string]interface{}{"name": "go-test", "terminated": map[string]interface{}{"exitCode": int64(1)}},
+				},
+			}
+			Expect(c.Status().Update(ctx, taskRun)).To(Succeed())
+
+			pipelineRun.Object["status"] = map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Succeeded", "status": "False", "reason": "Failed", "message": "Tasks Completed: 1 (Failed: 1)"},
+				},
+			}
+			Expect(c.Status().Update(ctx, pipelineRun)).To(Succeed())
+
+			Eventually(func() ([]metav1.Condition, error) {
+				runnable, err := getRunnable()
+				return runnable.Status.Conditions, err
+			}).Should(ContainElement(
+				MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal("StampedObjectCondition"),
+					"Status": Equal(metav1.ConditionFalse),
+					"Message": Equal(fmt.Sprintf("pipelinerun [%s] failed: taskrun [%s] failed at step [go-test] with reason [Failed]: step exited with 1",
+						pipelineRun.GetName(), taskRun.GetName())),
+				}),
+			))
+		})
+	})
 })
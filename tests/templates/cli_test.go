@@ -15,13 +15,144 @@
 package templates
 
 import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	cartotesting "github.com/vmware-tanzu/cartographer/pkg/testing"
 )
 
+var (
+	cartotestOnce sync.Once
+	cartotestPath string
+	cartotestErr  error
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if cartotestPath != "" {
+		_ = os.RemoveAll(filepath.Dir(cartotestPath))
+	}
+	os.Exit(code)
+}
+
+// runCartotest runs the cartotest CLI, built once for the package, so that its flags are
+// exercised as users pass them. It returns the stdout and stderr of the run.
+func runCartotest(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+
+	cartotestOnce.Do(func() {
+		dir, err := os.MkdirTemp("", "cartotest")
+		if err != nil {
+			cartotestErr = err
+			return
+		}
+		cartotestPath = filepath.Join(dir, "cartotest")
+
+		output, err := exec.Command("go", "build", "-o", cartotestPath, "github.com/vmware-tanzu/cartographer/cmd/cartotest").CombinedOutput()
+		if err != nil {
+			cartotestErr = fmt.Errorf("%w: %s", err, output)
+		}
+	})
+	if cartotestErr != nil {
+		t.Fatal("failed to build cartotest,", "err:", cartotestErr)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(cartotestPath, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+
+	return stdout.String(), stderr.String(), err
+}
+
+// copyCases copies a directory of test cases to a temporary directory, so that a test may
+// change them, and returns the path of the copy
+func copyCases(t *testing.T, directory string) string {
+	t.Helper()
+
+	destination := filepath.Join(t.TempDir(), filepath.Base(directory))
+	err := filepath.WalkDir(directory, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relative, err := filepath.Rel(directory, path)
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			return os.MkdirAll(filepath.Join(destination, relative), 0755)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(destination, relative), content, 0644)
+	})
+	if err != nil {
+		t.Fatal("failed to copy test cases,", "directory:", directory, "err:", err)
+	}
+
+	return destination
+}
+
+// breakExpectation replaces old with new in an expected file, so that its test fails
+func breakExpectation(t *testing.T, path, old, new string) {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal("failed to read expected file,", "path:", path, "err:", err)
+	}
+	if !bytes.Contains(content, []byte(old)) {
+		t.Fatal("expected file does not hold the value to change,", "path:", path, "value:", old)
+	}
+
+	err = os.WriteFile(path, bytes.Replace(content, []byte(old), []byte(new), 1), 0644)
+	if err != nil {
+		t.Fatal("failed to write expected file,", "path:", path, "err:", err)
+	}
+}
+
+type jsonReport struct {
+	Passed bool `json:"passed"`
+	Tests  []struct {
+		Name            string  `json:"name"`
+		Path            string  `json:"path"`
+		Status          string  `json:"status"`
+		DurationSeconds float64 `json:"durationSeconds"`
+		Failure         string  `json:"failure"`
+		Diff            string  `json:"diff"`
+	} `json:"tests"`
+}
+
+func runCartotestJSON(t *testing.T, args ...string) (*jsonReport, string, error) {
+	t.Helper()
+
+	stdout, stderr, err := runCartotest(t, append([]string{"--output", "json"}, args...)...)
+
+	report := &jsonReport{}
+	if jsonErr := json.Unmarshal([]byte(stdout), report); jsonErr != nil {
+		t.Fatal("json report is invalid,", "err:", jsonErr, "stdout:", stdout, "stderr:", stderr)
+	}
+
+	return report, stderr, err
+}
+
 func TestCLIExample(t *testing.T) {
-	directories := []string{"kpack", "deliverable", "deployment", "options"}
+	directories := []string{"kpack", "deliverable", "deployment", "options", "supply-chain", "observed", "delivery", "runnable"}
 
 	for _, directory := range directories {
 		err := cartotesting.CliTest(directory)
@@ -30,3 +161,220 @@ func TestCLIExample(t *testing.T) {
 		}
 	}
 }
+
+func TestCLIEvalExample(t *testing.T) {
+	err := cartotesting.CliEval("delivery", "deliverable-$(deliverable.metadata.name)$")
+	if err != nil {
+		t.Fatal("cli eval failed,", "err:", err)
+	}
+}
+
+func TestCLILintExample(t *testing.T) {
+	err := cartotesting.CliLint("supply-chain", "delivery", "runnable")
+	if err != nil {
+		t.Fatal("cli lint failed,", "err:", err)
+	}
+}
+
+func TestCLIJSONReport(t *testing.T) {
+	report, _, err := runCartotestJSON(t, "options")
+	if err != nil {
+		t.Fatal("cli test failed,", "err:", err)
+	}
+
+	if !report.Passed || len(report.Tests) != 2 {
+		t.Fatalf("expected the 2 tests to pass, got %+v", report)
+	}
+	for i, name := range []string{"option1", "option2"} {
+		test := report.Tests[i]
+		if test.Name != name || test.Path != filepath.Join("options", name) || test.Status != "passed" {
+			t.Errorf("unexpected result of test %s: %+v", name, test)
+		}
+	}
+}
+
+func TestCLIJSONReportOfFailedTest(t *testing.T) {
+	directory := copyCases(t, "options")
+	breakExpectation(t, filepath.Join(directory, "option2", "expected.yaml"), `some_value: "2"`, `some_value: "3"`)
+
+	report, _, err := runCartotestJSON(t, directory)
+	if err == nil {
+		t.Fatal("expected cli test to fail")
+	}
+
+	if report.Passed || len(report.Tests) != 2 {
+		t.Fatalf("expected 1 of 2 tests to fail, got %+v", report)
+	}
+
+	failed := report.Tests[1]
+	if failed.Name != "option2" || failed.Status != "failed" {
+		t.Fatalf("expected option2 to fail, got %+v", failed)
+	}
+	if !strings.Contains(failed.Diff, `"3"`) || !strings.Contains(failed.Diff, `"2"`) {
+		t.Errorf("expected the diff of the failed test, got %q", failed.Diff)
+	}
+}
+
+func TestCLIJUnitReport(t *testing.T) {
+	directory := copyCases(t, "observed")
+	reportFile := filepath.Join(t.TempDir(), "report.xml")
+
+	_, stderr, err := runCartotest(t, "--output", "junit", "--output-file", reportFile, directory)
+	if err != nil {
+		t.Fatal("cli test failed,", "err:", err, "stderr:", stderr)
+	}
+
+	if !strings.Contains(stderr, "PASS") {
+		t.Errorf("expected the text report on stderr, got %q", stderr)
+	}
+
+	content, err := os.ReadFile(reportFile)
+	if err != nil {
+		t.Fatal("junit report was not written,", "err:", err)
+	}
+
+	var report struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Suites   []struct {
+			TestCases []struct {
+				Name      string    `xml:"name,attr"`
+				Classname string    `xml:"classname,attr"`
+				Failure   *struct{} `xml:"failure"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal(content, &report); err != nil {
+		t.Fatal("junit report is invalid,", "err:", err)
+	}
+
+	if report.Tests != 2 || report.Failures != 0 || len(report.Suites) != 1 || len(report.Suites[0].TestCases) != 2 {
+		t.Fatalf("expected 2 passed tests in one suite, got %+v", report)
+	}
+	for i, name := range []string{"not-ready", "ready"} {
+		testCase := report.Suites[0].TestCases[i]
+		if testCase.Classname != filepath.Join(directory, name) {
+			t.Errorf("unexpected test case %+v", testCase)
+		}
+		if testCase.Failure != nil {
+			t.Errorf("unexpected failure of test case %+v", testCase)
+		}
+	}
+}
+
+func TestCLIUpdate(t *testing.T) {
+	directory := copyCases(t, "options")
+	expectedFile := filepath.Join(directory, "option1", "expected.yaml")
+	breakExpectation(t, expectedFile, `some_value: "1"`, `some_value: "9"`)
+
+	_, stderr, err := runCartotest(t, "--update", directory)
+	if err != nil {
+		t.Fatal("cli update failed,", "err:", err, "stderr:", stderr)
+	}
+
+	if !strings.Contains(stderr, "updated 1 expected files") || !strings.Contains(stderr, expectedFile) {
+		t.Errorf("expected the updated file to be reported, got %q", stderr)
+	}
+
+	content, err := os.ReadFile(expectedFile)
+	if err != nil {
+		t.Fatal("failed to read updated file,", "err:", err)
+	}
+	if !strings.HasPrefix(string(content), "# Copyright 2021 VMware\n") || !strings.Contains(string(content), "\n---\n") {
+		t.Errorf("expected the license header and document separator to be kept, got:\n%s", content)
+	}
+	if !strings.Contains(string(content), `some_value: "1"`) {
+		t.Errorf("expected the stamped value to be written, got:\n%s", content)
+	}
+	if strings.Contains(string(content), "metadata:") {
+		t.Errorf("expected the ignored metadata to be stripped, got:\n%s", content)
+	}
+
+	report, _, err := runCartotestJSON(t, directory)
+	if err != nil || !report.Passed {
+		t.Errorf("expected the updated tests to pass, got %+v, err: %v", report, err)
+	}
+}
+
+func TestCLIRunAndSkip(t *testing.T) {
+	report, _, err := runCartotestJSON(t, "--run", "option1", "options")
+	if err != nil {
+		t.Fatal("cli test failed,", "err:", err)
+	}
+	if len(report.Tests) != 1 || report.Tests[0].Name != "option1" {
+		t.Errorf("expected only option1 to run, got %+v", report.Tests)
+	}
+
+	report, _, err = runCartotestJSON(t, "--skip", "option1", "options")
+	if err != nil {
+		t.Fatal("cli test failed,", "err:", err)
+	}
+	if len(report.Tests) != 1 || report.Tests[0].Name != "option2" {
+		t.Errorf("expected only option2 to run, got %+v", report.Tests)
+	}
+}
+
+func TestCLIParallel(t *testing.T) {
+	directory := copyCases(t, "options")
+	breakExpectation(t, filepath.Join(directory, "option2", "expected.yaml"), `some_value: "2"`, `some_value: "3"`)
+
+	report, _, err := runCartotestJSON(t, "--parallel", "2", directory)
+	if err == nil {
+		t.Fatal("expected cli test to fail")
+	}
+
+	if len(report.Tests) != 2 || report.Tests[0].Status != "passed" || report.Tests[1].Status != "failed" {
+		t.Errorf("expected the results of parallel tests in order, got %+v", report.Tests)
+	}
+}
+
+func TestCLITimeout(t *testing.T) {
+	// a ytt that never returns holds up stamping until the test times out
+	bin := t.TempDir()
+	fakeYtt := "#!/bin/sh\nif [ \"$1\" = \"--version\" ]; then\n  echo \"ytt version 0.0.0\"\n  exit 0\nfi\nexec sleep 30\n"
+	if err := os.WriteFile(filepath.Join(bin, "ytt"), []byte(fakeYtt), 0755); err != nil {
+		t.Fatal("failed to write fake ytt,", "err:", err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	report, _, err := runCartotestJSON(t, "--timeout", "1s", "--run", "ytt-template", "deliverable")
+	if err == nil {
+		t.Fatal("expected cli test to fail")
+	}
+
+	if len(report.Tests) != 1 || report.Tests[0].Status != "failed" || !strings.Contains(report.Tests[0].Failure, "test timed out after 1s") {
+		t.Fatalf("expected the test to time out, got %+v", report.Tests)
+	}
+	if report.Tests[0].DurationSeconds > 3 {
+		t.Errorf("expected the test to stop once timed out, took %.2fs", report.Tests[0].DurationSeconds)
+	}
+}
+
+func TestCLIUpdateAndDumpContextReuseTheTestRun(t *testing.T) {
+	// a ytt that records its runs and never returns holds up stamping until the test times out
+	bin := t.TempDir()
+	runs := filepath.Join(bin, "runs")
+	fakeYtt := fmt.Sprintf("#!/bin/sh\nif [ \"$1\" = \"--version\" ]; then\n  echo \"ytt version 0.0.0\"\n  exit 0\nfi\necho run >> %s\nexec sleep 30\n", runs)
+	if err := os.WriteFile(filepath.Join(bin, "ytt"), []byte(fakeYtt), 0755); err != nil {
+		t.Fatal("failed to write fake ytt,", "err:", err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	directory := copyCases(t, "deliverable")
+	report, _, err := runCartotestJSON(t, "--update", "--dump-context", t.TempDir(), "--timeout", "1s", "--run", "ytt-template", directory)
+	if err == nil {
+		t.Fatal("expected cli test to fail")
+	}
+
+	if len(report.Tests) != 1 || !strings.Contains(report.Tests[0].Failure, "test timed out after 1s") {
+		t.Fatalf("expected the test to time out, got %+v", report.Tests)
+	}
+
+	content, err := os.ReadFile(runs)
+	if err != nil {
+		t.Fatal("failed to read the runs of ytt,", "err:", err)
+	}
+	if count := strings.Count(string(content), "run\n"); count != 1 {
+		t.Errorf("expected the test to stamp once, ytt ran %d times", count)
+	}
+}
@@ -340,6 +340,110 @@ func TestTemplateExample(t *testing.T) {
 				IgnoreMetadata: true,
 			},
 		},
+		"outputs and health read from the stamped object": {
+			Given: cartotesting.Given{
+				Template: &cartotesting.TemplateFile{
+					Path: filepath.Join("observed", "template.yaml"),
+				},
+				Workload: &cartotesting.WorkloadFile{
+					Path: filepath.Join("observed", "workload.yaml"),
+				},
+				Fixture: &cartotesting.FixtureObject{Object: &unstructured.Unstructured{Object: map[string]interface{}{
+					"status": map[string]interface{}{
+						"artifact":   map[string]interface{}{"url": "some-url", "revision": "some-revision"},
+						"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "Unknown"}},
+					},
+				}}},
+			},
+			ExpectOutputs: &templates.Output{Source: &templates.Source{URL: "some-url", Revision: "some-revision"}},
+			ExpectHealth:  &cartotesting.HealthExpectation{Status: metav1.ConditionUnknown},
+		},
+		"every resource of a supply chain realized in order": {
+			Given: cartotesting.Given{
+				Templates: []cartotesting.Template{
+					&cartotesting.TemplateFile{Path: filepath.Join("supply-chain", "source-template.yaml")},
+					&cartotesting.TemplateFile{Path: filepath.Join("supply-chain", "image-template.yaml")},
+					&cartotesting.TemplateFile{Path: filepath.Join("supply-chain", "deploy-template.yaml")},
+				},
+				Workload: &cartotesting.WorkloadFile{
+					Path: filepath.Join("supply-chain", "workload.yaml"),
+				},
+				SupplyChain: &cartotesting.SupplyChainFileSet{
+					Paths: []string{
+						filepath.Join("supply-chain", "supply-chain.yaml"),
+					},
+				},
+				Fixtures: map[string]cartotesting.Fixture{
+					"source-provider": &cartotesting.FixtureFile{Path: filepath.Join("supply-chain", "source-fixture.yaml")},
+					"image-builder": &cartotesting.FixtureObject{Object: &unstructured.Unstructured{Object: map[string]interface{}{
+						"status": map[string]interface{}{"latestImage": "my-image"},
+					}}},
+				},
+			},
+			ExpectResources: map[string]*cartotesting.ResourceExpectation{
+				"source-provider": {
+					Object: &cartotesting.ExpectedFile{Path: filepath.Join("supply-chain", "expected-source.yaml")},
+				},
+				"image-builder": {
+					Outputs: &templates.Output{Image: "my-image"},
+				},
+				"deployer": {
+					Outputs: &templates.Output{},
+				},
+			},
+			CompareOptions: &cartotesting.CompareOptions{
+				IgnoreOwnerRefs: true,
+			},
+		},
+		"deployment template stamped for a deliverable": {
+			Given: cartotesting.Given{
+				Template: &cartotesting.TemplateFile{
+					Path: filepath.Join("delivery", "template.yaml"),
+				},
+				Deliverable: &cartotesting.DeliverableFile{
+					Path: filepath.Join("delivery", "deliverable.yaml"),
+				},
+				Delivery: &cartotesting.MockDelivery{
+					Inputs: &cartotesting.SupplyChainInputsObject{
+						Inputs: &cartotesting.Inputs{
+							Deployment: &templates.SourceInput{URL: "some-url", Revision: "some-revision"},
+						},
+					},
+				},
+				Fixture: &cartotesting.FixtureObject{Object: &unstructured.Unstructured{Object: map[string]interface{}{
+					"metadata": map[string]interface{}{"generation": int64(1)},
+					"status": map[string]interface{}{
+						"observedGeneration": int64(1),
+						"conditions":         []interface{}{map[string]interface{}{"type": "ReconcileSucceeded", "status": "True"}},
+					},
+				}}},
+			},
+			ExpectOutputs: &templates.Output{Source: &templates.Source{URL: "some-url", Revision: "some-revision"}},
+			ExpectHealth:  &cartotesting.HealthExpectation{Status: metav1.ConditionTrue},
+		},
+		"run template stamped for a runnable": {
+			Given: cartotesting.Given{
+				Template: &cartotesting.TemplateFile{
+					Path: filepath.Join("runnable", "template.yaml"),
+				},
+				Runnable: &cartotesting.RunnableFile{
+					Path: filepath.Join("runnable", "runnable.yaml"),
+				},
+				Selected: &cartotesting.FixtureObject{Object: &unstructured.Unstructured{Object: map[string]interface{}{
+					"metadata": map[string]interface{}{"name": "some-pipeline"},
+				}}},
+				Fixture: &cartotesting.FixtureObject{Object: &unstructured.Unstructured{Object: map[string]interface{}{
+					"status": map[string]interface{}{
+						"conditions": []interface{}{map[string]interface{}{"type": "Succeeded", "status": "True"}},
+					},
+				}}},
+			},
+			ExpectRunOutputs: templates.Outputs{
+				"url":      apiextensionsv1.JSON{Raw: []byte(`"http://source-controller.flux-system.svc.cluster.local./gitrepository/default/hello/abc123.tar.gz"`)},
+				"revision": apiextensionsv1.JSON{Raw: []byte(`"main/abc123"`)},
+			},
+			ExpectHealth: &cartotesting.HealthExpectation{Status: metav1.ConditionTrue},
+		},
 	}
 
 	testSuite.Run(t)